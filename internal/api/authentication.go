@@ -40,7 +40,7 @@ func authenticateHandler() func(http.Handler) http.Handler {
 				return
 			}
 
-			userID, err := manager.GetInstance().SessionStore.Authenticate(w, r)
+			user, apiKey, err := manager.GetInstance().SessionStore.Authenticate(w, r)
 			if err != nil {
 				if !errors.Is(err, session.ErrUnauthorized) {
 					http.Error(w, err.Error(), http.StatusInternalServerError)
@@ -75,7 +75,7 @@ func authenticateHandler() func(http.Handler) http.Handler {
 
 			if c.HasCredentials() {
 				// authentication is required
-				if userID == "" && !allowUnauthenticated(r) {
+				if user == nil && !allowUnauthenticated(r) {
 					// if graphql or a non-webpage was requested, we just return a forbidden error
 					ext := path.Ext(r.URL.Path)
 					if r.URL.Path == gqlEndpoint || (ext != "" && ext != ".html") {
@@ -100,9 +100,15 @@ func authenticateHandler() func(http.Handler) http.Handler {
 					http.Redirect(w, r, u.String(), http.StatusFound)
 					return
 				}
-			}
 
-			ctx = session.SetCurrentUserID(ctx, userID)
+				// per-user state is only used when authentication is enabled
+				if user != nil {
+					ctx = session.SetCurrentUser(ctx, user)
+				}
+				if apiKey != nil {
+					ctx = session.SetCurrentAPIKey(ctx, apiKey)
+				}
+			}
 
 			r = r.WithContext(ctx)
 
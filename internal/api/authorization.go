@@ -0,0 +1,362 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/stashapp/stash/internal/manager/config"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/session"
+)
+
+var ErrForbidden = errors.New("forbidden")
+
+// The minimum role required for root fields is the viewer role for queries
+// and subscriptions, and the editor role for mutations. The following maps
+// contain the fields that require a different role.
+var (
+	queryRoles = map[string]models.UserRole{
+		"parseSceneFilenames":         models.UserRoleEditor,
+		"scrapeSingleScene":           models.UserRoleEditor,
+		"scrapeMultiScenes":           models.UserRoleEditor,
+		"scrapeSingleStudio":          models.UserRoleEditor,
+		"scrapeSinglePerformer":       models.UserRoleEditor,
+		"scrapeMultiPerformers":       models.UserRoleEditor,
+		"scrapeSingleGallery":         models.UserRoleEditor,
+		"scrapeSingleImage":           models.UserRoleEditor,
+		"scrapeSingleMovie":           models.UserRoleEditor,
+		"scrapeSingleGroup":           models.UserRoleEditor,
+		"scrapeURL":                   models.UserRoleEditor,
+		"scrapePerformerURL":          models.UserRoleEditor,
+		"scrapeSceneURL":              models.UserRoleEditor,
+		"scrapeGalleryURL":            models.UserRoleEditor,
+		"scrapeImageURL":              models.UserRoleEditor,
+		"scrapeMovieURL":              models.UserRoleEditor,
+		"scrapeGroupURL":              models.UserRoleEditor,
+		"scrapeStudioURL":             models.UserRoleEditor,
+		"logs":                        models.UserRoleAdmin,
+		"directory":                   models.UserRoleAdmin,
+		"validateStashBoxCredentials": models.UserRoleAdmin,
+		"installedPackages":           models.UserRoleAdmin,
+		"availablePackages":           models.UserRoleAdmin,
+		"scheduledTasks":              models.UserRoleAdmin,
+		"webhooks":                    models.UserRoleAdmin,
+		"webhookEvents":               models.UserRoleAdmin,
+		"webhookDeliveries":           models.UserRoleAdmin,
+		"dlnaStatus":                  models.UserRoleAdmin,
+		"activeStreams":               models.UserRoleAdmin,
+		"users":                       models.UserRoleAdmin,
+		"findUser":                    models.UserRoleAdmin,
+		"findEditHistory":             models.UserRoleEditor,
+		"findTrashedFiles":            models.UserRoleAdmin,
+		"renameFilesPreview":          models.UserRoleAdmin,
+	}
+
+	mutationRoles = map[string]models.UserRole{
+		// activity tracking is stored per user
+		"sceneIncrementO":         models.UserRoleViewer,
+		"sceneDecrementO":         models.UserRoleViewer,
+		"sceneAddO":               models.UserRoleViewer,
+		"sceneDeleteO":            models.UserRoleViewer,
+		"sceneResetO":             models.UserRoleViewer,
+		"sceneSaveActivity":       models.UserRoleViewer,
+		"sceneResetActivity":      models.UserRoleViewer,
+		"sceneIncrementPlayCount": models.UserRoleViewer,
+		"sceneAddPlay":            models.UserRoleViewer,
+		"sceneDeletePlay":         models.UserRoleViewer,
+		"sceneResetPlayCount":     models.UserRoleViewer,
+		"userChangePassword":      models.UserRoleViewer,
+		"apiKeyCreate":            models.UserRoleViewer,
+		"apiKeyRevoke":            models.UserRoleViewer,
+
+		"setup":                     models.UserRoleAdmin,
+		"migrate":                   models.UserRoleAdmin,
+		"downloadFFMpeg":            models.UserRoleAdmin,
+		"configureGeneral":          models.UserRoleAdmin,
+		"configureInterface":        models.UserRoleAdmin,
+		"configureDLNA":             models.UserRoleAdmin,
+		"configureScraping":         models.UserRoleAdmin,
+		"configureDefaults":         models.UserRoleAdmin,
+		"configurePlugin":           models.UserRoleAdmin,
+		"generateAPIKey":            models.UserRoleAdmin,
+		"exportObjects":             models.UserRoleAdmin,
+		"importObjects":             models.UserRoleAdmin,
+		"metadataImport":            models.UserRoleAdmin,
+		"metadataExport":            models.UserRoleAdmin,
+		"metadataScan":              models.UserRoleAdmin,
+		"metadataGenerate":          models.UserRoleAdmin,
+		"metadataAutoTag":           models.UserRoleAdmin,
+		"metadataClean":             models.UserRoleAdmin,
+		"metadataCleanGenerated":    models.UserRoleAdmin,
+		"metadataIdentify":          models.UserRoleAdmin,
+		"migrateHashNaming":         models.UserRoleAdmin,
+		"migrateSceneScreenshots":   models.UserRoleAdmin,
+		"migrateBlobs":              models.UserRoleAdmin,
+		"anonymiseDatabase":         models.UserRoleAdmin,
+		"optimiseDatabase":          models.UserRoleAdmin,
+		"reloadScrapers":            models.UserRoleAdmin,
+		"setPluginsEnabled":         models.UserRoleAdmin,
+		"runPluginTask":             models.UserRoleAdmin,
+		"runPluginOperation":        models.UserRoleAdmin,
+		"reloadPlugins":             models.UserRoleAdmin,
+		"installPackages":           models.UserRoleAdmin,
+		"updatePackages":            models.UserRoleAdmin,
+		"uninstallPackages":         models.UserRoleAdmin,
+		"stopJob":                   models.UserRoleAdmin,
+		"stopAllJobs":               models.UserRoleAdmin,
+		"setJobPriority":            models.UserRoleAdmin,
+		"pauseJob":                  models.UserRoleAdmin,
+		"resumeJob":                 models.UserRoleAdmin,
+		"scheduledTaskCreate":       models.UserRoleAdmin,
+		"scheduledTaskUpdate":       models.UserRoleAdmin,
+		"scheduledTaskDestroy":      models.UserRoleAdmin,
+		"webhookCreate":             models.UserRoleAdmin,
+		"webhookUpdate":             models.UserRoleAdmin,
+		"webhookDestroy":            models.UserRoleAdmin,
+		"userCreate":                models.UserRoleAdmin,
+		"userUpdate":                models.UserRoleAdmin,
+		"userDestroy":               models.UserRoleAdmin,
+		"backupDatabase":            models.UserRoleAdmin,
+		"querySQL":                  models.UserRoleAdmin,
+		"execSQL":                   models.UserRoleAdmin,
+		"stashBoxBatchPerformerTag": models.UserRoleAdmin,
+		"stashBoxBatchStudioTag":    models.UserRoleAdmin,
+		"enableDLNA":                models.UserRoleAdmin,
+		"disableDLNA":               models.UserRoleAdmin,
+		"addTempDLNAIP":             models.UserRoleAdmin,
+		"removeTempDLNAIP":          models.UserRoleAdmin,
+		"stopStream":                models.UserRoleAdmin,
+		"restoreTrashedFiles":       models.UserRoleAdmin,
+		"renameFiles":               models.UserRoleAdmin,
+
+		"customFieldDefinitionCreate":  models.UserRoleAdmin,
+		"customFieldDefinitionUpdate":  models.UserRoleAdmin,
+		"customFieldDefinitionDestroy": models.UserRoleAdmin,
+
+		"metadataExportNFO": models.UserRoleAdmin,
+	}
+
+	subscriptionRoles = map[string]models.UserRole{
+		"loggingSubscribe": models.UserRoleAdmin,
+	}
+
+	// admin mutations that API keys with the jobs scope may use
+	jobMutations = map[string]bool{
+		"metadataImport":            true,
+		"metadataExport":            true,
+		"metadataScan":              true,
+		"metadataGenerate":          true,
+		"metadataAutoTag":           true,
+		"metadataClean":             true,
+		"metadataCleanGenerated":    true,
+		"metadataIdentify":          true,
+		"migrateHashNaming":         true,
+		"migrateSceneScreenshots":   true,
+		"migrateBlobs":              true,
+		"optimiseDatabase":          true,
+		"backupDatabase":            true,
+		"exportObjects":             true,
+		"importObjects":             true,
+		"runPluginTask":             true,
+		"stopJob":                   true,
+		"stopAllJobs":               true,
+		"setJobPriority":            true,
+		"pauseJob":                  true,
+		"resumeJob":                 true,
+		"stashBoxBatchPerformerTag": true,
+		"stashBoxBatchStudioTag":    true,
+	}
+
+	// mutations that require the admin scope regardless of the required role,
+	// so that API keys cannot be used to manage credentials or modify the
+	// filesystem
+	adminScopeMutations = map[string]bool{
+		"userChangePassword":  true,
+		"apiKeyCreate":        true,
+		"apiKeyRevoke":        true,
+		"moveFiles":           true,
+		"deleteFiles":         true,
+		"restoreTrashedFiles": true,
+		"renameFiles":         true,
+		"metadataExportNFO":   true,
+		"webhookCreate":       true,
+		"webhookUpdate":       true,
+		"webhookDestroy":      true,
+	}
+
+	// update mutations that viewers may use to set their own rating
+	ratingMutations = map[string]bool{
+		"sceneUpdate":     true,
+		"imageUpdate":     true,
+		"galleryUpdate":   true,
+		"performerUpdate": true,
+		"studioUpdate":    true,
+		"movieUpdate":     true,
+		"groupUpdate":     true,
+	}
+)
+
+// isRatingUpdate returns true if the input of the root field only sets
+// the rating of an object.
+func isRatingUpdate(ctx context.Context, field graphql.CollectedField) bool {
+	if !ratingMutations[field.Name] {
+		return false
+	}
+
+	args := field.ArgumentMap(graphql.GetOperationContext(ctx).Variables)
+	input, ok := args["input"].(map[string]interface{})
+	if !ok {
+		return false
+	}
+
+	for k := range input {
+		if k != "id" && k != "rating100" {
+			return false
+		}
+	}
+
+	return true
+}
+
+// deletesFiles returns true if the root field is a destroy mutation that
+// deletes files from the filesystem.
+func deletesFiles(ctx context.Context, field graphql.CollectedField) bool {
+	args := field.ArgumentMap(graphql.GetOperationContext(ctx).Variables)
+	input, ok := args["input"].(map[string]interface{})
+	if !ok {
+		return false
+	}
+
+	deleteFile, _ := input["delete_file"].(bool)
+	return deleteFile
+}
+
+func requiredRole(ctx context.Context, fc *graphql.RootFieldContext) models.UserRole {
+	switch fc.Object {
+	case "Mutation":
+		if role, found := mutationRoles[fc.Field.Name]; found {
+			return role
+		}
+		if isRatingUpdate(ctx, fc.Field) {
+			return models.UserRoleViewer
+		}
+		return models.UserRoleEditor
+	case "Subscription":
+		if role, found := subscriptionRoles[fc.Field.Name]; found {
+			return role
+		}
+	default:
+		if role, found := queryRoles[fc.Field.Name]; found {
+			return role
+		}
+	}
+
+	return models.UserRoleViewer
+}
+
+// roleScope returns the API key scope corresponding to the provided role.
+func roleScope(role models.UserRole) models.APIKeyScope {
+	switch role {
+	case models.UserRoleAdmin:
+		return models.APIKeyScopeAdmin
+	case models.UserRoleEditor:
+		return models.APIKeyScopeMetadata
+	default:
+		return models.APIKeyScopeRead
+	}
+}
+
+func requiredScope(ctx context.Context, fc *graphql.RootFieldContext, role models.UserRole) models.APIKeyScope {
+	if fc.Object != "Mutation" {
+		return roleScope(role)
+	}
+
+	switch {
+	case adminScopeMutations[fc.Field.Name], deletesFiles(ctx, fc.Field):
+		return models.APIKeyScopeAdmin
+	case jobMutations[fc.Field.Name]:
+		return models.APIKeyScopeJobs
+	case role == models.UserRoleAdmin:
+		return models.APIKeyScopeAdmin
+	default:
+		// all other mutations modify library content or activity
+		return models.APIKeyScopeMetadata
+	}
+}
+
+// unrestricted returns true if a request without a current user is allowed
+// unrestricted access. This is the case when authentication is disabled, and
+// for requests made by plugins. Otherwise, requests without a current user
+// are denied.
+func unrestricted(ctx context.Context) bool {
+	return !config.GetInstance().HasCredentials() || session.IsPluginRequest(ctx)
+}
+
+// authorizeRootField is a graphql root field middleware that returns an
+// error if the current user does not have the role required for the field,
+// or if the request was authenticated with an API key that does not have
+// the required scope.
+func authorizeRootField(ctx context.Context, next graphql.RootResolver) graphql.Marshaler {
+	fc := graphql.GetRootFieldContext(ctx)
+
+	u := session.GetCurrentUser(ctx)
+	if u == nil {
+		if unrestricted(ctx) {
+			return next(ctx)
+		}
+
+		graphql.AddError(ctx, fmt.Errorf("%w: %s requires authentication", ErrForbidden, fc.Field.Name))
+		return graphql.Null
+	}
+
+	role := requiredRole(ctx, fc)
+	if !u.HasRole(role) {
+		graphql.AddError(ctx, fmt.Errorf("%w: %s requires the %s role", ErrForbidden, fc.Field.Name, role))
+		return graphql.Null
+	}
+
+	if apiKey := session.GetCurrentAPIKey(ctx); apiKey != nil {
+		if scope := requiredScope(ctx, fc, role); !apiKey.HasScope(scope) {
+			graphql.AddError(ctx, fmt.Errorf("%w: %s requires the %s API key scope", ErrForbidden, fc.Field.Name, scope))
+			return graphql.Null
+		}
+	}
+
+	return next(ctx)
+}
+
+// requireRole returns a http middleware that only allows requests from users
+// with the provided role, authenticated with an API key with the
+// corresponding scope if applicable.
+func requireRole(role models.UserRole) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+			u := session.GetCurrentUser(ctx)
+			if (u == nil && !unrestricted(ctx)) || (u != nil && !u.HasRole(role)) {
+				http.Error(w, ErrForbidden.Error(), http.StatusForbidden)
+				return
+			}
+
+			if apiKey := session.GetCurrentAPIKey(ctx); apiKey != nil && !apiKey.HasScope(roleScope(role)) {
+				http.Error(w, ErrForbidden.Error(), http.StatusForbidden)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func isAdmin(ctx context.Context) bool {
+	u := session.GetCurrentUser(ctx)
+	if u == nil {
+		return unrestricted(ctx)
+	}
+
+	apiKey := session.GetCurrentAPIKey(ctx)
+	return u.HasRole(models.UserRoleAdmin) && (apiKey == nil || apiKey.HasScope(models.APIKeyScopeAdmin))
+}
@@ -0,0 +1,54 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/session"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequireRole(t *testing.T) {
+	admin := &models.User{ID: 1, Role: models.UserRoleAdmin}
+	viewer := &models.User{ID: 2, Role: models.UserRoleViewer}
+
+	readKey := &models.APIKey{ID: 1, Scopes: []models.APIKeyScope{models.APIKeyScopeRead}}
+	adminKey := &models.APIKey{ID: 2, Scopes: []models.APIKeyScope{models.APIKeyScopeAdmin}}
+
+	tests := []struct {
+		name   string
+		role   models.UserRole
+		user   *models.User
+		apiKey *models.APIKey
+		want   int
+	}{
+		{"viewer route", models.UserRoleViewer, viewer, nil, http.StatusOK},
+		{"viewer route with read key", models.UserRoleViewer, viewer, readKey, http.StatusOK},
+		{"admin route as viewer", models.UserRoleAdmin, viewer, nil, http.StatusForbidden},
+		{"admin route as admin", models.UserRoleAdmin, admin, nil, http.StatusOK},
+		{"admin route with read key", models.UserRoleAdmin, admin, readKey, http.StatusForbidden},
+		{"admin route with admin key", models.UserRoleAdmin, admin, adminKey, http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := requireRole(tt.role)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			}))
+
+			ctx := session.SetCurrentUser(context.Background(), tt.user)
+			if tt.apiKey != nil {
+				ctx = session.SetCurrentAPIKey(ctx, tt.apiKey)
+			}
+
+			r := httptest.NewRequest(http.MethodGet, "/downloads/hash/file", nil).WithContext(ctx)
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, r)
+
+			assert.Equal(t, tt.want, w.Code)
+		})
+	}
+}
@@ -0,0 +1,139 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/stashapp/stash/pkg/plugin"
+	"github.com/stashapp/stash/pkg/plugin/hook"
+)
+
+// executePreHooks executes the plugin pre-hooks of the provided type.
+// input must be a pointer to the operation input. If the input is an object
+// and a hook returns a modified input, then input is updated with the
+// returned values. Other inputs, such as ids, cannot be modified.
+// If translator is not nil, it is updated to include the fields set by the
+// hook.
+// Returns an error if a hook rejects the operation.
+func (r *mutationResolver) executePreHooks(ctx context.Context, id int, hookType hook.TriggerEnum, input interface{}, translator *changesetTranslator) error {
+	var inputFields []string
+	if translator != nil {
+		inputFields = translator.getFields()
+	}
+
+	output, err := r.hookExecutor.ExecutePreHooks(ctx, id, hookType, input, inputFields)
+	if err != nil {
+		return err
+	}
+
+	if output == nil || reflect.ValueOf(input).Elem().Kind() != reflect.Struct {
+		return nil
+	}
+
+	if err := applyPreHookOutput(output, input, translator); err != nil {
+		return fmt.Errorf("%s: %w", hookType.String(), err)
+	}
+
+	return nil
+}
+
+// withPreHookDeadline returns a context in which the pre-hooks of an
+// operation that applies to multiple objects must complete. The deadline is
+// shared by the pre-hooks of all objects, so that the operation is not
+// delayed by plugin.PreHookTimeout for each object.
+func withPreHookDeadline(ctx context.Context) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(ctx, plugin.PreHookTimeout)
+}
+
+// executeBulkPreHooks executes the plugin pre-hooks of the provided type for
+// each object of an operation that applies to multiple objects. The input is
+// shared by all objects, so changes made by a hook apply to the whole
+// operation and are provided to the hooks of the following objects.
+// The pre-hooks of all objects share a single deadline.
+func (r *mutationResolver) executeBulkPreHooks(ctx context.Context, ids []int, hookType hook.TriggerEnum, input interface{}, translator *changesetTranslator) error {
+	ctx, cancel := withPreHookDeadline(ctx)
+	defer cancel()
+
+	for _, id := range ids {
+		if err := r.executePreHooks(ctx, id, hookType, input, translator); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// applyPreHookOutput merges the values of the provided hook output into
+// input, which must be a pointer. Fields not present in the output are left
+// unchanged. The id and ids fields cannot be changed by hooks.
+//
+// Fields are added to the translator if the output sets them to a non-null
+// value. Fields set to null in the output are only applied if they were
+// already present in the input. This allows hooks to return the input they
+// were provided without unintentionally clearing unset fields.
+func applyPreHookOutput(output interface{}, input interface{}, translator *changesetTranslator) error {
+	outputMap, err := toJSONMap(output)
+	if err != nil {
+		return fmt.Errorf("%w: hook returned invalid input: %v", ErrInput, err)
+	}
+
+	merged, err := toJSONMap(input)
+	if err != nil {
+		return err
+	}
+
+	for k, v := range outputMap {
+		if k == "id" || k == "ids" {
+			continue
+		}
+
+		if v == nil && translator != nil && !translator.hasField(k) {
+			continue
+		}
+
+		merged[k] = v
+
+		if translator != nil {
+			if translator.inputMap == nil {
+				translator.inputMap = make(map[string]interface{})
+			}
+			translator.inputMap[k] = v
+		}
+	}
+
+	data, err := json.Marshal(merged)
+	if err != nil {
+		return err
+	}
+
+	// decode into a new value so that values referenced by the original
+	// input are not modified
+	v := reflect.ValueOf(input).Elem()
+	newValue := reflect.New(v.Type())
+	if err := json.Unmarshal(data, newValue.Interface()); err != nil {
+		return fmt.Errorf("%w: hook returned invalid input: %v", ErrInput, err)
+	}
+
+	v.Set(newValue.Elem())
+	return nil
+}
+
+func toJSONMap(v interface{}) (map[string]interface{}, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var ret map[string]interface{}
+	if err := json.Unmarshal(data, &ret); err != nil {
+		return nil, err
+	}
+
+	if ret == nil {
+		return nil, fmt.Errorf("expected object, got %s", data)
+	}
+
+	return ret, nil
+}
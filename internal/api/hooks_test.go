@@ -0,0 +1,187 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/plugin/hook"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyPreHookOutput(t *testing.T) {
+	title := "title"
+	newTitle := "new title"
+	details := "details"
+	rating := 50
+
+	tests := []struct {
+		name       string
+		inputMap   map[string]interface{}
+		output     interface{}
+		want       models.SceneUpdateInput
+		wantFields []string
+		wantErr    bool
+	}{
+		{
+			"modify field",
+			map[string]interface{}{"id": "1", "title": title},
+			map[string]interface{}{"title": newTitle},
+			models.SceneUpdateInput{ID: "1", Title: &newTitle},
+			[]string{"id", "title"},
+			false,
+		},
+		{
+			"add field",
+			map[string]interface{}{"id": "1", "title": title},
+			map[string]interface{}{"details": details},
+			models.SceneUpdateInput{ID: "1", Title: &title, Details: &details},
+			[]string{"id", "title", "details"},
+			false,
+		},
+		{
+			"null unset field ignored",
+			map[string]interface{}{"id": "1", "title": title},
+			map[string]interface{}{"title": title, "details": nil, "rating100": float64(rating)},
+			models.SceneUpdateInput{ID: "1", Title: &title, Rating100: &rating},
+			[]string{"id", "title", "rating100"},
+			false,
+		},
+		{
+			"clear set field",
+			map[string]interface{}{"id": "1", "title": title},
+			map[string]interface{}{"title": nil},
+			models.SceneUpdateInput{ID: "1"},
+			[]string{"id", "title"},
+			false,
+		},
+		{
+			"id unchanged",
+			map[string]interface{}{"id": "1", "title": title},
+			map[string]interface{}{"id": "2"},
+			models.SceneUpdateInput{ID: "1", Title: &title},
+			[]string{"id", "title"},
+			false,
+		},
+		{
+			"invalid output",
+			map[string]interface{}{"id": "1"},
+			"invalid",
+			models.SceneUpdateInput{},
+			nil,
+			true,
+		},
+		{
+			"invalid field type",
+			map[string]interface{}{"id": "1"},
+			map[string]interface{}{"title": 1},
+			models.SceneUpdateInput{},
+			nil,
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := models.SceneUpdateInput{ID: "1"}
+			if v, ok := tt.inputMap["title"].(string); ok {
+				input.Title = &v
+			}
+
+			translator := &changesetTranslator{
+				inputMap: tt.inputMap,
+			}
+
+			err := applyPreHookOutput(tt.output, &input, translator)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("applyPreHookOutput() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if err != nil {
+				assert.True(t, errors.Is(err, ErrInput))
+				return
+			}
+
+			assert.Equal(t, tt.want, input)
+			assert.ElementsMatch(t, tt.wantFields, translator.getFields())
+		})
+	}
+}
+
+// preHookExecutor returns output from its pre-hooks, and rejects the
+// operation for rejectID.
+type preHookExecutor struct {
+	mockHookExecutor
+	output    interface{}
+	rejectID  int
+	ids       []int
+	deadlines []time.Time
+}
+
+func (e *preHookExecutor) ExecutePreHooks(ctx context.Context, id int, hookType hook.TriggerEnum, input interface{}, inputFields []string) (interface{}, error) {
+	e.ids = append(e.ids, id)
+	deadline, _ := ctx.Deadline()
+	e.deadlines = append(e.deadlines, deadline)
+	if id == e.rejectID {
+		return nil, errors.New("rejected")
+	}
+	return e.output, nil
+}
+
+func TestExecuteBulkPreHooks(t *testing.T) {
+	title := "title"
+	newTitle := "new title"
+
+	t.Run("modifies shared input", func(t *testing.T) {
+		executor := &preHookExecutor{
+			output: map[string]interface{}{"title": newTitle, "ids": []string{"3"}},
+		}
+		r := &mutationResolver{&Resolver{hookExecutor: executor}}
+
+		input := BulkSceneUpdateInput{Ids: []string{"1", "2"}, Title: &title}
+		translator := &changesetTranslator{
+			inputMap: map[string]interface{}{"ids": input.Ids, "title": title},
+		}
+
+		err := r.executeBulkPreHooks(context.Background(), []int{1, 2}, hook.SceneUpdatePre, &input, translator)
+		assert.NoError(t, err)
+		assert.Equal(t, []int{1, 2}, executor.ids)
+		assert.Equal(t, newTitle, *input.Title)
+		assert.Equal(t, []string{"1", "2"}, input.Ids)
+	})
+
+	t.Run("rejected by any object", func(t *testing.T) {
+		executor := &preHookExecutor{rejectID: 2}
+		r := &mutationResolver{&Resolver{hookExecutor: executor}}
+
+		ids := []string{"1", "2", "3"}
+		err := r.executeBulkPreHooks(context.Background(), []int{1, 2, 3}, hook.TagDestroyPre, &ids, nil)
+		assert.Error(t, err)
+		assert.Equal(t, []int{1, 2}, executor.ids)
+	})
+
+	t.Run("shared deadline", func(t *testing.T) {
+		executor := &preHookExecutor{}
+		r := &mutationResolver{&Resolver{hookExecutor: executor}}
+
+		ids := []string{"1", "2", "3"}
+		err := r.executeBulkPreHooks(context.Background(), []int{1, 2, 3}, hook.TagDestroyPre, &ids, nil)
+		assert.NoError(t, err)
+		assert.Len(t, executor.deadlines, 3)
+		assert.False(t, executor.deadlines[0].IsZero())
+		assert.Equal(t, executor.deadlines[0], executor.deadlines[1])
+		assert.Equal(t, executor.deadlines[0], executor.deadlines[2])
+	})
+
+	t.Run("non-object input unchanged", func(t *testing.T) {
+		executor := &preHookExecutor{output: []string{"4"}}
+		r := &mutationResolver{&Resolver{hookExecutor: executor}}
+
+		ids := []string{"1"}
+		err := r.executeBulkPreHooks(context.Background(), []int{1}, hook.TagDestroyPre, &ids, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"1"}, ids)
+	})
+}
@@ -0,0 +1,219 @@
+// Code generated by github.com/vektah/dataloaden, DO NOT EDIT.
+
+package loaders
+
+import (
+	"sync"
+	"time"
+)
+
+// CustomFieldsLoaderConfig captures the config to create a new CustomFieldsLoader
+type CustomFieldsLoaderConfig struct {
+	// Fetch is a method that provides the data for the loader
+	Fetch func(keys []int) ([]map[string]interface{}, []error)
+
+	// Wait is how long wait before sending a batch
+	Wait time.Duration
+
+	// MaxBatch will limit the maximum number of keys to send in one batch, 0 = not limit
+	MaxBatch int
+}
+
+// NewCustomFieldsLoader creates a new CustomFieldsLoader given a fetch, wait, and maxBatch
+func NewCustomFieldsLoader(config CustomFieldsLoaderConfig) *CustomFieldsLoader {
+	return &CustomFieldsLoader{
+		fetch:    config.Fetch,
+		wait:     config.Wait,
+		maxBatch: config.MaxBatch,
+	}
+}
+
+// CustomFieldsLoader batches and caches requests
+type CustomFieldsLoader struct {
+	// this method provides the data for the loader
+	fetch func(keys []int) ([]map[string]interface{}, []error)
+
+	// how long to done before sending a batch
+	wait time.Duration
+
+	// this will limit the maximum number of keys to send in one batch, 0 = no limit
+	maxBatch int
+
+	// INTERNAL
+
+	// lazily created cache
+	cache map[int]map[string]interface{}
+
+	// the current batch. keys will continue to be collected until timeout is hit,
+	// then everything will be sent to the fetch method and out to the listeners
+	batch *customFieldsLoaderBatch
+
+	// mutex to prevent races
+	mu sync.Mutex
+}
+
+type customFieldsLoaderBatch struct {
+	keys    []int
+	data    []map[string]interface{}
+	error   []error
+	closing bool
+	done    chan struct{}
+}
+
+// Load a map by key, batching and caching will be applied automatically
+func (l *CustomFieldsLoader) Load(key int) (map[string]interface{}, error) {
+	return l.LoadThunk(key)()
+}
+
+// LoadThunk returns a function that when called will block waiting for a map.
+// This method should be used if you want one goroutine to make requests to many
+// different data loaders without blocking until the thunk is called.
+func (l *CustomFieldsLoader) LoadThunk(key int) func() (map[string]interface{}, error) {
+	l.mu.Lock()
+	if it, ok := l.cache[key]; ok {
+		l.mu.Unlock()
+		return func() (map[string]interface{}, error) {
+			return it, nil
+		}
+	}
+	if l.batch == nil {
+		l.batch = &customFieldsLoaderBatch{done: make(chan struct{})}
+	}
+	batch := l.batch
+	pos := batch.keyIndex(l, key)
+	l.mu.Unlock()
+
+	return func() (map[string]interface{}, error) {
+		<-batch.done
+
+		var data map[string]interface{}
+		if pos < len(batch.data) {
+			data = batch.data[pos]
+		}
+
+		var err error
+		// its convenient to be able to return a single error for everything
+		if len(batch.error) == 1 {
+			err = batch.error[0]
+		} else if batch.error != nil {
+			err = batch.error[pos]
+		}
+
+		if err == nil {
+			l.mu.Lock()
+			l.unsafeSet(key, data)
+			l.mu.Unlock()
+		}
+
+		return data, err
+	}
+}
+
+// LoadAll fetches many keys at once. It will be broken into appropriate sized
+// sub batches depending on how the loader is configured
+func (l *CustomFieldsLoader) LoadAll(keys []int) ([]map[string]interface{}, []error) {
+	results := make([]func() (map[string]interface{}, error), len(keys))
+
+	for i, key := range keys {
+		results[i] = l.LoadThunk(key)
+	}
+
+	maps := make([]map[string]interface{}, len(keys))
+	errors := make([]error, len(keys))
+	for i, thunk := range results {
+		maps[i], errors[i] = thunk()
+	}
+	return maps, errors
+}
+
+// LoadAllThunk returns a function that when called will block waiting for a maps.
+// This method should be used if you want one goroutine to make requests to many
+// different data loaders without blocking until the thunk is called.
+func (l *CustomFieldsLoader) LoadAllThunk(keys []int) func() ([]map[string]interface{}, []error) {
+	results := make([]func() (map[string]interface{}, error), len(keys))
+	for i, key := range keys {
+		results[i] = l.LoadThunk(key)
+	}
+	return func() ([]map[string]interface{}, []error) {
+		maps := make([]map[string]interface{}, len(keys))
+		errors := make([]error, len(keys))
+		for i, thunk := range results {
+			maps[i], errors[i] = thunk()
+		}
+		return maps, errors
+	}
+}
+
+// Prime the cache with the provided key and value. If the key already exists, no change is made
+// and false is returned.
+// (To forcefully prime the cache, clear the key first with loader.clear(key).prime(key, value).)
+func (l *CustomFieldsLoader) Prime(key int, value map[string]interface{}) bool {
+	l.mu.Lock()
+	var found bool
+	if _, found = l.cache[key]; !found {
+		l.unsafeSet(key, value)
+	}
+	l.mu.Unlock()
+	return !found
+}
+
+// Clear the value at key from the cache, if it exists
+func (l *CustomFieldsLoader) Clear(key int) {
+	l.mu.Lock()
+	delete(l.cache, key)
+	l.mu.Unlock()
+}
+
+func (l *CustomFieldsLoader) unsafeSet(key int, value map[string]interface{}) {
+	if l.cache == nil {
+		l.cache = map[int]map[string]interface{}{}
+	}
+	l.cache[key] = value
+}
+
+// keyIndex will return the location of the key in the batch, if its not found
+// it will add the key to the batch
+func (b *customFieldsLoaderBatch) keyIndex(l *CustomFieldsLoader, key int) int {
+	for i, existingKey := range b.keys {
+		if key == existingKey {
+			return i
+		}
+	}
+
+	pos := len(b.keys)
+	b.keys = append(b.keys, key)
+	if pos == 0 {
+		go b.startTimer(l)
+	}
+
+	if l.maxBatch != 0 && pos >= l.maxBatch-1 {
+		if !b.closing {
+			b.closing = true
+			l.batch = nil
+			go b.end(l)
+		}
+	}
+
+	return pos
+}
+
+func (b *customFieldsLoaderBatch) startTimer(l *CustomFieldsLoader) {
+	time.Sleep(l.wait)
+	l.mu.Lock()
+
+	// we must have hit a batch limit and are already finalizing this batch
+	if b.closing {
+		l.mu.Unlock()
+		return
+	}
+
+	l.batch = nil
+	l.mu.Unlock()
+
+	b.end(l)
+}
+
+func (b *customFieldsLoaderBatch) end(l *CustomFieldsLoader) {
+	b.data, b.error = l.fetch(b.keys)
+	close(b.done)
+}
@@ -18,6 +18,7 @@
 //go:generate go run github.com/vektah/dataloaden SceneOHistoryLoader int []time.Time
 //go:generate go run github.com/vektah/dataloaden ScenePlayHistoryLoader int []time.Time
 //go:generate go run github.com/vektah/dataloaden SceneLastPlayedLoader int *time.Time
+//go:generate go run github.com/vektah/dataloaden CustomFieldsLoader int map[string]interface{}
 package loaders
 
 import (
@@ -58,6 +59,14 @@ type Loaders struct {
 	TagByID       *TagLoader
 	GroupByID     *GroupLoader
 	FileByID      *FileLoader
+
+	SceneCustomFields     *CustomFieldsLoader
+	ImageCustomFields     *CustomFieldsLoader
+	GalleryCustomFields   *CustomFieldsLoader
+	PerformerCustomFields *CustomFieldsLoader
+	StudioCustomFields    *CustomFieldsLoader
+	TagCustomFields       *CustomFieldsLoader
+	GroupCustomFields     *CustomFieldsLoader
 }
 
 type Middleware struct {
@@ -148,6 +157,41 @@ func (m Middleware) Middleware(next http.Handler) http.Handler {
 				maxBatch: maxBatch,
 				fetch:    m.fetchScenesOHistory(ctx),
 			},
+			SceneCustomFields: &CustomFieldsLoader{
+				wait:     wait,
+				maxBatch: maxBatch,
+				fetch:    m.fetchCustomFields(ctx, m.Repository.Scene),
+			},
+			ImageCustomFields: &CustomFieldsLoader{
+				wait:     wait,
+				maxBatch: maxBatch,
+				fetch:    m.fetchCustomFields(ctx, m.Repository.Image),
+			},
+			GalleryCustomFields: &CustomFieldsLoader{
+				wait:     wait,
+				maxBatch: maxBatch,
+				fetch:    m.fetchCustomFields(ctx, m.Repository.Gallery),
+			},
+			PerformerCustomFields: &CustomFieldsLoader{
+				wait:     wait,
+				maxBatch: maxBatch,
+				fetch:    m.fetchCustomFields(ctx, m.Repository.Performer),
+			},
+			StudioCustomFields: &CustomFieldsLoader{
+				wait:     wait,
+				maxBatch: maxBatch,
+				fetch:    m.fetchCustomFields(ctx, m.Repository.Studio),
+			},
+			TagCustomFields: &CustomFieldsLoader{
+				wait:     wait,
+				maxBatch: maxBatch,
+				fetch:    m.fetchCustomFields(ctx, m.Repository.Tag),
+			},
+			GroupCustomFields: &CustomFieldsLoader{
+				wait:     wait,
+				maxBatch: maxBatch,
+				fetch:    m.fetchCustomFields(ctx, m.Repository.Group),
+			},
 		}
 
 		newCtx := context.WithValue(r.Context(), loadersCtxKey, ldrs)
@@ -345,3 +389,14 @@ func (m Middleware) fetchScenesLastPlayed(ctx context.Context) func(keys []int)
 		return ret, toErrorSlice(err)
 	}
 }
+
+func (m Middleware) fetchCustomFields(ctx context.Context, r models.CustomFieldsReader) func(keys []int) ([]map[string]interface{}, []error) {
+	return func(keys []int) (ret []map[string]interface{}, errs []error) {
+		err := m.Repository.WithDB(ctx, func(ctx context.Context) error {
+			var err error
+			ret, err = r.GetCustomFieldsBulk(ctx, keys)
+			return err
+		})
+		return ret, toErrorSlice(err)
+	}
+}
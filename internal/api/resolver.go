@@ -30,6 +30,7 @@ var (
 
 type hookExecutor interface {
 	ExecutePostHooks(ctx context.Context, id int, hookType hook.TriggerEnum, input interface{}, inputFields []string)
+	ExecutePreHooks(ctx context.Context, id int, hookType hook.TriggerEnum, input interface{}, inputFields []string) (interface{}, error)
 }
 
 type Resolver struct {
@@ -105,6 +106,15 @@ func (r *Resolver) Plugin() PluginResolver {
 func (r *Resolver) ConfigResult() ConfigResultResolver {
 	return &configResultResolver{r}
 }
+func (r *Resolver) APIKey() APIKeyResolver {
+	return &apiKeyResolver{r}
+}
+func (r *Resolver) EditHistory() EditHistoryResolver {
+	return &editHistoryResolver{r}
+}
+func (r *Resolver) ActiveStream() ActiveStreamResolver {
+	return &activeStreamResolver{r}
+}
 
 type mutationResolver struct{ *Resolver }
 type queryResolver struct{ *Resolver }
@@ -129,6 +139,9 @@ type imageFileResolver struct{ *Resolver }
 type savedFilterResolver struct{ *Resolver }
 type pluginResolver struct{ *Resolver }
 type configResultResolver struct{ *Resolver }
+type apiKeyResolver struct{ *Resolver }
+type editHistoryResolver struct{ *Resolver }
+type activeStreamResolver struct{ *Resolver }
 
 func (r *Resolver) withTxn(ctx context.Context, fn func(ctx context.Context) error) error {
 	return r.repository.WithTxn(ctx, fn)
@@ -1,6 +1,10 @@
 package api
 
-import "context"
+import (
+	"context"
+
+	"github.com/stashapp/stash/pkg/models"
+)
 
 func (r *galleryFileResolver) Fingerprint(ctx context.Context, obj *GalleryFile, type_ string) (*string, error) {
 	fp := obj.BaseFile.Fingerprints.For(type_)
@@ -28,3 +32,33 @@ func (r *videoFileResolver) Fingerprint(ctx context.Context, obj *VideoFile, typ
 	}
 	return nil, nil
 }
+
+func (r *videoFileResolver) Streams(ctx context.Context, obj *VideoFile) (ret []*models.VideoStream, err error) {
+	if obj.VideoFile.Streams != nil {
+		return obj.VideoFile.Streams, nil
+	}
+
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		ret, err = r.repository.File.GetStreams(ctx, obj.ID)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (r *videoFileResolver) Chapters(ctx context.Context, obj *VideoFile) (ret []*models.VideoChapter, err error) {
+	if obj.VideoFile.Chapters != nil {
+		return obj.VideoFile.Chapters, nil
+	}
+
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		ret, err = r.repository.File.GetChapters(ctx, obj.ID)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
@@ -216,3 +216,7 @@ func (r *galleryResolver) Image(ctx context.Context, obj *models.Gallery, index
 
 	return
 }
+
+func (r *galleryResolver) CustomFields(ctx context.Context, obj *models.Gallery) (map[string]interface{}, error) {
+	return loaders.From(ctx).GalleryCustomFields.Load(obj.ID)
+}
@@ -166,3 +166,7 @@ func (r *imageResolver) Urls(ctx context.Context, obj *models.Image) ([]string,
 
 	return obj.URLs.List(), nil
 }
+
+func (r *imageResolver) CustomFields(ctx context.Context, obj *models.Image) (map[string]interface{}, error) {
+	return loaders.From(ctx).ImageCustomFields.Load(obj.ID)
+}
@@ -192,3 +192,7 @@ func (r *groupResolver) Scenes(ctx context.Context, obj *models.Group) (ret []*m
 
 	return ret, nil
 }
+
+func (r *groupResolver) CustomFields(ctx context.Context, obj *models.Group) (map[string]interface{}, error) {
+	return loaders.From(ctx).GroupCustomFields.Load(obj.ID)
+}
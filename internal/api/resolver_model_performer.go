@@ -272,3 +272,7 @@ func (r *performerResolver) Groups(ctx context.Context, obj *models.Performer) (
 func (r *performerResolver) Movies(ctx context.Context, obj *models.Performer) (ret []*models.Group, err error) {
 	return r.Groups(ctx, obj)
 }
+
+func (r *performerResolver) CustomFields(ctx context.Context, obj *models.Performer) (map[string]interface{}, error) {
+	return loaders.From(ctx).PerformerCustomFields.Load(obj.ID)
+}
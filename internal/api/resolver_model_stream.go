@@ -0,0 +1,33 @@
+package api
+
+import (
+	"context"
+
+	"github.com/stashapp/stash/internal/api/loaders"
+	"github.com/stashapp/stash/pkg/ffmpeg"
+	"github.com/stashapp/stash/pkg/models"
+)
+
+func (r *activeStreamResolver) Scene(ctx context.Context, obj *ffmpeg.ActiveStream) (*models.Scene, error) {
+	if obj.SceneID == 0 {
+		return nil, nil
+	}
+
+	return loaders.From(ctx).SceneByID.Load(obj.SceneID)
+}
+
+func (r *activeStreamResolver) File(ctx context.Context, obj *ffmpeg.ActiveStream) (*VideoFile, error) {
+	f, err := loaders.From(ctx).FileByID.Load(obj.FileID)
+	if err != nil || f == nil {
+		return nil, err
+	}
+
+	vf, err := convertVideoFile(f)
+	if err != nil {
+		return nil, err
+	}
+
+	return &VideoFile{
+		VideoFile: vf,
+	}, nil
+}
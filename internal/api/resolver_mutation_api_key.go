@@ -0,0 +1,123 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/stashapp/stash/internal/manager"
+	"github.com/stashapp/stash/internal/manager/config"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/session"
+	"github.com/stashapp/stash/pkg/user"
+)
+
+// canManageAPIKeysOf returns true if the current user may manage the API keys
+// of the user with the provided id. Admin users may manage the keys of all
+// users.
+func canManageAPIKeysOf(ctx context.Context, userID int) bool {
+	currentUser := session.GetCurrentUser(ctx)
+	return currentUser == nil || currentUser.ID == userID || currentUser.HasRole(models.UserRoleAdmin)
+}
+
+func (r *mutationResolver) APIKeyCreate(ctx context.Context, input APIKeyCreateInput) (*APIKeyCreateResult, error) {
+	if !config.GetInstance().HasCredentials() {
+		return nil, errAuthenticationDisabled
+	}
+
+	name := strings.TrimSpace(input.Name)
+	if name == "" {
+		return nil, user.ErrAPIKeyNameMissing
+	}
+
+	if input.ExpiresAt != nil && !input.ExpiresAt.After(time.Now()) {
+		return nil, errors.New("expires_at must be in the future")
+	}
+
+	var userID int
+	switch {
+	case input.UserID != nil:
+		var err error
+		userID, err = strconv.Atoi(*input.UserID)
+		if err != nil {
+			return nil, fmt.Errorf("converting user id: %w", err)
+		}
+	case session.GetCurrentUser(ctx) != nil:
+		userID = session.GetCurrentUser(ctx).ID
+	default:
+		return nil, errors.New("user_id is required")
+	}
+
+	if !canManageAPIKeysOf(ctx, userID) {
+		return nil, fmt.Errorf("%w: cannot create API keys for other users", ErrForbidden)
+	}
+
+	key, keyHash, err := user.GenerateAPIKey()
+	if err != nil {
+		return nil, err
+	}
+
+	newAPIKey := models.NewAPIKey()
+	newAPIKey.UserID = userID
+	newAPIKey.Name = name
+	newAPIKey.KeyHash = keyHash
+	newAPIKey.Scopes = input.Scopes
+	newAPIKey.ExpiresAt = input.ExpiresAt
+
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		u, err := r.repository.User.Find(ctx, userID)
+		if err != nil {
+			return err
+		}
+		if u == nil {
+			return fmt.Errorf("user with id %d not found", userID)
+		}
+
+		return r.repository.APIKey.Create(ctx, &newAPIKey)
+	}); err != nil {
+		return nil, err
+	}
+
+	return &APIKeyCreateResult{
+		APIKey: &newAPIKey,
+		Key:    key,
+	}, nil
+}
+
+func (r *mutationResolver) APIKeyRevoke(ctx context.Context, id string) (bool, error) {
+	idInt, err := strconv.Atoi(id)
+	if err != nil {
+		return false, fmt.Errorf("converting id: %w", err)
+	}
+
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		qb := r.repository.APIKey
+
+		existing, err := qb.Find(ctx, idInt)
+		if err != nil {
+			return err
+		}
+		if existing == nil || !canManageAPIKeysOf(ctx, existing.UserID) {
+			return fmt.Errorf("api key with id %d not found", idInt)
+		}
+
+		if existing.RevokedAt != nil {
+			return nil
+		}
+
+		partial := models.NewAPIKeyPartial()
+		partial.RevokedAt = models.NewOptionalTime(time.Now())
+
+		_, err = qb.UpdatePartial(ctx, idInt, partial)
+		return err
+	}); err != nil {
+		return false, err
+	}
+
+	manager.GetInstance().SessionStore.ClearCache()
+
+	return true, nil
+}
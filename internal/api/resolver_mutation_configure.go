@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"strconv"
 
 	"github.com/stashapp/stash/internal/manager"
@@ -81,9 +82,28 @@ func (r *mutationResolver) setConfigFloat(key string, value *float64) {
 	}
 }
 
+// stashesChanged returns true if the input stash paths differ from the
+// existing stash paths.
+func stashesChanged(existing config.StashConfigs, input []*config.StashConfigInput) bool {
+	if len(existing) != len(input) {
+		return true
+	}
+
+	for i, s := range input {
+		if *existing[i] != config.StashConfig(*s) {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (r *mutationResolver) ConfigureGeneral(ctx context.Context, input ConfigGeneralInput) (*ConfigGeneralResult, error) {
 	c := config.GetInstance()
 
+	// the library watcher is only restarted if the watched paths change
+	refreshLibraryWatcher := false
+
 	existingPaths := c.GetStashPaths()
 	if input.Stashes != nil {
 		for _, s := range input.Stashes {
@@ -101,8 +121,19 @@ func (r *mutationResolver) ConfigureGeneral(ctx context.Context, input ConfigGen
 					return makeConfigGeneralResult(), err
 				}
 			}
+
+			if s.TrashPath != "" {
+				if fsutil.IsPathInDir(s.TrashPath, s.Path) {
+					return makeConfigGeneralResult(), fmt.Errorf("trash path %s cannot contain library path %s", s.TrashPath, s.Path)
+				}
+
+				if err := fsutil.EnsureDir(s.TrashPath); err != nil {
+					return makeConfigGeneralResult(), fmt.Errorf("creating trash directory: %w", err)
+				}
+			}
 		}
 		c.SetInterface(config.Stash, input.Stashes)
+		refreshLibraryWatcher = refreshLibraryWatcher || stashesChanged(existingPaths, input.Stashes)
 	}
 
 	checkConfigOverride := func(key string) error {
@@ -156,6 +187,7 @@ func (r *mutationResolver) ConfigureGeneral(ctx context.Context, input ConfigGen
 		}
 
 		c.SetString(config.Generated, *input.GeneratedPath)
+		refreshLibraryWatcher = true
 	}
 
 	refreshScraperCache := false
@@ -269,6 +301,9 @@ func (r *mutationResolver) ConfigureGeneral(ctx context.Context, input ConfigGen
 
 	r.setConfigBool(config.CalculateMD5, input.CalculateMd5)
 	r.setConfigInt(config.ParallelTasks, input.ParallelTasks)
+	r.setConfigInt(config.IOJobConcurrency, input.IoJobConcurrency)
+	r.setConfigInt(config.CPUJobConcurrency, input.CPUJobConcurrency)
+	r.setConfigInt(config.NetworkJobConcurrency, input.NetworkJobConcurrency)
 	r.setConfigBool(config.PreviewAudio, input.PreviewAudio)
 	r.setConfigInt(config.PreviewSegments, input.PreviewSegments)
 	r.setConfigFloat(config.PreviewSegmentDuration, input.PreviewSegmentDuration)
@@ -283,6 +318,13 @@ func (r *mutationResolver) ConfigureGeneral(ctx context.Context, input ConfigGen
 		c.SetString(config.MaxTranscodeSize, input.MaxTranscodeSize.String())
 	}
 
+	if input.TranscodeVideoCodec != nil {
+		c.SetString(config.TranscodeVideoCodec, input.TranscodeVideoCodec.String())
+	}
+
+	r.setConfigInt(config.MaxLiveTranscodes, input.MaxLiveTranscodes)
+	r.setConfigInt(config.MaxLiveTranscodesPerClient, input.MaxLiveTranscodesPerClient)
+
 	if input.MaxStreamingTranscodeSize != nil {
 		c.SetString(config.MaxStreamingTranscodeSize, input.MaxStreamingTranscodeSize.String())
 	}
@@ -298,7 +340,13 @@ func (r *mutationResolver) ConfigureGeneral(ctx context.Context, input ConfigGen
 		c.SetString(config.GalleryCoverRegex, *input.GalleryCoverRegex)
 	}
 
+	r.setConfigString(config.ChapterMarkerPrimaryTag, input.ChapterMarkerPrimaryTag)
+
+	previousUsername := c.GetUsername()
+	credentialsChanged := false
+
 	if input.Username != nil && *input.Username != c.GetUsername() {
+		credentialsChanged = true
 		c.SetString(config.Username, *input.Username)
 		if *input.Password == "" {
 			logger.Info("Username cleared")
@@ -318,11 +366,20 @@ func (r *mutationResolver) ConfigureGeneral(ctx context.Context, input ConfigGen
 			} else {
 				logger.Info("Password changed")
 			}
+			credentialsChanged = true
 			c.SetPassword(*input.Password)
 		}
 	}
 
+	if credentialsChanged {
+		if _, err := manager.GetInstance().UserService.RenameAdmin(ctx, previousUsername); err != nil {
+			return makeConfigGeneralResult(), err
+		}
+		manager.GetInstance().SessionStore.ClearCache()
+	}
+
 	r.setConfigInt(config.MaxSessionAge, input.MaxSessionAge)
+	r.setConfigInt(config.TrashRetentionDays, input.TrashRetentionDays)
 	r.setConfigString(config.LogFile, input.LogFile)
 	r.setConfigBool(config.LogOut, input.LogOut)
 	r.setConfigBool(config.LogAccess, input.LogAccess)
@@ -340,6 +397,7 @@ func (r *mutationResolver) ConfigureGeneral(ctx context.Context, input ConfigGen
 				return makeConfigGeneralResult(), fmt.Errorf("video exclusion pattern '%v' invalid: %w", r, err)
 			}
 		}
+		refreshLibraryWatcher = refreshLibraryWatcher || !slices.Equal(input.Excludes, c.GetExcludes())
 		c.SetInterface(config.Exclude, input.Excludes)
 	}
 
@@ -350,6 +408,7 @@ func (r *mutationResolver) ConfigureGeneral(ctx context.Context, input ConfigGen
 				return makeConfigGeneralResult(), fmt.Errorf("image/gallery exclusion pattern '%v' invalid: %w", r, err)
 			}
 		}
+		refreshLibraryWatcher = refreshLibraryWatcher || !slices.Equal(input.ImageExcludes, c.GetImageExcludes())
 		c.SetInterface(config.ImageExclude, input.ImageExcludes)
 	}
 
@@ -366,6 +425,10 @@ func (r *mutationResolver) ConfigureGeneral(ctx context.Context, input ConfigGen
 	}
 
 	r.setConfigBool(config.CreateGalleriesFromFolders, input.CreateGalleriesFromFolders)
+	if input.WatchLibrary != nil && *input.WatchLibrary != c.GetWatchLibrary() {
+		c.SetBool(config.WatchLibrary, *input.WatchLibrary)
+		refreshLibraryWatcher = true
+	}
 
 	if input.CustomPerformerImageLocation != nil {
 		c.SetString(config.CustomPerformerImageLocation, *input.CustomPerformerImageLocation)
@@ -413,6 +476,9 @@ func (r *mutationResolver) ConfigureGeneral(ctx context.Context, input ConfigGen
 	}
 
 	manager.GetInstance().RefreshConfig()
+	if refreshLibraryWatcher {
+		manager.GetInstance().RefreshLibraryWatcher()
+	}
 	if refreshScraperCache {
 		manager.GetInstance().RefreshScraperCache()
 	}
@@ -636,6 +702,8 @@ func (r *mutationResolver) GenerateAPIKey(ctx context.Context, input GenerateAPI
 		return newAPIKey, err
 	}
 
+	manager.GetInstance().SessionStore.ClearCache()
+
 	return newAPIKey, nil
 }
 
@@ -0,0 +1,101 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+func validateCustomFieldName(ctx context.Context, qb models.CustomFieldDefinitionReader, entityType models.CustomFieldEntityType, name string, id int) error {
+	if name == "" {
+		return errors.New("name must be non-empty")
+	}
+
+	existing, err := qb.FindByName(ctx, entityType, name)
+	if err != nil {
+		return err
+	}
+
+	if existing != nil && existing.ID != id {
+		return fmt.Errorf("custom field %q already exists for %s", name, entityType)
+	}
+
+	return nil
+}
+
+func (r *mutationResolver) CustomFieldDefinitionCreate(ctx context.Context, input CustomFieldDefinitionCreateInput) (*models.CustomFieldDefinition, error) {
+	currentTime := time.Now()
+	newDefinition := models.CustomFieldDefinition{
+		EntityType: input.EntityType,
+		Name:       strings.TrimSpace(input.Name),
+		Type:       input.Type,
+		CreatedAt:  currentTime,
+		UpdatedAt:  currentTime,
+	}
+
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		qb := r.repository.CustomField
+
+		if err := validateCustomFieldName(ctx, qb, newDefinition.EntityType, newDefinition.Name, 0); err != nil {
+			return err
+		}
+
+		return qb.Create(ctx, &newDefinition)
+	}); err != nil {
+		return nil, err
+	}
+
+	return &newDefinition, nil
+}
+
+func (r *mutationResolver) CustomFieldDefinitionUpdate(ctx context.Context, input CustomFieldDefinitionUpdateInput) (ret *models.CustomFieldDefinition, err error) {
+	id, err := strconv.Atoi(input.ID)
+	if err != nil {
+		return nil, fmt.Errorf("converting id: %w", err)
+	}
+
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		qb := r.repository.CustomField
+
+		ret, err = qb.Find(ctx, id)
+		if err != nil {
+			return err
+		}
+		if ret == nil {
+			return fmt.Errorf("custom field definition with id %d not found", id)
+		}
+
+		ret.Name = strings.TrimSpace(input.Name)
+		ret.UpdatedAt = time.Now()
+
+		if err := validateCustomFieldName(ctx, qb, ret.EntityType, ret.Name, ret.ID); err != nil {
+			return err
+		}
+
+		return qb.Update(ctx, ret)
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (r *mutationResolver) CustomFieldDefinitionDestroy(ctx context.Context, id string) (bool, error) {
+	idInt, err := strconv.Atoi(id)
+	if err != nil {
+		return false, fmt.Errorf("converting id: %w", err)
+	}
+
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		return r.repository.CustomField.Destroy(ctx, idInt)
+	}); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
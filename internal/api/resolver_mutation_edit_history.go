@@ -0,0 +1,108 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+// decodeEditInput decodes the provided update input map into the update
+// input of the edited object.
+func decodeEditInput(inputMap map[string]interface{}, input interface{}) error {
+	encoded, err := json.Marshal(inputMap)
+	if err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(encoded, input); err != nil {
+		return fmt.Errorf("decoding update input: %w", err)
+	}
+
+	return nil
+}
+
+func (r *mutationResolver) RevertEdit(ctx context.Context, id string) (bool, error) {
+	editID, err := strconv.Atoi(id)
+	if err != nil {
+		return false, fmt.Errorf("converting id: %w", err)
+	}
+
+	var edit *models.EditHistory
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		edit, err = r.repository.EditHistory.Find(ctx, editID)
+		return err
+	}); err != nil {
+		return false, err
+	}
+
+	if edit == nil {
+		return false, fmt.Errorf("edit with id %d not found", editID)
+	}
+
+	// restore the previous values of the changed fields using the
+	// update input of the object
+	inputMap := edit.OldValues()
+	inputMap["id"] = strconv.Itoa(edit.ObjectID)
+
+	translator := changesetTranslator{
+		inputMap: inputMap,
+	}
+
+	ctx = models.WithEditSource(ctx, models.EditSourceRevert)
+
+	switch edit.ObjectType {
+	case models.EditObjectTypeScene:
+		var input models.SceneUpdateInput
+		if err := decodeEditInput(inputMap, &input); err != nil {
+			return false, err
+		}
+		_, err = r.applySceneUpdate(ctx, input, translator)
+	case models.EditObjectTypeImage:
+		var input ImageUpdateInput
+		if err := decodeEditInput(inputMap, &input); err != nil {
+			return false, err
+		}
+		_, err = r.applyImageUpdate(ctx, input, translator)
+	case models.EditObjectTypeGallery:
+		var input models.GalleryUpdateInput
+		if err := decodeEditInput(inputMap, &input); err != nil {
+			return false, err
+		}
+		_, err = r.applyGalleryUpdate(ctx, input, translator)
+	case models.EditObjectTypePerformer:
+		var input models.PerformerUpdateInput
+		if err := decodeEditInput(inputMap, &input); err != nil {
+			return false, err
+		}
+		_, err = r.applyPerformerUpdate(ctx, input, translator)
+	case models.EditObjectTypeStudio:
+		var input models.StudioUpdateInput
+		if err := decodeEditInput(inputMap, &input); err != nil {
+			return false, err
+		}
+		_, err = r.applyStudioUpdate(ctx, input, translator)
+	case models.EditObjectTypeTag:
+		var input TagUpdateInput
+		if err := decodeEditInput(inputMap, &input); err != nil {
+			return false, err
+		}
+		_, err = r.applyTagUpdate(ctx, input, translator)
+	case models.EditObjectTypeGroup:
+		var input GroupUpdateInput
+		if err := decodeEditInput(inputMap, &input); err != nil {
+			return false, err
+		}
+		_, err = r.applyGroupUpdate(ctx, input, translator)
+	default:
+		return false, fmt.Errorf("unsupported object type %s", edit.ObjectType)
+	}
+
+	if err != nil {
+		return false, fmt.Errorf("reverting edit %d: %w", editID, err)
+	}
+
+	return true, nil
+}
@@ -149,7 +149,7 @@ func (r *mutationResolver) DeleteFiles(ctx context.Context, ids []string) (ret b
 		return false, fmt.Errorf("converting ids: %w", err)
 	}
 
-	fileDeleter := file.NewDeleter()
+	fileDeleter := manager.GetInstance().NewFileDeleter()
 	destroyer := &file.ZipDestroyer{
 		FileDestroyer:   r.repository.File,
 		FolderDestroyer: r.repository.Folder,
@@ -208,6 +208,29 @@ func (r *mutationResolver) DeleteFiles(ctx context.Context, ids []string) (ret b
 	return true, nil
 }
 
+func (r *mutationResolver) RestoreTrashedFiles(ctx context.Context, ids []string) (string, error) {
+	trashedIDs, err := stringslice.StringSliceToIntSlice(ids)
+	if err != nil {
+		return "", fmt.Errorf("converting ids: %w", err)
+	}
+
+	jobID, err := manager.GetInstance().RestoreTrashedFiles(ctx, trashedIDs)
+	if err != nil {
+		return "", err
+	}
+
+	return strconv.Itoa(jobID), nil
+}
+
+func (r *mutationResolver) RenameFiles(ctx context.Context, input manager.RenameFilesInput) (string, error) {
+	jobID, err := manager.GetInstance().RenameFiles(ctx, input)
+	if err != nil {
+		return "", err
+	}
+
+	return strconv.Itoa(jobID), nil
+}
+
 func (r *mutationResolver) FileSetFingerprints(ctx context.Context, input FileSetFingerprintsInput) (bool, error) {
 	fileIDInt, err := strconv.Atoi(input.ID)
 	if err != nil {
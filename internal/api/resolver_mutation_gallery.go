@@ -8,7 +8,6 @@ import (
 	"strconv"
 
 	"github.com/stashapp/stash/internal/manager"
-	"github.com/stashapp/stash/pkg/file"
 	"github.com/stashapp/stash/pkg/gallery"
 	"github.com/stashapp/stash/pkg/image"
 	"github.com/stashapp/stash/pkg/models"
@@ -31,15 +30,19 @@ func (r *mutationResolver) getGallery(ctx context.Context, id int) (ret *models.
 }
 
 func (r *mutationResolver) GalleryCreate(ctx context.Context, input GalleryCreateInput) (*models.Gallery, error) {
+	translator := changesetTranslator{
+		inputMap: getUpdateInputMap(ctx),
+	}
+
+	if err := r.executePreHooks(ctx, 0, hook.GalleryCreatePre, &input, &translator); err != nil {
+		return nil, err
+	}
+
 	// name must be provided
 	if input.Title == "" {
 		return nil, errors.New("title must not be empty")
 	}
 
-	translator := changesetTranslator{
-		inputMap: getUpdateInputMap(ctx),
-	}
-
 	// Populate a new gallery from the input
 	newGallery := models.NewGallery()
 
@@ -86,6 +89,14 @@ func (r *mutationResolver) GalleryCreate(ctx context.Context, input GalleryCreat
 			return err
 		}
 
+		if len(input.CustomFields) > 0 {
+			if err := qb.SetCustomFields(ctx, newGallery.ID, models.CustomFieldsInput{
+				Full: input.CustomFields,
+			}); err != nil {
+				return fmt.Errorf("setting custom fields: %w", err)
+			}
+		}
+
 		return nil
 	}); err != nil {
 		return nil, err
@@ -100,6 +111,21 @@ func (r *mutationResolver) GalleryUpdate(ctx context.Context, input models.Galle
 		inputMap: getUpdateInputMap(ctx),
 	}
 
+	return r.applyGalleryUpdate(ctx, input, translator)
+}
+
+// applyGalleryUpdate updates the gallery using the provided translator, executing
+// the update hooks.
+func (r *mutationResolver) applyGalleryUpdate(ctx context.Context, input models.GalleryUpdateInput, translator changesetTranslator) (ret *models.Gallery, err error) {
+	galleryID, err := strconv.Atoi(input.ID)
+	if err != nil {
+		return nil, fmt.Errorf("converting id: %w", err)
+	}
+
+	if err := r.executePreHooks(ctx, galleryID, hook.GalleryUpdatePre, &input, &translator); err != nil {
+		return nil, err
+	}
+
 	// Start the transaction and save the gallery
 	if err := r.withTxn(ctx, func(ctx context.Context) error {
 		ret, err = r.galleryUpdate(ctx, input, translator)
@@ -116,6 +142,28 @@ func (r *mutationResolver) GalleryUpdate(ctx context.Context, input models.Galle
 func (r *mutationResolver) GalleriesUpdate(ctx context.Context, input []*models.GalleryUpdateInput) (ret []*models.Gallery, err error) {
 	inputMaps := getUpdateInputMaps(ctx)
 
+	// execute pre hooks outside txn
+	// the pre hooks of all objects share a single deadline
+	hookCtx, cancel := withPreHookDeadline(ctx)
+	defer cancel()
+
+	for i, gallery := range input {
+		galleryID, err := strconv.Atoi(gallery.ID)
+		if err != nil {
+			return nil, fmt.Errorf("converting id: %w", err)
+		}
+
+		translator := changesetTranslator{
+			inputMap: inputMaps[i],
+		}
+
+		if err := r.executePreHooks(hookCtx, galleryID, hook.GalleryUpdatePre, gallery, &translator); err != nil {
+			return nil, err
+		}
+
+		inputMaps[i] = translator.inputMap
+	}
+
 	// Start the transaction and save the galleries
 	if err := r.withTxn(ctx, func(ctx context.Context) error {
 		for i, gallery := range input {
@@ -246,6 +294,12 @@ func (r *mutationResolver) galleryUpdate(ctx context.Context, input models.Galle
 		return nil, err
 	}
 
+	if input.CustomFields != nil {
+		if err := qb.SetCustomFields(ctx, galleryID, *input.CustomFields); err != nil {
+			return nil, fmt.Errorf("setting custom fields: %w", err)
+		}
+	}
+
 	return gallery, nil
 }
 
@@ -259,6 +313,11 @@ func (r *mutationResolver) BulkGalleryUpdate(ctx context.Context, input BulkGall
 		inputMap: getUpdateInputMap(ctx),
 	}
 
+	// execute pre hooks outside of txn
+	if err := r.executeBulkPreHooks(ctx, galleryIDs, hook.GalleryUpdatePre, &input, &translator); err != nil {
+		return nil, err
+	}
+
 	// Populate gallery from the input
 	updatedGallery := models.NewGalleryPartial()
 
@@ -333,10 +392,14 @@ func (r *mutationResolver) GalleryDestroy(ctx context.Context, input models.Gall
 		return false, fmt.Errorf("converting ids: %w", err)
 	}
 
+	if err := r.executeBulkPreHooks(ctx, galleryIDs, hook.GalleryDestroyPre, &input, nil); err != nil {
+		return false, err
+	}
+
 	var galleries []*models.Gallery
 	var imgsDestroyed []*models.Image
 	fileDeleter := &image.FileDeleter{
-		Deleter: file.NewDeleter(),
+		Deleter: manager.GetInstance().NewFileDeleter(),
 		Paths:   manager.GetInstance().Paths,
 	}
 
@@ -545,6 +608,14 @@ func (r *mutationResolver) getGalleryChapter(ctx context.Context, id int) (ret *
 }
 
 func (r *mutationResolver) GalleryChapterCreate(ctx context.Context, input GalleryChapterCreateInput) (*models.GalleryChapter, error) {
+	translator := changesetTranslator{
+		inputMap: getUpdateInputMap(ctx),
+	}
+
+	if err := r.executePreHooks(ctx, 0, hook.GalleryChapterCreatePre, &input, &translator); err != nil {
+		return nil, err
+	}
+
 	galleryID, err := strconv.Atoi(input.GalleryID)
 	if err != nil {
 		return nil, fmt.Errorf("converting gallery id: %w", err)
@@ -588,6 +659,10 @@ func (r *mutationResolver) GalleryChapterUpdate(ctx context.Context, input Galle
 		inputMap: getUpdateInputMap(ctx),
 	}
 
+	if err := r.executePreHooks(ctx, chapterID, hook.GalleryChapterUpdatePre, &input, &translator); err != nil {
+		return nil, err
+	}
+
 	// Populate gallery chapter from the input
 	updatedChapter := models.NewGalleryChapterPartial()
 
@@ -650,6 +725,10 @@ func (r *mutationResolver) GalleryChapterDestroy(ctx context.Context, id string)
 		return false, fmt.Errorf("converting id: %w", err)
 	}
 
+	if err := r.executePreHooks(ctx, chapterID, hook.GalleryChapterDestroyPre, &id, nil); err != nil {
+		return false, err
+	}
+
 	if err := r.withTxn(ctx, func(ctx context.Context) error {
 		qb := r.repository.GalleryChapter
 
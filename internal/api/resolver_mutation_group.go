@@ -13,11 +13,7 @@ import (
 	"github.com/stashapp/stash/pkg/utils"
 )
 
-func groupFromGroupCreateInput(ctx context.Context, input GroupCreateInput) (*models.Group, error) {
-	translator := changesetTranslator{
-		inputMap: getUpdateInputMap(ctx),
-	}
-
+func groupFromGroupCreateInput(translator changesetTranslator, input GroupCreateInput) (*models.Group, error) {
 	// Populate a new group from the input
 	newGroup := models.NewGroup()
 
@@ -62,7 +58,15 @@ func groupFromGroupCreateInput(ctx context.Context, input GroupCreateInput) (*mo
 }
 
 func (r *mutationResolver) GroupCreate(ctx context.Context, input GroupCreateInput) (*models.Group, error) {
-	newGroup, err := groupFromGroupCreateInput(ctx, input)
+	translator := changesetTranslator{
+		inputMap: getUpdateInputMap(ctx),
+	}
+
+	if err := r.executePreHooks(ctx, 0, hook.GroupCreatePre, &input, &translator); err != nil {
+		return nil, err
+	}
+
+	newGroup, err := groupFromGroupCreateInput(translator, input)
 	if err != nil {
 		return nil, err
 	}
@@ -97,6 +101,15 @@ func (r *mutationResolver) GroupCreate(ctx context.Context, input GroupCreateInp
 			return err
 		}
 
+		qb := r.repository.Group
+		if len(input.CustomFields) > 0 {
+			if err := qb.SetCustomFields(ctx, newGroup.ID, models.CustomFieldsInput{
+				Full: input.CustomFields,
+			}); err != nil {
+				return fmt.Errorf("setting custom fields: %w", err)
+			}
+		}
+
 		return nil
 	}); err != nil {
 		return nil, err
@@ -154,13 +167,23 @@ func groupPartialFromGroupUpdateInput(translator changesetTranslator, input Grou
 }
 
 func (r *mutationResolver) GroupUpdate(ctx context.Context, input GroupUpdateInput) (*models.Group, error) {
+	translator := changesetTranslator{
+		inputMap: getUpdateInputMap(ctx),
+	}
+
+	return r.applyGroupUpdate(ctx, input, translator)
+}
+
+// applyGroupUpdate updates the group using the provided translator, executing
+// the update hooks.
+func (r *mutationResolver) applyGroupUpdate(ctx context.Context, input GroupUpdateInput, translator changesetTranslator) (*models.Group, error) {
 	groupID, err := strconv.Atoi(input.ID)
 	if err != nil {
 		return nil, fmt.Errorf("converting id: %w", err)
 	}
 
-	translator := changesetTranslator{
-		inputMap: getUpdateInputMap(ctx),
+	if err := r.executePreHooks(ctx, groupID, hook.GroupUpdatePre, &input, &translator); err != nil {
+		return nil, err
 	}
 
 	updatedGroup, err := groupPartialFromGroupUpdateInput(translator, input)
@@ -202,6 +225,13 @@ func (r *mutationResolver) GroupUpdate(ctx context.Context, input GroupUpdateInp
 			return err
 		}
 
+		qb := r.repository.Group
+		if input.CustomFields != nil {
+			if err := qb.SetCustomFields(ctx, groupID, *input.CustomFields); err != nil {
+				return fmt.Errorf("setting custom fields: %w", err)
+			}
+		}
+
 		return nil
 	}); err != nil {
 		return nil, err
@@ -258,6 +288,11 @@ func (r *mutationResolver) BulkGroupUpdate(ctx context.Context, input BulkGroupU
 		inputMap: getUpdateInputMap(ctx),
 	}
 
+	// execute pre hooks outside of txn
+	if err := r.executeBulkPreHooks(ctx, groupIDs, hook.GroupUpdatePre, &input, &translator); err != nil {
+		return nil, err
+	}
+
 	// Populate group from the input
 	updatedGroup, err := groupPartialFromBulkGroupUpdateInput(translator, input)
 	if err != nil {
@@ -304,6 +339,10 @@ func (r *mutationResolver) GroupDestroy(ctx context.Context, input GroupDestroyI
 		return false, fmt.Errorf("converting id: %w", err)
 	}
 
+	if err := r.executePreHooks(ctx, id, hook.GroupDestroyPre, &input, nil); err != nil {
+		return false, err
+	}
+
 	if err := r.withTxn(ctx, func(ctx context.Context) error {
 		return r.repository.Group.Destroy(ctx, id)
 	}); err != nil {
@@ -323,6 +362,10 @@ func (r *mutationResolver) GroupsDestroy(ctx context.Context, groupIDs []string)
 		return false, fmt.Errorf("converting ids: %w", err)
 	}
 
+	if err := r.executeBulkPreHooks(ctx, ids, hook.GroupDestroyPre, &groupIDs, nil); err != nil {
+		return false, err
+	}
+
 	if err := r.withTxn(ctx, func(ctx context.Context) error {
 		qb := r.repository.Group
 		for _, id := range ids {
@@ -6,7 +6,6 @@ import (
 	"strconv"
 
 	"github.com/stashapp/stash/internal/manager"
-	"github.com/stashapp/stash/pkg/file"
 	"github.com/stashapp/stash/pkg/image"
 	"github.com/stashapp/stash/pkg/models"
 	"github.com/stashapp/stash/pkg/plugin"
@@ -33,6 +32,21 @@ func (r *mutationResolver) ImageUpdate(ctx context.Context, input ImageUpdateInp
 		inputMap: getUpdateInputMap(ctx),
 	}
 
+	return r.applyImageUpdate(ctx, input, translator)
+}
+
+// applyImageUpdate updates the image using the provided translator, executing
+// the update hooks.
+func (r *mutationResolver) applyImageUpdate(ctx context.Context, input ImageUpdateInput, translator changesetTranslator) (ret *models.Image, err error) {
+	imageID, err := strconv.Atoi(input.ID)
+	if err != nil {
+		return nil, fmt.Errorf("converting id: %w", err)
+	}
+
+	if err := r.executePreHooks(ctx, imageID, hook.ImageUpdatePre, &input, &translator); err != nil {
+		return nil, err
+	}
+
 	// Start the transaction and save the image
 	if err := r.withTxn(ctx, func(ctx context.Context) error {
 		ret, err = r.imageUpdate(ctx, input, translator)
@@ -49,6 +63,28 @@ func (r *mutationResolver) ImageUpdate(ctx context.Context, input ImageUpdateInp
 func (r *mutationResolver) ImagesUpdate(ctx context.Context, input []*ImageUpdateInput) (ret []*models.Image, err error) {
 	inputMaps := getUpdateInputMaps(ctx)
 
+	// execute pre hooks outside txn
+	// the pre hooks of all objects share a single deadline
+	hookCtx, cancel := withPreHookDeadline(ctx)
+	defer cancel()
+
+	for i, image := range input {
+		imageID, err := strconv.Atoi(image.ID)
+		if err != nil {
+			return nil, fmt.Errorf("converting id: %w", err)
+		}
+
+		translator := changesetTranslator{
+			inputMap: inputMaps[i],
+		}
+
+		if err := r.executePreHooks(hookCtx, imageID, hook.ImageUpdatePre, image, &translator); err != nil {
+			return nil, err
+		}
+
+		inputMaps[i] = translator.inputMap
+	}
+
 	// Start the transaction and save the image
 	if err := r.withTxn(ctx, func(ctx context.Context) error {
 		for i, image := range input {
@@ -190,6 +226,12 @@ func (r *mutationResolver) imageUpdate(ctx context.Context, input ImageUpdateInp
 		}
 	}
 
+	if input.CustomFields != nil {
+		if err := qb.SetCustomFields(ctx, imageID, *input.CustomFields); err != nil {
+			return nil, fmt.Errorf("setting custom fields: %w", err)
+		}
+	}
+
 	return image, nil
 }
 
@@ -203,6 +245,11 @@ func (r *mutationResolver) BulkImageUpdate(ctx context.Context, input BulkImageU
 		inputMap: getUpdateInputMap(ctx),
 	}
 
+	// execute pre hooks outside of txn
+	if err := r.executeBulkPreHooks(ctx, imageIDs, hook.ImageUpdatePre, &input, &translator); err != nil {
+		return nil, err
+	}
+
 	// Populate image from the input
 	updatedImage := models.NewImagePartial()
 
@@ -308,9 +355,13 @@ func (r *mutationResolver) ImageDestroy(ctx context.Context, input models.ImageD
 		return false, fmt.Errorf("converting id: %w", err)
 	}
 
+	if err := r.executePreHooks(ctx, imageID, hook.ImageDestroyPre, &input, nil); err != nil {
+		return false, err
+	}
+
 	var i *models.Image
 	fileDeleter := &image.FileDeleter{
-		Deleter: file.NewDeleter(),
+		Deleter: manager.GetInstance().NewFileDeleter(),
 		Paths:   manager.GetInstance().Paths,
 	}
 	if err := r.withTxn(ctx, func(ctx context.Context) error {
@@ -348,9 +399,13 @@ func (r *mutationResolver) ImagesDestroy(ctx context.Context, input models.Image
 		return false, fmt.Errorf("converting ids: %w", err)
 	}
 
+	if err := r.executeBulkPreHooks(ctx, imageIDs, hook.ImageDestroyPre, &input, nil); err != nil {
+		return false, err
+	}
+
 	var images []*models.Image
 	fileDeleter := &image.FileDeleter{
-		Deleter: file.NewDeleter(),
+		Deleter: manager.GetInstance().NewFileDeleter(),
 		Paths:   manager.GetInstance().Paths,
 	}
 	if err := r.withTxn(ctx, func(ctx context.Context) error {
@@ -22,3 +22,42 @@ func (r *mutationResolver) StopAllJobs(ctx context.Context) (bool, error) {
 	manager.GetInstance().JobManager.CancelAll()
 	return true, nil
 }
+
+func (r *mutationResolver) SetJobPriority(ctx context.Context, jobID string, priority int) (bool, error) {
+	id, err := strconv.Atoi(jobID)
+	if err != nil {
+		return false, fmt.Errorf("converting id: %w", err)
+	}
+
+	if err := manager.GetInstance().JobManager.SetPriority(id, priority); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (r *mutationResolver) PauseJob(ctx context.Context, jobID string) (bool, error) {
+	id, err := strconv.Atoi(jobID)
+	if err != nil {
+		return false, fmt.Errorf("converting id: %w", err)
+	}
+
+	if err := manager.GetInstance().JobManager.Pause(id); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (r *mutationResolver) ResumeJob(ctx context.Context, jobID string) (bool, error) {
+	id, err := strconv.Atoi(jobID)
+	if err != nil {
+		return false, fmt.Errorf("converting id: %w", err)
+	}
+
+	if err := manager.GetInstance().JobManager.Resume(id); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
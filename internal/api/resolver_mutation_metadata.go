@@ -11,6 +11,7 @@ import (
 	"github.com/stashapp/stash/internal/manager"
 	"github.com/stashapp/stash/internal/manager/config"
 	"github.com/stashapp/stash/internal/manager/task"
+	"github.com/stashapp/stash/pkg/job"
 	"github.com/stashapp/stash/pkg/logger"
 )
 
@@ -53,6 +54,15 @@ func (r *mutationResolver) MetadataExport(ctx context.Context) (string, error) {
 	return strconv.Itoa(jobID), nil
 }
 
+func (r *mutationResolver) MetadataExportNfo(ctx context.Context, input manager.ExportNFOInput) (string, error) {
+	jobID, err := manager.GetInstance().ExportNFO(ctx, input)
+	if err != nil {
+		return "", err
+	}
+
+	return strconv.Itoa(jobID), nil
+}
+
 func (r *mutationResolver) ExportObjects(ctx context.Context, input manager.ExportObjectsInput) (*string, error) {
 	t := manager.CreateExportTask(config.GetInstance().GetVideoFileNamingAlgorithm(), input)
 
@@ -88,8 +98,7 @@ func (r *mutationResolver) MetadataAutoTag(ctx context.Context, input manager.Au
 }
 
 func (r *mutationResolver) MetadataIdentify(ctx context.Context, input identify.Options) (string, error) {
-	t := manager.CreateIdentifyJob(input)
-	jobID := manager.GetInstance().JobManager.Add(ctx, "Identifying...", t)
+	jobID := manager.GetInstance().Identify(ctx, input)
 
 	return strconv.Itoa(jobID), nil
 }
@@ -109,7 +118,10 @@ func (r *mutationResolver) MetadataCleanGenerated(ctx context.Context, input tas
 		Repository:               mgr.Repository,
 		BlobCleaner:              mgr.Repository.Blob,
 	}
-	jobID := mgr.JobManager.Add(ctx, "Cleaning generated files...", t)
+	jobID := mgr.JobManager.AddWithOptions(ctx, "Cleaning generated files...", t, job.Options{
+		ResourceClass: job.ResourceClassIO,
+		Exclusive:     true,
+	})
 
 	return strconv.Itoa(jobID), nil
 }
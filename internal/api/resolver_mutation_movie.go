@@ -29,6 +29,11 @@ func (r *mutationResolver) MovieCreate(ctx context.Context, input MovieCreateInp
 		inputMap: getUpdateInputMap(ctx),
 	}
 
+	// movies are groups, so run the group hooks
+	if err := r.executePreHooks(ctx, 0, hook.GroupCreatePre, &input, &translator); err != nil {
+		return nil, err
+	}
+
 	// Populate a new group from the input
 	newGroup := models.NewGroup()
 
@@ -128,6 +133,11 @@ func (r *mutationResolver) MovieUpdate(ctx context.Context, input MovieUpdateInp
 		inputMap: getUpdateInputMap(ctx),
 	}
 
+	// movies are groups, so run the group hooks
+	if err := r.executePreHooks(ctx, groupID, hook.GroupUpdatePre, &input, &translator); err != nil {
+		return nil, err
+	}
+
 	// Populate group from the input
 	updatedGroup := models.NewGroupPartial()
 
@@ -215,6 +225,11 @@ func (r *mutationResolver) BulkMovieUpdate(ctx context.Context, input BulkMovieU
 		inputMap: getUpdateInputMap(ctx),
 	}
 
+	// execute pre hooks outside of txn
+	if err := r.executeBulkPreHooks(ctx, groupIDs, hook.GroupUpdatePre, &input, &translator); err != nil {
+		return nil, err
+	}
+
 	// Populate group from the input
 	updatedGroup := models.NewGroupPartial()
 
@@ -275,6 +290,10 @@ func (r *mutationResolver) MovieDestroy(ctx context.Context, input MovieDestroyI
 		return false, fmt.Errorf("converting id: %w", err)
 	}
 
+	if err := r.executePreHooks(ctx, id, hook.GroupDestroyPre, &input, nil); err != nil {
+		return false, err
+	}
+
 	if err := r.withTxn(ctx, func(ctx context.Context) error {
 		return r.repository.Group.Destroy(ctx, id)
 	}); err != nil {
@@ -294,6 +313,10 @@ func (r *mutationResolver) MoviesDestroy(ctx context.Context, groupIDs []string)
 		return false, fmt.Errorf("converting ids: %w", err)
 	}
 
+	if err := r.executeBulkPreHooks(ctx, ids, hook.GroupDestroyPre, &groupIDs, nil); err != nil {
+		return false, err
+	}
+
 	if err := r.withTxn(ctx, func(ctx context.Context) error {
 		qb := r.repository.Group
 		for _, id := range ids {
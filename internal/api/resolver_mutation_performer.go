@@ -2,6 +2,7 @@ package api
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strconv"
 
@@ -34,6 +35,10 @@ func (r *mutationResolver) PerformerCreate(ctx context.Context, input models.Per
 		inputMap: getUpdateInputMap(ctx),
 	}
 
+	if err := r.executePreHooks(ctx, 0, hook.PerformerCreatePre, &input, &translator); err != nil {
+		return nil, err
+	}
+
 	// Populate a new performer from the input
 	newPerformer := models.NewPerformer()
 
@@ -120,6 +125,14 @@ func (r *mutationResolver) PerformerCreate(ctx context.Context, input models.Per
 			}
 		}
 
+		if len(input.CustomFields) > 0 {
+			if err := qb.SetCustomFields(ctx, newPerformer.ID, models.CustomFieldsInput{
+				Full: input.CustomFields,
+			}); err != nil {
+				return fmt.Errorf("setting custom fields: %w", err)
+			}
+		}
+
 		return nil
 	}); err != nil {
 		return nil, err
@@ -219,17 +232,7 @@ func (r *mutationResolver) handleLegacyURLs(ctx context.Context, performerID int
 	return nil
 }
 
-func (r *mutationResolver) PerformerUpdate(ctx context.Context, input models.PerformerUpdateInput) (*models.Performer, error) {
-	performerID, err := strconv.Atoi(input.ID)
-	if err != nil {
-		return nil, fmt.Errorf("converting id: %w", err)
-	}
-
-	translator := changesetTranslator{
-		inputMap: getUpdateInputMap(ctx),
-	}
-
-	// Populate performer from the input
+func performerPartialFromInput(input models.PerformerUpdateInput, translator changesetTranslator) (*models.PerformerPartial, error) {
 	updatedPerformer := models.NewPerformerPartial()
 
 	updatedPerformer.Name = translator.optionalString(input.Name, "name")
@@ -254,17 +257,10 @@ func (r *mutationResolver) PerformerUpdate(ctx context.Context, input models.Per
 	updatedPerformer.StashIDs = translator.updateStashIDs(input.StashIds, "stash_ids")
 
 	if translator.hasField("urls") {
-		// ensure url/twitter/instagram are not included in the input
-		if err := r.validateNoLegacyURLs(translator); err != nil {
-			return nil, err
-		}
-
 		updatedPerformer.URLs = translator.updateStrings(input.Urls, "urls")
 	}
 
-	legacyURL := translator.optionalString(input.URL, "url")
-	legacyTwitter := translator.optionalString(input.Twitter, "twitter")
-	legacyInstagram := translator.optionalString(input.Instagram, "instagram")
+	var err error
 
 	updatedPerformer.Birthdate, err = translator.optionalDate(input.Birthdate, "birthdate")
 	if err != nil {
@@ -290,6 +286,46 @@ func (r *mutationResolver) PerformerUpdate(ctx context.Context, input models.Per
 		return nil, fmt.Errorf("converting tag ids: %w", err)
 	}
 
+	return &updatedPerformer, nil
+}
+
+func (r *mutationResolver) PerformerUpdate(ctx context.Context, input models.PerformerUpdateInput) (*models.Performer, error) {
+	translator := changesetTranslator{
+		inputMap: getUpdateInputMap(ctx),
+	}
+
+	return r.applyPerformerUpdate(ctx, input, translator)
+}
+
+// applyPerformerUpdate updates the performer using the provided translator, executing
+// the update hooks.
+func (r *mutationResolver) applyPerformerUpdate(ctx context.Context, input models.PerformerUpdateInput, translator changesetTranslator) (*models.Performer, error) {
+	performerID, err := strconv.Atoi(input.ID)
+	if err != nil {
+		return nil, fmt.Errorf("converting id: %w", err)
+	}
+
+	if err := r.executePreHooks(ctx, performerID, hook.PerformerUpdatePre, &input, &translator); err != nil {
+		return nil, err
+	}
+
+	if translator.hasField("urls") {
+		// ensure url/twitter/instagram are not included in the input
+		if err := r.validateNoLegacyURLs(translator); err != nil {
+			return nil, err
+		}
+	}
+
+	// Populate performer from the input
+	updatedPerformer, err := performerPartialFromInput(input, translator)
+	if err != nil {
+		return nil, err
+	}
+
+	legacyURL := translator.optionalString(input.URL, "url")
+	legacyTwitter := translator.optionalString(input.Twitter, "twitter")
+	legacyInstagram := translator.optionalString(input.Instagram, "instagram")
+
 	var imageData []byte
 	imageIncluded := translator.hasField("image")
 	if input.Image != nil {
@@ -304,16 +340,16 @@ func (r *mutationResolver) PerformerUpdate(ctx context.Context, input models.Per
 		qb := r.repository.Performer
 
 		if legacyURL.Set || legacyTwitter.Set || legacyInstagram.Set {
-			if err := r.handleLegacyURLs(ctx, performerID, legacyURL, legacyTwitter, legacyInstagram, &updatedPerformer); err != nil {
+			if err := r.handleLegacyURLs(ctx, performerID, legacyURL, legacyTwitter, legacyInstagram, updatedPerformer); err != nil {
 				return err
 			}
 		}
 
-		if err := performer.ValidateUpdate(ctx, performerID, updatedPerformer, qb); err != nil {
+		if err := performer.ValidateUpdate(ctx, performerID, *updatedPerformer, qb); err != nil {
 			return err
 		}
 
-		_, err = qb.UpdatePartial(ctx, performerID, updatedPerformer)
+		_, err = qb.UpdatePartial(ctx, performerID, *updatedPerformer)
 		if err != nil {
 			return err
 		}
@@ -325,6 +361,12 @@ func (r *mutationResolver) PerformerUpdate(ctx context.Context, input models.Per
 			}
 		}
 
+		if input.CustomFields != nil {
+			if err := qb.SetCustomFields(ctx, performerID, *input.CustomFields); err != nil {
+				return fmt.Errorf("setting custom fields: %w", err)
+			}
+		}
+
 		return nil
 	}); err != nil {
 		return nil, err
@@ -344,6 +386,11 @@ func (r *mutationResolver) BulkPerformerUpdate(ctx context.Context, input BulkPe
 		inputMap: getUpdateInputMap(ctx),
 	}
 
+	// execute pre hooks outside of txn
+	if err := r.executeBulkPreHooks(ctx, performerIDs, hook.PerformerUpdatePre, &input, &translator); err != nil {
+		return nil, err
+	}
+
 	// Populate performer from the input
 	updatedPerformer := models.NewPerformerPartial()
 
@@ -451,12 +498,127 @@ func (r *mutationResolver) BulkPerformerUpdate(ctx context.Context, input BulkPe
 	return newRet, nil
 }
 
+// performersMergeInput is the input passed to Performer.Merge.Post hooks.
+type performersMergeInput struct {
+	Source      []string                     `json:"source"`
+	Destination string                       `json:"destination"`
+	Values      *models.PerformerUpdateInput `json:"values"`
+}
+
+func (r *mutationResolver) PerformersMerge(ctx context.Context, source []string, destination string, values *models.PerformerUpdateInput) (*models.Performer, error) {
+	srcIDs, err := stringslice.StringSliceToIntSlice(source)
+	if err != nil {
+		return nil, fmt.Errorf("converting source ids: %w", err)
+	}
+
+	destID, err := strconv.Atoi(destination)
+	if err != nil {
+		return nil, fmt.Errorf("converting destination id: %w", err)
+	}
+
+	if len(srcIDs) == 0 {
+		return nil, errors.New("source must not be empty")
+	}
+
+	var updatedPerformer *models.PerformerPartial
+	var legacyURL, legacyTwitter, legacyInstagram models.OptionalString
+	var imageData []byte
+	imageIncluded := false
+
+	if values != nil {
+		translator := changesetTranslator{
+			inputMap: getNamedUpdateInputMap(ctx, "values"),
+		}
+
+		if translator.hasField("urls") {
+			// ensure url/twitter/instagram are not included in the input
+			if err := r.validateNoLegacyURLs(translator); err != nil {
+				return nil, err
+			}
+		}
+
+		updatedPerformer, err = performerPartialFromInput(*values, translator)
+		if err != nil {
+			return nil, err
+		}
+
+		legacyURL = translator.optionalString(values.URL, "url")
+		legacyTwitter = translator.optionalString(values.Twitter, "twitter")
+		legacyInstagram = translator.optionalString(values.Instagram, "instagram")
+
+		imageIncluded = translator.hasField("image")
+		if values.Image != nil {
+			imageData, err = utils.ProcessImageInput(ctx, *values.Image)
+			if err != nil {
+				return nil, fmt.Errorf("processing image: %w", err)
+			}
+		}
+	} else {
+		v := models.NewPerformerPartial()
+		updatedPerformer = &v
+	}
+
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		qb := r.repository.Performer
+
+		dest, err := qb.Find(ctx, destID)
+		if err != nil {
+			return err
+		}
+
+		if dest == nil {
+			return fmt.Errorf("performer with id %d not found", destID)
+		}
+
+		if err := qb.Merge(ctx, srcIDs, destID); err != nil {
+			return err
+		}
+
+		// values override the merged values of the destination
+		if legacyURL.Set || legacyTwitter.Set || legacyInstagram.Set {
+			if err := r.handleLegacyURLs(ctx, destID, legacyURL, legacyTwitter, legacyInstagram, updatedPerformer); err != nil {
+				return err
+			}
+		}
+
+		if err := performer.ValidateUpdate(ctx, destID, *updatedPerformer, qb); err != nil {
+			return err
+		}
+
+		if _, err := qb.UpdatePartial(ctx, destID, *updatedPerformer); err != nil {
+			return err
+		}
+
+		if imageIncluded {
+			if err := qb.UpdateImage(ctx, destID, imageData); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	r.hookExecutor.ExecutePostHooks(ctx, destID, hook.PerformerMergePost, performersMergeInput{
+		Source:      source,
+		Destination: destination,
+		Values:      values,
+	}, nil)
+
+	return r.getPerformer(ctx, destID)
+}
+
 func (r *mutationResolver) PerformerDestroy(ctx context.Context, input PerformerDestroyInput) (bool, error) {
 	id, err := strconv.Atoi(input.ID)
 	if err != nil {
 		return false, fmt.Errorf("converting id: %w", err)
 	}
 
+	if err := r.executePreHooks(ctx, id, hook.PerformerDestroyPre, &input, nil); err != nil {
+		return false, err
+	}
+
 	if err := r.withTxn(ctx, func(ctx context.Context) error {
 		return r.repository.Performer.Destroy(ctx, id)
 	}); err != nil {
@@ -474,6 +636,10 @@ func (r *mutationResolver) PerformersDestroy(ctx context.Context, performerIDs [
 		return false, fmt.Errorf("converting ids: %w", err)
 	}
 
+	if err := r.executeBulkPreHooks(ctx, ids, hook.PerformerDestroyPre, &performerIDs, nil); err != nil {
+		return false, err
+	}
+
 	if err := r.withTxn(ctx, func(ctx context.Context) error {
 		qb := r.repository.Performer
 		for _, id := range ids {
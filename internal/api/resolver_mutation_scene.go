@@ -8,7 +8,6 @@ import (
 	"time"
 
 	"github.com/stashapp/stash/internal/manager"
-	"github.com/stashapp/stash/pkg/file"
 	"github.com/stashapp/stash/pkg/logger"
 	"github.com/stashapp/stash/pkg/models"
 	"github.com/stashapp/stash/pkg/plugin"
@@ -36,6 +35,10 @@ func (r *mutationResolver) SceneCreate(ctx context.Context, input models.SceneCr
 		inputMap: getUpdateInputMap(ctx),
 	}
 
+	if err := r.executePreHooks(ctx, 0, hook.SceneCreatePre, &input, &translator); err != nil {
+		return nil, err
+	}
+
 	fileIDs, err := translator.fileIDSliceFromStringSlice(input.FileIds)
 	if err != nil {
 		return nil, fmt.Errorf("converting file ids: %w", err)
@@ -104,7 +107,19 @@ func (r *mutationResolver) SceneCreate(ctx context.Context, input models.SceneCr
 
 	if err := r.withTxn(ctx, func(ctx context.Context) error {
 		ret, err = r.Resolver.sceneService.Create(ctx, &newScene, fileIDs, coverImageData)
-		return err
+		if err != nil {
+			return err
+		}
+
+		if len(input.CustomFields) > 0 {
+			if err := r.repository.Scene.SetCustomFields(ctx, ret.ID, models.CustomFieldsInput{
+				Full: input.CustomFields,
+			}); err != nil {
+				return fmt.Errorf("setting custom fields: %w", err)
+			}
+		}
+
+		return nil
 	}); err != nil {
 		return nil, err
 	}
@@ -117,6 +132,21 @@ func (r *mutationResolver) SceneUpdate(ctx context.Context, input models.SceneUp
 		inputMap: getUpdateInputMap(ctx),
 	}
 
+	return r.applySceneUpdate(ctx, input, translator)
+}
+
+// applySceneUpdate updates the scene using the provided translator, executing
+// the update hooks.
+func (r *mutationResolver) applySceneUpdate(ctx context.Context, input models.SceneUpdateInput, translator changesetTranslator) (ret *models.Scene, err error) {
+	sceneID, err := strconv.Atoi(input.ID)
+	if err != nil {
+		return nil, fmt.Errorf("converting id: %w", err)
+	}
+
+	if err := r.executePreHooks(ctx, sceneID, hook.SceneUpdatePre, &input, &translator); err != nil {
+		return nil, err
+	}
+
 	// Start the transaction and save the scene
 	if err := r.withTxn(ctx, func(ctx context.Context) error {
 		ret, err = r.sceneUpdate(ctx, input, translator)
@@ -132,6 +162,28 @@ func (r *mutationResolver) SceneUpdate(ctx context.Context, input models.SceneUp
 func (r *mutationResolver) ScenesUpdate(ctx context.Context, input []*models.SceneUpdateInput) (ret []*models.Scene, err error) {
 	inputMaps := getUpdateInputMaps(ctx)
 
+	// execute pre hooks outside of txn
+	// the pre hooks of all objects share a single deadline
+	hookCtx, cancel := withPreHookDeadline(ctx)
+	defer cancel()
+
+	for i, scene := range input {
+		sceneID, err := strconv.Atoi(scene.ID)
+		if err != nil {
+			return nil, fmt.Errorf("converting id: %w", err)
+		}
+
+		translator := changesetTranslator{
+			inputMap: inputMaps[i],
+		}
+
+		if err := r.executePreHooks(hookCtx, sceneID, hook.SceneUpdatePre, scene, &translator); err != nil {
+			return nil, err
+		}
+
+		inputMaps[i] = translator.inputMap
+	}
+
 	// Start the transaction and save the scenes
 	if err := r.withTxn(ctx, func(ctx context.Context) error {
 		for i, scene := range input {
@@ -313,6 +365,12 @@ func (r *mutationResolver) sceneUpdate(ctx context.Context, input models.SceneUp
 		return nil, err
 	}
 
+	if input.CustomFields != nil {
+		if err := qb.SetCustomFields(ctx, sceneID, *input.CustomFields); err != nil {
+			return nil, fmt.Errorf("setting custom fields: %w", err)
+		}
+	}
+
 	return scene, nil
 }
 
@@ -339,6 +397,11 @@ func (r *mutationResolver) BulkSceneUpdate(ctx context.Context, input BulkSceneU
 		inputMap: getUpdateInputMap(ctx),
 	}
 
+	// execute pre hooks outside of txn
+	if err := r.executeBulkPreHooks(ctx, sceneIDs, hook.SceneUpdatePre, &input, &translator); err != nil {
+		return nil, err
+	}
+
 	// Populate scene from the input
 	updatedScene := models.NewScenePartial()
 
@@ -427,11 +490,15 @@ func (r *mutationResolver) SceneDestroy(ctx context.Context, input models.SceneD
 		return false, fmt.Errorf("converting id: %w", err)
 	}
 
+	if err := r.executePreHooks(ctx, sceneID, hook.SceneDestroyPre, &input, nil); err != nil {
+		return false, err
+	}
+
 	fileNamingAlgo := manager.GetInstance().Config.GetVideoFileNamingAlgorithm()
 
 	var s *models.Scene
 	fileDeleter := &scene.FileDeleter{
-		Deleter:        file.NewDeleter(),
+		Deleter:        manager.GetInstance().NewFileDeleter(),
 		FileNamingAlgo: fileNamingAlgo,
 		Paths:          manager.GetInstance().Paths,
 	}
@@ -480,11 +547,15 @@ func (r *mutationResolver) ScenesDestroy(ctx context.Context, input models.Scene
 		return false, fmt.Errorf("converting ids: %w", err)
 	}
 
+	if err := r.executeBulkPreHooks(ctx, sceneIDs, hook.SceneDestroyPre, &input, nil); err != nil {
+		return false, err
+	}
+
 	var scenes []*models.Scene
 	fileNamingAlgo := manager.GetInstance().Config.GetVideoFileNamingAlgorithm()
 
 	fileDeleter := &scene.FileDeleter{
-		Deleter:        file.NewDeleter(),
+		Deleter:        manager.GetInstance().NewFileDeleter(),
 		FileNamingAlgo: fileNamingAlgo,
 		Paths:          manager.GetInstance().Paths,
 	}
@@ -594,7 +665,7 @@ func (r *mutationResolver) SceneMerge(ctx context.Context, input SceneMergeInput
 
 	mgr := manager.GetInstance()
 	fileDeleter := &scene.FileDeleter{
-		Deleter:        file.NewDeleter(),
+		Deleter:        manager.GetInstance().NewFileDeleter(),
 		FileNamingAlgo: mgr.Config.GetVideoFileNamingAlgorithm(),
 		Paths:          mgr.Paths,
 	}
@@ -637,6 +708,14 @@ func (r *mutationResolver) getSceneMarker(ctx context.Context, id int) (ret *mod
 }
 
 func (r *mutationResolver) SceneMarkerCreate(ctx context.Context, input SceneMarkerCreateInput) (*models.SceneMarker, error) {
+	translator := changesetTranslator{
+		inputMap: getUpdateInputMap(ctx),
+	}
+
+	if err := r.executePreHooks(ctx, 0, hook.SceneMarkerCreatePre, &input, &translator); err != nil {
+		return nil, err
+	}
+
 	sceneID, err := strconv.Atoi(input.SceneID)
 	if err != nil {
 		return nil, fmt.Errorf("converting scene id: %w", err)
@@ -652,9 +731,14 @@ func (r *mutationResolver) SceneMarkerCreate(ctx context.Context, input SceneMar
 
 	newMarker.Title = input.Title
 	newMarker.Seconds = input.Seconds
+	newMarker.EndSeconds = input.EndSeconds
 	newMarker.PrimaryTagID = primaryTagID
 	newMarker.SceneID = sceneID
 
+	if err := scene.ValidateMarker(&newMarker); err != nil {
+		return nil, err
+	}
+
 	tagIDs, err := stringslice.StringSliceToIntSlice(input.TagIds)
 	if err != nil {
 		return nil, fmt.Errorf("converting tag ids: %w", err)
@@ -690,11 +774,16 @@ func (r *mutationResolver) SceneMarkerUpdate(ctx context.Context, input SceneMar
 		inputMap: getUpdateInputMap(ctx),
 	}
 
+	if err := r.executePreHooks(ctx, markerID, hook.SceneMarkerUpdatePre, &input, &translator); err != nil {
+		return nil, err
+	}
+
 	// Populate scene marker from the input
 	updatedMarker := models.NewSceneMarkerPartial()
 
 	updatedMarker.Title = translator.optionalString(input.Title, "title")
 	updatedMarker.Seconds = translator.optionalFloat64(input.Seconds, "seconds")
+	updatedMarker.EndSeconds = translator.optionalFloat64(input.EndSeconds, "end_seconds")
 	updatedMarker.SceneID, err = translator.optionalIntFromString(input.SceneID, "scene_id")
 	if err != nil {
 		return nil, fmt.Errorf("converting scene id: %w", err)
@@ -716,7 +805,7 @@ func (r *mutationResolver) SceneMarkerUpdate(ctx context.Context, input SceneMar
 	mgr := manager.GetInstance()
 
 	fileDeleter := &scene.FileDeleter{
-		Deleter:        file.NewDeleter(),
+		Deleter:        manager.GetInstance().NewFileDeleter(),
 		FileNamingAlgo: mgr.Config.GetVideoFileNamingAlgorithm(),
 		Paths:          mgr.Paths,
 	}
@@ -740,6 +829,10 @@ func (r *mutationResolver) SceneMarkerUpdate(ctx context.Context, input SceneMar
 			return err
 		}
 
+		if err := scene.ValidateMarker(newMarker); err != nil {
+			return err
+		}
+
 		existingScene, err := sqb.Find(ctx, existingMarker.SceneID)
 		if err != nil {
 			return err
@@ -748,8 +841,10 @@ func (r *mutationResolver) SceneMarkerUpdate(ctx context.Context, input SceneMar
 			return fmt.Errorf("scene with id %d not found", existingMarker.SceneID)
 		}
 
-		// remove the marker preview if the scene changed or if the timestamp was changed
-		if existingMarker.SceneID != newMarker.SceneID || existingMarker.Seconds != newMarker.Seconds {
+		// remove the marker preview if the scene changed or if the time range was changed
+		endChanged := (existingMarker.EndSeconds == nil) != (newMarker.EndSeconds == nil) ||
+			(existingMarker.EndSeconds != nil && *existingMarker.EndSeconds != *newMarker.EndSeconds)
+		if existingMarker.SceneID != newMarker.SceneID || existingMarker.Seconds != newMarker.Seconds || endChanged {
 			seconds := int(existingMarker.Seconds)
 			if err := fileDeleter.MarkMarkerFiles(existingScene, seconds); err != nil {
 				return err
@@ -784,10 +879,14 @@ func (r *mutationResolver) SceneMarkerDestroy(ctx context.Context, id string) (b
 		return false, fmt.Errorf("converting id: %w", err)
 	}
 
+	if err := r.executePreHooks(ctx, markerID, hook.SceneMarkerDestroyPre, &id, nil); err != nil {
+		return false, err
+	}
+
 	fileNamingAlgo := manager.GetInstance().Config.GetVideoFileNamingAlgorithm()
 
 	fileDeleter := &scene.FileDeleter{
-		Deleter:        file.NewDeleter(),
+		Deleter:        manager.GetInstance().NewFileDeleter(),
 		FileNamingAlgo: fileNamingAlgo,
 		Paths:          manager.GetInstance().Paths,
 	}
@@ -0,0 +1,86 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/stashapp/stash/internal/manager"
+	"github.com/stashapp/stash/internal/manager/config"
+)
+
+func (r *mutationResolver) ScheduledTaskCreate(ctx context.Context, input ScheduledTaskCreateInput) (*ScheduledTask, error) {
+	t := config.ScheduledTask{
+		Name:     input.Name,
+		Type:     input.Type,
+		Schedule: input.Schedule,
+		Enabled:  true,
+	}
+
+	if input.Enabled != nil {
+		t.Enabled = *input.Enabled
+	}
+
+	scheduler := manager.GetInstance().Scheduler
+	created, err := scheduler.CreateScheduledTask(t)
+	if err != nil {
+		return nil, err
+	}
+
+	return scheduledTaskToModel(*created, scheduler.Status(created.ID)), nil
+}
+
+func (r *mutationResolver) ScheduledTaskUpdate(ctx context.Context, input ScheduledTaskUpdateInput) (*ScheduledTask, error) {
+	id, err := strconv.Atoi(input.ID)
+	if err != nil {
+		return nil, fmt.Errorf("converting id: %w", err)
+	}
+
+	mgr := manager.GetInstance()
+
+	var existing *config.ScheduledTask
+	for _, t := range mgr.Config.GetScheduledTasks() {
+		if t.ID == id {
+			existing = t
+			break
+		}
+	}
+
+	if existing == nil {
+		return nil, fmt.Errorf("%w: %d", manager.ErrScheduledTaskNotFound, id)
+	}
+
+	t := *existing
+	if input.Name != nil {
+		t.Name = *input.Name
+	}
+	if input.Type != nil {
+		t.Type = *input.Type
+	}
+	if input.Schedule != nil {
+		t.Schedule = *input.Schedule
+	}
+	if input.Enabled != nil {
+		t.Enabled = *input.Enabled
+	}
+
+	updated, err := mgr.Scheduler.UpdateScheduledTask(t)
+	if err != nil {
+		return nil, err
+	}
+
+	return scheduledTaskToModel(*updated, mgr.Scheduler.Status(updated.ID)), nil
+}
+
+func (r *mutationResolver) ScheduledTaskDestroy(ctx context.Context, id string) (bool, error) {
+	idInt, err := strconv.Atoi(id)
+	if err != nil {
+		return false, fmt.Errorf("converting id: %w", err)
+	}
+
+	if err := manager.GetInstance().Scheduler.DestroyScheduledTask(idInt); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
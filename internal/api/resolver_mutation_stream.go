@@ -0,0 +1,16 @@
+package api
+
+import (
+	"context"
+
+	"github.com/stashapp/stash/internal/manager"
+)
+
+func (r *mutationResolver) StopStream(ctx context.Context, id string) (bool, error) {
+	sm := manager.GetInstance().StreamManager
+	if sm == nil {
+		return false, nil
+	}
+
+	return sm.StopStream(id), nil
+}
@@ -2,6 +2,7 @@ package api
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strconv"
 
@@ -29,6 +30,10 @@ func (r *mutationResolver) StudioCreate(ctx context.Context, input models.Studio
 		inputMap: getUpdateInputMap(ctx),
 	}
 
+	if err := r.executePreHooks(ctx, 0, hook.StudioCreatePre, &input, &translator); err != nil {
+		return nil, err
+	}
+
 	// Populate a new studio from the input
 	newStudio := models.NewStudio()
 
@@ -82,6 +87,14 @@ func (r *mutationResolver) StudioCreate(ctx context.Context, input models.Studio
 			}
 		}
 
+		if len(input.CustomFields) > 0 {
+			if err := qb.SetCustomFields(ctx, newStudio.ID, models.CustomFieldsInput{
+				Full: input.CustomFields,
+			}); err != nil {
+				return fmt.Errorf("setting custom fields: %w", err)
+			}
+		}
+
 		return nil
 	}); err != nil {
 		return nil, err
@@ -92,13 +105,23 @@ func (r *mutationResolver) StudioCreate(ctx context.Context, input models.Studio
 }
 
 func (r *mutationResolver) StudioUpdate(ctx context.Context, input models.StudioUpdateInput) (*models.Studio, error) {
+	translator := changesetTranslator{
+		inputMap: getUpdateInputMap(ctx),
+	}
+
+	return r.applyStudioUpdate(ctx, input, translator)
+}
+
+// applyStudioUpdate updates the studio using the provided translator, executing
+// the update hooks.
+func (r *mutationResolver) applyStudioUpdate(ctx context.Context, input models.StudioUpdateInput, translator changesetTranslator) (*models.Studio, error) {
 	studioID, err := strconv.Atoi(input.ID)
 	if err != nil {
 		return nil, fmt.Errorf("converting id: %w", err)
 	}
 
-	translator := changesetTranslator{
-		inputMap: getUpdateInputMap(ctx),
+	if err := r.executePreHooks(ctx, studioID, hook.StudioUpdatePre, &input, &translator); err != nil {
+		return nil, err
 	}
 
 	// Populate studio from the input
@@ -154,6 +177,12 @@ func (r *mutationResolver) StudioUpdate(ctx context.Context, input models.Studio
 			}
 		}
 
+		if input.CustomFields != nil {
+			if err := qb.SetCustomFields(ctx, studioID, *input.CustomFields); err != nil {
+				return fmt.Errorf("setting custom fields: %w", err)
+			}
+		}
+
 		return nil
 	}); err != nil {
 		return nil, err
@@ -169,6 +198,10 @@ func (r *mutationResolver) StudioDestroy(ctx context.Context, input StudioDestro
 		return false, fmt.Errorf("converting id: %w", err)
 	}
 
+	if err := r.executePreHooks(ctx, id, hook.StudioDestroyPre, &input, nil); err != nil {
+		return false, err
+	}
+
 	if err := r.withTxn(ctx, func(ctx context.Context) error {
 		return r.repository.Studio.Destroy(ctx, id)
 	}); err != nil {
@@ -186,6 +219,10 @@ func (r *mutationResolver) StudiosDestroy(ctx context.Context, studioIDs []strin
 		return false, fmt.Errorf("converting ids: %w", err)
 	}
 
+	if err := r.executeBulkPreHooks(ctx, ids, hook.StudioDestroyPre, &studioIDs, nil); err != nil {
+		return false, err
+	}
+
 	if err := r.withTxn(ctx, func(ctx context.Context) error {
 		qb := r.repository.Studio
 		for _, id := range ids {
@@ -205,3 +242,47 @@ func (r *mutationResolver) StudiosDestroy(ctx context.Context, studioIDs []strin
 
 	return true, nil
 }
+
+func (r *mutationResolver) StudiosMerge(ctx context.Context, input StudiosMergeInput) (*models.Studio, error) {
+	source, err := stringslice.StringSliceToIntSlice(input.Source)
+	if err != nil {
+		return nil, fmt.Errorf("converting source ids: %w", err)
+	}
+
+	destination, err := strconv.Atoi(input.Destination)
+	if err != nil {
+		return nil, fmt.Errorf("converting destination id: %w", err)
+	}
+
+	if len(source) == 0 {
+		return nil, errors.New("source must not be empty")
+	}
+
+	var s *models.Studio
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		qb := r.repository.Studio
+
+		var err error
+		s, err = qb.Find(ctx, destination)
+		if err != nil {
+			return err
+		}
+
+		if s == nil {
+			return fmt.Errorf("studio with id %d not found", destination)
+		}
+
+		if err := studio.ValidateMerge(ctx, destination, source, qb); err != nil {
+			return err
+		}
+
+		return qb.Merge(ctx, source, destination)
+	}); err != nil {
+		return nil, err
+	}
+
+	r.hookExecutor.ExecutePostHooks(ctx, s.ID, hook.StudioMergePost, input, nil)
+
+	// refetch, since the parent may have changed
+	return r.getStudio(ctx, s.ID)
+}
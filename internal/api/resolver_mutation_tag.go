@@ -29,6 +29,10 @@ func (r *mutationResolver) TagCreate(ctx context.Context, input TagCreateInput)
 		inputMap: getUpdateInputMap(ctx),
 	}
 
+	if err := r.executePreHooks(ctx, 0, hook.TagCreatePre, &input, &translator); err != nil {
+		return nil, err
+	}
+
 	// Populate a new tag from the input
 	newTag := models.NewTag()
 
@@ -79,6 +83,14 @@ func (r *mutationResolver) TagCreate(ctx context.Context, input TagCreateInput)
 			}
 		}
 
+		if len(input.CustomFields) > 0 {
+			if err := qb.SetCustomFields(ctx, newTag.ID, models.CustomFieldsInput{
+				Full: input.CustomFields,
+			}); err != nil {
+				return fmt.Errorf("setting custom fields: %w", err)
+			}
+		}
+
 		return nil
 	}); err != nil {
 		return nil, err
@@ -89,13 +101,23 @@ func (r *mutationResolver) TagCreate(ctx context.Context, input TagCreateInput)
 }
 
 func (r *mutationResolver) TagUpdate(ctx context.Context, input TagUpdateInput) (*models.Tag, error) {
+	translator := changesetTranslator{
+		inputMap: getUpdateInputMap(ctx),
+	}
+
+	return r.applyTagUpdate(ctx, input, translator)
+}
+
+// applyTagUpdate updates the tag using the provided translator, executing
+// the update hooks.
+func (r *mutationResolver) applyTagUpdate(ctx context.Context, input TagUpdateInput, translator changesetTranslator) (*models.Tag, error) {
 	tagID, err := strconv.Atoi(input.ID)
 	if err != nil {
 		return nil, fmt.Errorf("converting id: %w", err)
 	}
 
-	translator := changesetTranslator{
-		inputMap: getUpdateInputMap(ctx),
+	if err := r.executePreHooks(ctx, tagID, hook.TagUpdatePre, &input, &translator); err != nil {
+		return nil, err
 	}
 
 	// Populate tag from the input
@@ -148,6 +170,12 @@ func (r *mutationResolver) TagUpdate(ctx context.Context, input TagUpdateInput)
 			}
 		}
 
+		if input.CustomFields != nil {
+			if err := qb.SetCustomFields(ctx, tagID, *input.CustomFields); err != nil {
+				return fmt.Errorf("setting custom fields: %w", err)
+			}
+		}
+
 		return nil
 	}); err != nil {
 		return nil, err
@@ -167,6 +195,11 @@ func (r *mutationResolver) BulkTagUpdate(ctx context.Context, input BulkTagUpdat
 		inputMap: getUpdateInputMap(ctx),
 	}
 
+	// execute pre hooks outside of txn
+	if err := r.executeBulkPreHooks(ctx, tagIDs, hook.TagUpdatePre, &input, &translator); err != nil {
+		return nil, err
+	}
+
 	// Populate scene from the input
 	updatedTag := models.NewTagPartial()
 
@@ -232,6 +265,10 @@ func (r *mutationResolver) TagDestroy(ctx context.Context, input TagDestroyInput
 		return false, fmt.Errorf("converting id: %w", err)
 	}
 
+	if err := r.executePreHooks(ctx, tagID, hook.TagDestroyPre, &input, nil); err != nil {
+		return false, err
+	}
+
 	if err := r.withTxn(ctx, func(ctx context.Context) error {
 		return r.repository.Tag.Destroy(ctx, tagID)
 	}); err != nil {
@@ -249,6 +286,10 @@ func (r *mutationResolver) TagsDestroy(ctx context.Context, tagIDs []string) (bo
 		return false, fmt.Errorf("converting ids: %w", err)
 	}
 
+	if err := r.executeBulkPreHooks(ctx, ids, hook.TagDestroyPre, &tagIDs, nil); err != nil {
+		return false, err
+	}
+
 	if err := r.withTxn(ctx, func(ctx context.Context) error {
 		qb := r.repository.Tag
 		for _, id := range ids {
@@ -38,6 +38,10 @@ type mockHookExecutor struct{}
 func (*mockHookExecutor) ExecutePostHooks(ctx context.Context, id int, hookType hook.TriggerEnum, input interface{}, inputFields []string) {
 }
 
+func (*mockHookExecutor) ExecutePreHooks(ctx context.Context, id int, hookType hook.TriggerEnum, input interface{}, inputFields []string) (interface{}, error) {
+	return nil, nil
+}
+
 func TestTagCreate(t *testing.T) {
 	db := mocks.NewDatabase()
 	r := newResolver(db)
@@ -0,0 +1,199 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/stashapp/stash/internal/manager"
+	"github.com/stashapp/stash/internal/manager/config"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/session"
+	"github.com/stashapp/stash/pkg/user"
+)
+
+var (
+	errAuthenticationDisabled = errors.New("authentication must be enabled to manage users")
+	errConfiguredUser         = errors.New("the username and password of the configured user must be changed in the configuration")
+)
+
+// isConfiguredUser returns true if u is the user configured in the
+// application configuration.
+func isConfiguredUser(u *models.User) bool {
+	c := config.GetInstance()
+	return c.HasCredentials() && u.Username == c.GetUsername()
+}
+
+func (r *mutationResolver) UserCreate(ctx context.Context, input UserCreateInput) (*models.User, error) {
+	if !config.GetInstance().HasCredentials() {
+		return nil, errAuthenticationDisabled
+	}
+
+	if input.Password == "" {
+		return nil, user.ErrPasswordMissing
+	}
+
+	passwordHash, err := user.HashPassword(input.Password)
+	if err != nil {
+		return nil, fmt.Errorf("hashing password: %w", err)
+	}
+
+	newUser := models.NewUser()
+	newUser.Username = strings.TrimSpace(input.Username)
+	newUser.PasswordHash = passwordHash
+	newUser.Role = input.Role
+
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		qb := r.repository.User
+
+		if err := user.ValidateUsername(ctx, 0, newUser.Username, qb); err != nil {
+			return err
+		}
+
+		return qb.Create(ctx, &newUser)
+	}); err != nil {
+		return nil, err
+	}
+
+	return &newUser, nil
+}
+
+func (r *mutationResolver) UserUpdate(ctx context.Context, input UserUpdateInput) (ret *models.User, err error) {
+	id, err := strconv.Atoi(input.ID)
+	if err != nil {
+		return nil, fmt.Errorf("converting id: %w", err)
+	}
+
+	updatedUser := models.NewUserPartial()
+
+	if input.Username != nil {
+		updatedUser.Username = models.NewOptionalString(strings.TrimSpace(*input.Username))
+	}
+	if input.Password != nil {
+		if *input.Password == "" {
+			return nil, user.ErrPasswordMissing
+		}
+
+		passwordHash, err := user.HashPassword(*input.Password)
+		if err != nil {
+			return nil, fmt.Errorf("hashing password: %w", err)
+		}
+		updatedUser.PasswordHash = models.NewOptionalString(passwordHash)
+	}
+	if input.Role != nil {
+		updatedUser.Role = models.NewOptionalString(input.Role.String())
+	}
+
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		qb := r.repository.User
+
+		existing, err := qb.Find(ctx, id)
+		if err != nil {
+			return err
+		}
+		if existing == nil {
+			return fmt.Errorf("user with id %d not found", id)
+		}
+
+		if isConfiguredUser(existing) && (updatedUser.Username.Set || updatedUser.PasswordHash.Set || updatedUser.Role.Set) {
+			return errConfiguredUser
+		}
+
+		if updatedUser.Username.Set {
+			if err := user.ValidateUsername(ctx, id, updatedUser.Username.Value, qb); err != nil {
+				return err
+			}
+		}
+
+		if input.Role != nil && *input.Role != models.UserRoleAdmin {
+			if err := user.ValidateRemoveAdmin(ctx, existing, qb); err != nil {
+				return err
+			}
+		}
+
+		ret, err = qb.UpdatePartial(ctx, id, updatedUser)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	// apply the changes to authenticated sessions
+	manager.GetInstance().SessionStore.ClearCache()
+
+	return ret, nil
+}
+
+func (r *mutationResolver) UserDestroy(ctx context.Context, id string) (bool, error) {
+	idInt, err := strconv.Atoi(id)
+	if err != nil {
+		return false, fmt.Errorf("converting id: %w", err)
+	}
+
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		qb := r.repository.User
+
+		existing, err := qb.Find(ctx, idInt)
+		if err != nil {
+			return err
+		}
+		if existing == nil {
+			return fmt.Errorf("user with id %d not found", idInt)
+		}
+
+		if isConfiguredUser(existing) {
+			return errConfiguredUser
+		}
+
+		if err := user.ValidateRemoveAdmin(ctx, existing, qb); err != nil {
+			return err
+		}
+
+		return qb.Destroy(ctx, idInt)
+	}); err != nil {
+		return false, err
+	}
+
+	manager.GetInstance().SessionStore.ClearCache()
+
+	return true, nil
+}
+
+func (r *mutationResolver) UserChangePassword(ctx context.Context, input UserChangePasswordInput) (bool, error) {
+	currentUser := session.GetCurrentUser(ctx)
+	if currentUser == nil {
+		return false, errAuthenticationDisabled
+	}
+
+	if isConfiguredUser(currentUser) {
+		return false, errConfiguredUser
+	}
+
+	if input.NewPassword == "" {
+		return false, user.ErrPasswordMissing
+	}
+
+	if !user.CheckPassword(currentUser.PasswordHash, input.CurrentPassword) {
+		return false, session.InvalidCredentialsError{Username: currentUser.Username}
+	}
+
+	passwordHash, err := user.HashPassword(input.NewPassword)
+	if err != nil {
+		return false, fmt.Errorf("hashing password: %w", err)
+	}
+
+	updatedUser := models.NewUserPartial()
+	updatedUser.PasswordHash = models.NewOptionalString(passwordHash)
+
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		_, err := r.repository.User.UpdatePartial(ctx, currentUser.ID, updatedUser)
+		return err
+	}); err != nil {
+		return false, err
+	}
+
+	manager.GetInstance().SessionStore.ClearCache()
+
+	return true, nil
+}
@@ -0,0 +1,87 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/stashapp/stash/internal/manager"
+	"github.com/stashapp/stash/pkg/webhook"
+)
+
+func toWebhookEvents(events []string) []webhook.Event {
+	ret := make([]webhook.Event, len(events))
+	for i, e := range events {
+		ret[i] = webhook.Event(e)
+	}
+
+	return ret
+}
+
+func (r *mutationResolver) WebhookCreate(ctx context.Context, input WebhookCreateInput) (*Webhook, error) {
+	w := webhook.Webhook{
+		Name:    input.Name,
+		URL:     input.URL,
+		Events:  toWebhookEvents(input.Events),
+		Enabled: true,
+	}
+
+	if input.Secret != nil {
+		w.Secret = *input.Secret
+	}
+	if input.Enabled != nil {
+		w.Enabled = *input.Enabled
+	}
+
+	created, err := manager.GetInstance().CreateWebhook(w)
+	if err != nil {
+		return nil, err
+	}
+
+	return webhookToModel(*created), nil
+}
+
+func (r *mutationResolver) WebhookUpdate(ctx context.Context, input WebhookUpdateInput) (*Webhook, error) {
+	id, err := strconv.Atoi(input.ID)
+	if err != nil {
+		return nil, fmt.Errorf("converting id: %w", err)
+	}
+
+	updated, err := manager.GetInstance().UpdateWebhook(id, func(w *webhook.Webhook) {
+		if input.Name != nil {
+			w.Name = *input.Name
+		}
+		if input.URL != nil {
+			w.URL = *input.URL
+		}
+		// the masked secret returned by the webhooks query leaves the
+		// secret unchanged
+		if input.Secret != nil && *input.Secret != webhookSecretMask {
+			w.Secret = *input.Secret
+		}
+		if input.Events != nil {
+			w.Events = toWebhookEvents(input.Events)
+		}
+		if input.Enabled != nil {
+			w.Enabled = *input.Enabled
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return webhookToModel(*updated), nil
+}
+
+func (r *mutationResolver) WebhookDestroy(ctx context.Context, id string) (bool, error) {
+	idInt, err := strconv.Atoi(id)
+	if err != nil {
+		return false, fmt.Errorf("converting id: %w", err)
+	}
+
+	if err := manager.GetInstance().DestroyWebhook(idInt); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
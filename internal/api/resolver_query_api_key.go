@@ -0,0 +1,29 @@
+package api
+
+import (
+	"context"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/session"
+)
+
+func (r *queryResolver) APIKeys(ctx context.Context) (ret []*models.APIKey, err error) {
+	currentUser := session.GetCurrentUser(ctx)
+
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		qb := r.repository.APIKey
+
+		// non-admin users may only see their own keys
+		if currentUser != nil && !currentUser.HasRole(models.UserRoleAdmin) {
+			ret, err = qb.FindByUserID(ctx, currentUser.ID)
+			return err
+		}
+
+		ret, err = qb.All(ctx)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
@@ -14,7 +14,23 @@ import (
 )
 
 func (r *queryResolver) Configuration(ctx context.Context) (*ConfigResult, error) {
-	return makeConfigResult(), nil
+	ret := makeConfigResult()
+
+	// credentials are only visible to admin users
+	if !isAdmin(ctx) {
+		ret.General.APIKey = ""
+		ret.General.Password = ""
+
+		stashBoxes := make([]*models.StashBox, len(ret.General.StashBoxes))
+		for i, box := range ret.General.StashBoxes {
+			redacted := *box
+			redacted.APIKey = ""
+			stashBoxes[i] = &redacted
+		}
+		ret.General.StashBoxes = stashBoxes
+	}
+
+	return ret, nil
 }
 
 func (r *queryResolver) Directory(ctx context.Context, path, locale *string) (*Directory, error) {
@@ -96,6 +112,9 @@ func makeConfigGeneralResult() *ConfigGeneralResult {
 		CalculateMd5:                  config.IsCalculateMD5(),
 		VideoFileNamingAlgorithm:      config.GetVideoFileNamingAlgorithm(),
 		ParallelTasks:                 config.GetParallelTasks(),
+		IoJobConcurrency:              config.GetIOJobConcurrency(),
+		CPUJobConcurrency:             config.GetCPUJobConcurrency(),
+		NetworkJobConcurrency:         config.GetNetworkJobConcurrency(),
 		PreviewAudio:                  config.GetPreviewAudio(),
 		PreviewSegments:               config.GetPreviewSegments(),
 		PreviewSegmentDuration:        config.GetPreviewSegmentDuration(),
@@ -104,14 +123,19 @@ func makeConfigGeneralResult() *ConfigGeneralResult {
 		PreviewPreset:                 config.GetPreviewPreset(),
 		TranscodeHardwareAcceleration: config.GetTranscodeHardwareAcceleration(),
 		MaxTranscodeSize:              &maxTranscodeSize,
+		TranscodeVideoCodec:           config.GetTranscodeVideoCodec(),
+		MaxLiveTranscodes:             config.GetMaxLiveTranscodes(),
+		MaxLiveTranscodesPerClient:    config.GetMaxLiveTranscodesPerClient(),
 		MaxStreamingTranscodeSize:     &maxStreamingTranscodeSize,
 		WriteImageThumbnails:          config.IsWriteImageThumbnails(),
 		CreateImageClipsFromVideos:    config.IsCreateImageClipsFromVideos(),
 		GalleryCoverRegex:             config.GetGalleryCoverRegex(),
+		ChapterMarkerPrimaryTag:       config.GetChapterMarkerPrimaryTag(),
 		APIKey:                        config.GetAPIKey(),
 		Username:                      config.GetUsername(),
 		Password:                      config.GetPasswordHash(),
 		MaxSessionAge:                 config.GetMaxSessionAge(),
+		TrashRetentionDays:            config.GetTrashRetentionDays(),
 		LogFile:                       &logFile,
 		LogOut:                        config.GetLogOut(),
 		LogLevel:                      config.GetLogLevel(),
@@ -120,6 +144,7 @@ func makeConfigGeneralResult() *ConfigGeneralResult {
 		ImageExtensions:               config.GetImageExtensions(),
 		GalleryExtensions:             config.GetGalleryExtensions(),
 		CreateGalleriesFromFolders:    config.GetCreateGalleriesFromFolders(),
+		WatchLibrary:                  config.GetWatchLibrary(),
 		Excludes:                      config.GetExcludes(),
 		ImageExcludes:                 config.GetImageExcludes(),
 		CustomPerformerImageLocation:  &customPerformerImageLocation,
@@ -0,0 +1,23 @@
+package api
+
+import (
+	"context"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+func (r *queryResolver) CustomFieldDefinitions(ctx context.Context, entityType *models.CustomFieldEntityType) (ret []*models.CustomFieldDefinition, err error) {
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		qb := r.repository.CustomField
+		if entityType != nil {
+			ret, err = qb.FindByEntityType(ctx, *entityType)
+		} else {
+			ret, err = qb.All(ctx)
+		}
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
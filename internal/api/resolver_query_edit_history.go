@@ -0,0 +1,25 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+func (r *queryResolver) FindEditHistory(ctx context.Context, objectType models.EditObjectType, id string) (ret []*models.EditHistory, err error) {
+	objectID, err := strconv.Atoi(id)
+	if err != nil {
+		return nil, fmt.Errorf("converting id: %w", err)
+	}
+
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		ret, err = r.repository.EditHistory.FindByObject(ctx, objectType, objectID)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
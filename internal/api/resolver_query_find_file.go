@@ -0,0 +1,62 @@
+package api
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+func (r *queryResolver) FindFiles(ctx context.Context, fileFilter *models.FileFilterType, filter *models.FindFilterType, ids []string) (ret *FindFilesResultType, err error) {
+	var fileIDs []models.FileID
+	for _, id := range ids {
+		idInt, err := strconv.Atoi(id)
+		if err != nil {
+			return nil, err
+		}
+		fileIDs = append(fileIDs, models.FileID(idInt))
+	}
+
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		var files []models.File
+		var err error
+		var total int
+
+		if len(fileIDs) > 0 {
+			files, err = r.repository.File.Find(ctx, fileIDs...)
+			total = len(files)
+		} else {
+			var result *models.FileQueryResult
+			result, err = r.repository.File.Query(ctx, models.FileQueryOptions{
+				QueryOptions: models.QueryOptions{
+					FindFilter: filter,
+					Count:      true,
+				},
+				FileFilter: fileFilter,
+			})
+			if err == nil {
+				total = result.Count
+				files, err = result.Resolve(ctx)
+			}
+		}
+		if err != nil {
+			return err
+		}
+
+		baseFiles := make([]BaseFile, len(files))
+		for i, f := range files {
+			baseFiles[i] = convertBaseFile(f)
+		}
+
+		ret = &FindFilesResultType{
+			Count: total,
+			Files: baseFiles,
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
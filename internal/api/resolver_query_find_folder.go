@@ -0,0 +1,57 @@
+package api
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+func (r *queryResolver) FindFolders(ctx context.Context, folderFilter *models.FolderFilterType, filter *models.FindFilterType, ids []string) (ret *FindFoldersResultType, err error) {
+	var folderIDs []models.FolderID
+	for _, id := range ids {
+		idInt, err := strconv.Atoi(id)
+		if err != nil {
+			return nil, err
+		}
+		folderIDs = append(folderIDs, models.FolderID(idInt))
+	}
+
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		var folders []*models.Folder
+		var err error
+		var total int
+
+		if len(folderIDs) > 0 {
+			folders, err = r.repository.Folder.FindMany(ctx, folderIDs)
+			total = len(folders)
+		} else {
+			var result *models.FolderQueryResult
+			result, err = r.repository.Folder.Query(ctx, models.FolderQueryOptions{
+				QueryOptions: models.QueryOptions{
+					FindFilter: filter,
+					Count:      true,
+				},
+				FolderFilter: folderFilter,
+			})
+			if err == nil {
+				total = result.Count
+				folders, err = result.Resolve(ctx)
+			}
+		}
+		if err != nil {
+			return err
+		}
+
+		ret = &FindFoldersResultType{
+			Count:   total,
+			Folders: folders,
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
@@ -34,14 +34,16 @@ func (r *queryResolver) FindJob(ctx context.Context, input FindJobInput) (*Job,
 
 func jobToJobModel(j job.Job) *Job {
 	ret := &Job{
-		ID:          strconv.Itoa(j.ID),
-		Status:      JobStatus(j.Status),
-		Description: j.Description,
-		SubTasks:    j.Details,
-		StartTime:   j.StartTime,
-		EndTime:     j.EndTime,
-		AddTime:     j.AddTime,
-		Error:       j.Error,
+		ID:            strconv.Itoa(j.ID),
+		Status:        JobStatus(j.Status),
+		Description:   j.Description,
+		SubTasks:      j.Details,
+		StartTime:     j.StartTime,
+		EndTime:       j.EndTime,
+		AddTime:       j.AddTime,
+		Error:         j.Error,
+		Priority:      j.Priority,
+		ResourceClass: JobResourceClass(j.ResourceClass),
 	}
 
 	if j.Progress != -1 {
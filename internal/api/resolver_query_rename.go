@@ -0,0 +1,33 @@
+package api
+
+import (
+	"context"
+
+	"github.com/stashapp/stash/internal/manager"
+)
+
+func (r *queryResolver) RenameFilesPreview(ctx context.Context, input manager.RenameFilesInput) ([]*FileRename, error) {
+	renames, err := manager.GetInstance().PreviewRenameFiles(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := make([]*FileRename, len(renames))
+	for i, rn := range renames {
+		ret[i] = &FileRename{
+			FileID:    rn.File.Base().ID.String(),
+			OldPath:   rn.OldPath,
+			Collision: rn.Collision,
+		}
+
+		if rn.Error != nil {
+			errStr := rn.Error.Error()
+			ret[i].Error = &errStr
+		} else {
+			newPath := rn.NewPath
+			ret[i].NewPath = &newPath
+		}
+	}
+
+	return ret, nil
+}
@@ -0,0 +1,40 @@
+package api
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/stashapp/stash/internal/manager"
+	"github.com/stashapp/stash/internal/manager/config"
+)
+
+func (r *queryResolver) ScheduledTasks(ctx context.Context) ([]*ScheduledTask, error) {
+	mgr := manager.GetInstance()
+	tasks := mgr.Config.GetScheduledTasks()
+
+	ret := make([]*ScheduledTask, len(tasks))
+	for i, t := range tasks {
+		ret[i] = scheduledTaskToModel(*t, mgr.Scheduler.Status(t.ID))
+	}
+
+	return ret, nil
+}
+
+func scheduledTaskToModel(t config.ScheduledTask, status manager.ScheduledTaskStatus) *ScheduledTask {
+	ret := &ScheduledTask{
+		ID:       strconv.Itoa(t.ID),
+		Name:     t.Name,
+		Type:     t.Type,
+		Schedule: t.Schedule,
+		Enabled:  t.Enabled,
+		NextRun:  status.NextRun,
+		LastRun:  status.LastRun,
+	}
+
+	if status.LastJobID != nil {
+		jobID := strconv.Itoa(*status.LastJobID)
+		ret.LastJobID = &jobID
+	}
+
+	return ret
+}
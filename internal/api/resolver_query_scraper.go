@@ -127,6 +127,23 @@ func filterGalleryTags(g []*scraper.ScrapedGallery) {
 	}
 }
 
+// filterImageTags removes tags matching excluded tag patterns from the provided scraped images
+func filterImageTags(i []*scraper.ScrapedImage) {
+	excludeRegexps := compileRegexps(manager.GetInstance().Config.GetScraperExcludeTagPatterns())
+
+	var ignoredTags []string
+
+	for _, s := range i {
+		var ignored []string
+		s.Tags, ignored = filterTags(excludeRegexps, s.Tags)
+		ignoredTags = sliceutil.AppendUniques(ignoredTags, ignored)
+	}
+
+	if len(ignoredTags) > 0 {
+		logger.Debugf("Scraping ignored tags: %s", strings.Join(ignoredTags, ", "))
+	}
+}
+
 // filterGalleryTags removes tags matching excluded tag patterns from the provided scraped galleries
 func filterPerformerTags(p []*models.ScrapedPerformer) {
 	excludeRegexps := compileRegexps(manager.GetInstance().Config.GetScraperExcludeTagPatterns())
@@ -144,6 +161,23 @@ func filterPerformerTags(p []*models.ScrapedPerformer) {
 	}
 }
 
+// filterStudioTags removes tags matching excluded tag patterns from the provided scraped studios
+func filterStudioTags(p []*models.ScrapedStudio) {
+	excludeRegexps := compileRegexps(manager.GetInstance().Config.GetScraperExcludeTagPatterns())
+
+	var ignoredTags []string
+
+	for _, s := range p {
+		var ignored []string
+		s.Tags, ignored = filterTags(excludeRegexps, s.Tags)
+		ignoredTags = sliceutil.AppendUniques(ignoredTags, ignored)
+	}
+
+	if len(ignoredTags) > 0 {
+		logger.Debugf("Scraping ignored tags: %s", strings.Join(ignoredTags, ", "))
+	}
+}
+
 // filterGroupTags removes tags matching excluded tag patterns from the provided scraped movies
 func filterGroupTags(p []*models.ScrapedMovie) {
 	excludeRegexps := compileRegexps(manager.GetInstance().Config.GetScraperExcludeTagPatterns())
@@ -197,6 +231,24 @@ func (r *queryResolver) ScrapeGalleryURL(ctx context.Context, url string) (*scra
 	return ret, nil
 }
 
+func (r *queryResolver) ScrapeImageURL(ctx context.Context, url string) (*scraper.ScrapedImage, error) {
+	content, err := r.scraperCache().ScrapeURL(ctx, url, scraper.ScrapeContentTypeImage)
+	if err != nil {
+		return nil, err
+	}
+
+	ret, err := marshalScrapedImage(content)
+	if err != nil {
+		return nil, err
+	}
+
+	if ret != nil {
+		filterImageTags([]*scraper.ScrapedImage{ret})
+	}
+
+	return ret, nil
+}
+
 func (r *queryResolver) ScrapeMovieURL(ctx context.Context, url string) (*models.ScrapedMovie, error) {
 	content, err := r.scraperCache().ScrapeURL(ctx, url, scraper.ScrapeContentTypeMovie)
 	if err != nil {
@@ -246,6 +298,24 @@ func (r *queryResolver) ScrapeGroupURL(ctx context.Context, url string) (*models
 	return group, nil
 }
 
+func (r *queryResolver) ScrapeStudioURL(ctx context.Context, url string) (*models.ScrapedStudio, error) {
+	content, err := r.scraperCache().ScrapeURL(ctx, url, scraper.ScrapeContentTypeStudio)
+	if err != nil {
+		return nil, err
+	}
+
+	ret, err := marshalScrapedStudio(content)
+	if err != nil {
+		return nil, err
+	}
+
+	if ret != nil {
+		filterStudioTags([]*models.ScrapedStudio{ret})
+	}
+
+	return ret, nil
+}
+
 func (r *queryResolver) ScrapeSingleScene(ctx context.Context, source scraper.Source, input ScrapeSingleSceneInput) ([]*scraper.ScrapedScene, error) {
 	var ret []*scraper.ScrapedScene
 
@@ -365,7 +435,26 @@ func (r *queryResolver) ScrapeSingleStudio(ctx context.Context, source scraper.S
 		return nil, nil
 	}
 
-	return nil, errors.New("stash_box_index must be set")
+	if source.ScraperID != nil {
+		if input.Query == nil {
+			return nil, ErrNotImplemented
+		}
+
+		content, err := r.scraperCache().ScrapeName(ctx, *source.ScraperID, *input.Query, scraper.ScrapeContentTypeStudio)
+		if err != nil {
+			return nil, err
+		}
+
+		ret, err := marshalScrapedStudios(content)
+		if err != nil {
+			return nil, err
+		}
+
+		filterStudioTags(ret)
+		return ret, nil
+	}
+
+	return nil, errors.New("scraper_id or stash_box_index must be set")
 }
 
 func (r *queryResolver) ScrapeSinglePerformer(ctx context.Context, source scraper.Source, input ScrapeSinglePerformerInput) ([]*models.ScrapedPerformer, error) {
@@ -491,6 +580,50 @@ func (r *queryResolver) ScrapeSingleGallery(ctx context.Context, source scraper.
 	return ret, nil
 }
 
+func (r *queryResolver) ScrapeSingleImage(ctx context.Context, source scraper.Source, input ScrapeSingleImageInput) ([]*scraper.ScrapedImage, error) {
+	var ret []*scraper.ScrapedImage
+
+	if source.StashBoxIndex != nil || source.StashBoxEndpoint != nil {
+		return nil, ErrNotSupported
+	}
+
+	if source.ScraperID == nil {
+		return nil, fmt.Errorf("%w: scraper_id must be set", ErrInput)
+	}
+
+	var c scraper.ScrapedContent
+
+	switch {
+	case input.ImageID != nil:
+		imageID, err := strconv.Atoi(*input.ImageID)
+		if err != nil {
+			return nil, fmt.Errorf("%w: image id is not an integer: '%s'", ErrInput, *input.ImageID)
+		}
+		c, err = r.scraperCache().ScrapeID(ctx, *source.ScraperID, imageID, scraper.ScrapeContentTypeImage)
+		if err != nil {
+			return nil, err
+		}
+		ret, err = marshalScrapedImages([]scraper.ScrapedContent{c})
+		if err != nil {
+			return nil, err
+		}
+	case input.ImageInput != nil:
+		c, err := r.scraperCache().ScrapeFragment(ctx, *source.ScraperID, scraper.Input{Image: input.ImageInput})
+		if err != nil {
+			return nil, err
+		}
+		ret, err = marshalScrapedImages([]scraper.ScrapedContent{c})
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, ErrNotImplemented
+	}
+
+	filterImageTags(ret)
+	return ret, nil
+}
+
 func (r *queryResolver) ScrapeSingleMovie(ctx context.Context, source scraper.Source, input ScrapeSingleMovieInput) ([]*models.ScrapedMovie, error) {
 	return nil, ErrNotSupported
 }
@@ -0,0 +1,22 @@
+package api
+
+import (
+	"context"
+
+	"github.com/stashapp/stash/internal/manager"
+	"github.com/stashapp/stash/pkg/ffmpeg"
+)
+
+func (r *queryResolver) ActiveStreams(ctx context.Context) ([]*ffmpeg.ActiveStream, error) {
+	sm := manager.GetInstance().StreamManager
+	if sm == nil {
+		return []*ffmpeg.ActiveStream{}, nil
+	}
+
+	ret := sm.ActiveStreams()
+	if ret == nil {
+		ret = []*ffmpeg.ActiveStream{}
+	}
+
+	return ret, nil
+}
@@ -0,0 +1,70 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/stashapp/stash/internal/manager"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/webhook"
+)
+
+func (r *queryResolver) Webhooks(ctx context.Context) ([]*Webhook, error) {
+	webhooks := manager.GetInstance().Config.GetWebhooks()
+
+	ret := make([]*Webhook, len(webhooks))
+	for i, w := range webhooks {
+		ret[i] = webhookToModel(*w)
+	}
+
+	return ret, nil
+}
+
+func (r *queryResolver) WebhookEvents(ctx context.Context) ([]string, error) {
+	events := webhook.AllEvents()
+
+	ret := make([]string, len(events))
+	for i, e := range events {
+		ret[i] = e.String()
+	}
+
+	return ret, nil
+}
+
+func (r *queryResolver) WebhookDeliveries(ctx context.Context, webhookID *string) ([]*models.WebhookDelivery, error) {
+	var id *int
+	if webhookID != nil {
+		idInt, err := strconv.Atoi(*webhookID)
+		if err != nil {
+			return nil, fmt.Errorf("converting webhook id: %w", err)
+		}
+		id = &idInt
+	}
+
+	return manager.GetInstance().WebhookDispatcher.Deliveries(ctx, id)
+}
+
+// webhookSecretMask is returned in place of a webhook's secret, so that
+// the secret cannot be read back.
+const webhookSecretMask = "********"
+
+func webhookToModel(w webhook.Webhook) *Webhook {
+	ret := &Webhook{
+		ID:      strconv.Itoa(w.ID),
+		Name:    w.Name,
+		URL:     w.URL,
+		Events:  make([]string, len(w.Events)),
+		Enabled: w.Enabled,
+	}
+
+	if w.Secret != "" {
+		ret.Secret = webhookSecretMask
+	}
+
+	for i, e := range w.Events {
+		ret.Events[i] = e.String()
+	}
+
+	return ret
+}
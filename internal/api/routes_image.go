@@ -3,19 +3,13 @@ package api
 import (
 	"context"
 	"errors"
-	"io/fs"
 	"net/http"
-	"os/exec"
 	"strconv"
 	"time"
 
 	"github.com/go-chi/chi/v5"
 
 	"github.com/stashapp/stash/internal/manager"
-	"github.com/stashapp/stash/internal/static"
-	"github.com/stashapp/stash/pkg/file"
-	"github.com/stashapp/stash/pkg/fsutil"
-	"github.com/stashapp/stash/pkg/image"
 	"github.com/stashapp/stash/pkg/logger"
 	"github.com/stashapp/stash/pkg/models"
 	"github.com/stashapp/stash/pkg/utils"
@@ -52,67 +46,8 @@ func (rs imageRoutes) Thumbnail(w http.ResponseWriter, r *http.Request) {
 }
 
 func (rs imageRoutes) serveThumbnail(w http.ResponseWriter, r *http.Request, img *models.Image, modTime *time.Time) {
-	mgr := manager.GetInstance()
-	filepath := mgr.Paths.Generated.GetThumbnailPath(img.Checksum, models.DefaultGthumbWidth)
-
-	// if the thumbnail doesn't exist, encode on the fly
-	exists, _ := fsutil.FileExists(filepath)
-	if exists {
-		if modTime == nil {
-			utils.ServeStaticFile(w, r, filepath)
-		} else {
-			utils.ServeStaticFileModTime(w, r, filepath, *modTime)
-		}
-	} else {
-		const useDefault = true
-
-		f := img.Files.Primary()
-		if f == nil {
-			rs.serveImage(w, r, img, useDefault)
-			return
-		}
-
-		// use the image thumbnail generate wait group to limit the number of concurrent thumbnail generation tasks
-		wg := &mgr.ImageThumbnailGenerateWaitGroup
-		wg.Add()
-		defer wg.Done()
-
-		clipPreviewOptions := image.ClipPreviewOptions{
-			InputArgs:  manager.GetInstance().Config.GetTranscodeInputArgs(),
-			OutputArgs: manager.GetInstance().Config.GetTranscodeOutputArgs(),
-			Preset:     manager.GetInstance().Config.GetPreviewPreset().String(),
-		}
-
-		encoder := image.NewThumbnailEncoder(manager.GetInstance().FFMpeg, manager.GetInstance().FFProbe, clipPreviewOptions)
-		data, err := encoder.GetThumbnail(f, models.DefaultGthumbWidth)
-		if err != nil {
-			// don't log for unsupported image format
-			// don't log for file not found - can optionally be logged in serveImage
-			if !errors.Is(err, image.ErrNotSupportedForThumbnail) && !errors.Is(err, fs.ErrNotExist) {
-				logger.Errorf("error generating thumbnail for %s: %v", f.Base().Path, err)
-
-				var exitErr *exec.ExitError
-				if errors.As(err, &exitErr) {
-					logger.Errorf("stderr: %s", string(exitErr.Stderr))
-				}
-			}
-
-			// backwards compatibility - fallback to original image instead
-			rs.serveImage(w, r, img, useDefault)
-			return
-		}
-
-		// write the generated thumbnail to disk if enabled
-		if manager.GetInstance().Config.IsWriteImageThumbnails() {
-			logger.Debugf("writing thumbnail to disk: %s", img.Path)
-			if err := fsutil.WriteFile(filepath, data); err == nil {
-				utils.ServeStaticFile(w, r, filepath)
-				return
-			}
-			logger.Errorf("error writing thumbnail for image %s: %v", img.Path, err)
-		}
-		utils.ServeStaticContent(w, r, data)
-	}
+	is := manager.ImageServer{}
+	is.ServeThumbnail(img, w, r, modTime)
 }
 
 func (rs imageRoutes) Preview(w http.ResponseWriter, r *http.Request) {
@@ -131,29 +66,8 @@ func (rs imageRoutes) Image(w http.ResponseWriter, r *http.Request) {
 }
 
 func (rs imageRoutes) serveImage(w http.ResponseWriter, r *http.Request, i *models.Image, useDefault bool) {
-	if i.Files.Primary() != nil {
-		err := i.Files.Primary().Base().Serve(&file.OsFS{}, w, r)
-		if err == nil {
-			return
-		}
-
-		if !useDefault {
-			http.Error(w, err.Error(), http.StatusInternalServerError)
-			return
-		}
-
-		// only log in debug since it can get noisy
-		logger.Debugf("Error serving %s: %v", i.DisplayName(), err)
-	}
-
-	if !useDefault {
-		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
-		return
-	}
-
-	// fallback to default image
-	image := static.ReadAll(static.DefaultImageImage)
-	utils.ServeImage(w, r, image)
+	is := manager.ImageServer{}
+	is.ServeImage(i, w, r, useDefault)
 }
 
 func (rs imageRoutes) ImageCtx(next http.Handler) http.Handler {
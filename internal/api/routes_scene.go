@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"errors"
+	"fmt"
 	"net/http"
 	"strconv"
 	"strings"
@@ -42,11 +43,18 @@ type CaptionFinder interface {
 	GetCaptions(ctx context.Context, fileID models.FileID) ([]*models.VideoCaption, error)
 }
 
+type VideoStreamFinder interface {
+	GetStreams(ctx context.Context, fileID models.FileID) ([]*models.VideoStream, error)
+}
+
+var errInvalidTrack = errors.New("invalid track")
+
 type sceneRoutes struct {
 	routes
 	sceneFinder       SceneFinder
 	fileGetter        models.FileGetter
 	captionFinder     CaptionFinder
+	streamFinder      VideoStreamFinder
 	sceneMarkerFinder SceneMarkerFinder
 	tagFinder         SceneMarkerTagFinder
 }
@@ -64,6 +72,7 @@ func (rs sceneRoutes) Routes() chi.Router {
 		r.Get("/stream.mkv", rs.StreamMKV)
 		r.Get("/stream.m3u8", rs.StreamHLS)
 		r.Get("/stream.m3u8/{segment}.ts", rs.StreamHLSSegment)
+		r.Get("/stream.m3u8/{segment}.m4s", rs.StreamHLSFMP4Segment)
 		r.Get("/stream.mpd", rs.StreamDASH)
 		r.Get("/stream.mpd/{segment}_v.webm", rs.StreamDASHVideoSegment)
 		r.Get("/stream.mpd/{segment}_a.webm", rs.StreamDASHAudioSegment)
@@ -153,11 +162,24 @@ func (rs sceneRoutes) streamTranscode(w http.ResponseWriter, r *http.Request, st
 	ss, _ := strconv.ParseFloat(startTime, 64)
 	resolution := r.Form.Get("resolution")
 
+	tracks, ok := rs.getTrackSelection(w, r, f)
+	if !ok {
+		return
+	}
+
+	codec, ok := getStreamCodec(w, r, streamType.Codecs, true)
+	if !ok {
+		return
+	}
+
 	options := ffmpeg.TranscodeOptions{
 		StreamType: streamType,
 		VideoFile:  f,
 		Resolution: resolution,
 		StartTime:  ss,
+		Tracks:     tracks,
+		Codec:      codec,
+		SceneID:    scene.ID,
 	}
 
 	logger.Debugf("[transcode] streaming scene %d as %s", scene.ID, streamType.MimeType)
@@ -165,14 +187,14 @@ func (rs sceneRoutes) streamTranscode(w http.ResponseWriter, r *http.Request, st
 }
 
 func (rs sceneRoutes) StreamHLS(w http.ResponseWriter, r *http.Request) {
-	rs.streamManifest(w, r, ffmpeg.StreamTypeHLS, "HLS")
+	rs.streamManifest(w, r, ffmpeg.StreamTypeHLS, ffmpeg.HLSCodecs, "HLS")
 }
 
 func (rs sceneRoutes) StreamDASH(w http.ResponseWriter, r *http.Request) {
-	rs.streamManifest(w, r, ffmpeg.StreamTypeDASHVideo, "DASH")
+	rs.streamManifest(w, r, ffmpeg.StreamTypeDASHVideo, ffmpeg.StreamTypeDASHVideo.Codecs, "DASH")
 }
 
-func (rs sceneRoutes) streamManifest(w http.ResponseWriter, r *http.Request, streamType *ffmpeg.StreamType, logName string) {
+func (rs sceneRoutes) streamManifest(w http.ResponseWriter, r *http.Request, streamType *ffmpeg.StreamType, codecs []ffmpeg.StreamCodec, logName string) {
 	scene := r.Context().Value(sceneKey).(*models.Scene)
 
 	streamManager := manager.GetInstance().StreamManager
@@ -192,14 +214,34 @@ func (rs sceneRoutes) streamManifest(w http.ResponseWriter, r *http.Request, str
 
 	resolution := r.Form.Get("resolution")
 
+	tracks, ok := rs.getTrackSelection(w, r, f)
+	if !ok {
+		return
+	}
+
+	codec, ok := getStreamCodec(w, r, codecs, true)
+	if !ok {
+		return
+	}
+
+	if r.Form.Get("adaptive") == "true" {
+		logger.Debugf("[transcode] returning adaptive %s manifest for scene %d", logName, scene.ID)
+		streamManager.ServeAdaptiveManifest(w, r, streamType, f, codec, tracks)
+		return
+	}
+
 	logger.Debugf("[transcode] returning %s manifest for scene %d", logName, scene.ID)
-	streamManager.ServeManifest(w, r, streamType, f, resolution)
+	streamManager.ServeManifest(w, r, streamType, f, resolution, codec, tracks)
 }
 
 func (rs sceneRoutes) StreamHLSSegment(w http.ResponseWriter, r *http.Request) {
 	rs.streamSegment(w, r, ffmpeg.StreamTypeHLS)
 }
 
+func (rs sceneRoutes) StreamHLSFMP4Segment(w http.ResponseWriter, r *http.Request) {
+	rs.streamSegment(w, r, ffmpeg.StreamTypeHLSFMP4)
+}
+
 func (rs sceneRoutes) StreamDASHVideoSegment(w http.ResponseWriter, r *http.Request) {
 	rs.streamSegment(w, r, ffmpeg.StreamTypeDASHVideo)
 }
@@ -231,17 +273,130 @@ func (rs sceneRoutes) streamSegment(w http.ResponseWriter, r *http.Request, stre
 	segment := chi.URLParam(r, "segment")
 	resolution := r.Form.Get("resolution")
 
+	tracks, ok := rs.getTrackSelection(w, r, f)
+	if !ok {
+		return
+	}
+
+	// the codec of segments is set by the manifest
+	codec, ok := getStreamCodec(w, r, streamType.Codecs, false)
+	if !ok {
+		return
+	}
+
 	options := ffmpeg.StreamOptions{
 		StreamType: streamType,
 		VideoFile:  f,
 		Resolution: resolution,
+		Codec:      codec,
+		Tracks:     tracks,
 		Hash:       sceneHash,
 		Segment:    segment,
+		SceneID:    scene.ID,
 	}
 
 	streamManager.ServeSegment(w, r, options)
 }
 
+// getStreamCodec returns the video codec requested by the codec query
+// parameter, or if negotiate is true, the preferred codec of the Accept
+// header. Writes an error response and returns false if the requested codec
+// is not supported. Assumes that the query form has been parsed.
+func getStreamCodec(w http.ResponseWriter, r *http.Request, codecs []ffmpeg.StreamCodec, negotiate bool) (ffmpeg.StreamCodec, bool) {
+	accept := ""
+	if negotiate {
+		accept = r.Header.Get("Accept")
+	}
+
+	codec, err := ffmpeg.NegotiateStreamCodec(r.Form.Get("codec"), accept, codecs)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return codec, false
+	}
+
+	return codec, true
+}
+
+// getTrackSelection returns the tracks selected by the audio_track and
+// subtitle_track query parameters, which are stream indexes within the file.
+// Writes an error response and returns false if the selection is invalid.
+// Assumes that the query form has been parsed.
+func (rs sceneRoutes) getTrackSelection(w http.ResponseWriter, r *http.Request, f *models.VideoFile) (ffmpeg.TrackSelection, bool) {
+	var ret ffmpeg.TrackSelection
+
+	audioTrack := r.Form.Get("audio_track")
+	subtitleTrack := r.Form.Get("subtitle_track")
+	if audioTrack == "" && subtitleTrack == "" {
+		return ret, true
+	}
+
+	streams, err := rs.getStreams(r, f)
+	if err != nil {
+		if !errors.Is(err, context.Canceled) {
+			logger.Warnf("[transcode] error getting streams: %v", err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return ret, false
+	}
+
+	if audioTrack != "" {
+		ret.Audio, err = findStream(streams, audioTrack, models.VideoStreamTypeAudio)
+	}
+	if err == nil && subtitleTrack != "" {
+		ret.Subtitle, err = findSubtitleStream(streams, subtitleTrack)
+	}
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return ret, false
+	}
+
+	return ret, true
+}
+
+func (rs sceneRoutes) getStreams(r *http.Request, f *models.VideoFile) ([]*models.VideoStream, error) {
+	var ret []*models.VideoStream
+	err := rs.withReadTxn(r, func(ctx context.Context) error {
+		var err error
+		ret, err = rs.streamFinder.GetStreams(ctx, f.ID)
+		return err
+	})
+
+	return ret, err
+}
+
+// findStream returns the index of the stream of the provided type, where
+// indexStr is the stream index from a query parameter.
+func findStream(streams []*models.VideoStream, indexStr string, streamType models.VideoStreamType) (*int, error) {
+	index, err := strconv.Atoi(indexStr)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %q", errInvalidTrack, indexStr)
+	}
+
+	s := models.StreamByIndex(streams, index)
+	if s == nil || s.Type != streamType {
+		return nil, fmt.Errorf("%w: stream %d is not a %s stream", errInvalidTrack, index, strings.ToLower(streamType.String()))
+	}
+
+	return &index, nil
+}
+
+// findSubtitleStream returns the index of the text subtitle stream, where
+// indexStr is the stream index from a query parameter.
+func findSubtitleStream(streams []*models.VideoStream, indexStr string) (*int, error) {
+	index, err := findStream(streams, indexStr, models.VideoStreamTypeSubtitle)
+	if err != nil {
+		return nil, err
+	}
+
+	s := models.StreamByIndex(streams, *index)
+	if !ffmpeg.IsTextSubtitleCodec(s.Codec) {
+		return nil, fmt.Errorf("%w: subtitle stream %d is not text based", errInvalidTrack, *index)
+	}
+
+	return index, nil
+}
+
 func (rs sceneRoutes) Screenshot(w http.ResponseWriter, r *http.Request) {
 	scene := r.Context().Value(sceneKey).(*models.Scene)
 
@@ -448,12 +603,58 @@ func (rs sceneRoutes) Caption(w http.ResponseWriter, r *http.Request, lang strin
 	}
 }
 
+// CaptionStream serves an embedded text subtitle stream of the primary file
+// as WebVTT.
+func (rs sceneRoutes) CaptionStream(w http.ResponseWriter, r *http.Request, streamIndex string) {
+	s := r.Context().Value(sceneKey).(*models.Scene)
+
+	f := s.Files.Primary()
+	if f == nil {
+		return
+	}
+
+	streams, err := rs.getStreams(r, f)
+	if errors.Is(err, context.Canceled) {
+		return
+	}
+	if err != nil {
+		logger.Warnf("read transaction error on fetch file streams: %v", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	index, err := findSubtitleStream(streams, streamIndex)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	args := ffmpeg.ExtractSubtitleArgs(f.Path, *index)
+	sub, err := manager.GetInstance().FFMpeg.GenerateOutput(r.Context(), args, nil)
+	if err != nil {
+		if !errors.Is(r.Context().Err(), context.Canceled) {
+			logger.Warnf("[caption] error extracting subtitle stream %d from %s: %v", *index, f.Path, err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/vtt")
+	utils.ServeStaticContent(w, r, sub)
+}
+
 func (rs sceneRoutes) CaptionLang(w http.ResponseWriter, r *http.Request) {
 	// serve caption based on lang query param, if provided
 	if err := r.ParseForm(); err != nil {
 		logger.Warnf("[caption] error parsing query form: %v", err)
 	}
 
+	// serve embedded subtitle stream if stream index is provided
+	if stream := r.Form.Get("stream"); stream != "" {
+		rs.CaptionStream(w, r, stream)
+		return
+	}
+
 	l := r.Form.Get("lang")
 	ext := r.Form.Get("type")
 	rs.Caption(w, r, l, ext)
@@ -76,6 +76,52 @@ func marshalScrapedGalleries(content []scraper.ScrapedContent) ([]*scraper.Scrap
 	return ret, nil
 }
 
+// marshalScrapedImages converts ScrapedContent into ScrapedImage. If
+// conversion fails, an error is returned.
+func marshalScrapedImages(content []scraper.ScrapedContent) ([]*scraper.ScrapedImage, error) {
+	var ret []*scraper.ScrapedImage
+	for _, c := range content {
+		if c == nil {
+			// graphql schema requires images to be non-nil
+			continue
+		}
+
+		switch i := c.(type) {
+		case *scraper.ScrapedImage:
+			ret = append(ret, i)
+		case scraper.ScrapedImage:
+			ret = append(ret, &i)
+		default:
+			return nil, fmt.Errorf("%w: cannot turn ScrapedContent into ScrapedImage", models.ErrConversion)
+		}
+	}
+
+	return ret, nil
+}
+
+// marshalScrapedStudios converts ScrapedContent into ScrapedStudio. If
+// conversion fails, an error is returned.
+func marshalScrapedStudios(content []scraper.ScrapedContent) ([]*models.ScrapedStudio, error) {
+	var ret []*models.ScrapedStudio
+	for _, c := range content {
+		if c == nil {
+			// graphql schema requires studios to be non-nil
+			continue
+		}
+
+		switch s := c.(type) {
+		case *models.ScrapedStudio:
+			ret = append(ret, s)
+		case models.ScrapedStudio:
+			ret = append(ret, &s)
+		default:
+			return nil, fmt.Errorf("%w: cannot turn ScrapedContent into ScrapedStudio", models.ErrConversion)
+		}
+	}
+
+	return ret, nil
+}
+
 // marshalScrapedMovies converts ScrapedContent into ScrapedMovie. If conversion
 // fails, an error is returned.
 func marshalScrapedMovies(content []scraper.ScrapedContent) ([]*models.ScrapedMovie, error) {
@@ -129,6 +175,26 @@ func marshalScrapedGallery(content scraper.ScrapedContent) (*scraper.ScrapedGall
 	return g[0], nil
 }
 
+// marshalScrapedImage will marshal a single scraped image
+func marshalScrapedImage(content scraper.ScrapedContent) (*scraper.ScrapedImage, error) {
+	i, err := marshalScrapedImages([]scraper.ScrapedContent{content})
+	if err != nil {
+		return nil, err
+	}
+
+	return i[0], nil
+}
+
+// marshalScrapedStudio will marshal a single scraped studio
+func marshalScrapedStudio(content scraper.ScrapedContent) (*models.ScrapedStudio, error) {
+	s, err := marshalScrapedStudios([]scraper.ScrapedContent{content})
+	if err != nil {
+		return nil, err
+	}
+
+	return s[0], nil
+}
+
 // marshalScrapedMovie will marshal a single scraped movie
 func marshalScrapedMovie(content scraper.ScrapedContent) (*models.ScrapedMovie, error) {
 	m, err := marshalScrapedMovies([]scraper.ScrapedContent{content})
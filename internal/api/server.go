@@ -34,7 +34,9 @@ import (
 	"github.com/stashapp/stash/internal/manager/config"
 	"github.com/stashapp/stash/pkg/fsutil"
 	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
 	"github.com/stashapp/stash/pkg/plugin"
+	"github.com/stashapp/stash/pkg/session"
 	"github.com/stashapp/stash/pkg/utils"
 	"github.com/stashapp/stash/ui"
 )
@@ -189,10 +191,19 @@ func Initialize() (*Server, error) {
 	gqlSrv.Use(gqlExtension.Introspection{})
 
 	gqlSrv.SetErrorPresenter(gqlErrorHandler)
+	gqlSrv.AroundRootFields(authorizeRootField)
 
 	gqlHandlerFunc := func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Cache-Control", "no-store")
-		gqlSrv.ServeHTTP(w, r)
+
+		// attribute edits to the UI unless the request was made by a plugin
+		ctx := r.Context()
+		editSource := models.EditSourceUI
+		if session.IsPluginRequest(ctx) {
+			editSource = models.EditSourcePlugin
+		}
+
+		gqlSrv.ServeHTTP(w, r.WithContext(models.WithEditSource(ctx, editSource)))
 	}
 
 	// register GQL handler with plugin cache
@@ -202,21 +213,27 @@ func Initialize() (*Server, error) {
 	pluginCache.RegisterGQLHandler(gqlHandler)
 
 	r.HandleFunc(gqlEndpoint, gqlHandlerFunc)
-	r.HandleFunc(playgroundEndpoint, func(w http.ResponseWriter, r *http.Request) {
+	r.With(requireRole(models.UserRoleAdmin)).HandleFunc(playgroundEndpoint, func(w http.ResponseWriter, r *http.Request) {
 		setPageSecurityHeaders(w, r, pluginCache.ListPlugins())
 		endpoint := getProxyPrefix(r) + gqlEndpoint
 		gqlPlayground.Handler("GraphQL playground", endpoint)(w, r)
 	})
 
-	r.Mount("/performer", server.getPerformerRoutes())
-	r.Mount("/scene", server.getSceneRoutes())
-	r.Mount("/gallery", server.getGalleryRoutes())
-	r.Mount("/image", server.getImageRoutes())
-	r.Mount("/studio", server.getStudioRoutes())
-	r.Mount("/group", server.getGroupRoutes())
-	r.Mount("/tag", server.getTagRoutes())
-	r.Mount("/downloads", server.getDownloadsRoutes())
-	r.Mount("/plugin", server.getPluginRoutes())
+	r.Group(func(r chi.Router) {
+		r.Use(requireRole(models.UserRoleViewer))
+
+		r.Mount("/performer", server.getPerformerRoutes())
+		r.Mount("/scene", server.getSceneRoutes())
+		r.Mount("/gallery", server.getGalleryRoutes())
+		r.Mount("/image", server.getImageRoutes())
+		r.Mount("/studio", server.getStudioRoutes())
+		r.Mount("/group", server.getGroupRoutes())
+		r.Mount("/tag", server.getTagRoutes())
+		r.Mount("/plugin", server.getPluginRoutes())
+	})
+
+	// downloads are only created by exports and backups
+	r.With(requireRole(models.UserRoleAdmin)).Mount("/downloads", server.getDownloadsRoutes())
 
 	r.HandleFunc("/css", cssHandler(cfg))
 	r.HandleFunc("/javascript", javascriptHandler(cfg))
@@ -236,7 +253,7 @@ func Initialize() (*Server, error) {
 	// Serve static folders
 	customServedFolders := cfg.GetCustomServedFolders()
 	if customServedFolders != nil {
-		r.Mount("/custom", getCustomRoutes(customServedFolders))
+		r.With(requireRole(models.UserRoleViewer)).Mount("/custom", getCustomRoutes(customServedFolders))
 	}
 
 	var uiFS fs.FS
@@ -329,6 +346,7 @@ func (s *Server) getSceneRoutes() chi.Router {
 		sceneFinder:       repo.Scene,
 		fileGetter:        repo.File,
 		captionFinder:     repo.File,
+		streamFinder:      repo.File,
 		sceneMarkerFinder: repo.SceneMarker,
 		tagFinder:         repo.Tag,
 	}.Routes()
@@ -25,6 +25,7 @@ import (
 	"context"
 	"encoding/xml"
 	"fmt"
+	"mime"
 	"net/http"
 	"net/url"
 	"os"
@@ -37,6 +38,7 @@ import (
 	"github.com/anacrolix/dms/dlna"
 	"github.com/anacrolix/dms/upnp"
 	"github.com/anacrolix/dms/upnpav"
+	"github.com/stashapp/stash/pkg/image"
 	"github.com/stashapp/stash/pkg/logger"
 	"github.com/stashapp/stash/pkg/models"
 	"github.com/stashapp/stash/pkg/scene"
@@ -45,6 +47,13 @@ import (
 
 var pageSize = 100
 
+const (
+	// imageIDPrefix is prepended to image object IDs to distinguish them
+	// from scene object IDs.
+	imageIDPrefix  = "image-"
+	imageSortOrder = "title"
+)
+
 type browse struct {
 	ObjectID       string
 	BrowseFlag     string
@@ -145,6 +154,73 @@ func sceneToContainer(scene *models.Scene, parent string, host string) interface
 	return item
 }
 
+func imageToContainer(image *models.Image, parent string, host string) interface{} {
+	imageID := strconv.Itoa(image.ID)
+
+	iconURI := (&url.URL{
+		Scheme: "http",
+		Host:   host,
+		Path:   iconPath,
+		RawQuery: url.Values{
+			"image": {imageID},
+		}.Encode(),
+	}).String()
+
+	obj := upnpav.Object{
+		ID:          imageIDPrefix + imageID,
+		Restricted:  1,
+		ParentID:    parent,
+		Title:       image.GetTitle(),
+		Class:       "object.item.imageItem.photo",
+		Icon:        iconURI,
+		AlbumArtURI: iconURI,
+	}
+
+	item := upnpav.Item{
+		Object: obj,
+		Res:    make([]upnpav.Resource, 0, 2),
+	}
+
+	mimeType := "image/jpeg"
+	var (
+		size       uint64
+		resolution string
+	)
+
+	if f := image.Files.Primary(); f != nil {
+		size = uint64(f.Base().Size)
+
+		if t := mime.TypeByExtension(filepath.Ext(f.Base().Basename)); t != "" {
+			mimeType = t
+		}
+
+		if vf, ok := f.(models.VisualFile); ok {
+			resolution = fmt.Sprintf("%dx%d", vf.GetWidth(), vf.GetHeight())
+		}
+	}
+
+	item.Res = append(item.Res, upnpav.Resource{
+		URL: (&url.URL{
+			Scheme: "http",
+			Host:   host,
+			Path:   resPath,
+			RawQuery: url.Values{
+				"image": {imageID},
+			}.Encode(),
+		}).String(),
+		ProtocolInfo: fmt.Sprintf("http-get:*:%s:%s", mimeType, dlna.ContentFeatures{}.String()),
+		Size:         size,
+		Resolution:   resolution,
+	})
+
+	item.Res = append(item.Res, upnpav.Resource{
+		URL:          iconURI,
+		ProtocolInfo: "http-get:*:image/jpeg:DLNA.ORG_PN=JPEG_TN",
+	})
+
+	return item
+}
+
 // ContentDirectory object from ObjectID.
 func (me *contentDirectoryService) objectFromID(id string) (o object, err error) {
 	o.Path, err = url.QueryUnescape(id)
@@ -291,7 +367,7 @@ func (me *contentDirectoryService) handleBrowseDirectChildren(obj object, host s
 
 	// Studios
 	if obj.Path == "studios" {
-		objs = me.getStudios()
+		objs = me.getStudios("studios")
 	}
 
 	if strings.HasPrefix(obj.Path, "studios/") {
@@ -300,7 +376,7 @@ func (me *contentDirectoryService) handleBrowseDirectChildren(obj object, host s
 
 	// Tags
 	if obj.Path == "tags" {
-		objs = me.getTags()
+		objs = me.getTags("tags")
 	}
 
 	if strings.HasPrefix(obj.Path, "tags/") {
@@ -309,7 +385,7 @@ func (me *contentDirectoryService) handleBrowseDirectChildren(obj object, host s
 
 	// Performers
 	if obj.Path == "performers" {
-		objs = me.getPerformers()
+		objs = me.getPerformers("performers")
 	}
 
 	if strings.HasPrefix(obj.Path, "performers/") {
@@ -334,6 +410,44 @@ func (me *contentDirectoryService) handleBrowseDirectChildren(obj object, host s
 		objs = me.getRatingScenes(childPath(paths), host)
 	}
 
+	// Galleries
+	if obj.Path == "galleries" {
+		objs = me.getGalleries()
+	}
+
+	if strings.HasPrefix(obj.Path, "galleries/") {
+		objs = me.getGalleryImages(childPath(paths), host)
+	}
+
+	// Images
+	if obj.Path == "images" {
+		objs = getImageRootObjects()
+	}
+
+	if obj.Path == "images/studios" {
+		objs = me.getStudios("images/studios")
+	}
+
+	if strings.HasPrefix(obj.Path, "images/studios/") {
+		objs = me.getStudioImages(childPath(childPath(paths)), host)
+	}
+
+	if obj.Path == "images/tags" {
+		objs = me.getTags("images/tags")
+	}
+
+	if strings.HasPrefix(obj.Path, "images/tags/") {
+		objs = me.getTagImages(childPath(childPath(paths)), host)
+	}
+
+	if obj.Path == "images/performers" {
+		objs = me.getPerformers("images/performers")
+	}
+
+	if strings.HasPrefix(obj.Path, "images/performers/") {
+		objs = me.getPerformerImages(childPath(childPath(paths)), host)
+	}
+
 	return makeBrowseResult(objs, me.updateIDString())
 }
 
@@ -341,6 +455,10 @@ func (me *contentDirectoryService) handleBrowseMetadata(obj object, host string)
 	var objs []interface{}
 	var updateID string
 
+	if strings.HasPrefix(obj.Path, imageIDPrefix) {
+		return me.handleBrowseImageMetadata(obj, host)
+	}
+
 	// if numeric, then must be scene, otherwise handle as if path
 	sceneID, err := strconv.Atoi(obj.Path)
 	if err != nil {
@@ -390,6 +508,39 @@ func (me *contentDirectoryService) handleBrowseMetadata(obj object, host string)
 	return makeBrowseResult(objs, updateID)
 }
 
+func (me *contentDirectoryService) handleBrowseImageMetadata(obj object, host string) (map[string]string, error) {
+	imageID, err := strconv.Atoi(strings.TrimPrefix(obj.Path, imageIDPrefix))
+	if err != nil {
+		return nil, upnp.Errorf(upnpav.NoSuchObjectErrorCode, "invalid image id %q", obj.Path)
+	}
+
+	var image *models.Image
+
+	r := me.repository
+	if err := r.WithReadTxn(context.TODO(), func(ctx context.Context) error {
+		image, err = r.ImageFinder.Find(ctx, imageID)
+		if image != nil {
+			err = image.LoadPrimaryFile(ctx, r.FileGetter)
+		}
+
+		return err
+	}); err != nil {
+		logger.Error(err.Error())
+	}
+
+	if image == nil {
+		return nil, upnp.Errorf(upnpav.NoSuchObjectErrorCode, "image not found")
+	}
+
+	objs := []interface{}{imageToContainer(image, "-1", host)}
+
+	// maximum update ID is 2**32, then rolls back to 0
+	const maxUpdateID int64 = 1 << 32
+	updateID := fmt.Sprint(image.UpdatedAt.Unix() % maxUpdateID)
+
+	return makeBrowseResult(objs, updateID)
+}
+
 func makeBrowseResult(objs []interface{}, updateID string) (map[string]string, error) {
 	result, err := xml.Marshal(objs)
 	if err != nil {
@@ -435,6 +586,20 @@ func getRootObjects() []interface{} {
 	objs = append(objs, makeStorageFolder("studios", "studios", rootID))
 	objs = append(objs, makeStorageFolder("groups", "groups", rootID))
 	objs = append(objs, makeStorageFolder("rating", "rating", rootID))
+	objs = append(objs, makeStorageFolder("galleries", "galleries", rootID))
+	objs = append(objs, makeStorageFolder("images", "images", rootID))
+
+	return objs
+}
+
+func getImageRootObjects() []interface{} {
+	const parentID = "images"
+
+	var objs []interface{}
+
+	objs = append(objs, makeStorageFolder("images/performers", "performers", parentID))
+	objs = append(objs, makeStorageFolder("images/tags", "tags", parentID))
+	objs = append(objs, makeStorageFolder("images/studios", "studios", parentID))
 
 	return objs
 }
@@ -538,7 +703,7 @@ func (me *contentDirectoryService) getAllScenes(host string) []interface{} {
 	return me.getVideos(&models.SceneFilterType{}, "all", host)
 }
 
-func (me *contentDirectoryService) getStudios() []interface{} {
+func (me *contentDirectoryService) getStudios(parentID string) []interface{} {
 	var objs []interface{}
 
 	r := me.repository
@@ -549,7 +714,7 @@ func (me *contentDirectoryService) getStudios() []interface{} {
 		}
 
 		for _, s := range studios {
-			objs = append(objs, makeStorageFolder("studios/"+strconv.Itoa(s.ID), s.Name, "studios"))
+			objs = append(objs, makeStorageFolder(parentID+"/"+strconv.Itoa(s.ID), s.Name, parentID))
 		}
 
 		return nil
@@ -578,7 +743,7 @@ func (me *contentDirectoryService) getStudioScenes(paths []string, host string)
 	return me.getVideos(sceneFilter, parentID, host)
 }
 
-func (me *contentDirectoryService) getTags() []interface{} {
+func (me *contentDirectoryService) getTags(parentID string) []interface{} {
 	var objs []interface{}
 
 	r := me.repository
@@ -589,7 +754,7 @@ func (me *contentDirectoryService) getTags() []interface{} {
 		}
 
 		for _, s := range tags {
-			objs = append(objs, makeStorageFolder("tags/"+strconv.Itoa(s.ID), s.Name, "tags"))
+			objs = append(objs, makeStorageFolder(parentID+"/"+strconv.Itoa(s.ID), s.Name, parentID))
 		}
 
 		return nil
@@ -618,7 +783,7 @@ func (me *contentDirectoryService) getTagScenes(paths []string, host string) []i
 	return me.getVideos(sceneFilter, parentID, host)
 }
 
-func (me *contentDirectoryService) getPerformers() []interface{} {
+func (me *contentDirectoryService) getPerformers(parentID string) []interface{} {
 	var objs []interface{}
 
 	r := me.repository
@@ -629,7 +794,7 @@ func (me *contentDirectoryService) getPerformers() []interface{} {
 		}
 
 		for _, s := range performers {
-			objs = append(objs, makeStorageFolder("performers/"+strconv.Itoa(s.ID), s.Name, "performers"))
+			objs = append(objs, makeStorageFolder(parentID+"/"+strconv.Itoa(s.ID), s.Name, parentID))
 		}
 
 		return nil
@@ -732,6 +897,159 @@ func (me *contentDirectoryService) getRatingScenes(paths []string, host string)
 	return me.getVideos(sceneFilter, parentID, host)
 }
 
+func (me *contentDirectoryService) getImages(imageFilter *models.ImageFilterType, parentID string, host string) []interface{} {
+	var objs []interface{}
+
+	r := me.repository
+	if err := r.WithReadTxn(context.TODO(), func(ctx context.Context) error {
+		sort := imageSortOrder
+		direction := models.SortDirectionEnumAsc
+		findFilter := &models.FindFilterType{
+			PerPage:   &pageSize,
+			Sort:      &sort,
+			Direction: &direction,
+		}
+
+		images, total, err := image.QueryWithCount(ctx, r.ImageFinder, imageFilter, findFilter)
+		if err != nil {
+			return err
+		}
+
+		if total > pageSize {
+			pager := imagePager{
+				imageFilter: imageFilter,
+				parentID:    parentID,
+			}
+
+			objs, err = pager.getPages(ctx, r.ImageFinder, total)
+			if err != nil {
+				return err
+			}
+		} else {
+			for _, i := range images {
+				if err := i.LoadPrimaryFile(ctx, r.FileGetter); err != nil {
+					return err
+				}
+
+				objs = append(objs, imageToContainer(i, parentID, host))
+			}
+		}
+
+		return nil
+	}); err != nil {
+		logger.Error(err.Error())
+	}
+
+	return objs
+}
+
+func (me *contentDirectoryService) getPageImages(imageFilter *models.ImageFilterType, parentID string, page int, host string) []interface{} {
+	var objs []interface{}
+
+	r := me.repository
+	if err := r.WithReadTxn(context.TODO(), func(ctx context.Context) error {
+		pager := imagePager{
+			imageFilter: imageFilter,
+			parentID:    parentID,
+		}
+
+		var err error
+		objs, err = pager.getPageImages(ctx, r.ImageFinder, r.FileGetter, page, host)
+		if err != nil {
+			return err
+		}
+
+		return nil
+	}); err != nil {
+		logger.Error(err.Error())
+	}
+
+	return objs
+}
+
+func (me *contentDirectoryService) getFilteredImages(imageFilter *models.ImageFilterType, parentID string, paths []string, host string) []interface{} {
+	page := getPageFromID(paths)
+	if page != nil {
+		return me.getPageImages(imageFilter, parentID, *page, host)
+	}
+
+	return me.getImages(imageFilter, parentID, host)
+}
+
+func (me *contentDirectoryService) getGalleries() []interface{} {
+	var objs []interface{}
+
+	r := me.repository
+	if err := r.WithReadTxn(context.TODO(), func(ctx context.Context) error {
+		galleries, err := r.GalleryFinder.All(ctx)
+		if err != nil {
+			return err
+		}
+
+		for _, g := range galleries {
+			objs = append(objs, makeStorageFolder("galleries/"+strconv.Itoa(g.ID), g.GetTitle(), "galleries"))
+		}
+
+		return nil
+	}); err != nil {
+		logger.Errorf(err.Error())
+	}
+
+	return objs
+}
+
+func (me *contentDirectoryService) getGalleryImages(paths []string, host string) []interface{} {
+	imageFilter := &models.ImageFilterType{
+		Galleries: &models.MultiCriterionInput{
+			Modifier: models.CriterionModifierIncludes,
+			Value:    []string{paths[0]},
+		},
+	}
+
+	parentID := "galleries/" + strings.Join(paths, "/")
+
+	return me.getFilteredImages(imageFilter, parentID, paths, host)
+}
+
+func (me *contentDirectoryService) getStudioImages(paths []string, host string) []interface{} {
+	imageFilter := &models.ImageFilterType{
+		Studios: &models.HierarchicalMultiCriterionInput{
+			Modifier: models.CriterionModifierIncludes,
+			Value:    []string{paths[0]},
+		},
+	}
+
+	parentID := "images/studios/" + strings.Join(paths, "/")
+
+	return me.getFilteredImages(imageFilter, parentID, paths, host)
+}
+
+func (me *contentDirectoryService) getTagImages(paths []string, host string) []interface{} {
+	imageFilter := &models.ImageFilterType{
+		Tags: &models.HierarchicalMultiCriterionInput{
+			Modifier: models.CriterionModifierIncludes,
+			Value:    []string{paths[0]},
+		},
+	}
+
+	parentID := "images/tags/" + strings.Join(paths, "/")
+
+	return me.getFilteredImages(imageFilter, parentID, paths, host)
+}
+
+func (me *contentDirectoryService) getPerformerImages(paths []string, host string) []interface{} {
+	imageFilter := &models.ImageFilterType{
+		Performers: &models.MultiCriterionInput{
+			Modifier: models.CriterionModifierIncludes,
+			Value:    []string{paths[0]},
+		},
+	}
+
+	parentID := "images/performers/" + strings.Join(paths, "/")
+
+	return me.getFilteredImages(imageFilter, parentID, paths, host)
+}
+
 // Represents a ContentDirectory object.
 type object struct {
 	Path           string // The cleaned, absolute path for the object relative to the server.
@@ -31,6 +31,8 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/anacrolix/dms/upnpav"
+	"github.com/stashapp/stash/pkg/models"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -78,3 +80,47 @@ func TestBrowseMetadataTags(t *testing.T) {
 
 	assert.Nil(t, err)
 }
+
+func TestBrowseMetadataInvalidImage(t *testing.T) {
+	argsXML := `<u:Browse xmlns:u="urn:schemas-upnp-org:service:ContentDirectory:1"><ObjectID>image-abc</ObjectID><BrowseFlag>BrowseMetadata</BrowseFlag><Filter>*</Filter><StartingIndex>0</StartingIndex><RequestedCount>0</RequestedCount><SortCriteria></SortCriteria></u:Browse>`
+	_, err := testHandleBrowse(argsXML)
+
+	assert.NotNil(t, err)
+}
+
+func TestImageToContainer(t *testing.T) {
+	const host = "localhost:1338"
+
+	image := &models.Image{
+		ID:    1,
+		Title: "title",
+		Files: models.NewRelatedFiles([]models.File{
+			&models.ImageFile{
+				BaseFile: &models.BaseFile{
+					Basename: "image.png",
+					Size:     100,
+				},
+				Width:  640,
+				Height: 480,
+			},
+		}),
+	}
+
+	item, ok := imageToContainer(image, "galleries/1", host).(upnpav.Item)
+	if !assert.True(t, ok) {
+		return
+	}
+
+	assert.Equal(t, "image-1", item.ID)
+	assert.Equal(t, "galleries/1", item.ParentID)
+	assert.Equal(t, "title", item.Title)
+	assert.Equal(t, "object.item.imageItem.photo", item.Class)
+
+	if assert.Len(t, item.Res, 2) {
+		assert.Equal(t, "http://"+host+resPath+"?image=1", item.Res[0].URL)
+		assert.True(t, strings.HasPrefix(item.Res[0].ProtocolInfo, "http-get:*:image/png:"))
+		assert.Equal(t, "640x480", item.Res[0].Resolution)
+		assert.Equal(t, uint64(100), item.Res[0].Size)
+		assert.Equal(t, "http://"+host+iconPath+"?image=1", item.Res[1].URL)
+	}
+}
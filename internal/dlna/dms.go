@@ -56,6 +56,15 @@ type SceneFinder interface {
 	models.SceneQueryer
 }
 
+type ImageFinder interface {
+	models.ImageGetter
+	models.ImageQueryer
+}
+
+type GalleryFinder interface {
+	All(ctx context.Context) ([]*models.Gallery, error)
+}
+
 type StudioFinder interface {
 	All(ctx context.Context) ([]*models.Studio, error)
 }
@@ -277,6 +286,7 @@ type Server struct {
 
 	repository         Repository
 	sceneServer        sceneServer
+	imageServer        imageServer
 	ipWhitelistManager *ipWhitelistManager
 	VideoSortOrder     string
 
@@ -438,6 +448,16 @@ func (me *Server) serviceControlHandler(w http.ResponseWriter, r *http.Request)
 }
 
 func (me *Server) serveIcon(w http.ResponseWriter, r *http.Request) {
+	if imageId := r.URL.Query().Get("image"); imageId != "" {
+		image := me.findImage(r, imageId)
+		if image == nil {
+			return
+		}
+
+		me.imageServer.ServeThumbnail(image, w, r, nil)
+		return
+	}
+
 	sceneId := r.URL.Query().Get("scene")
 	if sceneId == "" {
 		return
@@ -464,6 +484,32 @@ func (me *Server) serveIcon(w http.ResponseWriter, r *http.Request) {
 	me.sceneServer.ServeScreenshot(scene, w, r)
 }
 
+// findImage returns the image with the provided id, with its primary file loaded.
+// Returns nil if the image is not found.
+func (me *Server) findImage(r *http.Request, imageId string) *models.Image {
+	idInt, err := strconv.Atoi(imageId)
+	if err != nil {
+		return nil
+	}
+
+	var image *models.Image
+	repo := me.repository
+	err = repo.WithReadTxn(r.Context(), func(ctx context.Context) error {
+		image, _ = repo.ImageFinder.Find(ctx, idInt)
+		if image == nil {
+			return nil
+		}
+
+		return image.LoadPrimaryFile(ctx, repo.FileGetter)
+	})
+	if err != nil {
+		logger.Warnf("failed to execute read transaction for image id (%v): %v", imageId, err)
+		return nil
+	}
+
+	return image
+}
+
 func (me *Server) contentDirectoryInitialEvent(ctx context.Context, urls []*url.URL, sid string) {
 	body := xmlMarshalOrPanic(upnp.PropertySet{
 		Properties: []upnp.Property{
@@ -594,6 +640,18 @@ func (me *Server) initMux(mux *http.ServeMux) {
 	mux.HandleFunc(contentDirectoryEventSubURL, me.contentDirectoryEventSubHandler)
 	mux.HandleFunc(iconPath, me.serveIcon)
 	mux.HandleFunc(resPath, func(w http.ResponseWriter, r *http.Request) {
+		if imageId := r.URL.Query().Get("image"); imageId != "" {
+			image := me.findImage(r, imageId)
+			if image == nil {
+				http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+				return
+			}
+
+			const useDefault = false
+			me.imageServer.ServeImage(image, w, r, useDefault)
+			return
+		}
+
 		sceneId := r.URL.Query().Get("scene")
 		var scene *models.Scene
 		repo := me.repository
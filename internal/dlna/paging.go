@@ -6,6 +6,7 @@ import (
 	"math"
 	"strconv"
 
+	"github.com/stashapp/stash/pkg/image"
 	"github.com/stashapp/stash/pkg/models"
 	"github.com/stashapp/stash/pkg/scene"
 )
@@ -84,3 +85,79 @@ func (p *scenePager) getPageVideos(ctx context.Context, r SceneFinder, f models.
 
 	return objs, nil
 }
+
+type imagePager struct {
+	imageFilter *models.ImageFilterType
+	parentID    string
+}
+
+func (p *imagePager) getPageID(page int) string {
+	return p.parentID + "/page/" + strconv.Itoa(page)
+}
+
+func (p *imagePager) getPages(ctx context.Context, r image.Queryer, total int) ([]interface{}, error) {
+	var objs []interface{}
+
+	// get the first image of each page to set an appropriate title
+	pages := int(math.Ceil(float64(total) / float64(pageSize)))
+
+	singlePageSize := 1
+	sort := imageSortOrder
+	findFilter := &models.FindFilterType{
+		PerPage: &singlePageSize,
+		Sort:    &sort,
+	}
+
+	for page := 1; page <= pages; page++ {
+		title := fmt.Sprintf("Page %d", page)
+		if pages <= 10 || (page-1)%(pages/10) == 0 {
+			thisPage := ((page - 1) * pageSize) + 1
+			findFilter.Page = &thisPage
+			images, err := image.Query(ctx, r, p.imageFilter, findFilter)
+			if err != nil {
+				return nil, err
+			}
+
+			imageTitle := images[0].GetTitle()
+
+			// use the first three letters as a prefix
+			if len(imageTitle) > 3 {
+				imageTitle = imageTitle[0:3]
+			}
+
+			title += fmt.Sprintf(" (%s...)", imageTitle)
+		}
+
+		objs = append(objs, makeStorageFolder(p.getPageID(page), title, p.parentID))
+	}
+
+	return objs, nil
+}
+
+func (p *imagePager) getPageImages(ctx context.Context, r image.Queryer, f models.FileGetter, page int, host string) ([]interface{}, error) {
+	var objs []interface{}
+
+	sort := imageSortOrder
+	direction := models.SortDirectionEnumAsc
+	findFilter := &models.FindFilterType{
+		PerPage:   &pageSize,
+		Page:      &page,
+		Sort:      &sort,
+		Direction: &direction,
+	}
+
+	images, err := image.Query(ctx, r, p.imageFilter, findFilter)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, i := range images {
+		if err := i.LoadPrimaryFile(ctx, f); err != nil {
+			return nil, err
+		}
+
+		objs = append(objs, imageToContainer(i, p.parentID, host))
+	}
+
+	return objs, nil
+}
@@ -18,6 +18,8 @@ type Repository struct {
 	TxnManager models.TxnManager
 
 	SceneFinder     SceneFinder
+	ImageFinder     ImageFinder
+	GalleryFinder   GalleryFinder
 	FileGetter      models.FileGetter
 	StudioFinder    StudioFinder
 	TagFinder       TagFinder
@@ -30,6 +32,8 @@ func NewRepository(repo models.Repository) Repository {
 		TxnManager:      repo.TxnManager,
 		FileGetter:      repo.File,
 		SceneFinder:     repo.Scene,
+		ImageFinder:     repo.Image,
+		GalleryFinder:   repo.Gallery,
 		StudioFinder:    repo.Studio,
 		TagFinder:       repo.Tag,
 		PerformerFinder: repo.Performer,
@@ -71,6 +75,11 @@ type sceneServer interface {
 	ServeScreenshot(scene *models.Scene, w http.ResponseWriter, r *http.Request)
 }
 
+type imageServer interface {
+	ServeImage(image *models.Image, w http.ResponseWriter, r *http.Request, useDefault bool)
+	ServeThumbnail(image *models.Image, w http.ResponseWriter, r *http.Request, modTime *time.Time)
+}
+
 type Config interface {
 	GetDLNAInterfaces() []string
 	GetDLNAServerName() string
@@ -83,6 +92,7 @@ type Service struct {
 	repository     Repository
 	config         Config
 	sceneServer    sceneServer
+	imageServer    imageServer
 	ipWhitelistMgr *ipWhitelistManager
 
 	server  *Server
@@ -154,6 +164,7 @@ func (s *Service) init() error {
 	s.server = &Server{
 		repository:         s.repository,
 		sceneServer:        s.sceneServer,
+		imageServer:        s.imageServer,
 		ipWhitelistManager: s.ipWhitelistMgr,
 		Interfaces:         interfaces,
 		HTTPConn: func() net.Listener {
@@ -215,10 +226,11 @@ func (s *Service) init() error {
 // }
 
 // NewService initialises and returns a new DLNA service.
-func NewService(repo Repository, cfg Config, sceneServer sceneServer) *Service {
+func NewService(repo Repository, cfg Config, sceneServer sceneServer, imageServer imageServer) *Service {
 	ret := &Service{
 		repository:  repo,
 		sceneServer: sceneServer,
+		imageServer: imageServer,
 		config:      cfg,
 		ipWhitelistMgr: &ipWhitelistManager{
 			config: cfg,
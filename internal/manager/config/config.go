@@ -28,6 +28,7 @@ import (
 	"github.com/stashapp/stash/pkg/models/paths"
 	"github.com/stashapp/stash/pkg/sliceutil"
 	"github.com/stashapp/stash/pkg/utils"
+	"github.com/stashapp/stash/pkg/webhook"
 )
 
 const (
@@ -50,6 +51,11 @@ const (
 
 	DefaultMaxSessionAge = 60 * 60 * 1 // 1 hours
 
+	// TrashRetentionDays is the number of days that trashed files are kept
+	// before they are deleted permanently.
+	TrashRetentionDays        = "trash_retention_days"
+	DefaultTrashRetentionDays = 30
+
 	Database = "database"
 
 	Exclude      = "exclude"
@@ -60,6 +66,10 @@ const (
 	GalleryExtensions          = "gallery_extensions"
 	CreateGalleriesFromFolders = "create_galleries_from_folders"
 
+	// WatchLibrary is the config key used to determine if the library paths
+	// are watched for changes and scanned automatically.
+	WatchLibrary = "watch_library"
+
 	// CalculateMD5 is the config key used to determine if MD5 should be calculated
 	// for video files.
 	CalculateMD5 = "calculate_md5"
@@ -69,8 +79,15 @@ const (
 	VideoFileNamingAlgorithm = "video_file_naming_algorithm"
 
 	MaxTranscodeSize          = "max_transcode_size"
+	TranscodeVideoCodec       = "transcode_video_codec"
 	MaxStreamingTranscodeSize = "max_streaming_transcode_size"
 
+	// MaxLiveTranscodes is the maximum number of concurrent live transcodes.
+	// MaxLiveTranscodesPerClient is the maximum per client address.
+	// Zero means no limit.
+	MaxLiveTranscodes          = "max_live_transcodes"
+	MaxLiveTranscodesPerClient = "max_live_transcodes_per_client"
+
 	// ffmpeg extra args options
 	TranscodeInputArgs      = "ffmpeg.transcode.input_args"
 	TranscodeOutputArgs     = "ffmpeg.transcode.output_args"
@@ -80,6 +97,13 @@ const (
 	ParallelTasks        = "parallel_tasks"
 	parallelTasksDefault = 1
 
+	// The number of queued jobs of each resource class that may run
+	// concurrently.
+	IOJobConcurrency      = "io_job_concurrency"
+	CPUJobConcurrency     = "cpu_job_concurrency"
+	NetworkJobConcurrency = "network_job_concurrency"
+	jobConcurrencyDefault = 1
+
 	PreviewPreset                 = "preview_preset"
 	TranscodeHardwareAcceleration = "ffmpeg.hardware_acceleration"
 
@@ -173,6 +197,10 @@ const (
 	GalleryCoverRegex        = "gallery_cover_regex"
 	galleryCoverRegexDefault = `(poster|cover|folder|board)\.[^\.]+$`
 
+	// Name of the primary tag of markers created from video chapters
+	ChapterMarkerPrimaryTag        = "chapter_marker_primary_tag"
+	chapterMarkerPrimaryTagDefault = "Chapter"
+
 	// Interface options
 	MenuItems = "menu_items"
 
@@ -263,6 +291,12 @@ const (
 	DefaultAutoTagSettings  = "defaults.auto_tag_task"
 	DefaultGenerateSettings = "defaults.generate_task"
 
+	// Scheduled tasks
+	ScheduledTasks = "scheduled_tasks"
+
+	// Webhooks
+	Webhooks = "webhooks"
+
 	DeleteFileDefault             = "defaults.delete_file"
 	DeleteGeneratedDefault        = "defaults.delete_generated"
 	deleteGeneratedDefaultDefault = true
@@ -771,6 +805,12 @@ func (i *Config) GetCreateGalleriesFromFolders() bool {
 	return i.getBool(CreateGalleriesFromFolders)
 }
 
+// GetWatchLibrary returns true if the library paths should be watched for
+// changes, with changed paths scanned automatically.
+func (i *Config) GetWatchLibrary() bool {
+	return i.getBool(WatchLibrary)
+}
+
 func (i *Config) GetLanguage() string {
 	ret := i.getString(Language)
 
@@ -805,6 +845,17 @@ func (i *Config) GetSequentialScanning() bool {
 	return i.getBool(SequentialScanning)
 }
 
+// GetChapterMarkerPrimaryTag returns the name of the primary tag given to
+// markers created from the chapters of video files.
+func (i *Config) GetChapterMarkerPrimaryTag() string {
+	ret := strings.TrimSpace(i.getString(ChapterMarkerPrimaryTag))
+	if ret == "" {
+		return chapterMarkerPrimaryTagDefault
+	}
+
+	return ret
+}
+
 func (i *Config) GetGalleryCoverRegex() string {
 	var regexString = i.getString(GalleryCoverRegex)
 
@@ -955,6 +1006,37 @@ func (i *Config) GetParallelTasksWithAutoDetection() int {
 	return parallelTasks
 }
 
+// GetIOJobConcurrency returns the number of IO-bound jobs, such as auto tag,
+// that may run concurrently.
+func (i *Config) GetIOJobConcurrency() int {
+	return i.getJobConcurrency(IOJobConcurrency)
+}
+
+// GetCPUJobConcurrency returns the number of CPU-bound jobs, such as
+// generate tasks, that may run concurrently.
+func (i *Config) GetCPUJobConcurrency() int {
+	return i.getJobConcurrency(CPUJobConcurrency)
+}
+
+// GetNetworkJobConcurrency returns the number of network-bound jobs, such as
+// identify tasks, that may run concurrently.
+func (i *Config) GetNetworkJobConcurrency() int {
+	return i.getJobConcurrency(NetworkJobConcurrency)
+}
+
+func (i *Config) getJobConcurrency(key string) int {
+	i.RLock()
+	defer i.RUnlock()
+
+	ret := jobConcurrencyDefault
+	v := i.forKey(key)
+	if v.Exists(key) {
+		ret = v.Int(key)
+	}
+
+	return ret
+}
+
 func (i *Config) GetPreviewAudio() bool {
 	return i.getBool(PreviewAudio)
 }
@@ -1011,6 +1093,30 @@ func (i *Config) GetMaxTranscodeSize() models.StreamingResolutionEnum {
 	return models.StreamingResolutionEnum(ret)
 }
 
+// GetTranscodeVideoCodec returns the video codec of generated transcodes.
+// Defaults to H264. Generated transcodes are served as the direct stream to
+// every client, regardless of whether the client supports the codec.
+func (i *Config) GetTranscodeVideoCodec() models.TranscodeVideoCodec {
+	ret := models.TranscodeVideoCodec(i.getString(TranscodeVideoCodec))
+	if !ret.IsValid() {
+		return models.TranscodeVideoCodecH264
+	}
+
+	return ret
+}
+
+// GetMaxLiveTranscodes returns the maximum number of concurrent live
+// transcodes. Zero or less means no limit.
+func (i *Config) GetMaxLiveTranscodes() int {
+	return i.getInt(MaxLiveTranscodes)
+}
+
+// GetMaxLiveTranscodesPerClient returns the maximum number of concurrent
+// live transcodes for a single client address. Zero or less means no limit.
+func (i *Config) GetMaxLiveTranscodesPerClient() int {
+	return i.getInt(MaxLiveTranscodesPerClient)
+}
+
 func (i *Config) GetMaxStreamingTranscodeSize() models.StreamingResolutionEnum {
 	ret := i.getString(MaxStreamingTranscodeSize)
 
@@ -1149,6 +1255,21 @@ func (i *Config) GetMaxSessionAge() int {
 	return ret
 }
 
+// GetTrashRetentionDays gets the number of days that trashed files are
+// kept before being purged. A value of zero or less disables purging.
+func (i *Config) GetTrashRetentionDays() int {
+	i.RLock()
+	defer i.RUnlock()
+
+	ret := DefaultTrashRetentionDays
+	v := i.forKey(TrashRetentionDays)
+	if v.Exists(TrashRetentionDays) {
+		ret = v.Int(TrashRetentionDays)
+	}
+
+	return ret
+}
+
 // GetCustomServedFolders gets the map of custom paths to their applicable
 // filesystem locations
 func (i *Config) GetCustomServedFolders() utils.URLMap {
@@ -1531,6 +1652,26 @@ func (i *Config) GetDefaultGenerateSettings() *models.GenerateMetadataOptions {
 	return nil
 }
 
+// GetScheduledTasks returns the configured scheduled tasks.
+func (i *Config) GetScheduledTasks() []*ScheduledTask {
+	var ret []*ScheduledTask
+	if err := i.unmarshalKey(ScheduledTasks, &ret); err != nil {
+		logger.Warnf("error in unmarshalkey: %v", err)
+	}
+
+	return ret
+}
+
+// GetWebhooks returns the configured webhooks.
+func (i *Config) GetWebhooks() []*webhook.Webhook {
+	var ret []*webhook.Webhook
+	if err := i.unmarshalKey(Webhooks, &ret); err != nil {
+		logger.Warnf("error in unmarshalkey: %v", err)
+	}
+
+	return ret
+}
+
 // GetDangerousAllowPublicWithoutAuth determines if the security feature is enabled.
 // See https://docs.stashapp.cc/networking/authentication-required-when-accessing-stash-from-the-internet
 func (i *Config) GetDangerousAllowPublicWithoutAuth() bool {
@@ -49,6 +49,7 @@ func TestConcurrentConfigAccess(t *testing.T) {
 				i.SetInterface(ImageExtensions, i.GetImageExtensions())
 				i.SetInterface(GalleryExtensions, i.GetGalleryExtensions())
 				i.SetInterface(CreateGalleriesFromFolders, i.GetCreateGalleriesFromFolders())
+				i.SetInterface(WatchLibrary, i.GetWatchLibrary())
 				i.SetInterface(Language, i.GetLanguage())
 				i.SetInterface(VideoFileNamingAlgorithm, i.GetVideoFileNamingAlgorithm())
 				i.SetInterface(ScrapersPath, i.GetScrapersPath())
@@ -57,6 +58,8 @@ func TestConcurrentConfigAccess(t *testing.T) {
 				i.SetInterface(ScraperCertCheck, i.GetScraperCertCheck())
 				i.SetInterface(ScraperExcludeTagPatterns, i.GetScraperExcludeTagPatterns())
 				i.SetInterface(StashBoxes, i.GetStashBoxes())
+				i.SetInterface(ScheduledTasks, i.GetScheduledTasks())
+				i.SetInterface(Webhooks, i.GetWebhooks())
 				i.GetDefaultPluginsPath()
 				i.SetInterface(PluginsPath, i.GetPluginsPath())
 				i.SetInterface(Host, i.GetHost())
@@ -3,6 +3,7 @@ package config
 import (
 	"testing"
 
+	"github.com/stashapp/stash/pkg/webhook"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -32,3 +33,55 @@ func TestConfig_GetAllPluginConfiguration(t *testing.T) {
 		"plugin2": {"key3": "value3"},
 	}, i.GetAllPluginConfiguration())
 }
+
+func TestConfig_GetScheduledTasks(t *testing.T) {
+	i := InitializeEmpty()
+
+	assert.Empty(t, i.GetScheduledTasks())
+
+	tasks := []*ScheduledTask{
+		{
+			ID:       1,
+			Name:     "Nightly scan",
+			Type:     ScheduledTaskTypeScan,
+			Schedule: "0 3 * * *",
+			Enabled:  true,
+		},
+		{
+			ID:       2,
+			Name:     "Weekly backup",
+			Type:     ScheduledTaskTypeBackup,
+			Schedule: "@weekly",
+		},
+	}
+
+	i.SetInterface(ScheduledTasks, tasks)
+
+	assert.Equal(t, tasks, i.GetScheduledTasks())
+}
+
+func TestConfig_GetWebhooks(t *testing.T) {
+	i := InitializeEmpty()
+
+	assert.Empty(t, i.GetWebhooks())
+
+	webhooks := []*webhook.Webhook{
+		{
+			ID:      1,
+			Name:    "Scans",
+			URL:     "https://example.com/hook",
+			Secret:  "secret",
+			Events:  []webhook.Event{webhook.EventScanComplete},
+			Enabled: true,
+		},
+		{
+			ID:   2,
+			Name: "Everything",
+			URL:  "http://localhost:8080",
+		},
+	}
+
+	i.SetInterface(Webhooks, webhooks)
+
+	assert.Equal(t, webhooks, i.GetWebhooks())
+}
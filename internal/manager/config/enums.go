@@ -48,3 +48,50 @@ func (e *BlobsStorageType) UnmarshalGQL(v interface{}) error {
 func (e BlobsStorageType) MarshalGQL(w io.Writer) {
 	fmt.Fprint(w, strconv.Quote(e.String()))
 }
+
+type ScheduledTaskType string
+
+const (
+	ScheduledTaskTypeScan     ScheduledTaskType = "SCAN"
+	ScheduledTaskTypeGenerate ScheduledTaskType = "GENERATE"
+	ScheduledTaskTypeAutoTag  ScheduledTaskType = "AUTO_TAG"
+	ScheduledTaskTypeClean    ScheduledTaskType = "CLEAN"
+	ScheduledTaskTypeBackup   ScheduledTaskType = "BACKUP"
+)
+
+var AllScheduledTaskType = []ScheduledTaskType{
+	ScheduledTaskTypeScan,
+	ScheduledTaskTypeGenerate,
+	ScheduledTaskTypeAutoTag,
+	ScheduledTaskTypeClean,
+	ScheduledTaskTypeBackup,
+}
+
+func (e ScheduledTaskType) IsValid() bool {
+	switch e {
+	case ScheduledTaskTypeScan, ScheduledTaskTypeGenerate, ScheduledTaskTypeAutoTag, ScheduledTaskTypeClean, ScheduledTaskTypeBackup:
+		return true
+	}
+	return false
+}
+
+func (e ScheduledTaskType) String() string {
+	return string(e)
+}
+
+func (e *ScheduledTaskType) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = ScheduledTaskType(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid ScheduledTaskType", str)
+	}
+	return nil
+}
+
+func (e ScheduledTaskType) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
@@ -11,12 +11,16 @@ type StashConfigInput struct {
 	Path         string `json:"path"`
 	ExcludeVideo bool   `json:"excludeVideo"`
 	ExcludeImage bool   `json:"excludeImage"`
+	TrashPath    string `json:"trashPath"`
 }
 
 type StashConfig struct {
 	Path         string `json:"path"`
 	ExcludeVideo bool   `json:"excludeVideo"`
 	ExcludeImage bool   `json:"excludeImage"`
+	// TrashPath is the directory that deleted files are moved to.
+	// Files are deleted permanently if empty.
+	TrashPath string `json:"trashPath"`
 }
 
 type StashConfigs []*StashConfig
@@ -38,3 +42,26 @@ func (s StashConfigs) GetStashFromDirPath(dirPath string) *StashConfig {
 	}
 	return nil
 }
+
+// GetTrashPath returns the trash directory of the library containing the
+// provided file path. Returns an empty string if the library does not have
+// a trash directory.
+func (s StashConfigs) GetTrashPath(path string) string {
+	stash := s.GetStashFromPath(path)
+	if stash == nil {
+		return ""
+	}
+
+	return stash.TrashPath
+}
+
+// IsTrashPath returns true if the provided path is within the trash
+// directory of any library.
+func (s StashConfigs) IsTrashPath(path string) bool {
+	for _, f := range s {
+		if f.TrashPath != "" && fsutil.IsPathInDir(f.TrashPath, path) {
+			return true
+		}
+	}
+	return false
+}
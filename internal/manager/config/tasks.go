@@ -17,6 +17,12 @@ type ScanMetadataOptions struct {
 	ScanGenerateThumbnails bool `json:"scanGenerateThumbnails"`
 	// Generate image thumbnails during scan
 	ScanGenerateClipPreviews bool `json:"scanGenerateClipPreviews"`
+	// Generate image phashes during scan
+	ScanGenerateImagePhashes bool `json:"scanGenerateImagePhashes"`
+	// Read scene metadata from NFO files when creating new scenes
+	ScanReadNFO bool `json:"scanReadNFO"`
+	// Create scene markers from video chapters during scan
+	ScanGenerateChapterMarkers bool `json:"scanGenerateChapterMarkers"`
 }
 
 type AutoTagMetadataOptions struct {
@@ -27,3 +33,14 @@ type AutoTagMetadataOptions struct {
 	// IDs of tags to tag files with, or "*" for all
 	Tags []string `json:"tags"`
 }
+
+// ScheduledTask is a task that is run periodically according to a cron
+// expression. Tasks are run using the default settings for the task type.
+type ScheduledTask struct {
+	ID   int               `json:"id"`
+	Name string            `json:"name"`
+	Type ScheduledTaskType `json:"type"`
+	// Cron expression describing when the task is run
+	Schedule string `json:"schedule"`
+	Enabled  bool   `json:"enabled"`
+}
@@ -27,7 +27,9 @@ import (
 	"github.com/stashapp/stash/pkg/scraper"
 	"github.com/stashapp/stash/pkg/session"
 	"github.com/stashapp/stash/pkg/sqlite"
+	"github.com/stashapp/stash/pkg/user"
 	"github.com/stashapp/stash/pkg/utils"
+	"github.com/stashapp/stash/pkg/webhook"
 	"github.com/stashapp/stash/ui"
 )
 
@@ -72,13 +74,20 @@ func Initialize(cfg *config.Config, l *log.Logger) (*Manager, error) {
 		Repository: db.Group,
 	}
 
+	userService := &user.Service{
+		TxnManager:       repo.TxnManager,
+		Repository:       db.User,
+		APIKeyRepository: db.APIKey,
+		Config:           cfg,
+	}
+
 	sceneServer := &SceneServer{
 		TxnManager:       repo.TxnManager,
 		SceneCoverGetter: repo.Scene,
 	}
 
 	dlnaRepository := dlna.NewRepository(repo)
-	dlnaService := dlna.NewService(dlnaRepository, cfg, sceneServer)
+	dlnaService := dlna.NewService(dlnaRepository, cfg, sceneServer, &ImageServer{})
 
 	mgr := &Manager{
 		Config: cfg,
@@ -98,6 +107,8 @@ func Initialize(cfg *config.Config, l *log.Logger) (*Manager, error) {
 
 		DLNAService: dlnaService,
 
+		WebhookDispatcher: webhook.NewDispatcher(cfg),
+
 		Database:   db,
 		Repository: repo,
 
@@ -105,10 +116,14 @@ func Initialize(cfg *config.Config, l *log.Logger) (*Manager, error) {
 		ImageService:   imageService,
 		GalleryService: galleryService,
 		GroupService:   groupService,
+		UserService:    userService,
 
 		scanSubs: &subscriptionManager{},
 	}
 
+	mgr.Scheduler = newScheduler(mgr)
+	initWebhooks(mgr.WebhookDispatcher, pluginCache, mgr.JobManager)
+
 	if !cfg.IsNewSystem() {
 		logger.Infof("using config file: %s", cfg.GetConfigFile())
 
@@ -130,7 +145,7 @@ func Initialize(cfg *config.Config, l *log.Logger) (*Manager, error) {
 
 		// create temporary session store - this will be re-initialised
 		// after config is complete
-		mgr.SessionStore = session.NewStore(cfg)
+		mgr.SessionStore = session.NewStore(cfg, userService)
 
 		logger.Warnf("config file %snot found. Assuming new system...", cfgFile)
 	}
@@ -189,7 +204,7 @@ func initJobManager(cfg *config.Config) *job.Manager {
 func (s *Manager) postInit(ctx context.Context) error {
 	s.RefreshConfig()
 
-	s.SessionStore = session.NewStore(s.Config)
+	s.SessionStore = session.NewStore(s.Config, s.UserService)
 	s.PluginCache.RegisterSessionStore(s.SessionStore)
 
 	s.RefreshPluginCache()
@@ -227,6 +242,7 @@ func (s *Manager) postInit(ctx context.Context) error {
 		})
 	}
 
+	databaseOpened := false
 	if err := s.Database.Open(s.Config.GetDatabasePath()); err != nil {
 		var migrationNeededErr *sqlite.MigrationNeededError
 		if errors.As(err, &migrationNeededErr) {
@@ -234,6 +250,13 @@ func (s *Manager) postInit(ctx context.Context) error {
 		} else {
 			return err
 		}
+	} else {
+		if _, err := s.UserService.EnsureAdmin(ctx); err != nil {
+			logger.Errorf("Error creating admin user: %v", err)
+		}
+
+		go s.PurgeTrash(ctx)
+		databaseOpened = true
 	}
 
 	// Set the proxy if defined in config
@@ -247,6 +270,14 @@ func (s *Manager) postInit(ctx context.Context) error {
 	s.RefreshFFMpeg(ctx)
 	s.RefreshStreamManager()
 
+	s.Scheduler.Start()
+	s.RefreshLibraryWatcher()
+
+	if databaseOpened {
+		s.restoreQueuedJobs(ctx)
+		s.initWebhookLog(ctx)
+	}
+
 	return nil
 }
 
@@ -0,0 +1,182 @@
+package manager
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/stashapp/stash/internal/identify"
+	"github.com/stashapp/stash/pkg/job"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/session"
+)
+
+// Kinds of jobs that are persisted and restored after a restart.
+const (
+	jobKindScan     = "scan"
+	jobKindGenerate = "generate"
+	jobKindAutoTag  = "auto_tag"
+	jobKindClean    = "clean"
+	jobKindIdentify = "identify"
+)
+
+// exclusiveJobKinds are the kinds of jobs that modify the library files, and
+// so are run with no other jobs running.
+var exclusiveJobKinds = map[string]bool{
+	jobKindScan:  true,
+	jobKindClean: true,
+}
+
+// jobStore persists queued jobs in the database.
+type jobStore struct {
+	repository models.Repository
+}
+
+func toQueuedJob(j job.PersistedJob) *models.QueuedJob {
+	return &models.QueuedJob{
+		ID:            j.ID,
+		Kind:          j.Kind,
+		Description:   j.Description,
+		Input:         j.Input,
+		Priority:      j.Priority,
+		ResourceClass: string(j.ResourceClass),
+		Paused:        j.Paused,
+		AddedAt:       j.AddTime,
+		UserID:        j.UserID,
+	}
+}
+
+func (s *jobStore) Create(ctx context.Context, j job.PersistedJob) error {
+	r := s.repository
+	return r.WithTxn(ctx, func(ctx context.Context) error {
+		return r.QueuedJob.Create(ctx, toQueuedJob(j))
+	})
+}
+
+func (s *jobStore) Update(ctx context.Context, j job.PersistedJob) error {
+	r := s.repository
+	return r.WithTxn(ctx, func(ctx context.Context) error {
+		return r.QueuedJob.Update(ctx, toQueuedJob(j))
+	})
+}
+
+func (s *jobStore) Delete(ctx context.Context, id int) error {
+	r := s.repository
+	return r.WithTxn(ctx, func(ctx context.Context) error {
+		return r.QueuedJob.Destroy(ctx, id)
+	})
+}
+
+// restoreQueuedJobs queues the jobs that were unfinished when the
+// application was last stopped, and persists newly queued jobs from then
+// on.
+func (s *Manager) restoreQueuedJobs(ctx context.Context) {
+	r := s.Repository
+
+	var queued []*models.QueuedJob
+	if err := r.WithReadTxn(ctx, func(ctx context.Context) error {
+		var err error
+		queued, err = r.QueuedJob.All(ctx)
+		return err
+	}); err != nil {
+		logger.Errorf("Error loading queued jobs: %v", err)
+		return
+	}
+
+	store := &jobStore{repository: r}
+
+	for _, qj := range queued {
+		e, err := s.queuedJobExec(qj)
+		if err != nil {
+			logger.Warnf("Could not restore job %q: %v", qj.Description, err)
+
+			if err := store.Delete(ctx, qj.ID); err != nil {
+				logger.Errorf("Error removing queued job %q: %v", qj.Description, err)
+			}
+			continue
+		}
+
+		s.JobManager.Restore(s.queuedJobContext(ctx, qj), job.PersistedJob{
+			ID:            qj.ID,
+			Kind:          qj.Kind,
+			Description:   qj.Description,
+			Input:         qj.Input,
+			Priority:      qj.Priority,
+			ResourceClass: job.ResourceClass(qj.ResourceClass),
+			Paused:        qj.Paused,
+			AddTime:       qj.AddedAt,
+			UserID:        qj.UserID,
+			Exclusive:     exclusiveJobKinds[qj.Kind],
+		}, e)
+	}
+
+	if len(queued) > 0 {
+		logger.Infof("Restored %d queued jobs", len(queued))
+	}
+
+	s.JobManager.SetStore(store)
+}
+
+// queuedJobContext returns the context to run a restored job in, containing
+// the user that queued the job. Jobs that were not queued by a user are run
+// as a system job.
+func (s *Manager) queuedJobContext(ctx context.Context, qj *models.QueuedJob) context.Context {
+	if qj.UserID == nil {
+		return s.systemContext(ctx)
+	}
+
+	u, err := s.UserService.Find(ctx, *qj.UserID)
+	if err != nil {
+		logger.Warnf("Error finding user of job %q: %v", qj.Description, err)
+	}
+	if u == nil {
+		return s.systemContext(ctx)
+	}
+
+	return session.SetCurrentUser(ctx, u)
+}
+
+// queuedJobExec recreates the job for the provided queued job.
+func (s *Manager) queuedJobExec(qj *models.QueuedJob) (job.JobExec, error) {
+	switch qj.Kind {
+	case jobKindScan:
+		var input ScanMetadataInput
+		if err := json.Unmarshal(qj.Input, &input); err != nil {
+			return nil, fmt.Errorf("unmarshalling input: %w", err)
+		}
+		if err := s.validateFFmpeg(); err != nil {
+			return nil, err
+		}
+		return s.newScanJob(input), nil
+	case jobKindGenerate:
+		var input GenerateMetadataInput
+		if err := json.Unmarshal(qj.Input, &input); err != nil {
+			return nil, fmt.Errorf("unmarshalling input: %w", err)
+		}
+		if err := s.validateFFmpeg(); err != nil {
+			return nil, err
+		}
+		return s.newGenerateJob(input), nil
+	case jobKindAutoTag:
+		var input AutoTagMetadataInput
+		if err := json.Unmarshal(qj.Input, &input); err != nil {
+			return nil, fmt.Errorf("unmarshalling input: %w", err)
+		}
+		return s.newAutoTagJob(input), nil
+	case jobKindClean:
+		var input CleanMetadataInput
+		if err := json.Unmarshal(qj.Input, &input); err != nil {
+			return nil, fmt.Errorf("unmarshalling input: %w", err)
+		}
+		return s.newCleanJob(input), nil
+	case jobKindIdentify:
+		var input identify.Options
+		if err := json.Unmarshal(qj.Input, &input); err != nil {
+			return nil, fmt.Errorf("unmarshalling input: %w", err)
+		}
+		return CreateIdentifyJob(input), nil
+	default:
+		return nil, fmt.Errorf("unknown job kind %q", qj.Kind)
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"sync"
 	"time"
 
 	"github.com/remeh/sizedwaitgroup"
@@ -27,6 +28,7 @@ import (
 	"github.com/stashapp/stash/pkg/scraper"
 	"github.com/stashapp/stash/pkg/session"
 	"github.com/stashapp/stash/pkg/sqlite"
+	"github.com/stashapp/stash/pkg/webhook"
 
 	// register custom migrations
 	_ "github.com/stashapp/stash/pkg/sqlite/migrations"
@@ -48,6 +50,7 @@ type Manager struct {
 
 	JobManager      *job.Manager
 	ReadLockManager *fsutil.ReadLockManager
+	Scheduler       *Scheduler
 
 	DownloadStore *DownloadStore
 	SessionStore  *session.Store
@@ -60,6 +63,8 @@ type Manager struct {
 
 	DLNAService *dlna.Service
 
+	WebhookDispatcher *webhook.Dispatcher
+
 	Database   *sqlite.Database
 	Repository models.Repository
 
@@ -67,8 +72,15 @@ type Manager struct {
 	ImageService   ImageService
 	GalleryService GalleryService
 	GroupService   GroupService
+	UserService    UserService
 
 	scanSubs *subscriptionManager
+
+	watcherMutex   sync.Mutex
+	libraryWatcher *libraryWatcher
+
+	// webhookMutex is held while the configured webhooks are modified
+	webhookMutex sync.Mutex
 }
 
 var instance *Manager
@@ -118,6 +130,12 @@ func (s *Manager) RefreshConfig() {
 
 		s.ImageThumbnailGenerateWaitGroup.Size = cfg.GetParallelTasksWithAutoDetection()
 	}
+
+	s.JobManager.SetConcurrency(job.ResourceClassIO, cfg.GetIOJobConcurrency())
+	s.JobManager.SetConcurrency(job.ResourceClassCPU, cfg.GetCPUJobConcurrency())
+	s.JobManager.SetConcurrency(job.ResourceClassNetwork, cfg.GetNetworkJobConcurrency())
+
+	s.WebhookDispatcher.Refresh()
 }
 
 // RefreshPluginCache refreshes the plugin cache.
@@ -306,6 +324,24 @@ func (s *Manager) validateFFmpeg() error {
 	return nil
 }
 
+// systemContext returns a context for jobs that are started by the system
+// rather than by a user, such as scheduled tasks. When authentication is
+// enabled, these jobs are run as the configured user, so that the changes
+// they make are attributed to a user.
+func (s *Manager) systemContext(ctx context.Context) context.Context {
+	if !s.Config.HasCredentials() {
+		return ctx
+	}
+
+	u, err := s.UserService.FindByUsername(ctx, s.Config.GetUsername())
+	if err != nil {
+		logger.Warnf("Error finding configured user: %v", err)
+		return ctx
+	}
+
+	return session.SetCurrentUser(ctx, u)
+}
+
 func (s *Manager) BackupDatabase(download bool) (string, string, error) {
 	var backupPath string
 	var backupName string
@@ -422,6 +458,20 @@ func (s *Manager) GetSystemStatus() *SystemStatus {
 func (s *Manager) Shutdown() {
 	// TODO: Each part of the manager needs to gracefully stop at some point
 
+	s.Scheduler.Stop()
+
+	// queued jobs are restored on the next start
+	s.JobManager.Stop()
+
+	s.watcherMutex.Lock()
+	if s.libraryWatcher != nil {
+		s.libraryWatcher.stop()
+		s.libraryWatcher = nil
+	}
+	s.watcherMutex.Unlock()
+
+	s.WebhookDispatcher.Stop()
+
 	if s.StreamManager != nil {
 		s.StreamManager.Shutdown()
 		s.StreamManager = nil
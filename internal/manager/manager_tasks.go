@@ -8,6 +8,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/stashapp/stash/internal/identify"
 	"github.com/stashapp/stash/internal/manager/config"
 	"github.com/stashapp/stash/pkg/file"
 	file_image "github.com/stashapp/stash/pkg/file/image"
@@ -100,12 +101,23 @@ func (s *Manager) Scan(ctx context.Context, input ScanMetadataInput) (int, error
 		return 0, err
 	}
 
+	return s.JobManager.AddWithOptions(ctx, "Scanning...", s.newScanJob(input), job.Options{
+		ResourceClass: job.ResourceClassIO,
+		Kind:          jobKindScan,
+		Input:         input,
+		Exclusive:     true,
+	}), nil
+}
+
+func (s *Manager) newScanJob(input ScanMetadataInput) *ScanJob {
 	scanner := &file.Scanner{
 		Repository: file.NewRepository(s.Repository),
 		FileDecorators: []file.Decorator{
 			&file.FilteredDecorator{
 				Decorator: &video.Decorator{
-					FFProbe: s.FFProbe,
+					FFProbe:       s.FFProbe,
+					StreamReader:  s.Repository.File,
+					ChapterReader: s.Repository.File,
 				},
 				Filter: file.FilterFunc(videoFileFilter),
 			},
@@ -120,13 +132,11 @@ func (s *Manager) Scan(ctx context.Context, input ScanMetadataInput) (int, error
 		FS:                    &file.OsFS{},
 	}
 
-	scanJob := ScanJob{
+	return &ScanJob{
 		scanner:       scanner,
 		input:         input,
 		subscriptions: s.scanSubs,
 	}
-
-	return s.JobManager.Add(ctx, "Scanning...", &scanJob), nil
 }
 
 func (s *Manager) Import(ctx context.Context) (int, error) {
@@ -200,12 +210,18 @@ func (s *Manager) Generate(ctx context.Context, input GenerateMetadataInput) (in
 		logger.Warnf("could not generate temporary directory: %v", err)
 	}
 
-	j := &GenerateJob{
+	return s.JobManager.AddWithOptions(ctx, "Generating...", s.newGenerateJob(input), job.Options{
+		ResourceClass: job.ResourceClassCPU,
+		Kind:          jobKindGenerate,
+		Input:         input,
+	}), nil
+}
+
+func (s *Manager) newGenerateJob(input GenerateMetadataInput) *GenerateJob {
+	return &GenerateJob{
 		repository: s.Repository,
 		input:      input,
 	}
-
-	return s.JobManager.Add(ctx, "Generating...", j), nil
 }
 
 func (s *Manager) GenerateDefaultScreenshot(ctx context.Context, sceneId string) int {
@@ -258,7 +274,9 @@ func (s *Manager) generateScreenshot(ctx context.Context, sceneId string, at *fl
 		return nil
 	})
 
-	return s.JobManager.Add(ctx, fmt.Sprintf("Generating screenshot for scene id %s", sceneId), j)
+	return s.JobManager.AddWithOptions(ctx, fmt.Sprintf("Generating screenshot for scene id %s", sceneId), j, job.Options{
+		ResourceClass: job.ResourceClassCPU,
+	})
 }
 
 type AutoTagMetadataInput struct {
@@ -273,12 +291,18 @@ type AutoTagMetadataInput struct {
 }
 
 func (s *Manager) AutoTag(ctx context.Context, input AutoTagMetadataInput) int {
-	j := autoTagJob{
+	return s.JobManager.AddWithOptions(ctx, "Auto-tagging...", s.newAutoTagJob(input), job.Options{
+		ResourceClass: job.ResourceClassIO,
+		Kind:          jobKindAutoTag,
+		Input:         input,
+	})
+}
+
+func (s *Manager) newAutoTagJob(input AutoTagMetadataInput) *autoTagJob {
+	return &autoTagJob{
 		repository: s.Repository,
 		input:      input,
 	}
-
-	return s.JobManager.Add(ctx, "Auto-tagging...", &j)
 }
 
 type CleanMetadataInput struct {
@@ -288,6 +312,15 @@ type CleanMetadataInput struct {
 }
 
 func (s *Manager) Clean(ctx context.Context, input CleanMetadataInput) int {
+	return s.JobManager.AddWithOptions(ctx, "Cleaning...", s.newCleanJob(input), job.Options{
+		ResourceClass: job.ResourceClassIO,
+		Kind:          jobKindClean,
+		Input:         input,
+		Exclusive:     true,
+	})
+}
+
+func (s *Manager) newCleanJob(input CleanMetadataInput) *cleanJob {
 	cleaner := &file.Cleaner{
 		FS:         &file.OsFS{},
 		Repository: file.NewRepository(s.Repository),
@@ -296,7 +329,7 @@ func (s *Manager) Clean(ctx context.Context, input CleanMetadataInput) int {
 		},
 	}
 
-	j := cleanJob{
+	return &cleanJob{
 		cleaner:      cleaner,
 		repository:   s.Repository,
 		sceneService: s.SceneService,
@@ -304,8 +337,15 @@ func (s *Manager) Clean(ctx context.Context, input CleanMetadataInput) int {
 		input:        input,
 		scanSubs:     s.scanSubs,
 	}
+}
 
-	return s.JobManager.Add(ctx, "Cleaning...", &j)
+// Identify queues a job to identify scenes using scrapers.
+func (s *Manager) Identify(ctx context.Context, input identify.Options) int {
+	return s.JobManager.AddWithOptions(ctx, "Identifying...", CreateIdentifyJob(input), job.Options{
+		ResourceClass: job.ResourceClassNetwork,
+		Kind:          jobKindIdentify,
+		Input:         input,
+	})
 }
 
 func (s *Manager) OptimiseDatabase(ctx context.Context) int {
@@ -518,7 +558,9 @@ func (s *Manager) StashBoxBatchPerformerTag(ctx context.Context, box *models.Sta
 		return nil
 	})
 
-	return s.JobManager.Add(ctx, "Batch stash-box performer tag...", j)
+	return s.JobManager.AddWithOptions(ctx, "Batch stash-box performer tag...", j, job.Options{
+		ResourceClass: job.ResourceClassNetwork,
+	})
 }
 
 func (s *Manager) StashBoxBatchStudioTag(ctx context.Context, box *models.StashBox, input StashBoxBatchTagInput) int {
@@ -639,5 +681,7 @@ func (s *Manager) StashBoxBatchStudioTag(ctx context.Context, box *models.StashB
 		return nil
 	})
 
-	return s.JobManager.Add(ctx, "Batch stash-box studio tag...", j)
+	return s.JobManager.AddWithOptions(ctx, "Batch stash-box studio tag...", j, job.Options{
+		ResourceClass: job.ResourceClassNetwork,
+	})
 }
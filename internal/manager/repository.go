@@ -43,3 +43,12 @@ type GroupService interface {
 	RemoveSubGroups(ctx context.Context, groupID int, subGroupIDs []int) error
 	ReorderSubGroups(ctx context.Context, groupID int, subGroupIDs []int, insertPointID int, insertAfter bool) error
 }
+
+type UserService interface {
+	Authenticate(ctx context.Context, username string, password string) (*models.User, error)
+	Find(ctx context.Context, id int) (*models.User, error)
+	FindByUsername(ctx context.Context, username string) (*models.User, error)
+	EnsureAdmin(ctx context.Context) (*models.User, error)
+	RenameAdmin(ctx context.Context, previousUsername string) (*models.User, error)
+	AuthenticateAPIKey(ctx context.Context, key string) (*models.APIKey, *models.User, error)
+}
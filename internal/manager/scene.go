@@ -112,6 +112,23 @@ func GetSceneStreamPaths(scene *models.Scene, directStreamURL *url.URL, maxStrea
 		return maxStreamingResolution.GetMinResolution() >= minResolution
 	}
 
+	makeAdaptiveStreamEndpoint := func(t endpointType) *SceneStreamEndpoint {
+		url := *directStreamURL
+		url.Path += t.extension
+
+		v := url.Query()
+		v.Set("adaptive", "true")
+		url.RawQuery = v.Encode()
+
+		label := t.label + " Adaptive"
+
+		return &SceneStreamEndpoint{
+			URL:      url.String(),
+			MimeType: &t.mimeType,
+			Label:    &label,
+		}
+	}
+
 	makeStreamEndpoint := func(t endpointType, resolution models.StreamingResolutionEnum) *SceneStreamEndpoint {
 		url := *directStreamURL
 		url.Path += t.extension
@@ -166,8 +183,9 @@ func GetSceneStreamPaths(scene *models.Scene, directStreamURL *url.URL, maxStrea
 
 	mp4Streams := []*SceneStreamEndpoint{}
 	webmStreams := []*SceneStreamEndpoint{}
-	hlsStreams := []*SceneStreamEndpoint{}
-	dashStreams := []*SceneStreamEndpoint{}
+	// adaptive streams include all of the resolutions below
+	hlsStreams := []*SceneStreamEndpoint{makeAdaptiveStreamEndpoint(hlsEndpointType)}
+	dashStreams := []*SceneStreamEndpoint{makeAdaptiveStreamEndpoint(dashEndpointType)}
 
 	if includeSceneStreamPath(models.StreamingResolutionEnumOriginal) {
 		mp4Streams = append(mp4Streams, makeStreamEndpoint(mp4EndpointType, models.StreamingResolutionEnumOriginal))
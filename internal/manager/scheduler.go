@@ -0,0 +1,495 @@
+package manager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/stashapp/stash/internal/manager/config"
+	"github.com/stashapp/stash/pkg/cron"
+	"github.com/stashapp/stash/pkg/job"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+)
+
+// schedulerIdleInterval is the maximum time the scheduler waits before
+// re-checking the schedules when no task is due.
+const schedulerIdleInterval = time.Hour
+
+// trashPurgeInterval is the interval at which expired trashed files are
+// purged while the server is running.
+const trashPurgeInterval = time.Hour
+
+var ErrScheduledTaskNotFound = errors.New("scheduled task not found")
+
+// ScheduledTaskStatus contains the runtime state of a scheduled task.
+type ScheduledTaskStatus struct {
+	NextRun   *time.Time
+	LastRun   *time.Time
+	LastJobID *int
+}
+
+type scheduleEntry struct {
+	task     config.ScheduledTask
+	schedule *cron.Schedule
+	nextRun  time.Time
+	lastRun  *time.Time
+
+	// ID of the job queued by the last run. Zero if not yet run.
+	lastJobID int
+}
+
+// Scheduler queues the configured scheduled tasks onto the job manager
+// when they are due. It also periodically purges expired trashed files.
+type Scheduler struct {
+	manager *Manager
+
+	mutex          sync.Mutex
+	entries        map[int]*scheduleEntry
+	nextTrashPurge time.Time
+
+	refresh chan struct{}
+	stop    chan struct{}
+	running bool
+}
+
+func newScheduler(m *Manager) *Scheduler {
+	return &Scheduler{
+		manager: m,
+		entries: make(map[int]*scheduleEntry),
+		refresh: make(chan struct{}, 1),
+	}
+}
+
+// Start loads the scheduled tasks from the configuration and starts the
+// scheduler. Has no effect if the scheduler is already running.
+func (s *Scheduler) Start() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.running {
+		return
+	}
+
+	now := time.Now()
+	s.load(now)
+
+	// the trash is purged on startup
+	s.nextTrashPurge = now.Add(trashPurgeInterval)
+
+	s.stop = make(chan struct{})
+	s.running = true
+	go s.run(s.stop)
+}
+
+// Stop stops the scheduler. Jobs that have already been queued are
+// unaffected.
+func (s *Scheduler) Stop() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if !s.running {
+		return
+	}
+
+	close(s.stop)
+	s.running = false
+}
+
+// Refresh reloads the scheduled tasks from the configuration.
+// Call this when the scheduled task configuration changes.
+func (s *Scheduler) Refresh() {
+	s.mutex.Lock()
+	s.load(time.Now())
+	s.mutex.Unlock()
+
+	s.wake()
+}
+
+// wake wakes the scheduler so that it recalculates the next wake time.
+func (s *Scheduler) wake() {
+	select {
+	case s.refresh <- struct{}{}:
+	default:
+	}
+}
+
+// load rebuilds the schedule entries from the configuration.
+// Runtime state is retained for tasks with an unchanged schedule.
+// Assumes lock held.
+func (s *Scheduler) load(now time.Time) {
+	entries := make(map[int]*scheduleEntry)
+
+	for _, t := range s.manager.Config.GetScheduledTasks() {
+		if t == nil {
+			continue
+		}
+
+		schedule, err := cron.Parse(t.Schedule)
+		if err != nil {
+			logger.Warnf("ignoring scheduled task %q: %v", t.Name, err)
+			continue
+		}
+
+		e := &scheduleEntry{
+			task:     *t,
+			schedule: schedule,
+			nextRun:  schedule.Next(now),
+		}
+
+		if existing := s.entries[t.ID]; existing != nil {
+			e.lastRun = existing.lastRun
+			e.lastJobID = existing.lastJobID
+			// the next run of a disabled task is not advanced, so it may
+			// have passed while the task was disabled
+			if existing.task.Enabled && existing.task.Schedule == t.Schedule && existing.nextRun.After(now) {
+				e.nextRun = existing.nextRun
+			}
+		}
+
+		entries[t.ID] = e
+	}
+
+	s.entries = entries
+}
+
+func (s *Scheduler) run(stop chan struct{}) {
+	for {
+		timer := time.NewTimer(s.untilNextRun(time.Now()))
+
+		select {
+		case <-stop:
+			timer.Stop()
+			return
+		case <-s.refresh:
+			timer.Stop()
+		case now := <-timer.C:
+			s.runDue(now)
+			s.purgeTrashIfDue(now)
+		}
+	}
+}
+
+// untilNextRun returns the duration until the next enabled task is due.
+func (s *Scheduler) untilNextRun(now time.Time) time.Duration {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	ret := schedulerIdleInterval
+	if d := s.nextTrashPurge.Sub(now); d < ret {
+		ret = d
+	}
+
+	for _, e := range s.entries {
+		if !e.task.Enabled || e.nextRun.IsZero() {
+			continue
+		}
+
+		if d := e.nextRun.Sub(now); d < ret {
+			ret = d
+		}
+	}
+
+	if ret < 0 {
+		ret = 0
+	}
+
+	return ret
+}
+
+func (s *Scheduler) runDue(now time.Time) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for _, e := range s.entries {
+		if !e.task.Enabled || e.nextRun.IsZero() || e.nextRun.After(now) {
+			continue
+		}
+
+		e.nextRun = e.schedule.Next(now)
+
+		if s.isQueued(e.lastJobID) {
+			logger.Infof("Skipping scheduled task %q: previous run is still queued", e.task.Name)
+			continue
+		}
+
+		jobID, err := s.queueTask(s.manager.systemContext(context.Background()), e.task)
+		if err != nil {
+			logger.Errorf("Error running scheduled task %q: %v", e.task.Name, err)
+			continue
+		}
+
+		logger.Infof("Queued scheduled task %q", e.task.Name)
+
+		t := now
+		e.lastRun = &t
+		e.lastJobID = jobID
+	}
+}
+
+// purgeTrashIfDue purges expired trashed files in the background if the
+// trash purge interval has elapsed.
+func (s *Scheduler) purgeTrashIfDue(now time.Time) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.nextTrashPurge.After(now) {
+		return
+	}
+
+	s.nextTrashPurge = now.Add(trashPurgeInterval)
+	go s.manager.PurgeTrash(s.manager.systemContext(context.Background()))
+}
+
+// isQueued returns true if the job with the provided ID has not yet finished.
+func (s *Scheduler) isQueued(jobID int) bool {
+	if jobID == 0 {
+		return false
+	}
+
+	j := s.manager.JobManager.GetJob(jobID)
+	if j == nil {
+		return false
+	}
+
+	switch j.Status {
+	case job.StatusReady, job.StatusPaused, job.StatusRunning, job.StatusStopping:
+		return true
+	}
+
+	return false
+}
+
+// queueTask adds the job for the provided task to the job queue, using the
+// default task settings from the configuration.
+func (s *Scheduler) queueTask(ctx context.Context, t config.ScheduledTask) (int, error) {
+	mgr := s.manager
+	cfg := mgr.Config
+
+	switch t.Type {
+	case config.ScheduledTaskTypeScan:
+		input := ScanMetadataInput{}
+		if opts := cfg.GetDefaultScanSettings(); opts != nil {
+			input.ScanMetadataOptions = *opts
+		}
+		return mgr.Scan(ctx, input)
+	case config.ScheduledTaskTypeGenerate:
+		input := GenerateMetadataInput{}
+		if opts := cfg.GetDefaultGenerateSettings(); opts != nil {
+			input = generateInputFromOptions(*opts)
+		}
+		return mgr.Generate(ctx, input)
+	case config.ScheduledTaskTypeAutoTag:
+		// default to auto-tagging everything
+		const wildcard = "*"
+		input := AutoTagMetadataInput{
+			Performers: []string{wildcard},
+			Studios:    []string{wildcard},
+			Tags:       []string{wildcard},
+		}
+		if opts := cfg.GetDefaultAutoTagSettings(); opts != nil {
+			input.Performers = opts.Performers
+			input.Studios = opts.Studios
+			input.Tags = opts.Tags
+		}
+		return mgr.AutoTag(ctx, input), nil
+	case config.ScheduledTaskTypeClean:
+		return mgr.Clean(ctx, CleanMetadataInput{}), nil
+	case config.ScheduledTaskTypeBackup:
+		j := job.MakeJobExec(func(ctx context.Context, progress *job.Progress) error {
+			backupPath, _, err := mgr.BackupDatabase(false)
+			if err != nil {
+				return fmt.Errorf("error backing up database: %w", err)
+			}
+
+			logger.Infof("Successfully backed up database to: %s", backupPath)
+			return nil
+		})
+		return mgr.JobManager.Add(ctx, "Backing up database...", j), nil
+	}
+
+	return 0, fmt.Errorf("unsupported scheduled task type: %s", t.Type)
+}
+
+func generateInputFromOptions(opts models.GenerateMetadataOptions) GenerateMetadataInput {
+	ret := GenerateMetadataInput{
+		Covers:                    opts.Covers,
+		Sprites:                   opts.Sprites,
+		Previews:                  opts.Previews,
+		ImagePreviews:             opts.ImagePreviews,
+		Markers:                   opts.Markers,
+		MarkerImagePreviews:       opts.MarkerImagePreviews,
+		MarkerScreenshots:         opts.MarkerScreenshots,
+		Transcodes:                opts.Transcodes,
+		Phashes:                   opts.Phashes,
+		InteractiveHeatmapsSpeeds: opts.InteractiveHeatmapsSpeeds,
+		ClipPreviews:              opts.ClipPreviews,
+		ImageThumbnails:           opts.ImageThumbnails,
+	}
+
+	if opts.PreviewOptions != nil {
+		ret.PreviewOptions = &GeneratePreviewOptionsInput{
+			PreviewSegments:        opts.PreviewOptions.PreviewSegments,
+			PreviewSegmentDuration: opts.PreviewOptions.PreviewSegmentDuration,
+			PreviewExcludeStart:    opts.PreviewOptions.PreviewExcludeStart,
+			PreviewExcludeEnd:      opts.PreviewOptions.PreviewExcludeEnd,
+			PreviewPreset:          opts.PreviewOptions.PreviewPreset,
+		}
+	}
+
+	return ret
+}
+
+// Status returns the runtime state of the scheduled task with the provided ID.
+// NextRun is nil if the task is disabled.
+func (s *Scheduler) Status(id int) ScheduledTaskStatus {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	var ret ScheduledTaskStatus
+
+	e := s.entries[id]
+	if e == nil {
+		return ret
+	}
+
+	if e.task.Enabled && !e.nextRun.IsZero() {
+		next := e.nextRun
+		ret.NextRun = &next
+	}
+
+	ret.LastRun = e.lastRun
+
+	if e.lastJobID != 0 {
+		jobID := e.lastJobID
+		ret.LastJobID = &jobID
+	}
+
+	return ret
+}
+
+// ValidateScheduledTask returns an error if the provided task is not valid.
+func ValidateScheduledTask(t config.ScheduledTask) error {
+	if strings.TrimSpace(t.Name) == "" {
+		return errors.New("name must not be blank")
+	}
+
+	if !t.Type.IsValid() {
+		return fmt.Errorf("invalid task type: %s", t.Type)
+	}
+
+	schedule, err := cron.Parse(t.Schedule)
+	if err != nil {
+		return err
+	}
+
+	if schedule.Next(time.Now()).IsZero() {
+		return fmt.Errorf("schedule %q never runs", t.Schedule)
+	}
+
+	return nil
+}
+
+// CreateScheduledTask validates and adds the provided task to the
+// configuration. The ID of the provided task is ignored.
+// Returns the created task.
+func (s *Scheduler) CreateScheduledTask(t config.ScheduledTask) (*config.ScheduledTask, error) {
+	if err := ValidateScheduledTask(t); err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	tasks := s.manager.Config.GetScheduledTasks()
+
+	maxID := 0
+	for _, existing := range tasks {
+		if existing.ID > maxID {
+			maxID = existing.ID
+		}
+	}
+
+	t.ID = maxID + 1
+	tasks = append(tasks, &t)
+
+	if err := s.saveScheduledTasks(tasks); err != nil {
+		return nil, err
+	}
+
+	return &t, nil
+}
+
+// UpdateScheduledTask validates and replaces the task with the same ID in
+// the configuration. Returns ErrScheduledTaskNotFound if no task exists with
+// the provided ID.
+func (s *Scheduler) UpdateScheduledTask(t config.ScheduledTask) (*config.ScheduledTask, error) {
+	if err := ValidateScheduledTask(t); err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	tasks := s.manager.Config.GetScheduledTasks()
+
+	found := false
+	for i, existing := range tasks {
+		if existing.ID == t.ID {
+			tasks[i] = &t
+			found = true
+			break
+		}
+	}
+
+	if !found {
+		return nil, fmt.Errorf("%w: %d", ErrScheduledTaskNotFound, t.ID)
+	}
+
+	if err := s.saveScheduledTasks(tasks); err != nil {
+		return nil, err
+	}
+
+	return &t, nil
+}
+
+// DestroyScheduledTask removes the task with the provided ID from the
+// configuration. Returns ErrScheduledTaskNotFound if no task exists with
+// the provided ID.
+func (s *Scheduler) DestroyScheduledTask(id int) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	tasks := s.manager.Config.GetScheduledTasks()
+
+	var newTasks []*config.ScheduledTask
+	for _, existing := range tasks {
+		if existing.ID != id {
+			newTasks = append(newTasks, existing)
+		}
+	}
+
+	if len(newTasks) == len(tasks) {
+		return fmt.Errorf("%w: %d", ErrScheduledTaskNotFound, id)
+	}
+
+	return s.saveScheduledTasks(newTasks)
+}
+
+// saveScheduledTasks writes the provided tasks to the configuration and
+// reloads the schedule entries. Assumes lock held.
+func (s *Scheduler) saveScheduledTasks(tasks []*config.ScheduledTask) error {
+	cfg := s.manager.Config
+	cfg.SetInterface(config.ScheduledTasks, tasks)
+
+	if err := cfg.Write(); err != nil {
+		return err
+	}
+
+	s.load(time.Now())
+	s.wake()
+	return nil
+}
@@ -0,0 +1,68 @@
+package manager
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stashapp/stash/internal/manager/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScheduler_loadReenabled(t *testing.T) {
+	cfg := config.InitializeEmpty()
+	s := newScheduler(&Manager{Config: cfg})
+
+	task := config.ScheduledTask{
+		ID:       1,
+		Name:     "scan",
+		Type:     config.ScheduledTaskTypeScan,
+		Schedule: "0 3 * * *",
+		Enabled:  true,
+	}
+
+	setTask := func(enabled bool) {
+		t := task
+		t.Enabled = enabled
+		cfg.SetInterface(config.ScheduledTasks, []*config.ScheduledTask{&t})
+	}
+
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.Local)
+
+	setTask(true)
+	s.load(start)
+	assert.Equal(t, time.Date(2024, 1, 2, 3, 0, 0, 0, time.Local), s.entries[task.ID].nextRun)
+
+	// disable the task and let its next run pass
+	setTask(false)
+	s.load(start.Add(time.Hour))
+	s.runDue(start.Add(48 * time.Hour))
+
+	// re-enabling must not run the task immediately
+	reenabled := start.Add(48 * time.Hour)
+	setTask(true)
+	s.load(reenabled)
+	assert.Equal(t, time.Date(2024, 1, 4, 3, 0, 0, 0, time.Local), s.entries[task.ID].nextRun)
+}
+
+func TestValidateScheduledTask(t *testing.T) {
+	tests := []struct {
+		name     string
+		schedule string
+		wantErr  bool
+	}{
+		{"valid", "0 3 * * *", false},
+		{"invalid", "0 3 * *", true},
+		{"never runs", "0 0 31 2 *", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateScheduledTask(config.ScheduledTask{
+				Name:     "task",
+				Type:     config.ScheduledTaskTypeScan,
+				Schedule: tt.schedule,
+			})
+			assert.Equal(t, tt.wantErr, err != nil, "ValidateScheduledTask() error = %v", err)
+		})
+	}
+}
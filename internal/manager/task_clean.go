@@ -52,6 +52,10 @@ func (j *cleanJob) Execute(ctx context.Context, progress *job.Progress) error {
 
 	j.cleanEmptyGalleries(ctx)
 
+	if !j.input.DryRun {
+		instance.PurgeTrash(ctx)
+	}
+
 	j.scanSubs.notify()
 	elapsed := time.Since(start)
 	logger.Info(fmt.Sprintf("Finished Cleaning (%s)", elapsed))
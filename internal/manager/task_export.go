@@ -169,6 +169,7 @@ func (t *ExportTask) Start(ctx context.Context, wg *sync.WaitGroup) {
 			}
 		}
 
+		t.ExportCustomFieldDefinitions(ctx)
 		t.ExportScenes(ctx, workerCount)
 		t.ExportImages(ctx, workerCount)
 		t.ExportGalleries(ctx, workerCount)
@@ -225,6 +226,10 @@ func (t *ExportTask) zipFiles(w io.Writer) error {
 		json: *paths.GetJSONPaths(""),
 	}
 
+	if err := t.zipFile(t.json.json.CustomFields, u.json.Metadata, z); err != nil {
+		logger.Warnf("error adding custom field definitions to zip: %v", err)
+	}
+
 	walkWarn(t.json.json.Tags, t.zipWalkFunc(u.json.Tags, z))
 	walkWarn(t.json.json.Galleries, t.zipWalkFunc(u.json.Galleries, z))
 	walkWarn(t.json.json.Performers, t.zipWalkFunc(u.json.Performers, z))
@@ -349,6 +354,33 @@ func (t *ExportTask) populateGalleryImages(ctx context.Context) {
 	}
 }
 
+// ExportCustomFieldDefinitions exports all custom field definitions, so that
+// the custom field values of the exported objects can be imported.
+func (t *ExportTask) ExportCustomFieldDefinitions(ctx context.Context) {
+	definitions, err := t.repository.CustomField.All(ctx)
+	if err != nil {
+		logger.Errorf("[custom fields] failed to fetch custom field definitions: %v", err)
+		return
+	}
+
+	logger.Info("[custom fields] exporting")
+
+	ret := make([]jsonschema.CustomFieldDefinition, len(definitions))
+	for i, d := range definitions {
+		ret[i] = jsonschema.CustomFieldDefinition{
+			EntityType: d.EntityType,
+			Name:       d.Name,
+			Type:       d.Type,
+		}
+	}
+
+	if err := t.json.saveCustomFieldDefinitions(ret); err != nil {
+		logger.Errorf("[custom fields] failed to save json: %v", err)
+	}
+
+	logger.Info("[custom fields] export complete")
+}
+
 func (t *ExportTask) ExportScenes(ctx context.Context, workers int) {
 	var scenesWg sync.WaitGroup
 
@@ -586,6 +618,12 @@ func (t *ExportTask) exportScene(ctx context.Context, wg *sync.WaitGroup, jobCha
 			t.performers.IDs = sliceutil.AppendUniques(t.performers.IDs, performer.GetIDs(performers))
 		}
 
+		newSceneJSON.CustomFields, err = sceneReader.GetCustomFields(ctx, s.ID)
+		if err != nil {
+			logger.Errorf("[scenes] <%s> error getting scene custom fields: %v", sceneHash, err)
+			continue
+		}
+
 		basename := filepath.Base(s.Path)
 		hash := s.OSHash
 
@@ -718,6 +756,12 @@ func (t *ExportTask) exportImage(ctx context.Context, wg *sync.WaitGroup, jobCha
 			t.performers.IDs = sliceutil.AppendUniques(t.performers.IDs, performer.GetIDs(performers))
 		}
 
+		newImageJSON.CustomFields, err = r.Image.GetCustomFields(ctx, s.ID)
+		if err != nil {
+			logger.Errorf("[images] <%s> error getting image custom fields: %v", imageHash, err)
+			continue
+		}
+
 		fn := newImageJSON.Filename(filepath.Base(s.Path), s.Checksum)
 
 		if err := t.json.saveImage(fn, newImageJSON); err != nil {
@@ -854,6 +898,12 @@ func (t *ExportTask) exportGallery(ctx context.Context, wg *sync.WaitGroup, jobC
 			t.performers.IDs = sliceutil.AppendUniques(t.performers.IDs, performer.GetIDs(performers))
 		}
 
+		newGalleryJSON.CustomFields, err = r.Gallery.GetCustomFields(ctx, g.ID)
+		if err != nil {
+			logger.Errorf("[galleries] <%s> error getting gallery custom fields: %v", g.DisplayName(), err)
+			continue
+		}
+
 		basename := ""
 		// use id in case multiple galleries with the same basename
 		hash := strconv.Itoa(g.ID)
@@ -938,6 +988,12 @@ func (t *ExportTask) exportPerformer(ctx context.Context, wg *sync.WaitGroup, jo
 			t.tags.IDs = sliceutil.AppendUniques(t.tags.IDs, tag.GetIDs(tags))
 		}
 
+		newPerformerJSON.CustomFields, err = performerReader.GetCustomFields(ctx, p.ID)
+		if err != nil {
+			logger.Errorf("[performers] <%s> error getting performer custom fields: %v", p.Name, err)
+			continue
+		}
+
 		fn := newPerformerJSON.Filename()
 
 		if err := t.json.savePerformer(fn, newPerformerJSON); err != nil {
@@ -1012,6 +1068,12 @@ func (t *ExportTask) exportStudio(ctx context.Context, wg *sync.WaitGroup, jobCh
 			t.tags.IDs = sliceutil.AppendUniques(t.tags.IDs, tag.GetIDs(tags))
 		}
 
+		newStudioJSON.CustomFields, err = studioReader.GetCustomFields(ctx, s.ID)
+		if err != nil {
+			logger.Errorf("[studios] <%s> error getting studio custom fields: %v", s.Name, err)
+			continue
+		}
+
 		fn := newStudioJSON.Filename()
 
 		if err := t.json.saveStudio(fn, newStudioJSON); err != nil {
@@ -1073,6 +1135,12 @@ func (t *ExportTask) exportTag(ctx context.Context, wg *sync.WaitGroup, jobChan
 			continue
 		}
 
+		newTagJSON.CustomFields, err = tagReader.GetCustomFields(ctx, thisTag.ID)
+		if err != nil {
+			logger.Errorf("[tags] <%s> error getting tag custom fields: %v", thisTag.Name, err)
+			continue
+		}
+
 		fn := newTagJSON.Filename()
 
 		if err := t.json.saveTag(fn, newTagJSON); err != nil {
@@ -1179,6 +1247,12 @@ func (t *ExportTask) exportGroup(ctx context.Context, wg *sync.WaitGroup, jobCha
 			}
 		}
 
+		newGroupJSON.CustomFields, err = groupReader.GetCustomFields(ctx, m.ID)
+		if err != nil {
+			logger.Errorf("[groups] <%s> error getting group custom fields: %v", m.Name, err)
+			continue
+		}
+
 		fn := newGroupJSON.Filename()
 
 		if err := t.json.saveGroup(fn, newGroupJSON); err != nil {
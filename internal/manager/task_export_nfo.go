@@ -0,0 +1,210 @@
+package manager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+
+	"github.com/stashapp/stash/pkg/fsutil"
+	"github.com/stashapp/stash/pkg/job"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/nfo"
+	"github.com/stashapp/stash/pkg/scene"
+	"github.com/stashapp/stash/pkg/sliceutil/stringslice"
+)
+
+type ExportNFOInput struct {
+	// IDs of the scenes to export. All scenes are exported if empty
+	SceneIDs []string `json:"scene_ids"`
+	// Overwrite existing NFO and poster files
+	Overwrite bool `json:"overwrite"`
+}
+
+// ExportNFO queues a job to write an NFO file and poster image alongside
+// each file of the scenes in the provided input.
+func (s *Manager) ExportNFO(ctx context.Context, input ExportNFOInput) (int, error) {
+	sceneIDs, err := stringslice.StringSliceToIntSlice(input.SceneIDs)
+	if err != nil {
+		return 0, fmt.Errorf("converting scene ids: %w", err)
+	}
+
+	j := &exportNFOJob{
+		repository: s.Repository,
+		sceneIDs:   sceneIDs,
+		overwrite:  input.Overwrite,
+	}
+
+	return s.JobManager.AddWithOptions(ctx, "Exporting NFO files...", j, job.Options{
+		ResourceClass: job.ResourceClassIO,
+	}), nil
+}
+
+type exportNFOJob struct {
+	repository models.Repository
+	sceneIDs   []int
+	overwrite  bool
+}
+
+// nfoExport is the content to be written alongside the files of a scene.
+type nfoExport struct {
+	movie *nfo.Movie
+	cover []byte
+	paths []string
+}
+
+func (j *exportNFOJob) Execute(ctx context.Context, progress *job.Progress) error {
+	r := j.repository
+
+	sceneIDs := j.sceneIDs
+	if len(sceneIDs) == 0 {
+		if err := r.WithReadTxn(ctx, func(ctx context.Context) error {
+			scenes, err := r.Scene.All(ctx)
+			if err != nil {
+				return err
+			}
+
+			for _, s := range scenes {
+				sceneIDs = append(sceneIDs, s.ID)
+			}
+			return nil
+		}); err != nil {
+			return fmt.Errorf("getting scenes: %w", err)
+		}
+	}
+
+	progress.SetTotal(len(sceneIDs))
+
+	written := 0
+	for _, id := range sceneIDs {
+		if job.IsCancelled(ctx) {
+			logger.Info("Stopping due to user request")
+			return nil
+		}
+
+		progress.ExecuteTask(fmt.Sprintf("Exporting NFO for scene %d", id), func() {
+			e, err := j.getExport(ctx, id)
+			if err != nil {
+				logger.Errorf("Error exporting NFO for scene %d: %v", id, err)
+				return
+			}
+
+			if e == nil {
+				logger.Warnf("Scene %d not found", id)
+				return
+			}
+
+			for _, p := range e.paths {
+				n, err := j.writeFiles(e, p)
+				if err != nil {
+					logger.Errorf("Error writing NFO for %q: %v", p, err)
+				}
+				written += n
+			}
+		})
+
+		progress.Increment()
+	}
+
+	logger.Infof("Wrote %d NFO and poster files", written)
+
+	return nil
+}
+
+func (j *exportNFOJob) getExport(ctx context.Context, sceneID int) (*nfoExport, error) {
+	var ret *nfoExport
+	r := j.repository
+	err := r.WithReadTxn(ctx, func(ctx context.Context) error {
+		s, err := r.Scene.Find(ctx, sceneID)
+		if err != nil || s == nil {
+			return err
+		}
+
+		if err := s.LoadFiles(ctx, r.Scene); err != nil {
+			return err
+		}
+
+		movie, err := scene.ToNFO(ctx, r.Studio, r.Performer, r.Tag, s)
+		if err != nil {
+			return err
+		}
+
+		cover, err := r.Scene.GetCover(ctx, s.ID)
+		if err != nil {
+			return fmt.Errorf("getting cover: %w", err)
+		}
+
+		ret = &nfoExport{
+			movie: movie,
+			cover: cover,
+		}
+
+		for _, f := range s.Files.List() {
+			// files within zip files can't have sidecars
+			if f.ZipFileID == nil {
+				ret.paths = append(ret.paths, f.Path)
+			}
+		}
+
+		return nil
+	})
+
+	return ret, err
+}
+
+// writeFiles writes the NFO file and poster image for the video file at
+// videoPath, returning the number of files written.
+func (j *exportNFOJob) writeFiles(e *nfoExport, videoPath string) (int, error) {
+	written := 0
+
+	nfoPath := nfo.GetSidecarPath(videoPath)
+	write, err := j.shouldWrite(nfoPath)
+	if err != nil {
+		return written, err
+	}
+
+	if write {
+		if err := nfo.SaveFile(nfoPath, e.movie); err != nil {
+			return written, err
+		}
+		written++
+	}
+
+	if len(e.cover) == 0 {
+		return written, nil
+	}
+
+	posterPath := nfo.GetPosterPath(videoPath)
+	write, err = j.shouldWrite(posterPath)
+	if err != nil {
+		return written, err
+	}
+
+	if write {
+		if err := os.WriteFile(posterPath, e.cover, 0644); err != nil {
+			return written, fmt.Errorf("writing poster: %w", err)
+		}
+		written++
+	}
+
+	return written, nil
+}
+
+func (j *exportNFOJob) shouldWrite(path string) (bool, error) {
+	if j.overwrite {
+		return true, nil
+	}
+
+	exists, err := fsutil.FileExists(path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return false, err
+	}
+
+	if exists {
+		logger.Debugf("Not overwriting existing file %q", path)
+	}
+
+	return !exists, nil
+}
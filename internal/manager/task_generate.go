@@ -32,6 +32,9 @@ type GenerateMetadataInput struct {
 	InteractiveHeatmapsSpeeds bool `json:"interactiveHeatmapsSpeeds"`
 	ClipPreviews              bool `json:"clipPreviews"`
 	ImageThumbnails           bool `json:"imageThumbnails"`
+	ImagePhashes              bool `json:"imagePhashes"`
+	// Create scene markers from video chapters for scenes without markers
+	ChapterMarkers bool `json:"chapterMarkers"`
 	// scene ids to generate for
 	SceneIDs []string `json:"sceneIDs"`
 	// marker ids to generate for
@@ -76,6 +79,8 @@ type totalsGenerate struct {
 	interactiveHeatmapSpeeds int64
 	clipPreviews             int64
 	imageThumbnails          int64
+	imagePhashes             int64
+	chapterMarkers           int64
 
 	tasks int
 }
@@ -181,6 +186,12 @@ func (j *GenerateJob) Execute(ctx context.Context, progress *job.Progress) error
 		if j.input.ImageThumbnails {
 			logMsg += fmt.Sprintf(" %d Image Thumbnails", totals.imageThumbnails)
 		}
+		if j.input.ImagePhashes {
+			logMsg += fmt.Sprintf(" %d Image phashes", totals.imagePhashes)
+		}
+		if j.input.ChapterMarkers {
+			logMsg += fmt.Sprintf(" %d chapter markers", totals.chapterMarkers)
+		}
 		if logMsg == "Generating" {
 			logMsg = "Nothing selected to generate"
 		}
@@ -284,7 +295,7 @@ func (j *GenerateJob) queueImagesTasks(ctx context.Context, g *generate.Generato
 
 	r := j.repository
 
-	for more := j.input.ClipPreviews || j.input.ImageThumbnails; more; {
+	for more := j.input.ClipPreviews || j.input.ImageThumbnails || j.input.ImagePhashes; more; {
 		if job.IsCancelled(ctx) {
 			return
 		}
@@ -411,6 +422,20 @@ func (j *GenerateJob) queueSceneJobs(ctx context.Context, g *generate.Generator,
 		}
 	}
 
+	if j.input.ChapterMarkers {
+		task := &GenerateChapterMarkersTask{
+			repository: r,
+			Scene:      *scene,
+			File:       scene.Files.Primary(),
+		}
+
+		if task.required(ctx) {
+			j.totals.chapterMarkers++
+			j.totals.tasks++
+			queue <- task
+		}
+	}
+
 	if j.input.Markers {
 		task := &GenerateMarkersTask{
 			repository:          r,
@@ -521,4 +546,26 @@ func (j *GenerateJob) queueImageJob(g *generate.Generator, image *models.Image,
 			queue <- task
 		}
 	}
+
+	if j.input.ImagePhashes {
+		for _, f := range image.Files.List() {
+			// phashes are only generated for image files, not video clips
+			imageFile, ok := f.(*models.ImageFile)
+			if !ok {
+				continue
+			}
+
+			task := &GenerateImagePhashTask{
+				repository: j.repository,
+				File:       imageFile,
+				Overwrite:  j.overwrite,
+			}
+
+			if task.required() {
+				j.totals.imagePhashes++
+				j.totals.tasks++
+				queue <- task
+			}
+		}
+	}
 }
@@ -0,0 +1,117 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/plugin/hook"
+	"github.com/stashapp/stash/pkg/scene"
+)
+
+// GenerateChapterMarkersTask creates scene markers from the chapters stored
+// for the video file of a scene when it was scanned. Markers are only created
+// if the scene does not have any markers.
+type GenerateChapterMarkersTask struct {
+	repository models.Repository
+	Scene      models.Scene
+	// File is the video file to read the chapters of
+	File *models.VideoFile
+}
+
+func (t *GenerateChapterMarkersTask) GetDescription() string {
+	return fmt.Sprintf("Creating chapter markers for %s", t.File.Path)
+}
+
+func (t *GenerateChapterMarkersTask) Start(ctx context.Context) {
+	mgr := GetInstance()
+	tagName := mgr.Config.GetChapterMarkerPrimaryTag()
+
+	r := t.repository
+	if err := r.WithTxn(ctx, func(ctx context.Context) error {
+		// chapters are set on files that were just probed
+		chapters := t.File.Chapters
+		if chapters == nil {
+			var err error
+			chapters, err = r.File.GetChapters(ctx, t.File.ID)
+			if err != nil {
+				return fmt.Errorf("getting chapters: %w", err)
+			}
+		}
+
+		if chapters == nil {
+			logger.Debugf("chapters of %s have not been read, scan the file to create chapter markers", t.File.Path)
+			return nil
+		}
+
+		if len(chapters) == 0 {
+			return nil
+		}
+
+		// markers may have been added since the task was queued
+		existing, err := r.SceneMarker.FindBySceneID(ctx, t.Scene.ID)
+		if err != nil {
+			return err
+		}
+
+		if len(existing) > 0 {
+			return nil
+		}
+
+		primaryTag, err := t.getOrCreateTag(ctx, tagName)
+		if err != nil {
+			return err
+		}
+
+		markers := scene.MarkersFromChapters(t.Scene.ID, primaryTag.ID, chapters)
+		for _, m := range markers {
+			if err := r.SceneMarker.Create(ctx, m); err != nil {
+				return fmt.Errorf("creating marker: %w", err)
+			}
+
+			mgr.PluginCache.RegisterPostHooks(ctx, m.ID, hook.SceneMarkerCreatePost, nil, nil)
+		}
+
+		logger.Infof("Created %d chapter markers for %s", len(markers), t.File.Path)
+		return nil
+	}); err != nil && ctx.Err() == nil {
+		logger.Errorf("error creating chapter markers for %s: %v", t.File.Path, err)
+	}
+}
+
+func (t *GenerateChapterMarkersTask) getOrCreateTag(ctx context.Context, name string) (*models.Tag, error) {
+	r := t.repository
+
+	ret, err := r.Tag.FindByName(ctx, name, true)
+	if err != nil {
+		return nil, fmt.Errorf("finding tag %q: %w", name, err)
+	}
+
+	if ret != nil {
+		return ret, nil
+	}
+
+	newTag := models.NewTag()
+	newTag.Name = name
+	if err := r.Tag.Create(ctx, &newTag); err != nil {
+		return nil, fmt.Errorf("creating tag %q: %w", name, err)
+	}
+
+	return &newTag, nil
+}
+
+// required returns true if the scene has a video file and no markers.
+func (t *GenerateChapterMarkersTask) required(ctx context.Context) bool {
+	if t.File == nil {
+		return false
+	}
+
+	markers, err := t.repository.SceneMarker.FindBySceneID(ctx, t.Scene.ID)
+	if err != nil {
+		logger.Errorf("error finding scene markers: %v", err)
+		return false
+	}
+
+	return len(markers) == 0
+}
@@ -36,6 +36,7 @@ func CreateIdentifyJob(input identify.Options) *IdentifyJob {
 
 func (j *IdentifyJob) Execute(ctx context.Context, progress *job.Progress) error {
 	j.progress = progress
+	ctx = models.WithEditSource(ctx, models.EditSourceIdentify)
 
 	// if no sources provided - just return
 	if len(j.input.Sources) == 0 {
@@ -8,6 +8,7 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/99designs/gqlgen/graphql"
 	"github.com/stashapp/stash/pkg/file"
@@ -124,6 +125,7 @@ func (t *ImportTask) Start(ctx context.Context) {
 		}
 	}
 
+	t.ImportCustomFieldDefinitions(ctx)
 	t.ImportTags(ctx)
 	t.ImportPerformers(ctx)
 	t.ImportStudios(ctx)
@@ -188,6 +190,65 @@ func (t *ImportTask) unzipFile() error {
 	return nil
 }
 
+// ImportCustomFieldDefinitions creates the custom field definitions that do
+// not already exist. Definitions must be imported before the objects that
+// use them.
+func (t *ImportTask) ImportCustomFieldDefinitions(ctx context.Context) {
+	logger.Info("[custom fields] importing")
+
+	definitions, err := jsonschema.LoadCustomFieldDefinitionsFile(t.json.json.CustomFields)
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			logger.Errorf("[custom fields] failed to read custom field definitions: %v", err)
+		}
+
+		return
+	}
+
+	r := t.repository
+
+	for _, d := range definitions {
+		if err := r.WithTxn(ctx, func(ctx context.Context) error {
+			return importCustomFieldDefinition(ctx, r.CustomField, d)
+		}); err != nil {
+			logger.Errorf("[custom fields] <%s %s> import failed: %v", d.EntityType, d.Name, err)
+		}
+	}
+
+	logger.Info("[custom fields] import complete")
+}
+
+func importCustomFieldDefinition(ctx context.Context, qb models.CustomFieldDefinitionReaderWriter, d jsonschema.CustomFieldDefinition) error {
+	if !d.EntityType.IsValid() {
+		return fmt.Errorf("invalid entity type %q", d.EntityType)
+	}
+	if !d.Type.IsValid() {
+		return fmt.Errorf("invalid type %q", d.Type)
+	}
+
+	existing, err := qb.FindByName(ctx, d.EntityType, d.Name)
+	if err != nil {
+		return err
+	}
+
+	if existing != nil {
+		if existing.Type != d.Type {
+			return fmt.Errorf("existing custom field has type %s", existing.Type)
+		}
+
+		return nil
+	}
+
+	currentTime := time.Now()
+	return qb.Create(ctx, &models.CustomFieldDefinition{
+		EntityType: d.EntityType,
+		Name:       d.Name,
+		Type:       d.Type,
+		CreatedAt:  currentTime,
+		UpdatedAt:  currentTime,
+	})
+}
+
 func (t *ImportTask) ImportPerformers(ctx context.Context) {
 	logger.Info("[performers] importing")
 
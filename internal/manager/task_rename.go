@@ -0,0 +1,403 @@
+package manager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	"github.com/stashapp/stash/pkg/file"
+	"github.com/stashapp/stash/pkg/file/video"
+	"github.com/stashapp/stash/pkg/fsutil"
+	"github.com/stashapp/stash/pkg/image"
+	"github.com/stashapp/stash/pkg/job"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/nfo"
+	"github.com/stashapp/stash/pkg/renamer"
+	"github.com/stashapp/stash/pkg/scene"
+)
+
+type RenameFilesInput struct {
+	// Path template for the renamed files, relative to the library
+	// containing each file
+	Template string `json:"template"`
+	// Exactly one of the filters must be provided
+	SceneFilter   *models.SceneFilterType   `json:"scene_filter"`
+	ImageFilter   *models.ImageFilterType   `json:"image_filter"`
+	GalleryFilter *models.GalleryFilterType `json:"gallery_filter"`
+	// Log the planned moves without moving any files
+	DryRun bool `json:"dry_run"`
+}
+
+func (i RenameFilesInput) validate() error {
+	filters := 0
+	if i.SceneFilter != nil {
+		filters++
+	}
+	if i.ImageFilter != nil {
+		filters++
+	}
+	if i.GalleryFilter != nil {
+		filters++
+	}
+
+	if filters != 1 {
+		return errors.New("exactly one of scene_filter, image_filter or gallery_filter must be provided")
+	}
+
+	return nil
+}
+
+func (s *Manager) newRenamePlanner(input RenameFilesInput) (*renamer.Planner, error) {
+	if err := input.validate(); err != nil {
+		return nil, err
+	}
+
+	t, err := renamer.ParseTemplate(input.Template)
+	if err != nil {
+		return nil, err
+	}
+
+	return &renamer.Planner{
+		Template:     t,
+		Statter:      &file.OsFS{},
+		LibraryDirFn: libraryDirFn(s.Config.GetStashPaths()),
+	}, nil
+}
+
+// PreviewRenameFiles returns the planned moves of the files matching the
+// provided input, without moving any files.
+func (s *Manager) PreviewRenameFiles(ctx context.Context, input RenameFilesInput) ([]*renamer.Rename, error) {
+	planner, err := s.newRenamePlanner(input)
+	if err != nil {
+		return nil, err
+	}
+
+	var ret []*renamer.Rename
+	r := s.Repository
+	if err := r.WithReadTxn(ctx, func(ctx context.Context) error {
+		items, err := renameItems(ctx, r, input)
+		if err != nil {
+			return err
+		}
+
+		ret, err = planner.Plan(items)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+// RenameFiles queues a job to move the files matching the provided input to
+// the paths produced by the template.
+func (s *Manager) RenameFiles(ctx context.Context, input RenameFilesInput) (int, error) {
+	planner, err := s.newRenamePlanner(input)
+	if err != nil {
+		return 0, err
+	}
+
+	j := &renameJob{
+		repository: s.Repository,
+		planner:    planner,
+		input:      input,
+	}
+
+	return s.JobManager.AddWithOptions(ctx, "Renaming files...", j, job.Options{
+		ResourceClass: job.ResourceClassIO,
+		Exclusive:     true,
+	}), nil
+}
+
+type renameJob struct {
+	repository models.Repository
+	planner    *renamer.Planner
+	input      RenameFilesInput
+}
+
+func (j *renameJob) Execute(ctx context.Context, progress *job.Progress) error {
+	var renames []*renamer.Rename
+	r := j.repository
+	if err := r.WithReadTxn(ctx, func(ctx context.Context) error {
+		items, err := renameItems(ctx, r, j.input)
+		if err != nil {
+			return err
+		}
+
+		renames, err = j.planner.Plan(items)
+		return err
+	}); err != nil {
+		return fmt.Errorf("planning renames: %w", err)
+	}
+
+	progress.SetTotal(len(renames))
+
+	moved := 0
+	for _, rn := range renames {
+		if job.IsCancelled(ctx) {
+			logger.Info("Stopping due to user request")
+			return nil
+		}
+
+		progress.ExecuteTask(fmt.Sprintf("Moving %s", rn.OldPath), func() {
+			switch {
+			case rn.Error != nil:
+				logger.Warnf("Not moving %q: %v", rn.OldPath, rn.Error)
+			case rn.Collision:
+				logger.Warnf("Not moving %q: %q is used by another file", rn.OldPath, rn.NewPath)
+			case j.input.DryRun:
+				logger.Infof("[dry run] Would move %q to %q", rn.OldPath, rn.NewPath)
+			default:
+				if err := j.renameFile(ctx, rn); err != nil {
+					logger.Errorf("Error moving %q to %q: %v", rn.OldPath, rn.NewPath, err)
+					return
+				}
+
+				logger.Infof("Moved %q to %q", rn.OldPath, rn.NewPath)
+				moved++
+			}
+		})
+
+		progress.Increment()
+	}
+
+	if !j.input.DryRun {
+		logger.Infof("Moved %d of %d files", moved, len(renames))
+	}
+
+	return nil
+}
+
+func (j *renameJob) renameFile(ctx context.Context, rn *renamer.Rename) error {
+	r := j.repository
+	return r.WithTxn(ctx, func(ctx context.Context) error {
+		mover := file.NewMover(r.File, r.Folder)
+		mover.RegisterHooks(ctx)
+
+		dir := filepath.Dir(rn.NewPath)
+		if err := mover.CreateFolderHierarchy(dir); err != nil {
+			return fmt.Errorf("creating folder hierarchy %s in filesystem: %w", dir, err)
+		}
+
+		folder, err := file.GetOrCreateFolderHierarchy(ctx, r.Folder, dir)
+		if err != nil {
+			return fmt.Errorf("getting or creating folder hierarchy: %w", err)
+		}
+
+		if err := mover.Move(ctx, rn.File, folder, filepath.Base(rn.NewPath)); err != nil {
+			return err
+		}
+
+		if _, isVideo := rn.File.(*models.VideoFile); isVideo {
+			return moveVideoSidecars(ctx, r.File, mover, rn.File.Base().ID, rn.OldPath, rn.NewPath)
+		}
+
+		return nil
+	})
+}
+
+// moveVideoSidecars moves the caption, funscript, NFO and poster files of a
+// video file so that they remain alongside it.
+func moveVideoSidecars(ctx context.Context, fileStore models.FileReaderWriter, mover *file.Mover, fileID models.FileID, oldPath, newPath string) error {
+	captions, err := fileStore.GetCaptions(ctx, fileID)
+	if err != nil {
+		return fmt.Errorf("getting captions: %w", err)
+	}
+
+	for _, c := range captions {
+		oldCaptionPath := c.Path(oldPath)
+		newCaptionPath := video.GetCaptionPath(newPath, c.LanguageCode, c.CaptionType)
+
+		if err := mover.MoveSidecar(oldCaptionPath, newCaptionPath); err != nil {
+			return fmt.Errorf("moving caption: %w", err)
+		}
+
+		c.Filename = filepath.Base(newCaptionPath)
+	}
+
+	if len(captions) > 0 {
+		if err := fileStore.UpdateCaptions(ctx, fileID, captions); err != nil {
+			return fmt.Errorf("updating captions: %w", err)
+		}
+	}
+
+	funscriptPath := video.GetFunscriptPath(oldPath)
+	if exists, _ := fsutil.FileExists(funscriptPath); exists {
+		if err := mover.MoveSidecar(funscriptPath, video.GetFunscriptPath(newPath)); err != nil {
+			return fmt.Errorf("moving funscript: %w", err)
+		}
+	}
+
+	nfoPath := nfo.GetSidecarPath(oldPath)
+	if exists, _ := fsutil.FileExists(nfoPath); exists {
+		if err := mover.MoveSidecar(nfoPath, nfo.GetSidecarPath(newPath)); err != nil {
+			return fmt.Errorf("moving NFO file: %w", err)
+		}
+	}
+
+	posterPath := nfo.GetPosterPath(oldPath)
+	if exists, _ := fsutil.FileExists(posterPath); exists {
+		if err := mover.MoveSidecar(posterPath, nfo.GetPosterPath(newPath)); err != nil {
+			return fmt.Errorf("moving poster: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// renameItems returns the primary files of the objects matching the filter
+// in the provided input.
+func renameItems(ctx context.Context, r models.Repository, input RenameFilesInput) ([]renamer.Item, error) {
+	perPage := -1
+	findFilter := &models.FindFilterType{
+		PerPage: &perPage,
+	}
+
+	l := &renameObjectLoader{
+		repository: r,
+		studios:    make(map[int]*models.Studio),
+	}
+
+	var ret []renamer.Item
+
+	switch {
+	case input.SceneFilter != nil:
+		scenes, err := scene.Query(ctx, r.Scene, input.SceneFilter, findFilter)
+		if err != nil {
+			return nil, fmt.Errorf("querying scenes: %w", err)
+		}
+
+		for _, s := range scenes {
+			if err := s.LoadPrimaryFile(ctx, r.File); err != nil {
+				return nil, err
+			}
+
+			f := s.Files.Primary()
+			if f == nil {
+				continue
+			}
+
+			performers, err := r.Performer.FindBySceneID(ctx, s.ID)
+			if err != nil {
+				return nil, err
+			}
+
+			o, err := l.object(ctx, s.ID, s.Title, s.Code, s.Date, s.StudioID, performers)
+			if err != nil {
+				return nil, err
+			}
+
+			ret = append(ret, renamer.Item{File: f, Object: o})
+		}
+	case input.ImageFilter != nil:
+		images, err := image.Query(ctx, r.Image, input.ImageFilter, findFilter)
+		if err != nil {
+			return nil, fmt.Errorf("querying images: %w", err)
+		}
+
+		for _, i := range images {
+			if err := i.LoadPrimaryFile(ctx, r.File); err != nil {
+				return nil, err
+			}
+
+			f := i.Files.Primary()
+			if f == nil {
+				continue
+			}
+
+			performers, err := r.Performer.FindByImageID(ctx, i.ID)
+			if err != nil {
+				return nil, err
+			}
+
+			o, err := l.object(ctx, i.ID, i.Title, i.Code, i.Date, i.StudioID, performers)
+			if err != nil {
+				return nil, err
+			}
+
+			ret = append(ret, renamer.Item{File: f, Object: o})
+		}
+	case input.GalleryFilter != nil:
+		galleries, _, err := r.Gallery.Query(ctx, input.GalleryFilter, findFilter)
+		if err != nil {
+			return nil, fmt.Errorf("querying galleries: %w", err)
+		}
+
+		for _, g := range galleries {
+			if err := g.LoadPrimaryFile(ctx, r.File); err != nil {
+				return nil, err
+			}
+
+			// folder-based galleries have no file to move
+			f := g.Files.Primary()
+			if f == nil {
+				continue
+			}
+
+			performers, err := r.Performer.FindByGalleryID(ctx, g.ID)
+			if err != nil {
+				return nil, err
+			}
+
+			o, err := l.object(ctx, g.ID, g.Title, g.Code, g.Date, g.StudioID, performers)
+			if err != nil {
+				return nil, err
+			}
+
+			ret = append(ret, renamer.Item{File: f, Object: o})
+		}
+	}
+
+	return ret, nil
+}
+
+// renameObjectLoader loads the metadata of objects, caching studios.
+type renameObjectLoader struct {
+	repository models.Repository
+	studios    map[int]*models.Studio
+}
+
+func (l *renameObjectLoader) studio(ctx context.Context, id *int) (*models.Studio, error) {
+	if id == nil {
+		return nil, nil
+	}
+
+	if ret, found := l.studios[*id]; found {
+		return ret, nil
+	}
+
+	ret, err := l.repository.Studio.Find(ctx, *id)
+	if err != nil {
+		return nil, fmt.Errorf("finding studio %d: %w", *id, err)
+	}
+
+	l.studios[*id] = ret
+	return ret, nil
+}
+
+func (l *renameObjectLoader) object(ctx context.Context, id int, title string, code string, date *models.Date, studioID *int, performers []*models.Performer) (renamer.Object, error) {
+	ret := renamer.Object{
+		ID:         id,
+		Title:      title,
+		Code:       code,
+		Date:       date,
+		Performers: performers,
+	}
+
+	var err error
+	ret.Studio, err = l.studio(ctx, studioID)
+	if err != nil {
+		return ret, err
+	}
+
+	if ret.Studio != nil {
+		ret.ParentStudio, err = l.studio(ctx, ret.Studio.ParentID)
+		if err != nil {
+			return ret, err
+		}
+	}
+
+	return ret, nil
+}
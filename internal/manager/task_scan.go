@@ -22,6 +22,7 @@ import (
 	"github.com/stashapp/stash/pkg/scene"
 	"github.com/stashapp/stash/pkg/scene/generate"
 	"github.com/stashapp/stash/pkg/txn"
+	"github.com/stashapp/stash/pkg/webhook"
 )
 
 type scanner interface {
@@ -43,6 +44,9 @@ func (j *ScanJob) Execute(ctx context.Context, progress *job.Progress) error {
 		return nil
 	}
 
+	// scanning updates objects in bulk, so do not record its edits
+	ctx = models.WithoutEditHistory(ctx)
+
 	sp := getScanPaths(input.Paths)
 	paths := make([]string, len(sp))
 	for i, p := range sp {
@@ -83,6 +87,12 @@ func (j *ScanJob) Execute(ctx context.Context, progress *job.Progress) error {
 	logger.Info(fmt.Sprintf("Scan finished (%s)", elapsed))
 
 	j.subscriptions.notify()
+
+	mgr.WebhookDispatcher.Notify(webhook.EventScanComplete, ScanCompletePayload{
+		Paths:    paths,
+		Duration: elapsed.Seconds(),
+	})
+
 	return nil
 }
 
@@ -276,6 +286,11 @@ func (f *scanFilter) Accept(ctx context.Context, path string, info fs.FileInfo)
 		return false
 	}
 
+	if f.stashPaths.IsTrashPath(path) {
+		logger.Debugf("Skipping %q as it is in a trash folder", path)
+		return false
+	}
+
 	// exit early on cutoff
 	if info.Mode().IsRegular() && info.ModTime().Before(f.minModTime) {
 		return false
@@ -359,6 +374,15 @@ func getScanHandlers(options ScanMetadataInput, taskQueue *job.TaskQueue, progre
 	r := mgr.Repository
 	pluginCache := mgr.PluginCache
 
+	var nfoImporter *scene.NFOImporter
+	if options.ScanReadNFO {
+		nfoImporter = &scene.NFOImporter{
+			StudioWriter:    r.Studio,
+			PerformerWriter: r.Performer,
+			TagWriter:       r.Tag,
+		}
+	}
+
 	return []file.Handler{
 		&file.FilteredHandler{
 			Filter: file.FilterFunc(imageFileFilter),
@@ -404,6 +428,7 @@ func getScanHandlers(options ScanMetadataInput, taskQueue *job.TaskQueue, progre
 					fileNamingAlgorithm: c.GetVideoFileNamingAlgorithm(),
 					sequentialScanning:  c.GetSequentialScanning(),
 				},
+				NFOImporter:         nfoImporter,
 				FileNamingAlgorithm: c.GetVideoFileNamingAlgorithm(),
 				Paths:               mgr.Paths,
 			},
@@ -463,6 +488,29 @@ func (g *imageGenerators) Generate(ctx context.Context, i *models.Image, f model
 		}
 	}
 
+	imageFile, isImage := f.(*models.ImageFile)
+	if isImage && t.ScanGenerateImagePhashes {
+		mgr := GetInstance()
+
+		progress.AddTotal(1)
+		phashFn := func(ctx context.Context) {
+			taskPhash := GenerateImagePhashTask{
+				repository: mgr.Repository,
+				File:       imageFile,
+				Overwrite:  overwrite,
+			}
+
+			taskPhash.Start(ctx)
+			progress.Increment()
+		}
+
+		if g.sequentialScanning {
+			phashFn(ctx)
+		} else {
+			g.taskQueue.Add(fmt.Sprintf("Generating phash for %s", path), phashFn)
+		}
+	}
+
 	return nil
 }
 
@@ -557,6 +605,37 @@ func (g *sceneGenerators) Generate(ctx context.Context, s *models.Scene, f *mode
 		}
 	}
 
+	if t.ScanGenerateChapterMarkers {
+		progress.AddTotal(1)
+		chaptersFn := func(ctx context.Context) {
+			taskChapters := GenerateChapterMarkersTask{
+				repository: mgr.Repository,
+				Scene:      *s,
+				File:       f,
+			}
+
+			// only create markers for scenes without existing markers
+			required := false
+			if err := mgr.Repository.WithReadTxn(ctx, func(ctx context.Context) error {
+				required = taskChapters.required(ctx)
+				return nil
+			}); err != nil {
+				logger.Errorf("error checking scene markers: %v", err)
+			}
+
+			if required {
+				taskChapters.Start(ctx)
+			}
+			progress.Increment()
+		}
+
+		if g.sequentialScanning {
+			chaptersFn(ctx)
+		} else {
+			g.taskQueue.Add(fmt.Sprintf("Creating chapter markers for %s", path), chaptersFn)
+		}
+	}
+
 	if t.ScanGenerateCovers {
 		progress.AddTotal(1)
 		g.taskQueue.Add(fmt.Sprintf("Generating cover for %s", path), func(ctx context.Context) {
@@ -9,6 +9,7 @@ import (
 	"github.com/stashapp/stash/pkg/logger"
 	"github.com/stashapp/stash/pkg/models"
 	"github.com/stashapp/stash/pkg/scene/generate"
+	"github.com/stashapp/stash/pkg/sliceutil"
 )
 
 type GenerateTranscodeTask struct {
@@ -69,13 +70,19 @@ func (t *GenerateTranscodeTask) Start(ctx context.Context) {
 
 	sceneHash := t.Scene.GetHash(t.fileNamingAlgorithm)
 	transcodeSize := config.GetInstance().GetMaxTranscodeSize()
+	encoder, sourceCodecs := transcodeEncoder(config.GetInstance().GetTranscodeVideoCodec())
+	if config.GetInstance().GetTranscodeHardwareAcceleration() {
+		if hwcodec := instance.FFMpeg.HWTranscodeCodec(encoder); hwcodec != nil {
+			encoder = *hwcodec
+		}
+	}
 
 	w, h := videoFile.TranscodeScale(transcodeSize.GetMaxResolution())
 
 	// if scale is being set, then we can't use stream copy
 	scaleSet := w == 0 && h == 0
 
-	if scaleSet && videoCodec == ffmpeg.H264 { // for non supported h264 files stream copy the video part
+	if scaleSet && sliceutil.Contains(sourceCodecs, videoCodec) { // for non supported files already in the output codec stream copy the video part
 		if audioCodec == ffmpeg.MissingUnsupported {
 			err = t.g.TranscodeCopyVideo(ctx, videoFile.Path, sceneHash)
 		} else {
@@ -83,8 +90,11 @@ func (t *GenerateTranscodeTask) Start(ctx context.Context) {
 		}
 	} else {
 		options := generate.TranscodeOptions{
-			Width:  w,
-			Height: h,
+			Width:        w,
+			Height:       h,
+			VideoCodec:   encoder,
+			SourceWidth:  videoFile.Width,
+			SourceHeight: videoFile.Height,
 		}
 
 		if audioCodec == ffmpeg.MissingUnsupported {
@@ -101,6 +111,20 @@ func (t *GenerateTranscodeTask) Start(ctx context.Context) {
 	}
 }
 
+// transcodeEncoder returns the software encoder for the transcode video
+// codec, and the probed codec names of files that are already encoded with
+// it.
+func transcodeEncoder(codec models.TranscodeVideoCodec) (ffmpeg.VideoCodec, []string) {
+	switch codec {
+	case models.TranscodeVideoCodecHevc:
+		return ffmpeg.VideoCodecLibX265, []string{ffmpeg.Hevc, ffmpeg.H265}
+	case models.TranscodeVideoCodecAv1:
+		return ffmpeg.VideoCodecLibSVTAV1, []string{ffmpeg.Av1}
+	default:
+		return ffmpeg.VideoCodecLibX264, []string{ffmpeg.H264}
+	}
+}
+
 // return true if transcode is needed
 // used only when counting files to generate, doesn't affect the actual transcode generation
 // if container is missing from DB it is treated as non supported in order not to delay the user
@@ -0,0 +1,143 @@
+package manager
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"time"
+
+	"github.com/stashapp/stash/internal/manager/config"
+	"github.com/stashapp/stash/pkg/file"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+)
+
+// NewFileDeleter returns a file deleter that moves deleted library files to
+// the trash directory of their library, if one is configured.
+func (s *Manager) NewFileDeleter() *file.Deleter {
+	stashPaths := s.Config.GetStashPaths()
+
+	ret := file.NewDeleter()
+	ret.Trash = &file.Trash{
+		TrashDirFn:   stashPaths.GetTrashPath,
+		LibraryDirFn: libraryDirFn(stashPaths),
+		Creator:      s.Repository.TrashedFile,
+	}
+
+	return ret
+}
+
+// libraryDirFn returns a function that returns the library directory
+// containing a path, or an empty string if the path is not in a library.
+func libraryDirFn(stashPaths config.StashConfigs) func(path string) string {
+	return func(path string) string {
+		if stash := stashPaths.GetStashFromPath(path); stash != nil {
+			return stash.Path
+		}
+		return ""
+	}
+}
+
+// RestoreTrashedFiles moves the provided trashed files back to their
+// original paths and queues a scan of the restored files. Returns the ID of
+// the scan job.
+func (s *Manager) RestoreTrashedFiles(ctx context.Context, ids []int) (int, error) {
+	// an empty scan path list would scan the entire library
+	if len(ids) == 0 {
+		return 0, errors.New("no trashed files provided")
+	}
+
+	renamer := file.NewRenamerRemover()
+	var restored []*models.TrashedFile
+
+	r := s.Repository
+	if err := r.WithTxn(ctx, func(ctx context.Context) error {
+		qb := r.TrashedFile
+		trashed, err := qb.FindMany(ctx, ids)
+		if err != nil {
+			return err
+		}
+
+		for _, f := range trashed {
+			if err := qb.Destroy(ctx, f.ID); err != nil {
+				return err
+			}
+
+			if err := file.RestoreTrashed(renamer, f); err != nil {
+				return err
+			}
+			restored = append(restored, f)
+		}
+
+		return nil
+	}); err != nil {
+		// move the already restored files back to the trash
+		for _, f := range restored {
+			if err := file.MoveToTrash(renamer, f); err != nil {
+				logger.Warn(err)
+			}
+		}
+
+		return 0, err
+	}
+
+	paths := make([]string, len(restored))
+	for i, f := range restored {
+		paths[i] = f.OriginalPath
+	}
+
+	input := ScanMetadataInput{
+		Paths: paths,
+	}
+	if opts := s.Config.GetDefaultScanSettings(); opts != nil {
+		input.ScanMetadataOptions = *opts
+	}
+
+	return s.Scan(ctx, input)
+}
+
+// PurgeTrash permanently deletes trashed files that are older than the
+// configured retention period. Has no effect if the retention period is
+// zero or less.
+func (s *Manager) PurgeTrash(ctx context.Context) {
+	retentionDays := s.Config.GetTrashRetentionDays()
+	if retentionDays <= 0 {
+		return
+	}
+
+	cutoff := time.Now().AddDate(0, 0, -retentionDays)
+
+	var toPurge []*models.TrashedFile
+	r := s.Repository
+	if err := r.WithReadTxn(ctx, func(ctx context.Context) error {
+		var err error
+		toPurge, err = r.TrashedFile.FindTrashedBefore(ctx, cutoff)
+		return err
+	}); err != nil {
+		logger.Errorf("Error finding expired trashed files: %v", err)
+		return
+	}
+
+	purged := 0
+	for _, f := range toPurge {
+		if err := os.Remove(f.TrashPath); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			logger.Warnf("Error deleting trashed file %q: %v", f.TrashPath, err)
+			continue
+		}
+
+		if err := r.WithTxn(ctx, func(ctx context.Context) error {
+			return r.TrashedFile.Destroy(ctx, f.ID)
+		}); err != nil {
+			logger.Errorf("Error removing trashed file %q: %v", f.TrashPath, err)
+			continue
+		}
+
+		logger.Debugf("Purged trashed file %q", f.OriginalPath)
+		purged++
+	}
+
+	if purged > 0 {
+		logger.Infof("Purged %d expired trashed files", purged)
+	}
+}
@@ -0,0 +1,307 @@
+package manager
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/stashapp/stash/internal/manager/config"
+	"github.com/stashapp/stash/pkg/fsutil"
+	"github.com/stashapp/stash/pkg/logger"
+)
+
+const (
+	// watchDebounceDelay is the time to wait after the last filesystem event
+	// before queuing a scan of the changed paths.
+	watchDebounceDelay = 5 * time.Second
+
+	// watchMaxDelay is the maximum time to wait after the first filesystem
+	// event before queuing a scan, regardless of further events.
+	watchMaxDelay = time.Minute
+)
+
+// libraryWatcher watches the library paths for changes and queues scan and
+// clean jobs for the changed paths.
+type libraryWatcher struct {
+	manager *Manager
+	watcher *fsnotify.Watcher
+
+	stashPaths        config.StashConfigs
+	generatedPath     string
+	videoExcludeRegex []*regexp.Regexp
+	imageExcludeRegex []*regexp.Regexp
+
+	// paths that have changed since the last flush.
+	// Only accessed from the run goroutine.
+	pending map[string]struct{}
+
+	done chan struct{}
+}
+
+func newLibraryWatcher(m *Manager) (*libraryWatcher, error) {
+	w, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+
+	c := m.Config
+
+	return &libraryWatcher{
+		manager:           m,
+		watcher:           w,
+		stashPaths:        c.GetStashPaths(),
+		generatedPath:     c.GetGeneratedPath(),
+		videoExcludeRegex: generateRegexps(c.GetExcludes()),
+		imageExcludeRegex: generateRegexps(c.GetImageExcludes()),
+		pending:           make(map[string]struct{}),
+		done:              make(chan struct{}),
+	}, nil
+}
+
+func (w *libraryWatcher) start() {
+	for _, s := range w.stashPaths {
+		w.addRecursive(s.Path)
+	}
+
+	go w.run()
+}
+
+func (w *libraryWatcher) stop() {
+	close(w.done)
+	if err := w.watcher.Close(); err != nil {
+		logger.Warnf("error closing library watcher: %v", err)
+	}
+}
+
+// isExcluded returns true if changes to the provided path should be ignored.
+func (w *libraryWatcher) isExcluded(path string) bool {
+	if fsutil.IsPathInDir(w.generatedPath, path) {
+		return true
+	}
+
+	if w.stashPaths.IsTrashPath(path) {
+		return true
+	}
+
+	s := w.stashPaths.GetStashFromDirPath(path)
+	if s == nil {
+		return true
+	}
+
+	// ignore the path if it matches both exclusion patterns
+	// add a trailing separator so that directories correctly match against patterns like path/.*
+	pathExcludeTest := path + string(filepath.Separator)
+	excludeVideo := s.ExcludeVideo || matchFileRegex(path, w.videoExcludeRegex) || matchFileRegex(pathExcludeTest, w.videoExcludeRegex)
+	excludeImage := s.ExcludeImage || matchFileRegex(path, w.imageExcludeRegex) || matchFileRegex(pathExcludeTest, w.imageExcludeRegex)
+
+	return excludeVideo && excludeImage
+}
+
+// addRecursive adds watches for the provided directory and all of its
+// subdirectories.
+func (w *libraryWatcher) addRecursive(root string) {
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			logger.Warnf("error walking %q for library watcher: %v", path, err)
+			return nil
+		}
+
+		if !d.IsDir() {
+			return nil
+		}
+
+		if w.isExcluded(path) {
+			return fs.SkipDir
+		}
+
+		if err := w.watcher.Add(path); err != nil {
+			logger.Warnf("error watching %q: %v", path, err)
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		logger.Warnf("error adding library watches for %q: %v", root, err)
+	}
+}
+
+func (w *libraryWatcher) run() {
+	var (
+		timer      *time.Timer
+		timerC     <-chan time.Time
+		firstEvent time.Time
+	)
+
+	for {
+		select {
+		case <-w.done:
+			if timer != nil {
+				timer.Stop()
+			}
+			return
+		case event, ok := <-w.watcher.Events:
+			if !ok {
+				return
+			}
+
+			if !w.handleEvent(event) {
+				continue
+			}
+
+			// debounce, but don't delay indefinitely if changes are continuous
+			now := time.Now()
+			if timerC == nil {
+				firstEvent = now
+			}
+
+			delay := watchDebounceDelay
+			if remaining := firstEvent.Add(watchMaxDelay).Sub(now); remaining < delay {
+				delay = remaining
+			}
+
+			if timer != nil {
+				timer.Stop()
+			}
+			timer = time.NewTimer(delay)
+			timerC = timer.C
+		case err, ok := <-w.watcher.Errors:
+			if !ok {
+				return
+			}
+			logger.Warnf("library watcher error: %v", err)
+		case <-timerC:
+			timer = nil
+			timerC = nil
+			w.flush()
+		}
+	}
+}
+
+// handleEvent records the path of the provided event as changed.
+// Returns false if the event is ignored.
+func (w *libraryWatcher) handleEvent(event fsnotify.Event) bool {
+	// permission changes don't affect the library
+	if event.Op == fsnotify.Chmod {
+		return false
+	}
+
+	path := event.Name
+	if w.isExcluded(path) {
+		return false
+	}
+
+	if event.Has(fsnotify.Create) {
+		// watch new directories
+		if info, err := os.Stat(path); err == nil && info.IsDir() {
+			w.addRecursive(path)
+		}
+	}
+
+	logger.Tracef("library watcher: %s", event)
+	w.pending[path] = struct{}{}
+	return true
+}
+
+// flush queues scan and clean jobs for the pending changed paths.
+func (w *libraryWatcher) flush() {
+	var scanPaths []string
+	var cleanPaths []string
+
+	for path := range w.pending {
+		_, err := os.Stat(path)
+		switch {
+		case err == nil:
+			scanPaths = append(scanPaths, path)
+		case errors.Is(err, fs.ErrNotExist):
+			// the removed path may be a file or a folder, so clean the
+			// containing folder
+			cleanPaths = append(cleanPaths, filepath.Dir(path))
+		default:
+			logger.Warnf("error checking changed path %q: %v", path, err)
+		}
+	}
+
+	w.pending = make(map[string]struct{})
+
+	scanPaths = topLevelPaths(scanPaths)
+	cleanPaths = topLevelPaths(cleanPaths)
+
+	mgr := w.manager
+	ctx := mgr.systemContext(context.Background())
+
+	// scan must be queued before clean so that moved files and folders are
+	// detected by the scanner before the old entries are removed
+	if len(scanPaths) > 0 {
+		logger.Infof("Library watcher: scanning %d changed paths", len(scanPaths))
+
+		input := ScanMetadataInput{
+			Paths: scanPaths,
+		}
+		if opts := mgr.Config.GetDefaultScanSettings(); opts != nil {
+			input.ScanMetadataOptions = *opts
+		}
+		// only changed files need to be processed
+		input.Rescan = false
+
+		if _, err := mgr.Scan(ctx, input); err != nil {
+			logger.Errorf("Library watcher: error queuing scan: %v", err)
+		}
+	}
+
+	if len(cleanPaths) > 0 {
+		logger.Infof("Library watcher: cleaning %d changed paths", len(cleanPaths))
+
+		mgr.Clean(ctx, CleanMetadataInput{
+			Paths: cleanPaths,
+		})
+	}
+}
+
+// topLevelPaths returns the provided paths, excluding any paths that are
+// contained within another path in the list.
+func topLevelPaths(paths []string) []string {
+	sort.Strings(paths)
+
+	var ret []string
+	for _, p := range paths {
+		if fsutil.IsPathInDirs(ret, p) {
+			continue
+		}
+		ret = append(ret, p)
+	}
+
+	return ret
+}
+
+// RefreshLibraryWatcher starts, stops or restarts the library watcher
+// as needed. Call this when the library or exclusion configuration changes.
+func (s *Manager) RefreshLibraryWatcher() {
+	s.watcherMutex.Lock()
+	defer s.watcherMutex.Unlock()
+
+	if s.libraryWatcher != nil {
+		s.libraryWatcher.stop()
+		s.libraryWatcher = nil
+	}
+
+	if !s.Config.GetWatchLibrary() {
+		return
+	}
+
+	w, err := newLibraryWatcher(s)
+	if err != nil {
+		logger.Errorf("error starting library watcher: %v", err)
+		return
+	}
+
+	logger.Info("Watching library paths for changes")
+	w.start()
+	s.libraryWatcher = w
+}
@@ -0,0 +1,50 @@
+package manager
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestTopLevelPaths(t *testing.T) {
+	p := filepath.Join
+
+	tests := []struct {
+		name  string
+		paths []string
+		want  []string
+	}{
+		{
+			"empty",
+			nil,
+			nil,
+		},
+		{
+			"unrelated",
+			[]string{p("b", "c"), p("a")},
+			[]string{p("a"), p("b", "c")},
+		},
+		{
+			"nested",
+			[]string{p("a", "b", "c.mp4"), p("a", "b"), p("a", "b", "d", "e.jpg")},
+			[]string{p("a", "b")},
+		},
+		{
+			"common prefix",
+			[]string{p("a", "b c", "d.mp4"), p("a", "b"), p("a", "b", "x"), p("a", "b c")},
+			[]string{p("a", "b"), p("a", "b c")},
+		},
+		{
+			"duplicates",
+			[]string{p("a", "b"), p("a", "b")},
+			[]string{p("a", "b")},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := topLevelPaths(tt.paths); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("topLevelPaths() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,215 @@
+package manager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/stashapp/stash/internal/manager/config"
+	"github.com/stashapp/stash/pkg/job"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/plugin"
+	"github.com/stashapp/stash/pkg/plugin/common"
+	"github.com/stashapp/stash/pkg/plugin/hook"
+	"github.com/stashapp/stash/pkg/webhook"
+)
+
+var ErrWebhookNotFound = errors.New("webhook not found")
+
+// JobFinishedPayload is the data sent to webhooks when a job is finished.
+type JobFinishedPayload struct {
+	ID          int        `json:"id"`
+	Description string     `json:"description"`
+	Status      job.Status `json:"status"`
+	StartTime   *time.Time `json:"start_time,omitempty"`
+	EndTime     *time.Time `json:"end_time,omitempty"`
+	Error       *string    `json:"error,omitempty"`
+}
+
+// ScanCompletePayload is the data sent to webhooks when a scan completes.
+type ScanCompletePayload struct {
+	Paths []string `json:"paths"`
+	// Duration of the scan in seconds
+	Duration float64 `json:"duration"`
+}
+
+// initWebhooks sends post hook and job events to the webhook dispatcher.
+func initWebhooks(dispatcher *webhook.Dispatcher, pluginCache *plugin.Cache, jobManager *job.Manager) {
+	pluginCache.RegisterPostHookListener(func(ctx context.Context, id int, hookType hook.TriggerEnum, input interface{}, inputFields []string) {
+		dispatcher.Notify(webhook.Event(hookType), common.HookContext{
+			ID:          id,
+			Type:        hookType.String(),
+			Input:       input,
+			InputFields: inputFields,
+		})
+	})
+
+	c := jobManager.Subscribe(context.Background())
+	go func() {
+		for j := range c.RemovedJob {
+			dispatcher.Notify(webhook.EventJobFinished, JobFinishedPayload{
+				ID:          j.ID,
+				Description: j.Description,
+				Status:      j.Status,
+				StartTime:   j.StartTime,
+				EndTime:     j.EndTime,
+				Error:       j.Error,
+			})
+		}
+	}()
+}
+
+// webhookLogSize is the number of deliveries kept in the webhook delivery
+// log.
+const webhookLogSize = 1000
+
+// webhookDeliveryStore persists webhook deliveries in the database.
+type webhookDeliveryStore struct {
+	repository models.Repository
+}
+
+func (s *webhookDeliveryStore) Create(ctx context.Context, d *models.WebhookDelivery) error {
+	r := s.repository
+	return r.WithTxn(ctx, func(ctx context.Context) error {
+		if err := r.WebhookLog.Create(ctx, d); err != nil {
+			return err
+		}
+
+		return r.WebhookLog.Prune(ctx, webhookLogSize)
+	})
+}
+
+func (s *webhookDeliveryStore) Update(ctx context.Context, d *models.WebhookDelivery) error {
+	r := s.repository
+	return r.WithTxn(ctx, func(ctx context.Context) error {
+		return r.WebhookLog.Update(ctx, d)
+	})
+}
+
+func (s *webhookDeliveryStore) FindRecent(ctx context.Context, webhookID *int, limit int) (ret []*models.WebhookDelivery, err error) {
+	r := s.repository
+	err = r.WithReadTxn(ctx, func(ctx context.Context) error {
+		ret, err = r.WebhookLog.FindRecent(ctx, webhookID, limit)
+		return err
+	})
+	return
+}
+
+// initWebhookLog fails the deliveries that were pending when the
+// application was last stopped, and logs deliveries in the database from
+// then on.
+func (s *Manager) initWebhookLog(ctx context.Context) {
+	r := s.Repository
+	if err := r.WithTxn(ctx, func(ctx context.Context) error {
+		return r.WebhookLog.FailPending(ctx, "interrupted by restart", time.Now())
+	}); err != nil {
+		logger.Errorf("Error updating pending webhook deliveries: %v", err)
+	}
+
+	s.WebhookDispatcher.SetStore(&webhookDeliveryStore{repository: r})
+}
+
+// CreateWebhook validates and adds a new webhook to the configuration,
+// assigning it a new ID.
+func (s *Manager) CreateWebhook(w webhook.Webhook) (*webhook.Webhook, error) {
+	if err := w.Validate(); err != nil {
+		return nil, err
+	}
+
+	s.webhookMutex.Lock()
+	defer s.webhookMutex.Unlock()
+
+	webhooks := s.Config.GetWebhooks()
+
+	maxID := 0
+	for _, existing := range webhooks {
+		if existing.ID > maxID {
+			maxID = existing.ID
+		}
+	}
+
+	w.ID = maxID + 1
+	webhooks = append(webhooks, &w)
+
+	if err := s.saveWebhooks(webhooks); err != nil {
+		return nil, err
+	}
+
+	return &w, nil
+}
+
+// UpdateWebhook applies update to a copy of the webhook with the provided
+// ID, then validates it and replaces the webhook in the configuration.
+// Returns ErrWebhookNotFound if no webhook exists with the provided ID.
+func (s *Manager) UpdateWebhook(id int, update func(w *webhook.Webhook)) (*webhook.Webhook, error) {
+	s.webhookMutex.Lock()
+	defer s.webhookMutex.Unlock()
+
+	webhooks := s.Config.GetWebhooks()
+
+	var w *webhook.Webhook
+	for i, existing := range webhooks {
+		if existing.ID == id {
+			updated := *existing
+			w = &updated
+			webhooks[i] = w
+			break
+		}
+	}
+
+	if w == nil {
+		return nil, fmt.Errorf("%w: %d", ErrWebhookNotFound, id)
+	}
+
+	update(w)
+	w.ID = id
+
+	if err := w.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := s.saveWebhooks(webhooks); err != nil {
+		return nil, err
+	}
+
+	return w, nil
+}
+
+// DestroyWebhook removes the webhook with the provided ID from the
+// configuration. Returns ErrWebhookNotFound if no webhook exists with the
+// provided ID.
+func (s *Manager) DestroyWebhook(id int) error {
+	s.webhookMutex.Lock()
+	defer s.webhookMutex.Unlock()
+
+	webhooks := s.Config.GetWebhooks()
+
+	var newWebhooks []*webhook.Webhook
+	for _, existing := range webhooks {
+		if existing.ID != id {
+			newWebhooks = append(newWebhooks, existing)
+		}
+	}
+
+	if len(newWebhooks) == len(webhooks) {
+		return fmt.Errorf("%w: %d", ErrWebhookNotFound, id)
+	}
+
+	return s.saveWebhooks(newWebhooks)
+}
+
+// saveWebhooks writes the webhooks to the configuration and reloads them in
+// the dispatcher. Must be called with webhookMutex held.
+func (s *Manager) saveWebhooks(webhooks []*webhook.Webhook) error {
+	cfg := s.Config
+	cfg.SetInterface(config.Webhooks, webhooks)
+
+	if err := cfg.Write(); err != nil {
+		return err
+	}
+
+	s.WebhookDispatcher.Refresh()
+	return nil
+}
@@ -0,0 +1,222 @@
+// Package cron provides parsing and evaluation of cron-style schedule expressions.
+package cron
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrInvalidExpression = errors.New("invalid cron expression")
+
+// macros are the supported shorthand expressions.
+var macros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+type fieldBounds struct {
+	name string
+	min  int
+	max  int
+}
+
+var (
+	minuteBounds     = fieldBounds{"minute", 0, 59}
+	hourBounds       = fieldBounds{"hour", 0, 23}
+	dayOfMonthBounds = fieldBounds{"day of month", 1, 31}
+	monthBounds      = fieldBounds{"month", 1, 12}
+	dayOfWeekBounds  = fieldBounds{"day of week", 0, 7}
+)
+
+// Schedule is a parsed cron expression. Each field is a bit set of the
+// values that match.
+type Schedule struct {
+	minute     uint64
+	hour       uint64
+	dayOfMonth uint64
+	month      uint64
+	dayOfWeek  uint64
+
+	// true if the day of month or day of week fields were not wildcards.
+	// Standard cron semantics match either day field when both are restricted.
+	domRestricted bool
+	dowRestricted bool
+}
+
+// Parse parses a standard five-field cron expression
+// (minute, hour, day of month, month, day of week).
+// The @yearly, @monthly, @weekly, @daily and @hourly macros are also supported.
+func Parse(expr string) (*Schedule, error) {
+	expr = strings.TrimSpace(expr)
+	if m, ok := macros[strings.ToLower(expr)]; ok {
+		expr = m
+	}
+
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("%w: expected 5 fields, found %d", ErrInvalidExpression, len(fields))
+	}
+
+	var err error
+	ret := &Schedule{}
+
+	if ret.minute, err = parseField(fields[0], minuteBounds); err != nil {
+		return nil, err
+	}
+	if ret.hour, err = parseField(fields[1], hourBounds); err != nil {
+		return nil, err
+	}
+	if ret.dayOfMonth, err = parseField(fields[2], dayOfMonthBounds); err != nil {
+		return nil, err
+	}
+	if ret.month, err = parseField(fields[3], monthBounds); err != nil {
+		return nil, err
+	}
+	if ret.dayOfWeek, err = parseField(fields[4], dayOfWeekBounds); err != nil {
+		return nil, err
+	}
+
+	// sunday may be specified as 0 or 7
+	if ret.dayOfWeek&(1<<7) != 0 {
+		ret.dayOfWeek |= 1
+	}
+
+	ret.domRestricted = fields[2] != "*"
+	ret.dowRestricted = fields[4] != "*"
+
+	return ret, nil
+}
+
+func parseField(field string, bounds fieldBounds) (uint64, error) {
+	var ret uint64
+	for _, part := range strings.Split(field, ",") {
+		bits, err := parseRange(part, bounds)
+		if err != nil {
+			return 0, err
+		}
+		ret |= bits
+	}
+
+	return ret, nil
+}
+
+// parseRange parses a single comma-separated element of a field.
+// Supported forms are *, n, n-m, and any of these followed by /step.
+func parseRange(part string, bounds fieldBounds) (uint64, error) {
+	invalid := func() error {
+		return fmt.Errorf("%w: invalid %s value %q", ErrInvalidExpression, bounds.name, part)
+	}
+
+	rangePart, stepPart, hasStep := strings.Cut(part, "/")
+
+	start, end := bounds.min, bounds.max
+	step := 1
+
+	switch {
+	case rangePart == "*":
+		// use full bounds
+	case strings.Contains(rangePart, "-"):
+		lo, hi, _ := strings.Cut(rangePart, "-")
+		var err error
+		if start, err = strconv.Atoi(lo); err != nil {
+			return 0, invalid()
+		}
+		if end, err = strconv.Atoi(hi); err != nil {
+			return 0, invalid()
+		}
+	default:
+		v, err := strconv.Atoi(rangePart)
+		if err != nil {
+			return 0, invalid()
+		}
+		start = v
+		// n/step means starting at n through to the maximum
+		if hasStep {
+			end = bounds.max
+		} else {
+			end = v
+		}
+	}
+
+	if hasStep {
+		var err error
+		step, err = strconv.Atoi(stepPart)
+		if err != nil || step <= 0 {
+			return 0, invalid()
+		}
+	}
+
+	if start < bounds.min || end > bounds.max || start > end {
+		return 0, invalid()
+	}
+
+	var ret uint64
+	for i := start; i <= end; i += step {
+		ret |= 1 << uint(i)
+	}
+
+	return ret, nil
+}
+
+// maxSearchYears is the number of years searched for a matching time before
+// giving up. Expressions such as "0 0 31 2 *" never match.
+const maxSearchYears = 5
+
+// Next returns the first time after t that matches the schedule.
+// The returned time has second and sub-second precision truncated.
+// Returns the zero time if no matching time could be found.
+func (s *Schedule) Next(t time.Time) time.Time {
+	loc := t.Location()
+
+	// start at the next whole minute
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(maxSearchYears, 0, 0)
+
+	for t.Before(limit) {
+		if s.month&(1<<uint(t.Month())) == 0 {
+			// advance to the start of the next month
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+			continue
+		}
+
+		if !s.dayMatches(t) {
+			// advance to the start of the next day
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+			continue
+		}
+
+		if s.hour&(1<<uint(t.Hour())) == 0 {
+			// advance to the start of the next hour
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+			continue
+		}
+
+		if s.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+
+		return t
+	}
+
+	return time.Time{}
+}
+
+func (s *Schedule) dayMatches(t time.Time) bool {
+	domMatch := s.dayOfMonth&(1<<uint(t.Day())) != 0
+	dowMatch := s.dayOfWeek&(1<<uint(t.Weekday())) != 0
+
+	if s.domRestricted && s.dowRestricted {
+		return domMatch || dowMatch
+	}
+
+	return domMatch && dowMatch
+}
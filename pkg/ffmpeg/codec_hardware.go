@@ -27,6 +27,13 @@ var (
 	VideoCodecIVP9  = makeVideoCodec("VP9 Intel Quick Sync Video (QSV)", "vp9_qsv")
 	VideoCodecVVP9  = makeVideoCodec("VP9 VAAPI", "vp9_vaapi")
 	VideoCodecVVPX  = makeVideoCodec("VP8 VAAPI", "vp8_vaapi")
+	VideoCodecN265  = makeVideoCodec("HEVC NVENC", "hevc_nvenc")
+	VideoCodecI265  = makeVideoCodec("HEVC Intel Quick Sync Video (QSV)", "hevc_qsv")
+	VideoCodecV265  = makeVideoCodec("HEVC VAAPI", "hevc_vaapi")
+	VideoCodecM265  = makeVideoCodec("HEVC VideoToolbox", "hevc_videotoolbox")
+	VideoCodecNAV1  = makeVideoCodec("AV1 NVENC", "av1_nvenc")
+	VideoCodecIAV1  = makeVideoCodec("AV1 Intel Quick Sync Video (QSV)", "av1_qsv")
+	VideoCodecVAV1  = makeVideoCodec("AV1 VAAPI", "av1_vaapi")
 )
 
 const minHeight int = 480
@@ -46,6 +53,13 @@ func (f *FFMpeg) InitHWSupport(ctx context.Context) {
 		VideoCodecIVP9,
 		VideoCodecVVP9,
 		VideoCodecM264,
+		VideoCodecN265,
+		VideoCodecI265,
+		VideoCodecV265,
+		VideoCodecM265,
+		VideoCodecNAV1,
+		VideoCodecIAV1,
+		VideoCodecVAV1,
 	} {
 		var args Args
 		args = append(args, "-hide_banner")
@@ -134,7 +148,9 @@ func (f *FFMpeg) hwCanFullHWTranscode(ctx context.Context, codec VideoCodec, vf
 func (f *FFMpeg) hwDeviceInit(args Args, toCodec VideoCodec, fullhw bool) Args {
 	switch toCodec {
 	case VideoCodecN264,
-		VideoCodecN264H:
+		VideoCodecN264H,
+		VideoCodecN265,
+		VideoCodecNAV1:
 		args = append(args, "-hwaccel_device")
 		args = append(args, "0")
 		if fullhw {
@@ -146,7 +162,9 @@ func (f *FFMpeg) hwDeviceInit(args Args, toCodec VideoCodec, fullhw bool) Args {
 			args = append(args, "cuda")
 		}
 	case VideoCodecV264,
-		VideoCodecVVP9:
+		VideoCodecVVP9,
+		VideoCodecV265,
+		VideoCodecVAV1:
 		args = append(args, "-vaapi_device")
 		args = append(args, "/dev/dri/renderD128")
 		if fullhw {
@@ -157,7 +175,9 @@ func (f *FFMpeg) hwDeviceInit(args Args, toCodec VideoCodec, fullhw bool) Args {
 		}
 	case VideoCodecI264,
 		VideoCodecI264C,
-		VideoCodecIVP9:
+		VideoCodecIVP9,
+		VideoCodecI265,
+		VideoCodecIAV1:
 		if fullhw {
 			args = append(args, "-hwaccel")
 			args = append(args, "qsv")
@@ -169,7 +189,8 @@ func (f *FFMpeg) hwDeviceInit(args Args, toCodec VideoCodec, fullhw bool) Args {
 			args = append(args, "-filter_hw_device")
 			args = append(args, "hw")
 		}
-	case VideoCodecM264:
+	case VideoCodecM264,
+		VideoCodecM265:
 		if fullhw {
 			args = append(args, "-hwaccel")
 			args = append(args, "videotoolbox")
@@ -189,24 +210,28 @@ func (f *FFMpeg) hwFilterInit(toCodec VideoCodec, fullhw bool) VideoFilter {
 	var videoFilter VideoFilter
 	switch toCodec {
 	case VideoCodecV264,
-		VideoCodecVVP9:
+		VideoCodecVVP9,
+		VideoCodecV265,
+		VideoCodecVAV1:
 		if !fullhw {
 			videoFilter = videoFilter.Append("format=nv12")
 			videoFilter = videoFilter.Append("hwupload")
 		}
-	case VideoCodecN264, VideoCodecN264H:
+	case VideoCodecN264, VideoCodecN264H, VideoCodecN265, VideoCodecNAV1:
 		if !fullhw {
 			videoFilter = videoFilter.Append("format=nv12")
 			videoFilter = videoFilter.Append("hwupload_cuda")
 		}
 	case VideoCodecI264,
 		VideoCodecI264C,
-		VideoCodecIVP9:
+		VideoCodecIVP9,
+		VideoCodecI265,
+		VideoCodecIAV1:
 		if !fullhw {
 			videoFilter = videoFilter.Append("hwupload=extra_hw_frames=64")
 			videoFilter = videoFilter.Append("format=qsv")
 		}
-	case VideoCodecM264:
+	case VideoCodecM264, VideoCodecM265:
 		if !fullhw {
 			videoFilter = videoFilter.Append("format=nv12")
 			videoFilter = videoFilter.Append("hwupload")
@@ -276,15 +301,15 @@ func (f *FFMpeg) hwCodecFilter(args VideoFilter, codec VideoCodec, vf *models.Vi
 // Apply format switching if applicable
 func (f *FFMpeg) hwApplyFullHWFilter(args VideoFilter, codec VideoCodec, fullhw bool) VideoFilter {
 	switch codec {
-	case VideoCodecN264, VideoCodecN264H:
+	case VideoCodecN264, VideoCodecN264H, VideoCodecN265, VideoCodecNAV1:
 		if fullhw && f.version.Gteq(Version{major: 5}) { // Added in FFMpeg 5
 			args = args.Append("scale_cuda=format=yuv420p")
 		}
-	case VideoCodecV264, VideoCodecVVP9:
+	case VideoCodecV264, VideoCodecVVP9, VideoCodecV265, VideoCodecVAV1:
 		if fullhw && f.version.Gteq(Version{major: 3, minor: 1}) { // Added in FFMpeg 3.1
 			args = args.Append("scale_vaapi=format=nv12")
 		}
-	case VideoCodecI264, VideoCodecI264C, VideoCodecIVP9:
+	case VideoCodecI264, VideoCodecI264C, VideoCodecIVP9, VideoCodecI265, VideoCodecIAV1:
 		if fullhw && f.version.Gteq(Version{major: 3, minor: 3}) { // Added in FFMpeg 3.3
 			args = args.Append("scale_qsv=format=nv12")
 		}
@@ -298,31 +323,31 @@ func (f *FFMpeg) hwApplyScaleTemplate(sargs string, codec VideoCodec, match []in
 	var template string
 
 	switch codec {
-	case VideoCodecN264, VideoCodecN264H:
+	case VideoCodecN264, VideoCodecN264H, VideoCodecN265, VideoCodecNAV1:
 		template = "scale_cuda=$value"
 		if fullhw && f.version.Gteq(Version{major: 5}) { // Added in FFMpeg 5
 			template += ":format=yuv420p"
 		}
-	case VideoCodecV264, VideoCodecVVP9:
+	case VideoCodecV264, VideoCodecVVP9, VideoCodecV265, VideoCodecVAV1:
 		template = "scale_vaapi=$value"
 		if fullhw && f.version.Gteq(Version{major: 3, minor: 1}) { // Added in FFMpeg 3.1
 			template += ":format=nv12"
 		}
-	case VideoCodecI264, VideoCodecI264C, VideoCodecIVP9:
+	case VideoCodecI264, VideoCodecI264C, VideoCodecIVP9, VideoCodecI265, VideoCodecIAV1:
 		template = "scale_qsv=$value"
 		if fullhw && f.version.Gteq(Version{major: 3, minor: 3}) { // Added in FFMpeg 3.3
 			template += ":format=nv12"
 		}
-	case VideoCodecM264:
+	case VideoCodecM264, VideoCodecM265:
 		template = "scale_vt=$value"
 	default:
 		return VideoFilter(sargs)
 	}
 
 	// BUG: [scale_qsv]: Size values less than -1 are not acceptable.
-	isIntel := codec == VideoCodecI264 || codec == VideoCodecI264C || codec == VideoCodecIVP9
+	isIntel := codec == VideoCodecI264 || codec == VideoCodecI264C || codec == VideoCodecIVP9 || codec == VideoCodecI265 || codec == VideoCodecIAV1
 	// BUG: scale_vt doesn't call ff_scale_adjust_dimensions, thus cant accept negative size values
-	isApple := codec == VideoCodecM264
+	isApple := codec == VideoCodecM264 || codec == VideoCodecM265
 	return VideoFilter(templateReplaceScale(sargs, template, match, vf, isIntel || isApple))
 }
 
@@ -350,6 +375,39 @@ func (f *FFMpeg) hwMaxResFilter(toCodec VideoCodec, vf *models.VideoFile, reqHei
 	return f.hwCodecFilter(videoFilter, toCodec, vf, fullhw)
 }
 
+// HWTranscodeCodec returns the hardware accelerated encoder producing the
+// same output as the provided software codec, or nil if none is available.
+// Only libx265 and libsvtav1 are supported.
+func (f *FFMpeg) HWTranscodeCodec(codec VideoCodec) *VideoCodec {
+	switch codec {
+	case VideoCodecLibX265:
+		return f.hwCodecHEVCCompatible()
+	case VideoCodecLibSVTAV1:
+		return f.hwCodecAV1Compatible()
+	}
+	return nil
+}
+
+// HWEncodeArgs returns the input and video arguments to encode a video of
+// srcWidth x srcHeight with the hardware codec, scaled to width x height if
+// both are non-zero. Frames are decoded in software and uploaded to the
+// encoder. The video arguments do not include the codec itself.
+func (f *FFMpeg) HWEncodeArgs(codec VideoCodec, srcWidth, srcHeight, width, height int) (inputArgs Args, videoArgs Args) {
+	inputArgs = f.hwDeviceInit(inputArgs, codec, false)
+
+	videoFilter := f.hwFilterInit(codec, false)
+	if width != 0 && height != 0 {
+		videoFilter = videoFilter.ScaleDimensions(width, height)
+	}
+	vf := &models.VideoFile{Width: srcWidth, Height: srcHeight}
+	videoFilter = f.hwCodecFilter(videoFilter, codec, vf, false)
+
+	videoArgs = videoArgs.VideoFilter(videoFilter)
+	videoArgs = append(videoArgs, codecArgs(codec)...)
+
+	return inputArgs, videoArgs
+}
+
 // Return if a hardware accelerated for HLS is available
 func (f *FFMpeg) hwCodecHLSCompatible() *VideoCodec {
 	for _, element := range f.hwCodecSupport {
@@ -393,3 +451,30 @@ func (f *FFMpeg) hwCodecWEBMCompatible() *VideoCodec {
 	}
 	return nil
 }
+
+// Return if a hardware accelerated codec for HEVC is available
+func (f *FFMpeg) hwCodecHEVCCompatible() *VideoCodec {
+	for _, element := range f.hwCodecSupport {
+		switch element {
+		case VideoCodecN265,
+			VideoCodecI265,
+			VideoCodecV265,
+			VideoCodecM265:
+			return &element
+		}
+	}
+	return nil
+}
+
+// Return if a hardware accelerated codec for AV1 is available
+func (f *FFMpeg) hwCodecAV1Compatible() *VideoCodec {
+	for _, element := range f.hwCodecSupport {
+		switch element {
+		case VideoCodecNAV1,
+			VideoCodecIAV1,
+			VideoCodecVAV1:
+			return &element
+		}
+	}
+	return nil
+}
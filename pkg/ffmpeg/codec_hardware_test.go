@@ -0,0 +1,61 @@
+package ffmpeg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFFMpeg_HWTranscodeCodec(t *testing.T) {
+	f := &FFMpeg{hwCodecSupport: []VideoCodec{VideoCodecN264, VideoCodecV265}}
+
+	assert.Equal(t, &VideoCodecV265, f.HWTranscodeCodec(VideoCodecLibX265))
+	assert.Nil(t, f.HWTranscodeCodec(VideoCodecLibSVTAV1))
+	assert.Nil(t, f.HWTranscodeCodec(VideoCodecLibX264))
+}
+
+func TestFFMpeg_HWEncodeArgs(t *testing.T) {
+	f := &FFMpeg{}
+
+	tests := []struct {
+		name          string
+		codec         VideoCodec
+		width         int
+		height        int
+		wantInputArgs Args
+		wantVideoArgs Args
+	}{
+		{
+			"vaapi",
+			VideoCodecV265,
+			0,
+			0,
+			Args{"-vaapi_device", "/dev/dri/renderD128"},
+			Args{"-vf", "format=nv12,hwupload", "-qp", "20", "-tag:v", "hvc1"},
+		},
+		{
+			"vaapi scaled",
+			VideoCodecV265,
+			-2,
+			720,
+			Args{"-vaapi_device", "/dev/dri/renderD128"},
+			Args{"-vf", "format=nv12,hwupload,scale_vaapi=-2:720", "-qp", "20", "-tag:v", "hvc1"},
+		},
+		{
+			"nvenc",
+			VideoCodecNAV1,
+			0,
+			0,
+			Args{"-hwaccel_device", "0"},
+			Args{"-vf", "format=nv12,hwupload_cuda", "-rc", "vbr", "-cq", "30"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inputArgs, videoArgs := f.HWEncodeArgs(tt.codec, 1920, 1080, tt.width, tt.height)
+			assert.Equal(t, tt.wantInputArgs, inputArgs)
+			assert.Equal(t, tt.wantVideoArgs, videoArgs)
+		})
+	}
+}
@@ -113,6 +113,30 @@ type VideoFile struct {
 	FrameCount   int64
 
 	AudioCodec string
+
+	// MediaStreams are the video, audio and subtitle streams of the file.
+	// Cover art is excluded.
+	MediaStreams []MediaStream
+
+	Chapters []Chapter
+}
+
+// MediaStream is a video, audio or subtitle stream of a video file.
+type MediaStream struct {
+	// Index is the index of the stream within the file
+	Index    int
+	Type     string
+	Codec    string
+	Language string
+	Title    string
+	Default  bool
+}
+
+// Chapter is a chapter of a video file. Times are in seconds.
+type Chapter struct {
+	Title string
+	Start float64
+	End   float64
 }
 
 // TranscodeScale calculates the dimension scaling for a transcode, where maxSize is the maximum size of the longest dimension of the input video.
@@ -220,6 +244,7 @@ func (f *FFProbe) NewVideoFile(videoPath string) (*VideoFile, error) {
 		"-print_format", "json",
 		"-show_format",
 		"-show_streams",
+		"-show_chapters",
 		"-show_error",
 	}
 
@@ -343,9 +368,58 @@ func parse(filePath string, probeJSON *FFProbeJSON) (*VideoFile, error) {
 		}
 	}
 
+	result.MediaStreams = parseMediaStreams(probeJSON.Streams)
+	result.Chapters = parseChapters(probeJSON.Chapters)
+
 	return result, nil
 }
 
+func parseMediaStreams(streams []FFProbeStream) []MediaStream {
+	var ret []MediaStream
+	for _, s := range streams {
+		switch s.CodecType {
+		case "video", "audio", "subtitle":
+		default:
+			continue
+		}
+
+		// skip cover art/thumbnails
+		if s.Disposition.AttachedPic != 0 {
+			continue
+		}
+
+		ret = append(ret, MediaStream{
+			Index:    s.Index,
+			Type:     s.CodecType,
+			Codec:    s.CodecName,
+			Language: strings.TrimSpace(s.Tags.Language),
+			Title:    strings.TrimSpace(s.Tags.Title),
+			Default:  s.Disposition.Default == 1,
+		})
+	}
+
+	return ret
+}
+
+func parseChapters(chapters []FFProbeChapter) []Chapter {
+	var ret []Chapter
+	for _, c := range chapters {
+		start, err := strconv.ParseFloat(c.StartTime, 64)
+		if err != nil {
+			continue
+		}
+		end, _ := strconv.ParseFloat(c.EndTime, 64)
+
+		ret = append(ret, Chapter{
+			Title: strings.TrimSpace(c.Tags.Title),
+			Start: start,
+			End:   end,
+		})
+	}
+
+	return ret
+}
+
 func isRotated(s *FFProbeStream) bool {
 	rotate, _ := strconv.ParseInt(s.Tags.Rotate, 10, 64)
 	if rotate != 180 && rotate != 0 {
@@ -0,0 +1,78 @@
+package ffmpeg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseChapters(t *testing.T) {
+	var chapters []FFProbeChapter
+
+	var c FFProbeChapter
+	c.StartTime = "0.000000"
+	c.EndTime = "62.500000"
+	c.Tags.Title = " Intro "
+	chapters = append(chapters, c)
+
+	c = FFProbeChapter{}
+	c.StartTime = "62.500000"
+	c.EndTime = "120.000000"
+	chapters = append(chapters, c)
+
+	// chapters without a valid start time are skipped
+	c = FFProbeChapter{}
+	c.StartTime = "N/A"
+	chapters = append(chapters, c)
+
+	assert.Equal(t, []Chapter{
+		{Title: "Intro", Start: 0, End: 62.5},
+		{Start: 62.5, End: 120},
+	}, parseChapters(chapters))
+}
+
+func TestParseMediaStreams(t *testing.T) {
+	var streams []FFProbeStream
+
+	var s FFProbeStream
+	s.Index = 0
+	s.CodecType = "video"
+	s.CodecName = "h264"
+	s.Disposition.Default = 1
+	streams = append(streams, s)
+
+	s = FFProbeStream{}
+	s.Index = 1
+	s.CodecType = "audio"
+	s.CodecName = "aac"
+	s.Tags.Language = "eng"
+	s.Tags.Title = " Stereo "
+	streams = append(streams, s)
+
+	// data streams are skipped
+	s = FFProbeStream{}
+	s.Index = 2
+	s.CodecType = "data"
+	streams = append(streams, s)
+
+	// cover art is skipped
+	s = FFProbeStream{}
+	s.Index = 3
+	s.CodecType = "video"
+	s.CodecName = "mjpeg"
+	s.Disposition.AttachedPic = 1
+	streams = append(streams, s)
+
+	s = FFProbeStream{}
+	s.Index = 4
+	s.CodecType = "subtitle"
+	s.CodecName = "subrip"
+	s.Tags.Language = "fre"
+	streams = append(streams, s)
+
+	assert.Equal(t, []MediaStream{
+		{Index: 0, Type: "video", Codec: "h264", Default: true},
+		{Index: 1, Type: "audio", Codec: "aac", Language: "eng", Title: "Stereo"},
+		{Index: 4, Type: "subtitle", Codec: "subrip", Language: "fre"},
+	}, parseMediaStreams(streams))
+}
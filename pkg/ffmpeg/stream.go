@@ -31,8 +31,11 @@ type StreamManager struct {
 	context    context.Context
 	cancelFunc context.CancelFunc
 
-	runningStreams map[string]*runningStream
-	streamsMutex   sync.Mutex
+	runningStreams    map[string]*runningStream
+	transcodeSessions map[string]*transcodeSession
+	stoppedSessions   map[sessionKey]time.Time
+	nextSessionID     int
+	streamsMutex      sync.Mutex
 }
 
 type StreamManagerConfig interface {
@@ -40,6 +43,8 @@ type StreamManagerConfig interface {
 	GetLiveTranscodeInputArgs() []string
 	GetLiveTranscodeOutputArgs() []string
 	GetTranscodeHardwareAcceleration() bool
+	GetMaxLiveTranscodes() int
+	GetMaxLiveTranscodesPerClient() int
 }
 
 func NewStreamManager(cacheDir string, encoder *FFMpeg, ffprobe *FFProbe, config StreamManagerConfig, lockManager *fsutil.ReadLockManager) *StreamManager {
@@ -50,14 +55,16 @@ func NewStreamManager(cacheDir string, encoder *FFMpeg, ffprobe *FFProbe, config
 	ctx, cancel := context.WithCancel(context.Background())
 
 	ret := &StreamManager{
-		cacheDir:       cacheDir,
-		encoder:        encoder,
-		ffprobe:        ffprobe,
-		config:         config,
-		lockManager:    lockManager,
-		context:        ctx,
-		cancelFunc:     cancel,
-		runningStreams: make(map[string]*runningStream),
+		cacheDir:          cacheDir,
+		encoder:           encoder,
+		ffprobe:           ffprobe,
+		config:            config,
+		lockManager:       lockManager,
+		context:           ctx,
+		cancelFunc:        cancel,
+		runningStreams:    make(map[string]*runningStream),
+		transcodeSessions: make(map[string]*transcodeSession),
+		stoppedSessions:   make(map[sessionKey]time.Time),
 	}
 
 	go func() {
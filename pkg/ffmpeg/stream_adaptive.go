@@ -0,0 +1,257 @@
+package ffmpeg
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/utils"
+
+	"github.com/zencoder/go-dash/v3/mpd"
+)
+
+// ErrAdaptiveUnsupported is returned when an adaptive manifest is requested
+// for a stream type that does not support it.
+var ErrAdaptiveUnsupported = errors.New("adaptive streaming is not supported for this stream type")
+
+// variantBandwidth is the estimated bandwidth in bits per second of each
+// streaming resolution. It is only used as a hint for the client when
+// choosing a variant.
+var variantBandwidth = map[models.StreamingResolutionEnum]int64{
+	models.StreamingResolutionEnumLow:        400000,
+	models.StreamingResolutionEnumStandard:   1200000,
+	models.StreamingResolutionEnumStandardHd: 2500000,
+	models.StreamingResolutionEnumFullHd:     5000000,
+	models.StreamingResolutionEnumFourK:      15000000,
+}
+
+// adaptiveVariant is a single resolution of an adaptive stream.
+type adaptiveVariant struct {
+	resolution models.StreamingResolutionEnum
+	width      int
+	height     int
+	bandwidth  int64
+}
+
+// adaptiveResolutions returns the streaming resolutions that are available
+// for the provided video, in ascending order. Resolutions above the source
+// resolution or above maxSize are excluded. A maxSize of 0 means no limit.
+func adaptiveResolutions(videoSize int, maxSize int) []models.StreamingResolutionEnum {
+	var ret []models.StreamingResolutionEnum
+	hasSourceSize := false
+
+	for _, res := range models.AllStreamingResolutionEnum {
+		if res == models.StreamingResolutionEnumOriginal {
+			continue
+		}
+
+		size := res.GetMaxResolution()
+		if size > videoSize || (maxSize != 0 && size > maxSize) {
+			continue
+		}
+
+		if size == videoSize {
+			hasSourceSize = true
+		}
+
+		ret = append(ret, res)
+	}
+
+	// only include the original resolution if it is not the same
+	// as one of the fixed resolutions
+	if !hasSourceSize && (maxSize == 0 || videoSize <= maxSize) {
+		ret = append(ret, models.StreamingResolutionEnumOriginal)
+	}
+
+	return ret
+}
+
+// adaptiveVariants returns the variants of an adaptive stream for a video
+// with the provided dimensions and bitrate.
+func (sm *StreamManager) adaptiveVariants(width, height int, bitRate int64) []adaptiveVariant {
+	videoSize := height
+	if width < videoSize {
+		videoSize = width
+	}
+
+	maxSize := sm.config.GetMaxStreamingTranscodeSize().GetMaxResolution()
+
+	var ret []adaptiveVariant
+	for _, res := range adaptiveResolutions(videoSize, maxSize) {
+		w, h := scaleDimensions(width, height, res.GetMaxResolution())
+
+		bandwidth, found := variantBandwidth[res]
+		if !found {
+			// original resolution - use the bitrate of the source if known
+			bandwidth = bitRate
+			if bandwidth <= 0 {
+				bandwidth = variantBandwidth[models.StreamingResolutionEnumFourK]
+			}
+		}
+
+		ret = append(ret, adaptiveVariant{
+			resolution: res,
+			width:      w,
+			height:     h,
+			bandwidth:  bandwidth,
+		})
+	}
+
+	return ret
+}
+
+// adaptiveQuery returns the query parameters shared by all variants
+// of an adaptive stream.
+func adaptiveQuery(r *http.Request, codec StreamCodec, tracks TrackSelection) url.Values {
+	urlQuery := url.Values{}
+
+	if codec != StreamCodecDefault {
+		urlQuery.Set(codecParamKey, string(codec))
+	}
+
+	if tracks.Audio != nil {
+		urlQuery.Set(audioTrackParamKey, strconv.Itoa(*tracks.Audio))
+	}
+
+	// TODO - this needs to be handled outside of this package
+	apikey := r.URL.Query().Get(apiKeyParamKey)
+	if apikey != "" {
+		urlQuery.Set(apiKeyParamKey, apikey)
+	}
+
+	return urlQuery
+}
+
+// serveHLSMasterPlaylist serves a HLS master playlist containing a variant
+// playlist for each available resolution. The URLs for the variant playlists
+// are of the form {r.URL}?resolution={resolution}.
+func serveHLSMasterPlaylist(sm *StreamManager, w http.ResponseWriter, r *http.Request, vf *models.VideoFile, codec StreamCodec, tracks TrackSelection) {
+	if sm.cacheDir == "" {
+		logger.Error("[transcode] cannot live transcode with HLS because cache dir is unset")
+		http.Error(w, "cannot live transcode with HLS because cache dir is unset", http.StatusServiceUnavailable)
+		return
+	}
+
+	baseUrl := *r.URL
+	baseUrl.RawQuery = ""
+	baseURL := baseUrl.String()
+
+	variants := sm.adaptiveVariants(vf.Width, vf.Height, vf.BitRate)
+
+	hasAudio := ProbeAudioCodec(vf.AudioCodec) != MissingUnsupported || tracks.Audio != nil
+
+	var buf bytes.Buffer
+	writeHLSMasterPlaylist(&buf, baseURL, adaptiveQuery(r, codec, tracks), variants, codec, hasAudio)
+
+	w.Header().Set("Content-Type", MimeHLS)
+	utils.ServeStaticContent(w, r, buf.Bytes())
+}
+
+// writeHLSMasterPlaylist writes a HLS master playlist of the variants. The
+// codecs of each variant are listed so that clients can skip variants they
+// cannot decode.
+func writeHLSMasterPlaylist(buf *bytes.Buffer, baseURL string, urlQuery url.Values, variants []adaptiveVariant, codec StreamCodec, hasAudio bool) {
+	codecs := hlsCodecString(codec)
+	if hasAudio {
+		codecs += "," + hlsAudioCodecString
+	}
+
+	fmt.Fprint(buf, "#EXTM3U\n")
+	fmt.Fprintf(buf, "#EXT-X-VERSION:%d\n", hlsVersion(hlsSegmentType(codec)))
+
+	for _, v := range variants {
+		q := url.Values{}
+		for k, vv := range urlQuery {
+			q[k] = vv
+		}
+		q.Set(resolutionParamKey, v.resolution.String())
+
+		fmt.Fprintf(buf, "#EXT-X-STREAM-INF:BANDWIDTH=%d,RESOLUTION=%dx%d,CODECS=\"%s\"\n", v.bandwidth, v.width, v.height, codecs)
+		fmt.Fprintf(buf, "%s?%s\n", baseURL, q.Encode())
+	}
+}
+
+// serveDASHAdaptiveManifest serves a DASH manifest with a video
+// representation for each available resolution. The representation id is
+// the resolution, and is passed as the resolution parameter when requesting
+// video segments.
+func serveDASHAdaptiveManifest(sm *StreamManager, w http.ResponseWriter, r *http.Request, vf *models.VideoFile, codec StreamCodec, tracks TrackSelection) {
+	if sm.cacheDir == "" {
+		logger.Error("[transcode] cannot live transcode with DASH because cache dir is unset")
+		http.Error(w, "cannot live transcode files with DASH because cache dir is unset", http.StatusServiceUnavailable)
+		return
+	}
+
+	probeResult, err := sm.ffprobe.NewVideoFile(vf.Path)
+	if err != nil {
+		logger.Warnf("[transcode] error generating DASH manifest: %v", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	framerate, videoWidth, videoHeight := dashVideoInfo(probeResult, vf)
+
+	urlQuery := adaptiveQuery(r, codec, tracks)
+
+	// the representation id is appended to the shared query parameters
+	videoQueryString := "?" + resolutionParamKey + "=$RepresentationID$"
+	if len(urlQuery) > 0 {
+		videoQueryString += "&" + urlQuery.Encode()
+	}
+
+	// the codec only applies to the video stream
+	urlQuery.Del(codecParamKey)
+	audioQueryString := ""
+	if len(urlQuery) > 0 {
+		audioQueryString = "?" + urlQuery.Encode()
+	}
+
+	mediaDuration := mpd.Duration(time.Duration(probeResult.FileDuration * float64(time.Second)))
+	m := mpd.NewMPD(mpd.DASH_PROFILE_LIVE, mediaDuration.String(), "PT4.0S")
+
+	baseUrl := r.URL.JoinPath("/")
+	baseUrl.RawQuery = ""
+	m.BaseURL = baseUrl.String()
+
+	video, _ := m.AddNewAdaptationSetVideo(MimeWebmVideo, "progressive", true, 1)
+
+	_, _ = video.SetNewSegmentTemplate(2, "init_v.webm"+videoQueryString, "$Number$_v.webm"+videoQueryString, 0, 1)
+	for _, v := range sm.adaptiveVariants(videoWidth, videoHeight, vf.BitRate) {
+		_, _ = video.AddNewRepresentationVideo(v.bandwidth, dashCodecString(codec), v.resolution.String(), framerate, int64(v.width), int64(v.height))
+	}
+
+	if ProbeAudioCodec(vf.AudioCodec) != MissingUnsupported || tracks.Audio != nil {
+		audio, _ := m.AddNewAdaptationSetAudio(MimeWebmAudio, true, 1, audioLanguage(probeResult, tracks))
+		_, _ = audio.SetNewSegmentTemplate(2, "init_a.webm"+audioQueryString, "$Number$_a.webm"+audioQueryString, 0, 1)
+		_, _ = audio.AddNewRepresentationAudio(48000, 96000, "opus", "1")
+	}
+
+	var buf bytes.Buffer
+	_ = m.Write(&buf)
+
+	w.Header().Set("Content-Type", MimeDASH)
+	utils.ServeStaticContent(w, r, buf.Bytes())
+}
+
+// ServeAdaptiveManifest serves a manifest containing every resolution that
+// the video can be streamed at, allowing the client to switch between
+// resolutions during playback.
+func (sm *StreamManager) ServeAdaptiveManifest(w http.ResponseWriter, r *http.Request, streamType *StreamType, vf *models.VideoFile, codec StreamCodec, tracks TrackSelection) {
+	if tracks.Subtitle != nil {
+		http.Error(w, ErrSegmentedSubtitles.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if streamType.ServeAdaptiveManifest == nil {
+		http.Error(w, ErrAdaptiveUnsupported.Error(), http.StatusBadRequest)
+		return
+	}
+
+	streamType.ServeAdaptiveManifest(sm, w, r, vf, codec, tracks)
+}
@@ -0,0 +1,102 @@
+package ffmpeg
+
+import (
+	"bytes"
+	"net/url"
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAdaptiveResolutions(t *testing.T) {
+	const (
+		low        = models.StreamingResolutionEnumLow
+		standard   = models.StreamingResolutionEnumStandard
+		standardHD = models.StreamingResolutionEnumStandardHd
+		fullHD     = models.StreamingResolutionEnumFullHd
+		original   = models.StreamingResolutionEnumOriginal
+	)
+
+	tests := []struct {
+		name      string
+		videoSize int
+		maxSize   int
+		want      []models.StreamingResolutionEnum
+	}{
+		{"source matches resolution", 1080, 0, []models.StreamingResolutionEnum{low, standard, standardHD, fullHD}},
+		{"source between resolutions", 900, 0, []models.StreamingResolutionEnum{low, standard, standardHD, original}},
+		{"limited by max size", 1080, 720, []models.StreamingResolutionEnum{low, standard, standardHD}},
+		{"source below max size", 600, 720, []models.StreamingResolutionEnum{low, standard, original}},
+		{"source below lowest", 200, 0, []models.StreamingResolutionEnum{original}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, adaptiveResolutions(tt.videoSize, tt.maxSize))
+		})
+	}
+}
+
+func TestScaleDimensions(t *testing.T) {
+	tests := []struct {
+		name       string
+		width      int
+		height     int
+		maxSize    int
+		wantWidth  int
+		wantHeight int
+	}{
+		{"no limit", 1920, 1080, 0, 1920, 1080},
+		{"landscape", 1920, 1080, 720, 1280, 720},
+		{"portrait", 1080, 1920, 720, 720, 1280},
+		{"smaller than max", 640, 480, 720, 640, 480},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w, h := scaleDimensions(tt.width, tt.height, tt.maxSize)
+			assert.Equal(t, tt.wantWidth, w)
+			assert.Equal(t, tt.wantHeight, h)
+		})
+	}
+}
+
+func TestWriteHLSMasterPlaylist(t *testing.T) {
+	variants := []adaptiveVariant{
+		{models.StreamingResolutionEnumLow, 426, 240, 400000},
+		{models.StreamingResolutionEnumOriginal, 1280, 720, 3000000},
+	}
+
+	urlQuery := url.Values{}
+	urlQuery.Set(audioTrackParamKey, "2")
+
+	var buf bytes.Buffer
+	writeHLSMasterPlaylist(&buf, "/scene/1/stream.m3u8", urlQuery, variants, StreamCodecDefault, true)
+
+	want := "#EXTM3U\n" +
+		"#EXT-X-VERSION:3\n" +
+		"#EXT-X-STREAM-INF:BANDWIDTH=400000,RESOLUTION=426x240,CODECS=\"avc1.640028,mp4a.40.2\"\n" +
+		"/scene/1/stream.m3u8?audio_track=2&resolution=LOW\n" +
+		"#EXT-X-STREAM-INF:BANDWIDTH=3000000,RESOLUTION=1280x720,CODECS=\"avc1.640028,mp4a.40.2\"\n" +
+		"/scene/1/stream.m3u8?audio_track=2&resolution=ORIGINAL\n"
+
+	assert.Equal(t, want, buf.String())
+}
+
+func TestWriteHLSMasterPlaylistHEVC(t *testing.T) {
+	variants := []adaptiveVariant{
+		{models.StreamingResolutionEnumLow, 426, 240, 400000},
+	}
+
+	urlQuery := url.Values{}
+	urlQuery.Set(codecParamKey, string(StreamCodecHEVC))
+
+	var buf bytes.Buffer
+	writeHLSMasterPlaylist(&buf, "/scene/1/stream.m3u8", urlQuery, variants, StreamCodecHEVC, false)
+
+	want := "#EXTM3U\n" +
+		"#EXT-X-VERSION:7\n" +
+		"#EXT-X-STREAM-INF:BANDWIDTH=400000,RESOLUTION=426x240,CODECS=\"hvc1.1.6.L120.90\"\n" +
+		"/scene/1/stream.m3u8?codec=hevc&resolution=LOW\n"
+
+	assert.Equal(t, want, buf.String())
+}
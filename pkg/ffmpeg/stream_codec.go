@@ -0,0 +1,189 @@
+package ffmpeg
+
+import (
+	"errors"
+	"fmt"
+	"mime"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// StreamCodec is an output video codec that can be requested by a client
+// for a live transcode.
+type StreamCodec string
+
+const (
+	// StreamCodecDefault is the default codec of the stream format.
+	StreamCodecDefault StreamCodec = ""
+	StreamCodecH264    StreamCodec = "h264"
+	StreamCodecHEVC    StreamCodec = "hevc"
+	StreamCodecVP9     StreamCodec = "vp9"
+	StreamCodecAV1     StreamCodec = "av1"
+)
+
+// ErrUnsupportedStreamCodec is returned when the requested codec is not
+// supported by the stream format.
+var ErrUnsupportedStreamCodec = errors.New("video codec is not supported for this stream format")
+
+// codecParamKey is the query parameter used to request a codec.
+const codecParamKey = "codec"
+
+// ParseStreamCodec returns the StreamCodec for a codec name or an RFC 6381
+// codecs string, such as "hevc" or "hvc1.1.6.L93.B0". Returns
+// StreamCodecDefault if the codec is not recognised.
+func ParseStreamCodec(s string) StreamCodec {
+	s = strings.ToLower(strings.TrimSpace(s))
+
+	// the codec name may be followed by profile information
+	name, _, _ := strings.Cut(s, ".")
+
+	switch name {
+	case "h264", "avc", "avc1", "avc3":
+		return StreamCodecH264
+	case "hevc", "h265", "hvc1", "hev1":
+		return StreamCodecHEVC
+	case "vp9", "vp09":
+		return StreamCodecVP9
+	case "av1", "av01":
+		return StreamCodecAV1
+	}
+
+	return StreamCodecDefault
+}
+
+type acceptedCodec struct {
+	codec StreamCodec
+	q     float64
+}
+
+// acceptedCodecs returns the video codecs listed in the codecs parameters
+// of an Accept header, ordered by preference.
+func acceptedCodecs(accept string) []StreamCodec {
+	var accepted []acceptedCodec
+
+	for _, mediaRange := range splitAccept(accept) {
+		_, params, err := mime.ParseMediaType(mediaRange)
+		if err != nil {
+			continue
+		}
+
+		q := 1.0
+		if qStr, ok := params["q"]; ok {
+			if v, err := strconv.ParseFloat(qStr, 64); err == nil {
+				q = v
+			}
+		}
+
+		for _, c := range strings.Split(params["codecs"], ",") {
+			if codec := ParseStreamCodec(c); codec != StreamCodecDefault && q > 0 {
+				accepted = append(accepted, acceptedCodec{codec: codec, q: q})
+			}
+		}
+	}
+
+	sort.SliceStable(accepted, func(i, j int) bool {
+		return accepted[i].q > accepted[j].q
+	})
+
+	ret := make([]StreamCodec, len(accepted))
+	for i, a := range accepted {
+		ret[i] = a.codec
+	}
+	return ret
+}
+
+// splitAccept splits an Accept header into its media ranges. Commas within
+// quoted parameter values are not treated as separators.
+func splitAccept(accept string) []string {
+	var ret []string
+	inQuotes := false
+	start := 0
+	for i, c := range accept {
+		switch c {
+		case '"':
+			inQuotes = !inQuotes
+		case ',':
+			if !inQuotes {
+				ret = append(ret, accept[start:i])
+				start = i + 1
+			}
+		}
+	}
+	ret = append(ret, accept[start:])
+	return ret
+}
+
+// NegotiateStreamCodec returns the codec to use for a stream format that
+// supports the provided codecs, the first of which is the default.
+// An explicitly requested codec takes precedence and must be supported.
+// Otherwise, the most preferred supported codec of the Accept header is
+// used. Returns StreamCodecDefault if the default codec should be used.
+func NegotiateStreamCodec(requested string, accept string, supported []StreamCodec) (StreamCodec, error) {
+	if len(supported) == 0 {
+		return StreamCodecDefault, nil
+	}
+
+	isSupported := func(c StreamCodec) bool {
+		for _, s := range supported {
+			if s == c {
+				return true
+			}
+		}
+		return false
+	}
+
+	normalise := func(c StreamCodec) StreamCodec {
+		if c == supported[0] {
+			return StreamCodecDefault
+		}
+		return c
+	}
+
+	if requested != "" {
+		c := ParseStreamCodec(requested)
+		if !isSupported(c) {
+			return StreamCodecDefault, fmt.Errorf("%w: %s", ErrUnsupportedStreamCodec, requested)
+		}
+		return normalise(c), nil
+	}
+
+	for _, c := range acceptedCodecs(accept) {
+		if isSupported(c) {
+			return normalise(c), nil
+		}
+	}
+
+	return StreamCodecDefault, nil
+}
+
+// selectCodec returns the hardware codec if available and hardware
+// acceleration is enabled, otherwise the software codec.
+func (sm *StreamManager) selectCodec(codec VideoCodec, hwcodec *VideoCodec) VideoCodec {
+	if hwcodec != nil && sm.config.GetTranscodeHardwareAcceleration() {
+		return *hwcodec
+	}
+	return codec
+}
+
+// hevcAV1Codec returns the encoder for HEVC or AV1 output. Returns false
+// for other codecs.
+func (sm *StreamManager) hevcAV1Codec(c StreamCodec) (VideoCodec, bool) {
+	switch c {
+	case StreamCodecHEVC:
+		return sm.selectCodec(VideoCodecLibX265, sm.encoder.hwCodecHEVCCompatible()), true
+	case StreamCodecAV1:
+		return sm.selectCodec(VideoCodecLibSVTAV1, sm.encoder.hwCodecAV1Compatible()), true
+	}
+	return VideoCodec{}, false
+}
+
+// isCodec returns true if the probed video codec matches the stream codec.
+func isCodec(videoCodec string, c StreamCodec) bool {
+	switch c {
+	case StreamCodecHEVC:
+		return videoCodec == Hevc || videoCodec == H265
+	default:
+		return videoCodec == string(c)
+	}
+}
@@ -0,0 +1,63 @@
+package ffmpeg
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseStreamCodec(t *testing.T) {
+	tests := []struct {
+		s    string
+		want StreamCodec
+	}{
+		{"hevc", StreamCodecHEVC},
+		{"HEVC", StreamCodecHEVC},
+		{"hvc1.1.6.L93.B0", StreamCodecHEVC},
+		{"av01.0.08M.08", StreamCodecAV1},
+		{"avc1.64001f", StreamCodecH264},
+		{"vp09.00.40.08", StreamCodecVP9},
+		{"mp4a.40.2", StreamCodecDefault},
+		{"", StreamCodecDefault},
+	}
+	for _, tt := range tests {
+		t.Run(tt.s, func(t *testing.T) {
+			assert.Equal(t, tt.want, ParseStreamCodec(tt.s))
+		})
+	}
+}
+
+func TestNegotiateStreamCodec(t *testing.T) {
+	mp4Codecs := []StreamCodec{StreamCodecH264, StreamCodecHEVC, StreamCodecAV1}
+
+	tests := []struct {
+		name      string
+		requested string
+		accept    string
+		supported []StreamCodec
+		want      StreamCodec
+		wantErr   error
+	}{
+		{"no preference", "", "*/*", mp4Codecs, StreamCodecDefault, nil},
+		{"requested", "av1", "*/*", mp4Codecs, StreamCodecAV1, nil},
+		{"requested default", "h264", "", mp4Codecs, StreamCodecDefault, nil},
+		{"requested unsupported", "vp9", "", mp4Codecs, StreamCodecDefault, ErrUnsupportedStreamCodec},
+		{"requested overrides accept", "hevc", `video/mp4; codecs="av01.0.08M.08"`, mp4Codecs, StreamCodecHEVC, nil},
+		{"accept", "", `video/mp4; codecs="hvc1.1.6.L93.B0, mp4a.40.2", */*`, mp4Codecs, StreamCodecHEVC, nil},
+		{"accept quality", "", `video/mp4; codecs="hvc1"; q=0.5, video/mp4; codecs="av01"`, mp4Codecs, StreamCodecAV1, nil},
+		{"accept unsupported", "", `video/webm; codecs="vp09"`, mp4Codecs, StreamCodecDefault, nil},
+		{"no codecs", "hevc", "", nil, StreamCodecDefault, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NegotiateStreamCodec(tt.requested, tt.accept, tt.supported)
+			if tt.wantErr != nil {
+				assert.True(t, errors.Is(err, tt.wantErr))
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -47,7 +47,14 @@ const (
 	// stopping transcode and deleting cache folder
 	maxIdleTime = 30 * time.Second
 
+	// maximum time a resolution of a stream may go unrequested while
+	// another resolution of the same stream is being requested before
+	// stopping its transcode. This occurs when an adaptive client
+	// switches resolution.
+	maxVariantIdleTime = 2 * segmentLength * time.Second
+
 	resolutionParamKey = "resolution"
+	audioTrackParamKey = "audio_track"
 	// TODO - setting the apikey in here isn't ideal
 	apiKeyParamKey = "apikey"
 )
@@ -55,15 +62,36 @@ const (
 type StreamType struct {
 	Name          string
 	SegmentType   *SegmentType
-	ServeManifest func(sm *StreamManager, w http.ResponseWriter, r *http.Request, vf *models.VideoFile, resolution string)
-	Args          func(codec VideoCodec, segment int, videoFilter VideoFilter, videoOnly bool, outputDir string) Args
+	ServeManifest func(sm *StreamManager, w http.ResponseWriter, r *http.Request, vf *models.VideoFile, resolution string, codec StreamCodec, tracks TrackSelection)
+	// ServeAdaptiveManifest serves a manifest containing all available
+	// resolutions. Nil if the stream type does not support adaptive streaming.
+	ServeAdaptiveManifest func(sm *StreamManager, w http.ResponseWriter, r *http.Request, vf *models.VideoFile, codec StreamCodec, tracks TrackSelection)
+	// Codecs are the video codecs that may be requested. The first is the default.
+	Codecs []StreamCodec
+	// Map returns the arguments to map the selected tracks to the output
+	Map  func(tracks TrackSelection, videoOnly bool) Args
+	Args func(codec VideoCodec, segment int, videoFilter VideoFilter, videoOnly bool, outputDir string) Args
+}
+
+func mapHLSTracks(tracks TrackSelection, videoOnly bool) Args {
+	// subtitles are not supported in segmented streams
+	tracks.Subtitle = nil
+	return tracks.mapArgs(videoOnly, "")
 }
 
+// HLSCodecs are the video codecs that may be requested for a HLS manifest.
+// H.264 segments are MPEG-TS and are served by StreamTypeHLS. HEVC segments
+// are fragmented MP4 and are served by StreamTypeHLSFMP4.
+var HLSCodecs = []StreamCodec{StreamCodecH264, StreamCodecHEVC}
+
 var (
 	StreamTypeHLS = &StreamType{
-		Name:          "hls",
-		SegmentType:   SegmentTypeTS,
-		ServeManifest: serveHLSManifest,
+		Name:                  "hls",
+		SegmentType:           SegmentTypeTS,
+		ServeManifest:         serveHLSManifest,
+		ServeAdaptiveManifest: serveHLSMasterPlaylist,
+		Codecs:                []StreamCodec{StreamCodecH264},
+		Map:                   mapHLSTracks,
 		Args: func(codec VideoCodec, segment int, videoFilter VideoFilter, videoOnly bool, outputDir string) (args Args) {
 			args = CodecInit(codec)
 			args = append(args,
@@ -95,10 +123,62 @@ var (
 			return
 		},
 	}
+	// StreamTypeHLSFMP4 serves the HEVC segments of HLS manifests. The
+	// manifests are served by StreamTypeHLS.
+	StreamTypeHLSFMP4 = &StreamType{
+		Name:        "hls-fmp4",
+		SegmentType: SegmentTypeFMP4,
+		ServeManifest: func(sm *StreamManager, w http.ResponseWriter, r *http.Request, vf *models.VideoFile, resolution string, codec StreamCodec, tracks TrackSelection) {
+			serveHLSManifest(sm, w, r, vf, resolution, StreamCodecHEVC, tracks)
+		},
+		Codecs: []StreamCodec{StreamCodecHEVC},
+		Map:    mapHLSTracks,
+		Args: func(codec VideoCodec, segment int, videoFilter VideoFilter, videoOnly bool, outputDir string) (args Args) {
+			// only generate the actual init segment (init.m4s)
+			// when generating the first segment
+			init := ".init"
+			if segment == 0 {
+				init = "init"
+			}
+
+			args = CodecInit(codec)
+			args = append(args,
+				"-flags", "+cgop",
+				"-force_key_frames", fmt.Sprintf("expr:gte(t,n_forced*%d)", segmentLength),
+				// required for HEVC playback on Apple devices
+				"-tag:v", "hvc1",
+			)
+			args = args.VideoFilter(videoFilter)
+			if videoOnly {
+				args = append(args, "-an")
+			} else {
+				args = append(args,
+					"-c:a", "aac",
+					"-ac", "2",
+				)
+			}
+			args = append(args,
+				"-sn",
+				"-copyts",
+				"-avoid_negative_ts", "disabled",
+				"-f", "hls",
+				"-start_number", fmt.Sprint(segment),
+				"-hls_time", fmt.Sprint(segmentLength),
+				"-hls_flags", "split_by_time",
+				"-hls_segment_type", "fmp4",
+				"-hls_fmp4_init_filename", init+".m4s",
+				"-hls_playlist_type", "vod",
+				"-hls_segment_filename", filepath.Join(outputDir, ".%d.m4s"),
+				filepath.Join(outputDir, "manifest.m3u8"),
+			)
+			return
+		},
+	}
 	StreamTypeHLSCopy = &StreamType{
 		Name:          "hls-copy",
 		SegmentType:   SegmentTypeTS,
 		ServeManifest: serveHLSManifest,
+		Map:           mapHLSTracks,
 		Args: func(codec VideoCodec, segment int, videoFilter VideoFilter, videoOnly bool, outputDir string) (args Args) {
 			args = CodecInit(codec)
 			if videoOnly {
@@ -126,9 +206,14 @@ var (
 		},
 	}
 	StreamTypeDASHVideo = &StreamType{
-		Name:          "dash-v",
-		SegmentType:   SegmentTypeWEBMVideo,
-		ServeManifest: serveDASHManifest,
+		Name:                  "dash-v",
+		SegmentType:           SegmentTypeWEBMVideo,
+		ServeManifest:         serveDASHManifest,
+		ServeAdaptiveManifest: serveDASHAdaptiveManifest,
+		Codecs:                []StreamCodec{StreamCodecVP9, StreamCodecAV1},
+		Map: func(tracks TrackSelection, videoOnly bool) Args {
+			return Args{"-map", "0:v:0"}
+		},
 		Args: func(codec VideoCodec, segment int, videoFilter VideoFilter, videoOnly bool, outputDir string) (args Args) {
 			// only generate the actual init segment (init_v.webm)
 			// when generating the first segment
@@ -146,7 +231,6 @@ var (
 			args = append(args,
 				"-copyts",
 				"-avoid_negative_ts", "disabled",
-				"-map", "0:v:0",
 				"-f", "webm_chunk",
 				"-chunk_start_index", fmt.Sprint(segment),
 				"-header", filepath.Join(outputDir, init+"_v.webm"),
@@ -159,6 +243,9 @@ var (
 		Name:          "dash-a",
 		SegmentType:   SegmentTypeWEBMAudio,
 		ServeManifest: serveDASHManifest,
+		Map: func(tracks TrackSelection, videoOnly bool) Args {
+			return Args{"-map", tracks.audioStream()}
+		},
 		Args: func(codec VideoCodec, segment int, videoFilter VideoFilter, videoOnly bool, outputDir string) (args Args) {
 			// only generate the actual init segment (init_a.webm)
 			// when generating the first segment
@@ -172,7 +259,6 @@ var (
 				"-ar", "48000",
 				"-copyts",
 				"-avoid_negative_ts", "disabled",
-				"-map", "0:a:0",
 				"-f", "webm_chunk",
 				"-chunk_start_index", fmt.Sprint(segment),
 				"-audio_chunk_duration", fmt.Sprint(segmentLength*1000),
@@ -206,6 +292,26 @@ var (
 			return segment, err
 		},
 	}
+	SegmentTypeFMP4 = &SegmentType{
+		Format:   "%d.m4s",
+		MimeType: MimeMp4Video,
+		MakeFilename: func(segment int) string {
+			if segment == -1 {
+				return "init.m4s"
+			}
+			return fmt.Sprintf("%d.m4s", segment)
+		},
+		ParseSegment: func(str string) (int, error) {
+			if str == "init" {
+				return -1, nil
+			}
+			segment, err := strconv.Atoi(str)
+			if err != nil || segment < 0 {
+				err = ErrInvalidSegment
+			}
+			return segment, err
+		},
+	}
 	SegmentTypeWEBMVideo = &SegmentType{
 		Format:   "%d_v.webm",
 		MimeType: MimeWebmVideo,
@@ -252,14 +358,23 @@ var (
 	}
 )
 
-var ErrInvalidSegment = errors.New("invalid segment")
+var (
+	ErrInvalidSegment = errors.New("invalid segment")
+
+	// ErrSegmentedSubtitles is returned when a subtitle track is requested for a HLS or DASH stream.
+	ErrSegmentedSubtitles = errors.New("subtitle tracks are not supported for HLS/DASH streams - use the caption endpoint instead")
+)
 
 type StreamOptions struct {
 	StreamType *StreamType
 	VideoFile  *models.VideoFile
 	Resolution string
+	Codec      StreamCodec
+	Tracks     TrackSelection
 	Hash       string
 	Segment    string
+	// SceneID is the scene being streamed. Used only to report active streams.
+	SceneID int
 }
 
 type transcodeProcess struct {
@@ -284,10 +399,18 @@ type waitingSegment struct {
 
 type runningStream struct {
 	dir              string
+	group            string // shared by all resolutions of the same stream
 	streamType       *StreamType
 	vf               *models.VideoFile
 	maxTranscodeSize int
+	codec            StreamCodec
+	videoCodec       VideoCodec
+	tracks           TrackSelection
 	outputDir        string
+	resolution       models.StreamingResolutionEnum
+	sceneID          int
+	clientAddress    string
+	startTime        time.Time
 
 	waitingSegments []*waitingSegment
 	tp              *transcodeProcess
@@ -299,12 +422,18 @@ func (t StreamType) String() string {
 	return t.Name
 }
 
-func (t StreamType) FileDir(hash string, maxTranscodeSize int) string {
-	if maxTranscodeSize == 0 {
-		return fmt.Sprintf("%s_%s", hash, t)
-	} else {
-		return fmt.Sprintf("%s_%s_%d", hash, t, maxTranscodeSize)
+func (t StreamType) FileDir(hash string, maxTranscodeSize int, codec StreamCodec, tracks TrackSelection) string {
+	ret := fmt.Sprintf("%s_%s", hash, t)
+	if maxTranscodeSize != 0 {
+		ret += fmt.Sprintf("_%d", maxTranscodeSize)
+	}
+	if codec != StreamCodecDefault {
+		ret += "_" + string(codec)
+	}
+	if tracks.Audio != nil {
+		ret += fmt.Sprintf("_a%d", *tracks.Audio)
 	}
+	return ret
 }
 
 func HLSGetCodec(sm *StreamManager, name string) (codec VideoCodec) {
@@ -319,6 +448,8 @@ func HLSGetCodec(sm *StreamManager, name string) (codec VideoCodec) {
 		if hwcodec := sm.encoder.hwCodecWEBMCompatible(); hwcodec != nil && sm.config.GetTranscodeHardwareAcceleration() {
 			codec = *hwcodec
 		}
+	case "hls-fmp4":
+		codec = sm.selectCodec(VideoCodecLibX265, sm.encoder.hwCodecHEVCCompatible())
 	case "hls-copy":
 		codec = VideoCodecCopy
 	}
@@ -326,6 +457,14 @@ func HLSGetCodec(sm *StreamManager, name string) (codec VideoCodec) {
 	return codec
 }
 
+// streamVideoCodec returns the encoder used for a segmented stream.
+func (sm *StreamManager) streamVideoCodec(streamType *StreamType, c StreamCodec) VideoCodec {
+	if codec, ok := sm.hevcAV1Codec(c); ok {
+		return codec
+	}
+	return HLSGetCodec(sm, streamType.Name)
+}
+
 func (s *runningStream) makeStreamArgs(sm *StreamManager, segment int) Args {
 	extraInputArgs := sm.config.GetLiveTranscodeInputArgs()
 	extraOutputArgs := sm.config.GetLiveTranscodeOutputArgs()
@@ -333,7 +472,7 @@ func (s *runningStream) makeStreamArgs(sm *StreamManager, segment int) Args {
 	args := Args{"-hide_banner"}
 	args = args.LogLevel(LogLevelError)
 
-	codec := HLSGetCodec(sm, s.streamType.Name)
+	codec := s.videoCodec
 
 	fullhw := sm.config.GetTranscodeHardwareAcceleration() && sm.encoder.hwCanFullHWTranscode(sm.context, codec, s.vf, s.maxTranscodeSize)
 	args = sm.encoder.hwDeviceInit(args, codec, fullhw)
@@ -345,10 +484,12 @@ func (s *runningStream) makeStreamArgs(sm *StreamManager, segment int) Args {
 
 	args = args.Input(s.vf.Path)
 
-	videoOnly := ProbeAudioCodec(s.vf.AudioCodec) == MissingUnsupported
+	videoOnly := ProbeAudioCodec(s.vf.AudioCodec) == MissingUnsupported && s.tracks.Audio == nil
 
 	videoFilter := sm.encoder.hwMaxResFilter(codec, s.vf, s.maxTranscodeSize, fullhw)
 
+	args = append(args, s.streamType.Map(s.tracks, videoOnly)...)
+
 	args = append(args, s.streamType.Args(codec, segment, videoFilter, videoOnly, s.outputDir)...)
 
 	args = append(args, extraOutputArgs...)
@@ -412,7 +553,7 @@ func segmentExists(path string) bool {
 
 // serveHLSManifest serves a generated HLS playlist. The URLs for the segments
 // are of the form {r.URL}/%d.ts{?urlQuery} where %d is the segment index.
-func serveHLSManifest(sm *StreamManager, w http.ResponseWriter, r *http.Request, vf *models.VideoFile, resolution string) {
+func serveHLSManifest(sm *StreamManager, w http.ResponseWriter, r *http.Request, vf *models.VideoFile, resolution string, codec StreamCodec, tracks TrackSelection) {
 	if sm.cacheDir == "" {
 		logger.Error("[transcode] cannot live transcode with HLS because cache dir is unset")
 		http.Error(w, "cannot live transcode with HLS because cache dir is unset", http.StatusServiceUnavailable)
@@ -437,6 +578,14 @@ func serveHLSManifest(sm *StreamManager, w http.ResponseWriter, r *http.Request,
 		urlQuery.Set(resolutionParamKey, resolution)
 	}
 
+	if codec != StreamCodecDefault {
+		urlQuery.Set(codecParamKey, string(codec))
+	}
+
+	if tracks.Audio != nil {
+		urlQuery.Set(audioTrackParamKey, strconv.Itoa(*tracks.Audio))
+	}
+
 	// TODO - this needs to be handled outside of this package
 	if apikey != "" {
 		urlQuery.Set(apiKeyParamKey, apikey)
@@ -448,15 +597,48 @@ func serveHLSManifest(sm *StreamManager, w http.ResponseWriter, r *http.Request,
 	}
 
 	var buf bytes.Buffer
+	writeHLSManifest(&buf, baseURL, urlQueryString, probeResult.FileDuration, codec)
 
-	fmt.Fprint(&buf, "#EXTM3U\n")
+	w.Header().Set("Content-Type", MimeHLS)
+	utils.ServeStaticContent(w, r, buf.Bytes())
+}
 
-	fmt.Fprint(&buf, "#EXT-X-VERSION:3\n")
-	fmt.Fprint(&buf, "#EXT-X-MEDIA-SEQUENCE:0\n")
-	fmt.Fprintf(&buf, "#EXT-X-TARGETDURATION:%d\n", segmentLength)
-	fmt.Fprint(&buf, "#EXT-X-PLAYLIST-TYPE:VOD\n")
+// hlsSegmentType returns the segment type of a HLS stream of the codec.
+func hlsSegmentType(codec StreamCodec) *SegmentType {
+	if codec == StreamCodecHEVC {
+		return SegmentTypeFMP4
+	}
+	return SegmentTypeTS
+}
 
-	leftover := probeResult.FileDuration
+// hlsVersion returns the HLS protocol version required by the segment type.
+// Fragmented MP4 segments require version 7.
+func hlsVersion(segmentType *SegmentType) int {
+	if segmentType == SegmentTypeFMP4 {
+		return 7
+	}
+	return 3
+}
+
+// writeHLSManifest writes a HLS media playlist of segments of the provided
+// duration. HEVC is not supported in MPEG-TS segments by all clients, so is
+// sent in fragmented MP4 segments, which require an init segment.
+func writeHLSManifest(buf *bytes.Buffer, baseURL string, urlQueryString string, duration float64, codec StreamCodec) {
+	segmentType := hlsSegmentType(codec)
+	version := hlsVersion(segmentType)
+
+	fmt.Fprint(buf, "#EXTM3U\n")
+
+	fmt.Fprintf(buf, "#EXT-X-VERSION:%d\n", version)
+	fmt.Fprint(buf, "#EXT-X-MEDIA-SEQUENCE:0\n")
+	fmt.Fprintf(buf, "#EXT-X-TARGETDURATION:%d\n", segmentLength)
+	fmt.Fprint(buf, "#EXT-X-PLAYLIST-TYPE:VOD\n")
+
+	if segmentType == SegmentTypeFMP4 {
+		fmt.Fprintf(buf, "#EXT-X-MAP:URI=\"%s/%s%s\"\n", baseURL, segmentType.MakeFilename(-1), urlQueryString)
+	}
+
+	leftover := duration
 	segment := 0
 
 	for leftover > 0 {
@@ -465,21 +647,18 @@ func serveHLSManifest(sm *StreamManager, w http.ResponseWriter, r *http.Request,
 			thisLength = leftover
 		}
 
-		fmt.Fprintf(&buf, "#EXTINF:%f,\n", thisLength)
-		fmt.Fprintf(&buf, "%s/%d.ts%s\n", baseURL, segment, urlQueryString)
+		fmt.Fprintf(buf, "#EXTINF:%f,\n", thisLength)
+		fmt.Fprintf(buf, "%s/%s%s\n", baseURL, segmentType.MakeFilename(segment), urlQueryString)
 
 		leftover -= thisLength
 		segment++
 	}
 
-	fmt.Fprint(&buf, "#EXT-X-ENDLIST\n")
-
-	w.Header().Set("Content-Type", MimeHLS)
-	utils.ServeStaticContent(w, r, buf.Bytes())
+	fmt.Fprint(buf, "#EXT-X-ENDLIST\n")
 }
 
 // serveDASHManifest serves a generated DASH manifest.
-func serveDASHManifest(sm *StreamManager, w http.ResponseWriter, r *http.Request, vf *models.VideoFile, resolution string) {
+func serveDASHManifest(sm *StreamManager, w http.ResponseWriter, r *http.Request, vf *models.VideoFile, resolution string, codec StreamCodec, tracks TrackSelection) {
 	if sm.cacheDir == "" {
 		logger.Error("[transcode] cannot live transcode with DASH because cache dir is unset")
 		http.Error(w, "cannot live transcode files with DASH because cache dir is unset", http.StatusServiceUnavailable)
@@ -493,37 +672,7 @@ func serveDASHManifest(sm *StreamManager, w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	var framerate string
-	var videoWidth int
-	var videoHeight int
-	videoStream := probeResult.VideoStream
-	if videoStream != nil {
-		framerate = videoStream.AvgFrameRate
-		videoWidth = videoStream.Width
-		videoHeight = videoStream.Height
-	} else {
-		// extract the framerate fraction from the file framerate
-		// framerates 0.1% below round numbers are common,
-		// attempt to infer when this is the case
-		fileFramerate := vf.FrameRate
-		rate1001, off1001 := math.Modf(fileFramerate * 1.001)
-		var numerator int
-		var denominator int
-		switch {
-		case off1001 < 0.005:
-			numerator = int(rate1001) * 1000
-			denominator = 1001
-		case off1001 > 0.995:
-			numerator = (int(rate1001) + 1) * 1000
-			denominator = 1001
-		default:
-			numerator = int(fileFramerate * 1000)
-			denominator = 1000
-		}
-		framerate = fmt.Sprintf("%d/%d", numerator, denominator)
-		videoHeight = vf.Height
-		videoWidth = vf.Width
-	}
+	framerate, videoWidth, videoHeight := dashVideoInfo(probeResult, vf)
 
 	urlQuery := url.Values{}
 
@@ -533,23 +682,20 @@ func serveDASHManifest(sm *StreamManager, w http.ResponseWriter, r *http.Request
 		urlQuery.Set(apiKeyParamKey, apikey)
 	}
 
+	if tracks.Audio != nil {
+		urlQuery.Set(audioTrackParamKey, strconv.Itoa(*tracks.Audio))
+	}
+
+	if codec != StreamCodecDefault {
+		urlQuery.Set(codecParamKey, string(codec))
+	}
+
 	maxTranscodeSize := sm.config.GetMaxStreamingTranscodeSize().GetMaxResolution()
 	if resolution != "" {
 		maxTranscodeSize = models.StreamingResolutionEnum(resolution).GetMaxResolution()
 		urlQuery.Set(resolutionParamKey, resolution)
 	}
-	if maxTranscodeSize != 0 {
-		videoSize := videoHeight
-		if videoWidth < videoSize {
-			videoSize = videoWidth
-		}
-
-		if maxTranscodeSize < videoSize {
-			scaleFactor := float64(maxTranscodeSize) / float64(videoSize)
-			videoWidth = int(float64(videoWidth) * scaleFactor)
-			videoHeight = int(float64(videoHeight) * scaleFactor)
-		}
-	}
+	videoWidth, videoHeight = scaleDimensions(videoWidth, videoHeight, maxTranscodeSize)
 
 	urlQueryString := ""
 	if len(urlQuery) > 0 {
@@ -566,10 +712,10 @@ func serveDASHManifest(sm *StreamManager, w http.ResponseWriter, r *http.Request
 	video, _ := m.AddNewAdaptationSetVideo(MimeWebmVideo, "progressive", true, 1)
 
 	_, _ = video.SetNewSegmentTemplate(2, "init_v.webm"+urlQueryString, "$Number$_v.webm"+urlQueryString, 0, 1)
-	_, _ = video.AddNewRepresentationVideo(200000, "vp09.00.40.08", "0", framerate, int64(videoWidth), int64(videoHeight))
+	_, _ = video.AddNewRepresentationVideo(200000, dashCodecString(codec), "0", framerate, int64(videoWidth), int64(videoHeight))
 
-	if ProbeAudioCodec(vf.AudioCodec) != MissingUnsupported {
-		audio, _ := m.AddNewAdaptationSetAudio(MimeWebmAudio, true, 1, "und")
+	if ProbeAudioCodec(vf.AudioCodec) != MissingUnsupported || tracks.Audio != nil {
+		audio, _ := m.AddNewAdaptationSetAudio(MimeWebmAudio, true, 1, audioLanguage(probeResult, tracks))
 		_, _ = audio.SetNewSegmentTemplate(2, "init_a.webm"+urlQueryString, "$Number$_a.webm"+urlQueryString, 0, 1)
 		_, _ = audio.AddNewRepresentationAudio(48000, 96000, "opus", "1")
 	}
@@ -581,8 +727,120 @@ func serveDASHManifest(sm *StreamManager, w http.ResponseWriter, r *http.Request
 	utils.ServeStaticContent(w, r, buf.Bytes())
 }
 
-func (sm *StreamManager) ServeManifest(w http.ResponseWriter, r *http.Request, streamType *StreamType, vf *models.VideoFile, resolution string) {
-	streamType.ServeManifest(sm, w, r, vf, resolution)
+// dashCodecString returns the RFC 6381 codecs string of the DASH video stream.
+func dashCodecString(codec StreamCodec) string {
+	if codec == StreamCodecDefault {
+		codec = StreamCodecVP9
+	}
+	return videoCodecString(codec)
+}
+
+// hlsCodecString returns the RFC 6381 codecs string of the HLS video stream.
+func hlsCodecString(codec StreamCodec) string {
+	if codec == StreamCodecDefault {
+		codec = StreamCodecH264
+	}
+	return videoCodecString(codec)
+}
+
+// videoCodecString returns the RFC 6381 codecs string of the video codec.
+// The profile and level are a hint for the client, and do not necessarily
+// match those of the encoded video.
+func videoCodecString(codec StreamCodec) string {
+	switch codec {
+	case StreamCodecH264:
+		return "avc1.640028"
+	case StreamCodecHEVC:
+		return "hvc1.1.6.L120.90"
+	case StreamCodecAV1:
+		return "av01.0.08M.08"
+	default:
+		return "vp09.00.40.08"
+	}
+}
+
+// hlsAudioCodecString is the RFC 6381 codecs string of the AAC-LC audio
+// stream of HLS segments.
+const hlsAudioCodecString = "mp4a.40.2"
+
+// dashVideoInfo returns the framerate fraction and dimensions of the video
+// stream of the file.
+func dashVideoInfo(probeResult *VideoFile, vf *models.VideoFile) (framerate string, width int, height int) {
+	videoStream := probeResult.VideoStream
+	if videoStream != nil {
+		return videoStream.AvgFrameRate, videoStream.Width, videoStream.Height
+	}
+
+	// extract the framerate fraction from the file framerate
+	// framerates 0.1% below round numbers are common,
+	// attempt to infer when this is the case
+	fileFramerate := vf.FrameRate
+	rate1001, off1001 := math.Modf(fileFramerate * 1.001)
+	var numerator int
+	var denominator int
+	switch {
+	case off1001 < 0.005:
+		numerator = int(rate1001) * 1000
+		denominator = 1001
+	case off1001 > 0.995:
+		numerator = (int(rate1001) + 1) * 1000
+		denominator = 1001
+	default:
+		numerator = int(fileFramerate * 1000)
+		denominator = 1000
+	}
+	framerate = fmt.Sprintf("%d/%d", numerator, denominator)
+	return framerate, vf.Width, vf.Height
+}
+
+// scaleDimensions returns the dimensions of a video scaled so that its
+// smaller dimension is at most maxSize. A maxSize of 0 means no scaling.
+func scaleDimensions(width, height, maxSize int) (int, int) {
+	if maxSize == 0 {
+		return width, height
+	}
+
+	videoSize := height
+	if width < videoSize {
+		videoSize = width
+	}
+
+	if maxSize < videoSize {
+		scaleFactor := float64(maxSize) / float64(videoSize)
+		width = int(float64(width) * scaleFactor)
+		height = int(float64(height) * scaleFactor)
+	}
+
+	return width, height
+}
+
+// audioLanguage returns the language of the selected audio stream,
+// or "und" if unknown.
+func audioLanguage(probeResult *VideoFile, tracks TrackSelection) string {
+	for _, s := range probeResult.MediaStreams {
+		if s.Type != "audio" {
+			continue
+		}
+
+		// use the first audio stream if none is selected
+		if tracks.Audio == nil || s.Index == *tracks.Audio {
+			if s.Language != "" {
+				return s.Language
+			}
+			break
+		}
+	}
+
+	return "und"
+}
+
+func (sm *StreamManager) ServeManifest(w http.ResponseWriter, r *http.Request, streamType *StreamType, vf *models.VideoFile, resolution string, codec StreamCodec, tracks TrackSelection) {
+	if tracks.Subtitle != nil {
+		http.Error(w, ErrSegmentedSubtitles.Error(), http.StatusBadRequest)
+		return
+	}
+
+	streamType.ServeManifest(sm, w, r, vf, resolution, codec, tracks)
 }
 
 func (sm *StreamManager) serveWaitingSegment(w http.ResponseWriter, r *http.Request, segment *waitingSegment) {
@@ -613,6 +871,11 @@ func (sm *StreamManager) ServeSegment(w http.ResponseWriter, r *http.Request, op
 		return
 	}
 
+	if options.Tracks.Subtitle != nil {
+		http.Error(w, ErrSegmentedSubtitles.Error(), http.StatusBadRequest)
+		return
+	}
+
 	streamType := options.StreamType
 
 	segment, err := streamType.SegmentType.ParseSegment(options.Segment)
@@ -627,7 +890,8 @@ func (sm *StreamManager) ServeSegment(w http.ResponseWriter, r *http.Request, op
 		maxTranscodeSize = models.StreamingResolutionEnum(options.Resolution).GetMaxResolution()
 	}
 
-	dir := options.StreamType.FileDir(options.Hash, maxTranscodeSize)
+	dir := options.StreamType.FileDir(options.Hash, maxTranscodeSize, options.Codec, options.Tracks)
+	group := options.StreamType.FileDir(options.Hash, 0, options.Codec, options.Tracks)
 	outputDir := filepath.Join(sm.cacheDir, dir)
 
 	name := streamType.SegmentType.MakeFilename(segment)
@@ -635,22 +899,45 @@ func (sm *StreamManager) ServeSegment(w http.ResponseWriter, r *http.Request, op
 
 	sm.streamsMutex.Lock()
 
+	now := time.Now()
+
 	stream := sm.runningStreams[dir]
 	if stream == nil {
 		stream = &runningStream{
 			dir:              dir,
+			group:            group,
 			streamType:       options.StreamType,
 			vf:               options.VideoFile,
 			maxTranscodeSize: maxTranscodeSize,
+			codec:            options.Codec,
+			videoCodec:       sm.streamVideoCodec(options.StreamType, options.Codec),
+			tracks:           options.Tracks,
 			outputDir:        outputDir,
+			resolution:       sm.resolutionOrDefault(options.Resolution),
+			sceneID:          options.SceneID,
+			clientAddress:    clientAddress(r),
+			startTime:        now,
 
 			// initialize to cap 10 to avoid reallocations
 			waitingSegments: make([]*waitingSegment, 0, 10),
 		}
+
+		mode := sm.encoder.streamMode(stream.videoCodec)
+		if streamType == StreamTypeDASHAudio {
+			// audio streams are not counted towards the limits
+			mode = StreamModeDirect
+		}
+
+		if err := sm.checkStart(stream.key(), mode); err != nil {
+			sm.streamsMutex.Unlock()
+			logger.Warnf("[transcode] not starting transcode of %s: %v", options.VideoFile.Path, err)
+			serveStartError(w, err)
+			return
+		}
+
 		sm.runningStreams[dir] = stream
 	}
 
-	now := time.Now()
 	stream.lastAccessed = now
 	if segment != -1 {
 		stream.lastSegment = segment
@@ -779,8 +1066,11 @@ func (sm *StreamManager) stopTranscode(stream *runningStream) {
 	}
 }
 
-func (sm *StreamManager) checkTranscode(stream *runningStream, now time.Time) {
-	if len(stream.waitingSegments) == 0 && stream.lastAccessed.Add(maxIdleTime).Before(now) {
+// checkTranscode expires the stream if no resolution of the stream has been
+// accessed recently, and stops the transcode if it is no longer required.
+// groupLastAccessed is the last time any resolution of the stream was accessed.
+func (sm *StreamManager) checkTranscode(stream *runningStream, now time.Time, groupLastAccessed time.Time) {
+	if len(stream.waitingSegments) == 0 && groupLastAccessed.Add(maxIdleTime).Before(now) {
 		// Stream expired. Cancel the transcode process and delete the files
 		logger.Debugf("[transcode] stream for %s not accessed recently. Cancelling transcode and removing files", stream.dir)
 
@@ -792,6 +1082,13 @@ func (sm *StreamManager) checkTranscode(stream *runningStream, now time.Time) {
 	}
 
 	if stream.tp != nil {
+		// client has switched to another resolution, stop transcode
+		if stream.lastAccessed.Add(maxVariantIdleTime).Before(groupLastAccessed) {
+			logger.Debugf("[transcode] stopping transcode for %s, another resolution is being streamed", stream.dir)
+			sm.stopTranscode(stream)
+			return
+		}
+
 		segmentType := stream.streamType.SegmentType
 		segment := stream.lastSegment
 		// if all segments up to maxSegmentBuffer exist, stop transcode
@@ -843,6 +1140,16 @@ func (sm *StreamManager) monitorStreams() {
 
 	now := time.Now()
 
+	sm.removeExpiredStops(now)
+
+	// streams of different resolutions share the same expiry
+	groupLastAccessed := make(map[string]time.Time)
+	for _, stream := range sm.runningStreams {
+		if stream.lastAccessed.After(groupLastAccessed[stream.group]) {
+			groupLastAccessed[stream.group] = stream.lastAccessed
+		}
+	}
+
 	for _, stream := range sm.runningStreams {
 		if stream.tp != nil {
 			stream.tp.checkSegments()
@@ -864,7 +1171,7 @@ func (sm *StreamManager) monitorStreams() {
 		stream.waitingSegments = temp
 
 		if !transcodeStarted {
-			sm.checkTranscode(stream, now)
+			sm.checkTranscode(stream, now, groupLastAccessed[stream.group])
 		}
 	}
 }
@@ -892,6 +1199,8 @@ func (sm *StreamManager) stopAndRemoveAll() {
 		sm.removeTranscodeFiles(stream)
 	}
 
+	sm.cancelTranscodeSessions()
+
 	// ensure nothing else can use the map
 	sm.runningStreams = nil
 }
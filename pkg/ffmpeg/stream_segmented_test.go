@@ -0,0 +1,72 @@
+package ffmpeg
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteHLSManifest(t *testing.T) {
+	const (
+		baseURL = "/scene/1/stream.m3u8"
+		query   = "?resolution=LOW"
+	)
+
+	tests := []struct {
+		name  string
+		codec StreamCodec
+		want  string
+	}{
+		{
+			"mpeg-ts",
+			StreamCodecDefault,
+			"#EXTM3U\n" +
+				"#EXT-X-VERSION:3\n" +
+				"#EXT-X-MEDIA-SEQUENCE:0\n" +
+				"#EXT-X-TARGETDURATION:2\n" +
+				"#EXT-X-PLAYLIST-TYPE:VOD\n" +
+				"#EXTINF:2.000000,\n" +
+				"/scene/1/stream.m3u8/0.ts?resolution=LOW\n" +
+				"#EXTINF:1.500000,\n" +
+				"/scene/1/stream.m3u8/1.ts?resolution=LOW\n" +
+				"#EXT-X-ENDLIST\n",
+		},
+		{
+			"hevc fmp4",
+			StreamCodecHEVC,
+			"#EXTM3U\n" +
+				"#EXT-X-VERSION:7\n" +
+				"#EXT-X-MEDIA-SEQUENCE:0\n" +
+				"#EXT-X-TARGETDURATION:2\n" +
+				"#EXT-X-PLAYLIST-TYPE:VOD\n" +
+				"#EXT-X-MAP:URI=\"/scene/1/stream.m3u8/init.m4s?resolution=LOW\"\n" +
+				"#EXTINF:2.000000,\n" +
+				"/scene/1/stream.m3u8/0.m4s?resolution=LOW\n" +
+				"#EXTINF:1.500000,\n" +
+				"/scene/1/stream.m3u8/1.m4s?resolution=LOW\n" +
+				"#EXT-X-ENDLIST\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			writeHLSManifest(&buf, baseURL, query, 3.5, tt.codec)
+			assert.Equal(t, tt.want, buf.String())
+		})
+	}
+}
+
+func TestSegmentTypeFMP4(t *testing.T) {
+	segment, err := SegmentTypeFMP4.ParseSegment("init")
+	assert.NoError(t, err)
+	assert.Equal(t, -1, segment)
+	assert.Equal(t, "init.m4s", SegmentTypeFMP4.MakeFilename(segment))
+
+	segment, err = SegmentTypeFMP4.ParseSegment("3")
+	assert.NoError(t, err)
+	assert.Equal(t, "3.m4s", SegmentTypeFMP4.MakeFilename(segment))
+
+	_, err = SegmentTypeFMP4.ParseSegment("-1")
+	assert.ErrorIs(t, err, ErrInvalidSegment)
+}
@@ -0,0 +1,343 @@
+package ffmpeg
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/session"
+)
+
+// stoppedStreamTimeout is the time that a client is prevented from
+// restarting a stream that was stopped using StopStream.
+const stoppedStreamTimeout = maxIdleTime
+
+var (
+	// ErrLiveTranscodeLimit is returned when the maximum number of concurrent live transcodes is reached.
+	ErrLiveTranscodeLimit = errors.New("maximum number of concurrent live transcodes reached")
+	// ErrClientLiveTranscodeLimit is returned when the maximum number of concurrent live transcodes for a client is reached.
+	ErrClientLiveTranscodeLimit = errors.New("maximum number of concurrent live transcodes for this client reached")
+	// ErrStreamStopped is returned when a client requests a stream that was recently stopped.
+	ErrStreamStopped = errors.New("stream was stopped by an administrator")
+)
+
+// StreamMode describes how the video of a live transcode is produced.
+type StreamMode string
+
+const (
+	// StreamModeDirect indicates that the video stream is copied without re-encoding.
+	StreamModeDirect StreamMode = "DIRECT"
+	// StreamModeHardware indicates that the video is encoded using a hardware encoder.
+	StreamModeHardware StreamMode = "HARDWARE"
+	// StreamModeSoftware indicates that the video is encoded in software, which is CPU intensive.
+	StreamModeSoftware StreamMode = "SOFTWARE"
+)
+
+var AllStreamMode = []StreamMode{
+	StreamModeDirect,
+	StreamModeHardware,
+	StreamModeSoftware,
+}
+
+func (e StreamMode) IsValid() bool {
+	switch e {
+	case StreamModeDirect, StreamModeHardware, StreamModeSoftware:
+		return true
+	}
+	return false
+}
+
+func (e StreamMode) String() string {
+	return string(e)
+}
+
+func (e *StreamMode) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = StreamMode(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid ActiveStreamMode", str)
+	}
+	return nil
+}
+
+func (e StreamMode) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+// ActiveStream describes a live transcode.
+type ActiveStream struct {
+	ID            string
+	SceneID       int
+	FileID        models.FileID
+	StreamType    string
+	Resolution    models.StreamingResolutionEnum
+	Codec         string
+	Mode          StreamMode
+	ClientAddress string
+	StartTime     time.Time
+}
+
+// sessionKey identifies the streams of a file to a single client. All
+// streams with the same key are counted as a single live transcode.
+type sessionKey struct {
+	clientAddress string
+	fileID        models.FileID
+}
+
+// transcodeSession is a live transcode that is piped directly to the client.
+type transcodeSession struct {
+	ActiveStream
+	cancel func()
+}
+
+func (s *transcodeSession) key() sessionKey {
+	return sessionKey{clientAddress: s.ClientAddress, fileID: s.FileID}
+}
+
+func (s *runningStream) key() sessionKey {
+	return sessionKey{clientAddress: s.clientAddress, fileID: s.vf.ID}
+}
+
+// clientAddress returns the address of the client without the port.
+// Requests proxied from the local network are attributed to the
+// originating client.
+func clientAddress(r *http.Request) string {
+	return session.ClientAddress(r)
+}
+
+// streamMode returns the StreamMode for a video encoder.
+func (f *FFMpeg) streamMode(codec VideoCodec) StreamMode {
+	if codec == VideoCodecCopy {
+		return StreamModeDirect
+	}
+
+	for _, c := range f.hwCodecSupport {
+		if c == codec {
+			return StreamModeHardware
+		}
+	}
+
+	return StreamModeSoftware
+}
+
+func (sm *StreamManager) resolutionOrDefault(resolution string) models.StreamingResolutionEnum {
+	if resolution == "" {
+		return sm.config.GetMaxStreamingTranscodeSize()
+	}
+	return models.StreamingResolutionEnum(resolution)
+}
+
+// activeSessions returns the keys of the streams that are transcoding video.
+// Assumes the lock is held.
+func (sm *StreamManager) activeSessions() map[sessionKey]bool {
+	ret := make(map[sessionKey]bool)
+	for _, s := range sm.transcodeSessions {
+		if s.Mode != StreamModeDirect {
+			ret[s.key()] = true
+		}
+	}
+	for _, s := range sm.runningStreams {
+		if s.streamType != StreamTypeDASHAudio && sm.encoder.streamMode(s.videoCodec) != StreamModeDirect {
+			ret[s.key()] = true
+		}
+	}
+	return ret
+}
+
+// checkStart returns an error if a new stream for the key is not allowed.
+// New streams of a file already being streamed to the client are always
+// allowed. Direct streams are not counted towards the limits.
+// Assumes the lock is held.
+func (sm *StreamManager) checkStart(key sessionKey, mode StreamMode) error {
+	if until, found := sm.stoppedSessions[key]; found && time.Now().Before(until) {
+		return ErrStreamStopped
+	}
+
+	if mode == StreamModeDirect {
+		return nil
+	}
+
+	sessions := sm.activeSessions()
+	if sessions[key] {
+		return nil
+	}
+
+	if limit := sm.config.GetMaxLiveTranscodes(); limit > 0 && len(sessions) >= limit {
+		return fmt.Errorf("%w (%d)", ErrLiveTranscodeLimit, limit)
+	}
+
+	if limit := sm.config.GetMaxLiveTranscodesPerClient(); limit > 0 {
+		count := 0
+		for k := range sessions {
+			if k.clientAddress == key.clientAddress {
+				count++
+			}
+		}
+
+		if count >= limit {
+			return fmt.Errorf("%w (%d)", ErrClientLiveTranscodeLimit, limit)
+		}
+	}
+
+	return nil
+}
+
+// serveStartError writes the error returned by checkStart to the response.
+func serveStartError(w http.ResponseWriter, err error) {
+	status := http.StatusTooManyRequests
+	if errors.Is(err, ErrStreamStopped) {
+		status = http.StatusForbidden
+	} else {
+		w.Header().Set("Retry-After", strconv.Itoa(int(maxIdleTime.Seconds())))
+	}
+
+	http.Error(w, err.Error(), status)
+}
+
+// startTranscodeSession registers a piped live transcode. The returned
+// function must be called when the transcode ends.
+func (sm *StreamManager) startTranscodeSession(r *http.Request, options TranscodeOptions, codec VideoCodec, cancel func()) (func(), error) {
+	sm.streamsMutex.Lock()
+	defer sm.streamsMutex.Unlock()
+
+	session := &transcodeSession{
+		ActiveStream: ActiveStream{
+			ID:            fmt.Sprintf("transcode-%d", sm.nextSessionID),
+			SceneID:       options.SceneID,
+			FileID:        options.VideoFile.ID,
+			StreamType:    options.StreamType.Name,
+			Resolution:    sm.resolutionOrDefault(options.Resolution),
+			Codec:         codec.CodeName,
+			Mode:          sm.encoder.streamMode(codec),
+			ClientAddress: clientAddress(r),
+			StartTime:     time.Now(),
+		},
+		cancel: cancel,
+	}
+
+	if err := sm.checkStart(session.key(), session.Mode); err != nil {
+		return nil, err
+	}
+
+	sm.nextSessionID++
+	sm.transcodeSessions[session.ID] = session
+
+	return func() {
+		sm.streamsMutex.Lock()
+		defer sm.streamsMutex.Unlock()
+
+		delete(sm.transcodeSessions, session.ID)
+	}, nil
+}
+
+// ActiveStreams returns the current live transcodes, ordered by start time.
+// Audio streams of DASH streams are not included.
+func (sm *StreamManager) ActiveStreams() []*ActiveStream {
+	sm.streamsMutex.Lock()
+	defer sm.streamsMutex.Unlock()
+
+	var ret []*ActiveStream
+	for _, s := range sm.transcodeSessions {
+		v := s.ActiveStream
+		ret = append(ret, &v)
+	}
+
+	for _, s := range sm.runningStreams {
+		if s.streamType == StreamTypeDASHAudio {
+			continue
+		}
+
+		ret = append(ret, &ActiveStream{
+			ID:            s.dir,
+			SceneID:       s.sceneID,
+			FileID:        s.vf.ID,
+			StreamType:    s.streamType.Name,
+			Resolution:    s.resolution,
+			Codec:         s.videoCodec.CodeName,
+			Mode:          sm.encoder.streamMode(s.videoCodec),
+			ClientAddress: s.clientAddress,
+			StartTime:     s.startTime,
+		})
+	}
+
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].StartTime.Before(ret[j].StartTime)
+	})
+
+	return ret
+}
+
+// StopStream stops the stream with the provided id, along with all other
+// streams of the same file to the same client. The client is prevented from
+// restarting the stream for a short time. Returns false if the stream was
+// not found.
+func (sm *StreamManager) StopStream(id string) bool {
+	sm.streamsMutex.Lock()
+	defer sm.streamsMutex.Unlock()
+
+	var key sessionKey
+	if s := sm.transcodeSessions[id]; s != nil {
+		key = s.key()
+	} else if s := sm.runningStreams[id]; s != nil {
+		key = s.key()
+	} else {
+		return false
+	}
+
+	logger.Infof("[transcode] stopping streams of file %d to %s", key.fileID, key.clientAddress)
+
+	sm.stoppedSessions[key] = time.Now().Add(stoppedStreamTimeout)
+
+	for _, s := range sm.transcodeSessions {
+		if s.key() == key {
+			s.cancel()
+		}
+	}
+
+	for _, s := range sm.runningStreams {
+		if s.key() != key {
+			continue
+		}
+
+		for _, segment := range s.waitingSegments {
+			if len(segment.available) == 0 {
+				segment.available <- ErrStreamStopped
+			}
+		}
+		s.waitingSegments = nil
+
+		sm.stopTranscode(s)
+		sm.removeTranscodeFiles(s)
+		delete(sm.runningStreams, s.dir)
+	}
+
+	return true
+}
+
+// removeExpiredStops removes stopped sessions that may be restarted.
+// Assumes the lock is held.
+func (sm *StreamManager) removeExpiredStops(now time.Time) {
+	for k, until := range sm.stoppedSessions {
+		if now.After(until) {
+			delete(sm.stoppedSessions, k)
+		}
+	}
+}
+
+// cancelTranscodeSessions cancels all piped live transcodes.
+// Assumes the lock is held.
+func (sm *StreamManager) cancelTranscodeSessions() {
+	for _, s := range sm.transcodeSessions {
+		s.cancel()
+	}
+}
@@ -0,0 +1,122 @@
+package ffmpeg
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+type testStreamConfig struct {
+	maxLiveTranscodes          int
+	maxLiveTranscodesPerClient int
+}
+
+func (c testStreamConfig) GetMaxStreamingTranscodeSize() models.StreamingResolutionEnum {
+	return models.StreamingResolutionEnumOriginal
+}
+func (c testStreamConfig) GetLiveTranscodeInputArgs() []string  { return nil }
+func (c testStreamConfig) GetLiveTranscodeOutputArgs() []string { return nil }
+func (c testStreamConfig) GetTranscodeHardwareAcceleration() bool {
+	return false
+}
+func (c testStreamConfig) GetMaxLiveTranscodes() int { return c.maxLiveTranscodes }
+func (c testStreamConfig) GetMaxLiveTranscodesPerClient() int {
+	return c.maxLiveTranscodesPerClient
+}
+
+func TestStreamManagerCheckStart(t *testing.T) {
+	session := func(client string, fileID models.FileID, mode StreamMode) *transcodeSession {
+		return &transcodeSession{
+			ActiveStream: ActiveStream{
+				FileID:        fileID,
+				Mode:          mode,
+				ClientAddress: client,
+			},
+		}
+	}
+
+	newManager := func(max, maxPerClient int, sessions ...*transcodeSession) *StreamManager {
+		sm := &StreamManager{
+			encoder: &FFMpeg{},
+			config: testStreamConfig{
+				maxLiveTranscodes:          max,
+				maxLiveTranscodesPerClient: maxPerClient,
+			},
+			runningStreams:    make(map[string]*runningStream),
+			transcodeSessions: make(map[string]*transcodeSession),
+			stoppedSessions:   make(map[sessionKey]time.Time),
+		}
+		for i, s := range sessions {
+			sm.transcodeSessions[fmt.Sprintf("transcode-%d", i)] = s
+		}
+		return sm
+	}
+
+	const (
+		clientA = "10.0.0.1"
+		clientB = "10.0.0.2"
+	)
+
+	tests := []struct {
+		name    string
+		sm      *StreamManager
+		key     sessionKey
+		mode    StreamMode
+		wantErr error
+	}{
+		{"no limits", newManager(0, 0, session(clientA, 1, StreamModeSoftware)), sessionKey{clientA, 2}, StreamModeSoftware, nil},
+		{"global limit", newManager(1, 0, session(clientA, 1, StreamModeSoftware)), sessionKey{clientB, 2}, StreamModeSoftware, ErrLiveTranscodeLimit},
+		{"same file allowed", newManager(1, 0, session(clientA, 1, StreamModeSoftware)), sessionKey{clientA, 1}, StreamModeSoftware, nil},
+		{"direct not limited", newManager(1, 0, session(clientA, 1, StreamModeSoftware)), sessionKey{clientB, 2}, StreamModeDirect, nil},
+		{"direct not counted", newManager(1, 0, session(clientA, 1, StreamModeDirect)), sessionKey{clientB, 2}, StreamModeSoftware, nil},
+		{"client limit", newManager(0, 1, session(clientA, 1, StreamModeHardware)), sessionKey{clientA, 2}, StreamModeSoftware, ErrClientLiveTranscodeLimit},
+		{"other client", newManager(0, 1, session(clientA, 1, StreamModeHardware)), sessionKey{clientB, 2}, StreamModeSoftware, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.sm.checkStart(tt.key, tt.mode)
+			if tt.wantErr != nil {
+				assert.True(t, errors.Is(err, tt.wantErr))
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestStreamManagerStopStream(t *testing.T) {
+	cancelled := false
+	sm := &StreamManager{
+		encoder:        &FFMpeg{},
+		config:         testStreamConfig{},
+		runningStreams: make(map[string]*runningStream),
+		transcodeSessions: map[string]*transcodeSession{
+			"transcode-0": {
+				ActiveStream: ActiveStream{
+					ID:            "transcode-0",
+					FileID:        1,
+					Mode:          StreamModeSoftware,
+					ClientAddress: "10.0.0.1",
+				},
+				cancel: func() { cancelled = true },
+			},
+		},
+		stoppedSessions: make(map[sessionKey]time.Time),
+	}
+
+	assert.False(t, sm.StopStream("missing"))
+	assert.True(t, sm.StopStream("transcode-0"))
+	assert.True(t, cancelled)
+
+	// the client cannot restart the stream, even as a direct stream
+	err := sm.checkStart(sessionKey{"10.0.0.1", 1}, StreamModeDirect)
+	assert.True(t, errors.Is(err, ErrStreamStopped))
+
+	// expired stops are removed
+	sm.removeExpiredStops(time.Now().Add(stoppedStreamTimeout + time.Second))
+	assert.NoError(t, sm.checkStart(sessionKey{"10.0.0.1", 1}, StreamModeDirect))
+}
@@ -0,0 +1,82 @@
+package ffmpeg
+
+import (
+	"fmt"
+	"slices"
+)
+
+// textSubtitleCodecs are the subtitle codecs that can be converted to text
+// formats such as WebVTT. Bitmap subtitles such as PGS cannot.
+var textSubtitleCodecs = []string{"subrip", "srt", "ass", "ssa", "webvtt", "mov_text", "text"}
+
+// IsTextSubtitleCodec returns true if the subtitle codec is text based.
+func IsTextSubtitleCodec(codec string) bool {
+	return slices.Contains(textSubtitleCodecs, codec)
+}
+
+// TrackSelection selects the audio and subtitle streams of a video file to
+// include in a live transcode. Tracks are identified by their stream index
+// within the file.
+type TrackSelection struct {
+	// Audio is the index of the audio stream. If nil, the first audio stream is used.
+	Audio *int
+	// Subtitle is the index of the subtitle stream. If nil, subtitles are not included.
+	Subtitle *int
+}
+
+// IsDefault returns true if no tracks are selected.
+func (s TrackSelection) IsDefault() bool {
+	return s.Audio == nil && s.Subtitle == nil
+}
+
+// audioStream returns the stream specifier of the selected audio stream.
+func (s TrackSelection) audioStream() string {
+	if s.Audio == nil {
+		return "0:a:0"
+	}
+
+	return fmt.Sprintf("0:%d", *s.Audio)
+}
+
+// mapArgs returns the arguments to map the first video stream and the
+// selected audio and subtitle streams to the output. subtitleCodec is the
+// codec used to encode subtitles in the output format.
+// Returns nil if no tracks are selected, so that the default ffmpeg stream
+// selection is used.
+func (s TrackSelection) mapArgs(videoOnly bool, subtitleCodec string) Args {
+	if s.IsDefault() {
+		return nil
+	}
+
+	args := Args{"-map", "0:v:0"}
+	if !videoOnly {
+		audio := s.audioStream()
+		if s.Audio == nil {
+			// the file may not have an audio stream
+			audio += "?"
+		}
+		args = append(args, "-map", audio)
+	}
+
+	if s.Subtitle != nil {
+		args = append(args,
+			"-map", fmt.Sprintf("0:%d", *s.Subtitle),
+			"-c:s", subtitleCodec,
+		)
+	}
+
+	return args
+}
+
+// ExtractSubtitleArgs returns the arguments to convert the subtitle stream
+// with the provided index to WebVTT, written to stdout.
+func ExtractSubtitleArgs(input string, streamIndex int) Args {
+	var args Args
+	args = args.LogLevel(LogLevelError)
+	args = args.Input(input)
+	args = append(args, "-map", fmt.Sprintf("0:%d", streamIndex))
+	args = args.Format(FormatWebVTT)
+	args = args.Output("pipe:")
+
+	return args
+}
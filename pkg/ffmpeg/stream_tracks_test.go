@@ -0,0 +1,39 @@
+package ffmpeg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTrackSelection_mapArgs(t *testing.T) {
+	audio := 2
+	subtitle := 3
+
+	tests := []struct {
+		name          string
+		tracks        TrackSelection
+		videoOnly     bool
+		subtitleCodec string
+		want          Args
+	}{
+		{"default", TrackSelection{}, false, "", nil},
+		{"audio", TrackSelection{Audio: &audio}, false, "", Args{"-map", "0:v:0", "-map", "0:2"}},
+		{"subtitle", TrackSelection{Subtitle: &subtitle}, false, "webvtt", Args{"-map", "0:v:0", "-map", "0:a:0?", "-map", "0:3", "-c:s", "webvtt"}},
+		{"subtitle video only", TrackSelection{Subtitle: &subtitle}, true, "copy", Args{"-map", "0:v:0", "-map", "0:3", "-c:s", "copy"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.tracks.mapArgs(tt.videoOnly, tt.subtitleCodec))
+		})
+	}
+}
+
+func TestStreamType_FileDir(t *testing.T) {
+	audio := 2
+
+	assert.Equal(t, "hash_hls", StreamTypeHLS.FileDir("hash", 0, StreamCodecDefault, TrackSelection{}))
+	assert.Equal(t, "hash_hls_720", StreamTypeHLS.FileDir("hash", 720, StreamCodecDefault, TrackSelection{}))
+	assert.Equal(t, "hash_hls_720_hevc", StreamTypeHLS.FileDir("hash", 720, StreamCodecHEVC, TrackSelection{}))
+	assert.Equal(t, "hash_dash-a_720_a2", StreamTypeDASHAudio.FileDir("hash", 720, StreamCodecDefault, TrackSelection{Audio: &audio}))
+}
@@ -14,13 +14,23 @@ import (
 )
 
 type StreamFormat struct {
+	Name     string
 	MimeType string
-	Args     func(codec VideoCodec, videoFilter VideoFilter, videoOnly bool) Args
+	// SubtitleCodec is the codec used to encode selected subtitle tracks
+	SubtitleCodec string
+	// Codecs are the video codecs that may be requested. The first is the default.
+	Codecs []StreamCodec
+	Args   func(codec VideoCodec, videoFilter VideoFilter, videoOnly bool) Args
 }
 
 func CodecInit(codec VideoCodec) (args Args) {
 	args = args.VideoCodec(codec)
+	return append(args, codecArgs(codec)...)
+}
 
+// codecArgs returns the encoder arguments for the codec, excluding the
+// codec itself.
+func codecArgs(codec VideoCodec) (args Args) {
 	switch codec {
 	// CPU Codecs
 	case VideoCodecLibX264:
@@ -30,6 +40,20 @@ func CodecInit(codec VideoCodec) (args Args) {
 			"-crf", "25",
 			"-sc_threshold", "0",
 		)
+	case VideoCodecLibX265:
+		args = append(args,
+			"-pix_fmt", "yuv420p",
+			"-preset", "veryfast",
+			"-crf", "28",
+			"-sc_threshold", "0",
+			"-tag:v", "hvc1",
+		)
+	case VideoCodecLibSVTAV1:
+		args = append(args,
+			"-pix_fmt", "yuv420p",
+			"-preset", "10",
+			"-crf", "35",
+		)
 	case VideoCodecVP9:
 		args = append(args,
 			"-pix_fmt", "yuv420p",
@@ -45,6 +69,17 @@ func CodecInit(codec VideoCodec) (args Args) {
 			"-rc", "vbr",
 			"-cq", "15",
 		)
+	case VideoCodecN265:
+		args = append(args,
+			"-rc", "vbr",
+			"-cq", "20",
+			"-tag:v", "hvc1",
+		)
+	case VideoCodecNAV1:
+		args = append(args,
+			"-rc", "vbr",
+			"-cq", "30",
+		)
 	case VideoCodecN264H:
 		args = append(args,
 			"-profile", "p7",
@@ -59,20 +94,31 @@ func CodecInit(codec VideoCodec) (args Args) {
 			"-coder", "cabac",
 			"-b_ref_mode", "middle",
 		)
-	case VideoCodecI264, VideoCodecIVP9:
+	case VideoCodecI264, VideoCodecIVP9, VideoCodecIAV1:
 		args = append(args,
 			"-global_quality", "20",
 			"-preset", "faster",
 		)
+	case VideoCodecI265:
+		args = append(args,
+			"-global_quality", "20",
+			"-preset", "faster",
+			"-tag:v", "hvc1",
+		)
 	case VideoCodecI264C:
 		args = append(args,
 			"-q", "20",
 			"-preset", "faster",
 		)
-	case VideoCodecV264, VideoCodecVVP9:
+	case VideoCodecV264, VideoCodecVVP9, VideoCodecVAV1:
 		args = append(args,
 			"-qp", "20",
 		)
+	case VideoCodecV265:
+		args = append(args,
+			"-qp", "20",
+			"-tag:v", "hvc1",
+		)
 	case VideoCodecA264:
 		args = append(args,
 			"-quality", "speed",
@@ -81,6 +127,11 @@ func CodecInit(codec VideoCodec) (args Args) {
 		args = append(args,
 			"-realtime", "1",
 		)
+	case VideoCodecM265:
+		args = append(args,
+			"-realtime", "1",
+			"-tag:v", "hvc1",
+		)
 	case VideoCodecO264:
 		args = append(args,
 			"-preset", "superfast",
@@ -93,7 +144,10 @@ func CodecInit(codec VideoCodec) (args Args) {
 
 var (
 	StreamTypeMP4 = StreamFormat{
-		MimeType: MimeMp4Video,
+		Name:          "mp4",
+		MimeType:      MimeMp4Video,
+		SubtitleCodec: "mov_text",
+		Codecs:        []StreamCodec{StreamCodecH264, StreamCodecHEVC, StreamCodecAV1},
 		Args: func(codec VideoCodec, videoFilter VideoFilter, videoOnly bool) (args Args) {
 			args = CodecInit(codec)
 			args = append(args, "-movflags", "frag_keyframe+empty_moov")
@@ -108,7 +162,10 @@ var (
 		},
 	}
 	StreamTypeWEBM = StreamFormat{
-		MimeType: MimeWebmVideo,
+		Name:          "webm",
+		MimeType:      MimeWebmVideo,
+		SubtitleCodec: "webvtt",
+		Codecs:        []StreamCodec{StreamCodecVP9, StreamCodecAV1},
 		Args: func(codec VideoCodec, videoFilter VideoFilter, videoOnly bool) (args Args) {
 			args = CodecInit(codec)
 			args = args.VideoFilter(videoFilter)
@@ -122,7 +179,9 @@ var (
 		},
 	}
 	StreamTypeMKV = StreamFormat{
-		MimeType: MimeMkvVideo,
+		Name:          "mkv",
+		MimeType:      MimeMkvVideo,
+		SubtitleCodec: "copy",
 		Args: func(codec VideoCodec, videoFilter VideoFilter, videoOnly bool) (args Args) {
 			args = CodecInit(codec)
 			if videoOnly {
@@ -146,6 +205,18 @@ type TranscodeOptions struct {
 	VideoFile  *models.VideoFile
 	Resolution string
 	StartTime  float64
+	Tracks     TrackSelection
+	// Codec is the requested video codec. Must be one of StreamType.Codecs.
+	Codec StreamCodec
+	// SceneID is the scene being streamed. Used only to report active streams.
+	SceneID int
+}
+
+func (o TranscodeOptions) maxTranscodeSize(sm *StreamManager) int {
+	if o.Resolution != "" {
+		return models.StreamingResolutionEnum(o.Resolution).GetMaxResolution()
+	}
+	return sm.config.GetMaxStreamingTranscodeSize().GetMaxResolution()
 }
 
 func (o TranscodeOptions) FileGetCodec(sm *StreamManager, maxTranscodeSize int) (codec VideoCodec) {
@@ -159,6 +230,15 @@ func (o TranscodeOptions) FileGetCodec(sm *StreamManager, maxTranscodeSize int)
 		}
 	}
 
+	if o.StreamType.MimeType != MimeMkvVideo {
+		if codec, ok := sm.hevcAV1Codec(o.Codec); ok {
+			if !needsResize && isCodec(o.VideoFile.VideoCodec, o.Codec) {
+				return VideoCodecCopy
+			}
+			return codec
+		}
+	}
+
 	switch o.StreamType.MimeType {
 	case MimeMp4Video:
 		if !needsResize && o.VideoFile.VideoCodec == H264 {
@@ -184,10 +264,7 @@ func (o TranscodeOptions) FileGetCodec(sm *StreamManager, maxTranscodeSize int)
 }
 
 func (o TranscodeOptions) makeStreamArgs(sm *StreamManager) Args {
-	maxTranscodeSize := sm.config.GetMaxStreamingTranscodeSize().GetMaxResolution()
-	if o.Resolution != "" {
-		maxTranscodeSize = models.StreamingResolutionEnum(o.Resolution).GetMaxResolution()
-	}
+	maxTranscodeSize := o.maxTranscodeSize(sm)
 	extraInputArgs := sm.config.GetLiveTranscodeInputArgs()
 	extraOutputArgs := sm.config.GetLiveTranscodeOutputArgs()
 
@@ -206,10 +283,11 @@ func (o TranscodeOptions) makeStreamArgs(sm *StreamManager) Args {
 
 	args = args.Input(o.VideoFile.Path)
 
-	videoOnly := ProbeAudioCodec(o.VideoFile.AudioCodec) == MissingUnsupported
+	videoOnly := ProbeAudioCodec(o.VideoFile.AudioCodec) == MissingUnsupported && o.Tracks.Audio == nil
 
 	videoFilter := sm.encoder.hwMaxResFilter(codec, o.VideoFile, maxTranscodeSize, fullhw)
 
+	args = append(args, o.Tracks.mapArgs(videoOnly, o.StreamType.SubtitleCodec)...)
 	args = append(args, o.StreamType.Args(codec, videoFilter, videoOnly)...)
 
 	args = append(args, extraOutputArgs...)
@@ -227,6 +305,16 @@ func (sm *StreamManager) ServeTranscode(w http.ResponseWriter, r *http.Request,
 	// due to ERR_INCOMPLETE_CHUNKED_ENCODING
 	// We trust that the request context will be closed, so we don't need to call Cancel on the returned context here.
 
+	codec := options.FileGetCodec(sm, options.maxTranscodeSize(sm))
+	done, err := sm.startTranscodeSession(r, options, codec, lockCtx.Cancel)
+	if err != nil {
+		lockCtx.Cancel()
+		logger.Warnf("[transcode] not starting transcode of %s: %v", options.VideoFile.Path, err)
+		serveStartError(w, err)
+		return
+	}
+	defer done()
+
 	handler, err := sm.getTranscodeStream(lockCtx, options)
 
 	if err != nil {
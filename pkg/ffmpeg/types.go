@@ -27,13 +27,27 @@ type FFProbeJSON struct {
 			Comment          string        `json:"comment"`
 		} `json:"tags"`
 	} `json:"format"`
-	Streams []FFProbeStream `json:"streams"`
-	Error   struct {
+	Streams  []FFProbeStream  `json:"streams"`
+	Chapters []FFProbeChapter `json:"chapters"`
+	Error    struct {
 		Code   int    `json:"code"`
 		String string `json:"string"`
 	} `json:"error"`
 }
 
+// FFProbeChapter is a JSON representation of a chapter of a media file.
+type FFProbeChapter struct {
+	ID        int64  `json:"id"`
+	TimeBase  string `json:"time_base"`
+	Start     int64  `json:"start"`
+	StartTime string `json:"start_time"`
+	End       int64  `json:"end"`
+	EndTime   string `json:"end_time"`
+	Tags      struct {
+		Title string `json:"title"`
+	} `json:"tags"`
+}
+
 // FFProbeStream is a JSON representation of an ffmpeg stream.
 type FFProbeStream struct {
 	AvgFrameRate       string `json:"avg_frame_rate"`
@@ -85,6 +99,7 @@ type FFProbeStream struct {
 		HandlerName  string        `json:"handler_name"`
 		Language     string        `json:"language"`
 		Rotate       string        `json:"rotate"`
+		Title        string        `json:"title"`
 	} `json:"tags"`
 	TimeBase      string `json:"time_base"`
 	Width         int    `json:"width,omitempty"`
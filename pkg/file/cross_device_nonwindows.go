@@ -0,0 +1,9 @@
+//go:build linux || darwin || !windows
+// +build linux darwin !windows
+
+package file
+
+import "syscall"
+
+// errCrossDevice is returned when renaming a file to a different filesystem.
+var errCrossDevice error = syscall.EXDEV
@@ -0,0 +1,9 @@
+//go:build windows
+// +build windows
+
+package file
+
+import "golang.org/x/sys/windows"
+
+// errCrossDevice is returned when renaming a file to a different volume.
+var errCrossDevice error = windows.ERROR_NOT_SAME_DEVICE
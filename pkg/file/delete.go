@@ -63,10 +63,15 @@ func newRenamerRemoverImpl() renamerRemoverImpl {
 // be restored to their original state with the Abort method. If the
 // transaction is committed, the marked files are then deleted from the
 // filesystem using the Complete method.
+//
+// If Trash is set, library files designated using LibraryFiles are moved to
+// the trash directory of their library instead of being deleted.
 type Deleter struct {
 	RenamerRemover RenamerRemover
+	Trash          *Trash
 	files          []string
 	dirs           []string
+	trashed        []trashedFile
 }
 
 func NewDeleter() *Deleter {
@@ -148,8 +153,15 @@ func (d *Deleter) Rollback() {
 		}
 	}
 
+	for _, f := range d.trashed {
+		if err := moveFile(d.RenamerRemover, f.trashPath, f.originalPath); err != nil {
+			logger.Warnf("Error restoring %q from trash: %v", f.originalPath, err)
+		}
+	}
+
 	d.files = nil
 	d.dirs = nil
+	d.trashed = nil
 }
 
 // Commit deletes all files marked for deletion and clears the marked list.
@@ -170,6 +182,7 @@ func (d *Deleter) Commit() {
 
 	d.files = nil
 	d.dirs = nil
+	d.trashed = nil
 }
 
 func (d *Deleter) renameForDelete(path string) error {
@@ -187,7 +200,7 @@ func Destroy(ctx context.Context, destroyer models.FileDestroyer, f models.File,
 
 	// don't delete files in zip files
 	if deleteFile && f.Base().ZipFileID == nil {
-		if err := fileDeleter.Files([]string{f.Base().Path}); err != nil {
+		if err := fileDeleter.LibraryFiles(ctx, []string{f.Base().Path}); err != nil {
 			return err
 		}
 	}
@@ -230,7 +243,7 @@ func (d *ZipDestroyer) DestroyZip(ctx context.Context, f models.File, fileDelete
 	}
 
 	if deleteFile {
-		if err := fileDeleter.Files([]string{f.Base().Path}); err != nil {
+		if err := fileDeleter.LibraryFiles(ctx, []string{f.Base().Path}); err != nil {
 			return err
 		}
 	}
@@ -104,6 +104,17 @@ func (m *Mover) Move(ctx context.Context, f models.File, folder *models.Folder,
 	return m.moveFile(oldPath, newPath)
 }
 
+// MoveSidecar moves a file that is not tracked in the database, such as a
+// caption or funscript file, so that it remains alongside a moved file.
+// The move is reverted if the transaction is rolled back.
+func (m *Mover) MoveSidecar(oldPath, newPath string) error {
+	if _, err := m.Renamer.Stat(newPath); !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("file %s already exists", newPath)
+	}
+
+	return m.moveFile(oldPath, newPath)
+}
+
 func (m *Mover) CreateFolderHierarchy(path string) error {
 	info, err := m.Renamer.Stat(path)
 	if err != nil {
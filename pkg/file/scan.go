@@ -959,6 +959,9 @@ func (s *scanJob) isHandlerRequired(ctx context.Context, f models.File) bool {
 // - file size
 // - image format, width or height
 // - video codec, audio codec, format, width, height, framerate or bitrate
+// - video streams and chapters, which were not stored before migration 75
+//
+// Assumes a database connection is present in the context.
 func (s *scanJob) isMissingMetadata(ctx context.Context, f scanFile, existing models.File) bool {
 	for _, h := range s.FileDecorators {
 		if h.IsMissingMetadata(ctx, f.fs, existing) {
@@ -1107,7 +1110,14 @@ func (s *scanJob) removeOutdatedFingerprints(existing models.File, fp models.Fin
 func (s *scanJob) onUnchangedFile(ctx context.Context, f scanFile, existing models.File) (models.File, error) {
 	var err error
 
-	isMissingMetdata := s.isMissingMetadata(ctx, f, existing)
+	var isMissingMetdata bool
+	if err := s.withDB(ctx, func(ctx context.Context) error {
+		isMissingMetdata = s.isMissingMetadata(ctx, f, existing)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
 	// set missing information
 	if isMissingMetdata {
 		existing, err = s.setMissingMetadata(ctx, f, existing)
@@ -0,0 +1,197 @@
+package file
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/stashapp/stash/pkg/fsutil"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+)
+
+// Trash moves deleted library files into the trash directory of their
+// library, recording the original location so that they can be restored.
+type Trash struct {
+	// TrashDirFn returns the trash directory for the provided file path.
+	// Files are deleted permanently if it returns an empty string.
+	TrashDirFn func(path string) string
+	// LibraryDirFn returns the library directory containing the provided
+	// file path. The path of the file relative to the library is preserved
+	// in the trash directory.
+	LibraryDirFn func(path string) string
+	Creator      models.TrashedFileCreator
+}
+
+type trashedFile struct {
+	originalPath string
+	trashPath    string
+}
+
+// trashDir returns the trash directory for the provided path, or an empty
+// string if files in the path should be deleted.
+func (t *Trash) trashDir(path string) string {
+	if t == nil || t.TrashDirFn == nil {
+		return ""
+	}
+
+	return t.TrashDirFn(path)
+}
+
+// trashPath returns an unused path in trashDir for the file at path.
+func (t *Trash) trashPath(statter Statter, trashDir string, path string) (string, error) {
+	rel := filepath.Base(path)
+	if t.LibraryDirFn != nil {
+		if libraryDir := t.LibraryDirFn(path); libraryDir != "" {
+			if r, err := filepath.Rel(libraryDir, path); err == nil && !strings.HasPrefix(r, "..") {
+				rel = r
+			}
+		}
+	}
+
+	ret := filepath.Join(trashDir, rel)
+	ext := filepath.Ext(ret)
+	base := strings.TrimSuffix(ret, ext)
+
+	// add a numeric suffix if a file with the same path is already trashed
+	for i := 1; ; i++ {
+		_, err := statter.Stat(ret)
+		if errors.Is(err, fs.ErrNotExist) {
+			return ret, nil
+		}
+		if err != nil {
+			return "", fmt.Errorf("checking trash path %q: %w", ret, err)
+		}
+
+		ret = base + "." + strconv.Itoa(i) + ext
+	}
+}
+
+// LibraryFiles designates library files to be deleted. If the library of
+// a file has a trash directory, the file is moved to the trash directory
+// and recorded as a trashed file. Otherwise, it is marked for deletion as
+// per Files.
+func (d *Deleter) LibraryFiles(ctx context.Context, paths []string) error {
+	for _, p := range paths {
+		trashDir := d.Trash.trashDir(p)
+		if trashDir == "" {
+			if err := d.Files([]string{p}); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err := d.trashFile(ctx, trashDir, p); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (d *Deleter) trashFile(ctx context.Context, trashDir string, path string) error {
+	// fail silently if the file does not exist
+	info, err := d.RenamerRemover.Stat(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			logger.Warnf("File %q does not exist and therefore cannot be deleted. Ignoring.", path)
+			return nil
+		}
+
+		return fmt.Errorf("check file %q exists: %w", path, err)
+	}
+
+	trashPath, err := d.Trash.trashPath(d.RenamerRemover, trashDir, path)
+	if err != nil {
+		return err
+	}
+
+	if err := fsutil.EnsureDir(filepath.Dir(trashPath)); err != nil {
+		return fmt.Errorf("creating trash directory: %w", err)
+	}
+
+	if err := moveFile(d.RenamerRemover, path, trashPath); err != nil {
+		return fmt.Errorf("moving file %q to trash: %w", path, err)
+	}
+	d.trashed = append(d.trashed, trashedFile{
+		originalPath: path,
+		trashPath:    trashPath,
+	})
+
+	if err := d.Trash.Creator.Create(ctx, &models.TrashedFile{
+		OriginalPath: path,
+		TrashPath:    trashPath,
+		Size:         info.Size(),
+		TrashedAt:    time.Now(),
+	}); err != nil {
+		return fmt.Errorf("recording trashed file %q: %w", path, err)
+	}
+
+	logger.Infof("Moved %q to trash %q", path, trashPath)
+
+	return nil
+}
+
+// RestoreTrashed moves a trashed file back to its original path.
+// An error is returned if a file already exists at the original path.
+func RestoreTrashed(renamer RenamerRemover, f *models.TrashedFile) error {
+	if _, err := renamer.Stat(f.OriginalPath); err == nil {
+		return fmt.Errorf("file already exists at %q", f.OriginalPath)
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("checking %q: %w", f.OriginalPath, err)
+	}
+
+	if err := fsutil.EnsureDir(filepath.Dir(f.OriginalPath)); err != nil {
+		return fmt.Errorf("creating directory for %q: %w", f.OriginalPath, err)
+	}
+
+	if err := moveFile(renamer, f.TrashPath, f.OriginalPath); err != nil {
+		return fmt.Errorf("restoring %q from trash: %w", f.OriginalPath, err)
+	}
+
+	return nil
+}
+
+// MoveToTrash moves a restored file back to its trash path. It is used to
+// undo RestoreTrashed.
+func MoveToTrash(renamer RenamerRemover, f *models.TrashedFile) error {
+	if err := moveFile(renamer, f.OriginalPath, f.TrashPath); err != nil {
+		return fmt.Errorf("moving %q back to trash: %w", f.OriginalPath, err)
+	}
+
+	return nil
+}
+
+// moveFile moves the file at src to dst. If the file cannot be renamed
+// because the trash directory is on a different filesystem to the library,
+// the file is copied to dst and then removed from src.
+func moveFile(rr RenamerRemover, src, dst string) error {
+	err := rr.Rename(src, dst)
+	if err == nil || !errors.Is(err, errCrossDevice) {
+		return err
+	}
+
+	if err := fsutil.CopyFile(src, dst); err != nil {
+		return fmt.Errorf("copying file across filesystems: %w", err)
+	}
+
+	if err := rr.Remove(src); err != nil {
+		// don't leave a copy of the file in both locations
+		if removeErr := rr.Remove(dst); removeErr != nil {
+			logger.Warnf("Error removing copied file %q: %v", dst, removeErr)
+		}
+		return fmt.Errorf("removing file after copying: %w", err)
+	}
+
+	return nil
+}
+
+// NewRenamerRemover returns a RenamerRemover for the filesystem.
+func NewRenamerRemover() RenamerRemover {
+	return newRenamerRemoverImpl()
+}
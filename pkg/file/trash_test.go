@@ -0,0 +1,74 @@
+package file
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testRenamerRemover uses the filesystem, except that Rename returns
+// renameErr.
+type testRenamerRemover struct {
+	renameErr error
+}
+
+func (r testRenamerRemover) Rename(oldpath, newpath string) error {
+	return &os.LinkError{Op: "rename", Old: oldpath, New: newpath, Err: r.renameErr}
+}
+
+func (r testRenamerRemover) Remove(name string) error {
+	return os.Remove(name)
+}
+
+func (r testRenamerRemover) RemoveAll(path string) error {
+	return os.RemoveAll(path)
+}
+
+func (r testRenamerRemover) Stat(path string) (fs.FileInfo, error) {
+	return os.Stat(path)
+}
+
+func TestMoveFile(t *testing.T) {
+	const contents = "contents"
+
+	tests := []struct {
+		name      string
+		renameErr error
+		wantMoved bool
+	}{
+		{"cross device", errCrossDevice, true},
+		{"permission denied", fs.ErrPermission, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			src := filepath.Join(dir, "src.mp4")
+			dst := filepath.Join(dir, "dst.mp4")
+
+			if err := os.WriteFile(src, []byte(contents), 0644); err != nil {
+				t.Fatalf("writing source file: %v", err)
+			}
+
+			err := moveFile(testRenamerRemover{renameErr: tt.renameErr}, src, dst)
+
+			_, srcErr := os.Stat(src)
+			got, dstErr := os.ReadFile(dst)
+
+			if tt.wantMoved {
+				assert.NoError(t, err)
+				assert.True(t, errors.Is(srcErr, fs.ErrNotExist), "source file was not removed")
+				assert.NoError(t, dstErr)
+				assert.Equal(t, contents, string(got))
+			} else {
+				assert.ErrorIs(t, err, tt.renameErr)
+				assert.NoError(t, srcErr, "source file was removed")
+				assert.True(t, errors.Is(dstErr, fs.ErrNotExist), "destination file was created")
+			}
+		})
+	}
+}
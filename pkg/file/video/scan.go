@@ -4,15 +4,33 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/stashapp/stash/pkg/ffmpeg"
 	"github.com/stashapp/stash/pkg/file"
+	"github.com/stashapp/stash/pkg/logger"
 	"github.com/stashapp/stash/pkg/models"
 )
 
+// StreamReader provides the stored streams of a video file.
+type StreamReader interface {
+	GetStreams(ctx context.Context, fileID models.FileID) ([]*models.VideoStream, error)
+}
+
+// ChapterReader provides the stored chapters of a video file.
+type ChapterReader interface {
+	GetChapters(ctx context.Context, fileID models.FileID) ([]*models.VideoChapter, error)
+}
+
 // Decorator adds video specific fields to a File.
 type Decorator struct {
 	FFProbe *ffmpeg.FFProbe
+	// StreamReader is used to detect files that were scanned before streams
+	// were stored. If nil, the streams are not checked.
+	StreamReader StreamReader
+	// ChapterReader is used to detect files that were scanned before
+	// chapters were stored. If nil, the chapters are not checked.
+	ChapterReader ChapterReader
 }
 
 func (d *Decorator) Decorate(ctx context.Context, fs models.FS, f models.File) (models.File, error) {
@@ -54,9 +72,44 @@ func (d *Decorator) Decorate(ctx context.Context, fs models.FS, f models.File) (
 		FrameRate:   videoFile.FrameRate,
 		BitRate:     videoFile.Bitrate,
 		Interactive: interactive,
+		Streams:     getStreams(videoFile),
+		Chapters:    getChapters(videoFile),
 	}, nil
 }
 
+// getChapters returns the chapters of the probed file. The returned slice is
+// non-nil so that existing chapters are replaced when the file is updated.
+func getChapters(videoFile *ffmpeg.VideoFile) []*models.VideoChapter {
+	ret := make([]*models.VideoChapter, 0, len(videoFile.Chapters))
+	for _, c := range videoFile.Chapters {
+		ret = append(ret, &models.VideoChapter{
+			Title:        c.Title,
+			StartSeconds: c.Start,
+			EndSeconds:   c.End,
+		})
+	}
+
+	return ret
+}
+
+// getStreams returns the streams of the probed file. The returned slice is
+// non-nil so that existing streams are replaced when the file is updated.
+func getStreams(videoFile *ffmpeg.VideoFile) []*models.VideoStream {
+	ret := make([]*models.VideoStream, 0, len(videoFile.MediaStreams))
+	for _, s := range videoFile.MediaStreams {
+		ret = append(ret, &models.VideoStream{
+			Index:    s.Index,
+			Type:     models.VideoStreamType(strings.ToUpper(s.Type)),
+			Codec:    s.Codec,
+			Language: s.Language,
+			Title:    s.Title,
+			Default:  s.Default,
+		})
+	}
+
+	return ret
+}
+
 func (d *Decorator) IsMissingMetadata(ctx context.Context, fs models.FS, f models.File) bool {
 	const (
 		unsetString = "unset"
@@ -77,5 +130,41 @@ func (d *Decorator) IsMissingMetadata(ctx context.Context, fs models.FS, f model
 		vf.Format == unsetString || vf.Width == unsetNumber ||
 		vf.Height == unsetNumber || vf.FrameRate == unsetNumber ||
 		vf.Duration == unsetNumber ||
-		vf.BitRate == unsetNumber || interactive != vf.Interactive
+		vf.BitRate == unsetNumber || interactive != vf.Interactive ||
+		d.isMissingStreams(ctx, vf) || d.isMissingChapters(ctx, vf)
+}
+
+// isMissingStreams returns true if no streams are stored for the file. All
+// probed video files have at least one stream, so this is only the case for
+// files scanned before streams were stored. Assumes a database connection is
+// present in the context.
+func (d *Decorator) isMissingStreams(ctx context.Context, vf *models.VideoFile) bool {
+	if d.StreamReader == nil || vf.Streams != nil || vf.ID == 0 {
+		return false
+	}
+
+	streams, err := d.StreamReader.GetStreams(ctx, vf.ID)
+	if err != nil {
+		logger.Warnf("error getting streams of %q: %v", vf.Path, err)
+		return false
+	}
+
+	return len(streams) == 0
+}
+
+// isMissingChapters returns true if the chapters of the file have not been
+// probed, which is the case for files scanned before chapters were stored.
+// Assumes a database connection is present in the context.
+func (d *Decorator) isMissingChapters(ctx context.Context, vf *models.VideoFile) bool {
+	if d.ChapterReader == nil || vf.Chapters != nil || vf.ID == 0 {
+		return false
+	}
+
+	chapters, err := d.ChapterReader.GetChapters(ctx, vf.ID)
+	if err != nil {
+		logger.Warnf("error getting chapters of %q: %v", vf.Path, err)
+		return false
+	}
+
+	return chapters == nil
 }
@@ -0,0 +1,98 @@
+package video
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+type streamReader map[models.FileID][]*models.VideoStream
+
+func (r streamReader) GetStreams(ctx context.Context, fileID models.FileID) ([]*models.VideoStream, error) {
+	return r[fileID], nil
+}
+
+func TestDecorator_isMissingStreams(t *testing.T) {
+	const (
+		withStreams    models.FileID = 1
+		withoutStreams models.FileID = 2
+	)
+
+	reader := streamReader{
+		withStreams: {{Index: 0, Type: models.VideoStreamTypeVideo, Codec: "h264"}},
+	}
+
+	videoFile := func(id models.FileID, streams []*models.VideoStream) *models.VideoFile {
+		return &models.VideoFile{
+			BaseFile: &models.BaseFile{ID: id},
+			Streams:  streams,
+		}
+	}
+
+	tests := []struct {
+		name   string
+		d      *Decorator
+		vf     *models.VideoFile
+		expect bool
+	}{
+		{"stored streams", &Decorator{StreamReader: reader}, videoFile(withStreams, nil), false},
+		{"no stored streams", &Decorator{StreamReader: reader}, videoFile(withoutStreams, nil), true},
+		{"probed streams", &Decorator{StreamReader: reader}, videoFile(withoutStreams, []*models.VideoStream{}), false},
+		{"new file", &Decorator{StreamReader: reader}, videoFile(0, nil), false},
+		{"no reader", &Decorator{}, videoFile(withoutStreams, nil), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expect, tt.d.isMissingStreams(context.Background(), tt.vf))
+		})
+	}
+}
+
+type chapterReader map[models.FileID][]*models.VideoChapter
+
+func (r chapterReader) GetChapters(ctx context.Context, fileID models.FileID) ([]*models.VideoChapter, error) {
+	return r[fileID], nil
+}
+
+func TestDecorator_isMissingChapters(t *testing.T) {
+	const (
+		withChapters    models.FileID = 1
+		noChapters      models.FileID = 2
+		chaptersUnknown models.FileID = 3
+	)
+
+	reader := chapterReader{
+		withChapters: {{Title: "Intro", StartSeconds: 0, EndSeconds: 10}},
+		noChapters:   {},
+	}
+
+	videoFile := func(id models.FileID, chapters []*models.VideoChapter) *models.VideoFile {
+		return &models.VideoFile{
+			BaseFile: &models.BaseFile{ID: id},
+			Chapters: chapters,
+		}
+	}
+
+	tests := []struct {
+		name   string
+		d      *Decorator
+		vf     *models.VideoFile
+		expect bool
+	}{
+		{"stored chapters", &Decorator{ChapterReader: reader}, videoFile(withChapters, nil), false},
+		{"probed without chapters", &Decorator{ChapterReader: reader}, videoFile(noChapters, nil), false},
+		{"not probed", &Decorator{ChapterReader: reader}, videoFile(chaptersUnknown, nil), true},
+		{"probed chapters", &Decorator{ChapterReader: reader}, videoFile(chaptersUnknown, []*models.VideoChapter{}), false},
+		{"new file", &Decorator{ChapterReader: reader}, videoFile(0, nil), false},
+		{"no reader", &Decorator{}, videoFile(chaptersUnknown, nil), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expect, tt.d.isMissingChapters(context.Background(), tt.vf))
+		})
+	}
+}
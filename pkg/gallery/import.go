@@ -12,6 +12,7 @@ import (
 
 type ImporterReaderWriter interface {
 	models.GalleryCreatorUpdater
+	models.CustomFieldsWriter
 	FindByFileID(ctx context.Context, fileID models.FileID) ([]*models.Gallery, error)
 	FindByFolderID(ctx context.Context, folderID models.FolderID) ([]*models.Gallery, error)
 	FindUserGalleryByTitle(ctx context.Context, title string) ([]*models.Gallery, error)
@@ -294,6 +295,14 @@ func (i *Importer) populateFilesFolder(ctx context.Context) error {
 }
 
 func (i *Importer) PostImport(ctx context.Context, id int) error {
+	if len(i.Input.CustomFields) > 0 {
+		if err := i.ReaderWriter.SetCustomFields(ctx, id, models.CustomFieldsInput{
+			Full: i.Input.CustomFields,
+		}); err != nil {
+			return fmt.Errorf("error setting custom fields: %v", err)
+		}
+	}
+
 	return nil
 }
 
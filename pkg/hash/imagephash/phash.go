@@ -0,0 +1,41 @@
+// Package imagephash generates perceptual hashes of image files.
+package imagephash
+
+import (
+	"fmt"
+	"image"
+
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+
+	"github.com/corona10/goimagehash"
+	_ "golang.org/x/image/webp"
+
+	"github.com/stashapp/stash/pkg/file"
+	"github.com/stashapp/stash/pkg/models"
+)
+
+// Generate returns the perceptual hash of the provided image file.
+// Only the first frame of animated images is used.
+// Returns an error if the image format cannot be decoded.
+func Generate(f *models.ImageFile) (*uint64, error) {
+	reader, err := f.Open(&file.OsFS{})
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	img, _, err := image.Decode(reader)
+	if err != nil {
+		return nil, fmt.Errorf("decoding image: %w", err)
+	}
+
+	hash, err := goimagehash.PerceptionHash(img)
+	if err != nil {
+		return nil, fmt.Errorf("computing phash: %w", err)
+	}
+
+	hashValue := hash.GetHash()
+	return &hashValue, nil
+}
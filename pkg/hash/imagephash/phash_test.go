@@ -0,0 +1,81 @@
+package imagephash
+
+import (
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/corona10/goimagehash"
+	"github.com/disintegration/imaging"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+func makeTestImage() image.Image {
+	const size = 256
+	img := image.NewRGBA(image.Rect(0, 0, size, size))
+	for x := 0; x < size; x++ {
+		for y := 0; y < size; y++ {
+			img.Set(x, y, color.RGBA{uint8(x), uint8(y), uint8((x * y) % 256), 255})
+		}
+	}
+
+	return img
+}
+
+func writeTestImage(t *testing.T, path string, img image.Image) *models.ImageFile {
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatalf("creating image: %v", err)
+	}
+	defer f.Close()
+
+	if err := png.Encode(f, img); err != nil {
+		t.Fatalf("encoding image: %v", err)
+	}
+
+	return &models.ImageFile{
+		BaseFile: &models.BaseFile{
+			Path: path,
+		},
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	dir := t.TempDir()
+
+	img := makeTestImage()
+	original := writeTestImage(t, filepath.Join(dir, "original.png"), img)
+	resized := writeTestImage(t, filepath.Join(dir, "resized.png"), imaging.Resize(img, 100, 0, imaging.Lanczos))
+
+	originalHash, err := Generate(original)
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	resizedHash, err := Generate(resized)
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	// resized copies should be near-identical
+	const maxDistance = 4
+	distance, err := goimagehash.NewImageHash(*originalHash, goimagehash.PHash).Distance(goimagehash.NewImageHash(*resizedHash, goimagehash.PHash))
+	if err != nil {
+		t.Fatalf("Distance() error = %v", err)
+	}
+	assert.LessOrEqual(t, distance, maxDistance)
+
+	// unsupported formats return an error
+	invalidPath := filepath.Join(dir, "invalid.png")
+	if err := os.WriteFile(invalidPath, []byte("not an image"), 0644); err != nil {
+		t.Fatalf("writing file: %v", err)
+	}
+
+	_, err = Generate(&models.ImageFile{BaseFile: &models.BaseFile{Path: invalidPath}})
+	assert.NotNil(t, err)
+}
@@ -17,6 +17,7 @@ type GalleryFinder interface {
 
 type ImporterReaderWriter interface {
 	models.ImageCreatorUpdater
+	models.CustomFieldsWriter
 	FindByFileID(ctx context.Context, fileID models.FileID) ([]*models.Image, error)
 }
 
@@ -301,6 +302,14 @@ func (i *Importer) populateTags(ctx context.Context) error {
 }
 
 func (i *Importer) PostImport(ctx context.Context, id int) error {
+	if len(i.Input.CustomFields) > 0 {
+		if err := i.ReaderWriter.SetCustomFields(ctx, id, models.CustomFieldsInput{
+			Full: i.Input.CustomFields,
+		}); err != nil {
+			return fmt.Errorf("error setting custom fields: %v", err)
+		}
+	}
+
 	return nil
 }
 
@@ -35,6 +35,9 @@ type Status string
 const (
 	// StatusReady means that the Job is not yet started.
 	StatusReady Status = "READY"
+	// StatusPaused means that the Job is queued but will not be started
+	// until it is resumed.
+	StatusPaused Status = "PAUSED"
 	// StatusRunning means that the job is currently running.
 	StatusRunning Status = "RUNNING"
 	// StatusStopping means that the job is cancelled but is still running.
@@ -47,6 +50,42 @@ const (
 	StatusFailed Status = "FAILED"
 )
 
+// ResourceClass is the class of resource that a Job mostly consumes. Jobs of
+// different resource classes are run concurrently, with the number of
+// concurrent jobs of each class limited separately.
+type ResourceClass string
+
+const (
+	// ResourceClassGeneral jobs are run exclusively. No other queued jobs are
+	// started while a general job is running.
+	ResourceClassGeneral ResourceClass = "GENERAL"
+	// ResourceClassIO jobs are disk-bound, such as scanning.
+	ResourceClassIO ResourceClass = "IO"
+	// ResourceClassCPU jobs are processor-bound, such as generating.
+	ResourceClassCPU ResourceClass = "CPU"
+	// ResourceClassNetwork jobs are network-bound, such as scraping.
+	ResourceClassNetwork ResourceClass = "NETWORK"
+)
+
+// ResourceClasses is the list of all resource classes.
+var ResourceClasses = []ResourceClass{
+	ResourceClassGeneral,
+	ResourceClassIO,
+	ResourceClassCPU,
+	ResourceClassNetwork,
+}
+
+// IsValid returns true if the resource class is a known value.
+func (c ResourceClass) IsValid() bool {
+	for _, v := range ResourceClasses {
+		if c == v {
+			return true
+		}
+	}
+
+	return false
+}
+
 // Job represents the status of a queued or running job.
 type Job struct {
 	ID     int
@@ -60,6 +99,22 @@ type Job struct {
 	EndTime   *time.Time
 	AddTime   time.Time
 	Error     *string
+	// Priority of the job. Queued jobs with a higher priority are started
+	// first.
+	Priority      int
+	ResourceClass ResourceClass
+
+	// kind and input are used to persist the job. Jobs without a kind are
+	// not persisted.
+	kind  string
+	input []byte
+	// exclusive jobs are run with no other queued jobs running.
+	exclusive bool
+	// userID is the id of the user that queued the job, and is persisted
+	// so that restored jobs are run as the same user.
+	userID *int
+	// immediate is true if the job was started outside of the queue
+	immediate bool
 
 	outerCtx   context.Context
 	exec       JobExec
@@ -80,7 +135,7 @@ func (j *Job) TimeElapsed() time.Duration {
 }
 
 func (j *Job) cancel() {
-	if j.Status == StatusReady {
+	if j.Status == StatusReady || j.Status == StatusPaused {
 		j.Status = StatusCancelled
 	} else if j.Status == StatusRunning {
 		j.Status = StatusStopping
@@ -91,6 +146,22 @@ func (j *Job) cancel() {
 	}
 }
 
+// isQueued returns true if the job has not yet been started.
+func (j *Job) isQueued() bool {
+	return j.Status == StatusReady || j.Status == StatusPaused
+}
+
+// isExclusive returns true if no other queued jobs may run at the same time
+// as the job.
+func (j *Job) isExclusive() bool {
+	return j.exclusive || j.ResourceClass == ResourceClassGeneral
+}
+
+// isActive returns true if the job is currently executing.
+func (j *Job) isActive() bool {
+	return j.Status == StatusRunning || j.Status == StatusStopping
+}
+
 func (j *Job) error(err error) {
 	errStr := err.Error()
 	j.Error = &errStr
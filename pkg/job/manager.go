@@ -2,6 +2,8 @@ package job
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"runtime/debug"
 	"sync"
 	"time"
@@ -13,17 +15,56 @@ import (
 const maxGraveyardSize = 10
 const defaultThrottleLimit = 100 * time.Millisecond
 
-// Manager maintains a queue of jobs. Jobs are executed one at a time.
+// DefaultConcurrency is the default number of jobs of each resource class
+// that may run concurrently.
+const DefaultConcurrency = 1
+
+var (
+	// ErrJobNotFound is returned when a job does not exist in the queue.
+	ErrJobNotFound = errors.New("job not found")
+	// ErrJobNotQueued is returned when attempting to modify a job that has
+	// already been started.
+	ErrJobNotQueued = errors.New("job has already been started")
+)
+
+// Options are the options used when queuing a job.
+type Options struct {
+	// Priority of the job. Queued jobs with a higher priority are started
+	// first. Jobs with the same priority are started in the order they were
+	// added.
+	Priority int
+	// ResourceClass of the job. Defaults to ResourceClassGeneral.
+	ResourceClass ResourceClass
+	// Kind identifies the type of the job when it is persisted. Jobs without
+	// a kind are not persisted.
+	Kind string
+	// Input is marshalled to JSON and persisted with the job, so that the job
+	// can be recreated after a restart.
+	Input interface{}
+	// Exclusive jobs are run with no other queued jobs running, regardless
+	// of their resource class. This is used for jobs that add, move or
+	// remove files that other jobs may be using.
+	Exclusive bool
+}
+
+// Manager maintains a queue of jobs. Jobs are executed in order of priority.
+// Jobs of different resource classes are executed concurrently, subject to
+// the concurrency limit of each resource class. General jobs are executed
+// one at a time, with no other jobs running.
 type Manager struct {
 	queue     []*Job
 	graveyard []*Job
 
-	mutex    sync.Mutex
-	notEmpty *sync.Cond
-	stop     chan struct{}
+	mutex   sync.Mutex
+	changed *sync.Cond
+	stop    chan struct{}
 
 	lastID int
 
+	concurrency map[ResourceClass]int
+	store       Store
+	persister   *persister
+
 	subscriptions       []*ManagerSubscription
 	updateThrottleLimit time.Duration
 }
@@ -32,10 +73,12 @@ type Manager struct {
 func NewManager() *Manager {
 	ret := &Manager{
 		stop:                make(chan struct{}),
+		concurrency:         make(map[ResourceClass]int),
+		persister:           newPersister(),
 		updateThrottleLimit: defaultThrottleLimit,
 	}
 
-	ret.notEmpty = sync.NewCond(&ret.mutex)
+	ret.changed = sync.NewCond(&ret.mutex)
 
 	go ret.dispatcher()
 
@@ -43,40 +86,169 @@ func NewManager() *Manager {
 }
 
 // Stop is used to stop the dispatcher thread. Once Stop is called, no
-// more Jobs will be processed.
+// more Jobs will be processed. The store is detached before the jobs are
+// cancelled, so that persisted jobs are restored on the next start.
 func (m *Manager) Stop() {
+	m.mutex.Lock()
+	m.store = nil
+	m.mutex.Unlock()
+
+	// wait for pending store operations to complete
+	m.persister.flush()
+
 	m.CancelAll()
+
+	m.persister.stop()
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
 	close(m.stop)
+	m.changed.Broadcast()
+}
+
+// SetStore sets the store used to persist queued jobs. Jobs queued before the
+// store is set are not persisted.
+func (m *Manager) SetStore(store Store) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.store = store
 }
 
-// Add queues a job.
+// SetConcurrency sets the number of jobs of the provided resource class that
+// may run concurrently. Values less than 1 are treated as 1. Has no effect
+// for general jobs, which are always run exclusively.
+func (m *Manager) SetConcurrency(class ResourceClass, n int) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if n < 1 {
+		n = 1
+	}
+
+	m.concurrency[class] = n
+	m.changed.Broadcast()
+}
+
+func (m *Manager) getConcurrency(class ResourceClass) int {
+	// assumes lock held
+	if class == ResourceClassGeneral {
+		return 1
+	}
+
+	if n, ok := m.concurrency[class]; ok {
+		return n
+	}
+
+	return DefaultConcurrency
+}
+
+// Add queues a job as a general job with the default priority.
 func (m *Manager) Add(ctx context.Context, description string, e JobExec) int {
+	return m.AddWithOptions(ctx, description, e, Options{})
+}
+
+// AddWithOptions queues a job using the provided options.
+func (m *Manager) AddWithOptions(ctx context.Context, description string, e JobExec, opts Options) int {
+	var input []byte
+	if opts.Kind != "" && opts.Input != nil {
+		var err error
+		input, err = json.Marshal(opts.Input)
+		if err != nil {
+			// the job can still be run, it just won't be persisted
+			logger.Warnf("Error marshalling input of job %q: %v", description, err)
+			opts.Kind = ""
+		}
+	}
+
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
 
-	t := time.Now()
+	j := &Job{
+		ID:            m.nextID(),
+		Status:        StatusReady,
+		Description:   description,
+		AddTime:       time.Now(),
+		Priority:      opts.Priority,
+		ResourceClass: opts.ResourceClass,
+		kind:          opts.Kind,
+		input:         input,
+		exclusive:     opts.Exclusive,
+		userID:        currentUserID(ctx),
+		exec:          e,
+		outerCtx:      ctx,
+	}
 
-	j := Job{
-		ID:          m.nextID(),
-		Status:      StatusReady,
-		Description: description,
-		AddTime:     t,
-		exec:        e,
-		outerCtx:    ctx,
+	m.queueJob(j)
+
+	m.persistCreate(j)
+
+	return j.ID
+}
+
+// Restore queues a job that was previously persisted. The job retains its
+// original ID, priority and paused state. The provided context should
+// contain the user that queued the job.
+func (m *Manager) Restore(ctx context.Context, pj PersistedJob, e JobExec) int {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	status := StatusReady
+	if pj.Paused {
+		status = StatusPaused
 	}
 
-	m.queue = append(m.queue, &j)
+	j := &Job{
+		ID:            pj.ID,
+		Status:        status,
+		Description:   pj.Description,
+		AddTime:       pj.AddTime,
+		Priority:      pj.Priority,
+		ResourceClass: pj.ResourceClass,
+		kind:          pj.Kind,
+		input:         pj.Input,
+		exclusive:     pj.Exclusive,
+		userID:        pj.UserID,
+		exec:          e,
+		outerCtx:      ctx,
+	}
 
-	if len(m.queue) == 1 {
-		// notify that there is now a job in the queue
-		m.notEmpty.Broadcast()
+	if j.ID > m.lastID {
+		m.lastID = j.ID
 	}
 
-	m.notifyNewJob(&j)
+	m.queueJob(j)
 
 	return j.ID
 }
 
+func (m *Manager) queueJob(j *Job) {
+	// assumes lock held
+	if !j.ResourceClass.IsValid() {
+		j.ResourceClass = ResourceClassGeneral
+	}
+
+	m.insertJob(j)
+	m.notifyNewJob(j)
+
+	// notify the dispatcher that there is a new job in the queue
+	m.changed.Broadcast()
+}
+
+// insertJob inserts the job into the queue, ahead of all queued jobs with a
+// lower priority.
+func (m *Manager) insertJob(j *Job) {
+	// assumes lock held
+	for i, qj := range m.queue {
+		if qj.isQueued() && qj.Priority < j.Priority {
+			m.queue = append(m.queue[:i], append([]*Job{j}, m.queue[i:]...)...)
+			return
+		}
+	}
+
+	m.queue = append(m.queue, j)
+}
+
 // Start adds a job and starts it immediately, concurrently with any other
 // jobs.
 func (m *Manager) Start(ctx context.Context, description string, e JobExec) int {
@@ -86,12 +258,14 @@ func (m *Manager) Start(ctx context.Context, description string, e JobExec) int
 	t := time.Now()
 
 	j := Job{
-		ID:          m.nextID(),
-		Status:      StatusReady,
-		Description: description,
-		AddTime:     t,
-		exec:        e,
-		outerCtx:    ctx,
+		ID:            m.nextID(),
+		Status:        StatusReady,
+		Description:   description,
+		AddTime:       t,
+		ResourceClass: ResourceClassGeneral,
+		immediate:     true,
+		exec:          e,
+		outerCtx:      ctx,
 	}
 
 	m.queue = append(m.queue, &j)
@@ -117,49 +291,65 @@ func (m *Manager) nextID() int {
 	return m.lastID
 }
 
-func (m *Manager) getReadyJob() *Job {
+// startReadyJobs starts queued jobs in queue order, as permitted by the
+// concurrency limits of their resource classes.
+func (m *Manager) startReadyJobs() {
 	// assumes lock held
+	running := make(map[ResourceClass]int)
+	total := 0
 	for _, j := range m.queue {
-		if j.Status == StatusReady {
-			return j
+		if j.isActive() && !j.immediate {
+			// no other jobs may be started while an exclusive job is running
+			if j.isExclusive() {
+				return
+			}
+
+			running[j.ResourceClass]++
+			total++
 		}
 	}
 
-	return nil
+	for _, j := range m.queue {
+		if j.Status != StatusReady {
+			continue
+		}
+
+		if j.isExclusive() {
+			// exclusive jobs wait for all running jobs to finish. Jobs after
+			// it in the queue are not started ahead of it.
+			if total == 0 {
+				m.dispatch(j.outerCtx, j)
+			}
+			return
+		}
+
+		if running[j.ResourceClass] >= m.getConcurrency(j.ResourceClass) {
+			continue
+		}
+
+		m.dispatch(j.outerCtx, j)
+		running[j.ResourceClass]++
+		total++
+	}
 }
 
 func (m *Manager) dispatcher() {
 	m.mutex.Lock()
+	defer m.mutex.Unlock()
 
 	for {
-		// wait until we have something to process
-		j := m.getReadyJob()
+		m.startReadyJobs()
 
-		for j == nil {
-			m.notEmpty.Wait()
+		// wait until the queue changes
+		m.changed.Wait()
 
-			// it's possible that we have been stopped - check here
-			select {
-			case <-m.stop:
-				m.mutex.Unlock()
-				return
-			default:
-				// keep going
-				j = m.getReadyJob()
-			}
+		// it's possible that we have been stopped - check here
+		select {
+		case <-m.stop:
+			return
+		default:
+			// keep going
 		}
-
-		done := m.dispatch(j.outerCtx, j)
-
-		// unlock the mutex and wait for the job to finish
-		m.mutex.Unlock()
-		<-done
-		m.mutex.Lock()
-
-		// remove the job from the queue
-		m.removeJob(j)
-
-		// process next job
 	}
 }
 
@@ -173,7 +363,7 @@ func (m *Manager) newProgress(j *Job) *Progress {
 	}
 }
 
-func (m *Manager) dispatch(ctx context.Context, j *Job) (done chan struct{}) {
+func (m *Manager) dispatch(ctx context.Context, j *Job) {
 	// assumes lock held
 	t := time.Now()
 	j.StartTime = &t
@@ -182,16 +372,12 @@ func (m *Manager) dispatch(ctx context.Context, j *Job) (done chan struct{}) {
 	ctx, cancelFunc := context.WithCancel(utils.ValueOnlyContext{Context: ctx})
 	j.cancelFunc = cancelFunc
 
-	done = make(chan struct{})
-	go m.executeJob(ctx, j, done)
+	go m.executeJob(ctx, j)
 
 	m.notifyJobUpdate(j)
-
-	return
 }
 
-func (m *Manager) executeJob(ctx context.Context, j *Job, done chan struct{}) {
-	defer close(done)
+func (m *Manager) executeJob(ctx context.Context, j *Job) {
 	defer m.onJobFinish(j)
 	defer func() {
 		if p := recover(); p != nil {
@@ -223,6 +409,11 @@ func (m *Manager) onJobFinish(job *Job) {
 	}
 	t := time.Now()
 	job.EndTime = &t
+
+	m.removeJob(job)
+
+	// notify the dispatcher that the job is finished
+	m.changed.Broadcast()
 }
 
 func (m *Manager) removeJob(job *Job) {
@@ -237,6 +428,8 @@ func (m *Manager) removeJob(job *Job) {
 
 	m.queue = append(m.queue[:index], m.queue[index+1:]...)
 
+	m.persistDelete(job)
+
 	m.graveyard = append(m.graveyard, job)
 	if len(m.graveyard) > maxGraveyardSize {
 		m.graveyard = m.graveyard[1:]
@@ -290,8 +483,10 @@ func (m *Manager) CancelAll() {
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
 
-	// call cancel on all
-	for _, j := range m.queue {
+	// call cancel on all. Iterate over a copy since cancelled jobs are
+	// removed from the queue.
+	queue := append([]*Job(nil), m.queue...)
+	for _, j := range queue {
 		j.cancel()
 
 		if j.Status == StatusCancelled {
@@ -301,6 +496,92 @@ func (m *Manager) CancelAll() {
 	}
 }
 
+// SetPriority sets the priority of a queued job, moving it within the
+// queue accordingly. Returns an error if the job does not exist or has
+// already been started.
+func (m *Manager) SetPriority(id int, priority int) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	index, j := m.getQueuedJob(id)
+	if j == nil {
+		return m.notQueuedError(id)
+	}
+
+	m.queue = append(m.queue[:index], m.queue[index+1:]...)
+	j.Priority = priority
+	m.insertJob(j)
+
+	m.onQueuedJobChanged(j)
+
+	return nil
+}
+
+// Pause prevents a queued job from being started until it is resumed.
+// Returns an error if the job does not exist or has already been started.
+func (m *Manager) Pause(id int) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	_, j := m.getQueuedJob(id)
+	if j == nil {
+		return m.notQueuedError(id)
+	}
+
+	if j.Status != StatusPaused {
+		j.Status = StatusPaused
+		m.onQueuedJobChanged(j)
+	}
+
+	return nil
+}
+
+// Resume allows a paused job to be started. Returns an error if the job does
+// not exist or has already been started.
+func (m *Manager) Resume(id int) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	_, j := m.getQueuedJob(id)
+	if j == nil {
+		return m.notQueuedError(id)
+	}
+
+	if j.Status == StatusPaused {
+		j.Status = StatusReady
+		m.onQueuedJobChanged(j)
+	}
+
+	return nil
+}
+
+func (m *Manager) getQueuedJob(id int) (int, *Job) {
+	// assumes lock held
+	index, j := m.getJob(m.queue, id)
+	if j == nil || !j.isQueued() {
+		return -1, nil
+	}
+
+	return index, j
+}
+
+func (m *Manager) notQueuedError(id int) error {
+	// assumes lock held
+	if _, j := m.getJob(m.queue, id); j != nil {
+		return ErrJobNotQueued
+	}
+
+	return ErrJobNotFound
+}
+
+func (m *Manager) onQueuedJobChanged(j *Job) {
+	// assumes lock held
+	m.persistUpdate(j)
+
+	m.notifyJobUpdate(j)
+	m.changed.Broadcast()
+}
+
 // GetJob returns a copy of the Job for the provided id. Returns nil if the job
 // does not exist.
 func (m *Manager) GetJob(id int) *Job {
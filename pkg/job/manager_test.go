@@ -2,6 +2,7 @@ package job
 
 import (
 	"context"
+	"sync"
 	"testing"
 	"time"
 
@@ -343,3 +344,300 @@ func TestSubscribe(t *testing.T) {
 
 	cancel()
 }
+
+func isStarted(e *testExec) bool {
+	select {
+	case <-e.started:
+		return true
+	default:
+		return false
+	}
+}
+
+func TestResourceClasses(t *testing.T) {
+	m := NewManager()
+	m.SetConcurrency(ResourceClassCPU, 2)
+
+	ctx := context.Background()
+	io1 := newTestExec(make(chan struct{}))
+	io2 := newTestExec(make(chan struct{}))
+	cpu1 := newTestExec(make(chan struct{}))
+	cpu2 := newTestExec(make(chan struct{}))
+	general := newTestExec(make(chan struct{}))
+	network := newTestExec(make(chan struct{}))
+
+	m.AddWithOptions(ctx, "io1", io1, Options{ResourceClass: ResourceClassIO})
+	m.AddWithOptions(ctx, "io2", io2, Options{ResourceClass: ResourceClassIO})
+	m.AddWithOptions(ctx, "cpu1", cpu1, Options{ResourceClass: ResourceClassCPU})
+	m.AddWithOptions(ctx, "cpu2", cpu2, Options{ResourceClass: ResourceClassCPU})
+	m.Add(ctx, "general", general)
+	m.AddWithOptions(ctx, "network", network, Options{ResourceClass: ResourceClassNetwork})
+
+	time.Sleep(sleepTime)
+
+	assert := assert.New(t)
+
+	// one io job and two cpu jobs should be running
+	assert.True(isStarted(io1))
+	assert.False(isStarted(io2))
+	assert.True(isStarted(cpu1))
+	assert.True(isStarted(cpu2))
+
+	// general job waits for running jobs, and blocks jobs queued after it
+	assert.False(isStarted(general))
+	assert.False(isStarted(network))
+
+	close(io1.finish)
+	time.Sleep(sleepTime)
+
+	// io2 is ahead of the general job in the queue
+	assert.True(isStarted(io2))
+	assert.False(isStarted(general))
+
+	close(io2.finish)
+	close(cpu1.finish)
+	close(cpu2.finish)
+	time.Sleep(sleepTime)
+
+	// general job should run alone
+	assert.True(isStarted(general))
+	assert.False(isStarted(network))
+
+	close(general.finish)
+	time.Sleep(sleepTime)
+
+	assert.True(isStarted(network))
+
+	close(network.finish)
+}
+
+func TestExclusive(t *testing.T) {
+	m := NewManager()
+
+	ctx := context.Background()
+	cpu := newTestExec(make(chan struct{}))
+	scan := newTestExec(make(chan struct{}))
+	network := newTestExec(make(chan struct{}))
+
+	m.AddWithOptions(ctx, "cpu", cpu, Options{ResourceClass: ResourceClassCPU})
+	m.AddWithOptions(ctx, "scan", scan, Options{ResourceClass: ResourceClassIO, Exclusive: true})
+	m.AddWithOptions(ctx, "network", network, Options{ResourceClass: ResourceClassNetwork})
+
+	time.Sleep(sleepTime)
+
+	assert := assert.New(t)
+
+	// exclusive job waits for running jobs, and blocks jobs queued after it
+	assert.True(isStarted(cpu))
+	assert.False(isStarted(scan))
+	assert.False(isStarted(network))
+
+	close(cpu.finish)
+	time.Sleep(sleepTime)
+
+	// exclusive job should run alone
+	assert.True(isStarted(scan))
+	assert.False(isStarted(network))
+
+	close(scan.finish)
+	time.Sleep(sleepTime)
+
+	assert.True(isStarted(network))
+
+	close(network.finish)
+}
+
+func TestPriority(t *testing.T) {
+	m := NewManager()
+
+	ctx := context.Background()
+	exec1 := newTestExec(make(chan struct{}))
+	exec2 := newTestExec(make(chan struct{}))
+	exec3 := newTestExec(make(chan struct{}))
+	exec4 := newTestExec(make(chan struct{}))
+
+	job1ID := m.Add(ctx, "job1", exec1)
+
+	// wait for the first job to start
+	time.Sleep(sleepTime)
+
+	job2ID := m.Add(ctx, "job2", exec2)
+	job3ID := m.AddWithOptions(ctx, "job3", exec3, Options{Priority: 1})
+	job4ID := m.Add(ctx, "job4", exec4)
+
+	time.Sleep(sleepTime)
+
+	assert := assert.New(t)
+
+	getQueueIDs := func() []int {
+		var ret []int
+		for _, j := range m.GetQueue() {
+			ret = append(ret, j.ID)
+		}
+		return ret
+	}
+
+	// higher priority job should be queued ahead of lower priority jobs,
+	// but not ahead of the running job
+	assert.Equal([]int{job1ID, job3ID, job2ID, job4ID}, getQueueIDs())
+
+	assert.NoError(m.SetPriority(job4ID, 2))
+	assert.Equal([]int{job1ID, job4ID, job3ID, job2ID}, getQueueIDs())
+
+	// running jobs cannot be reprioritised
+	assert.ErrorIs(m.SetPriority(job1ID, 5), ErrJobNotQueued)
+	assert.ErrorIs(m.SetPriority(100, 5), ErrJobNotFound)
+
+	close(exec1.finish)
+	time.Sleep(sleepTime)
+
+	assert.True(isStarted(exec4))
+	assert.False(isStarted(exec3))
+
+	close(exec4.finish)
+	close(exec3.finish)
+	close(exec2.finish)
+}
+
+func TestPauseResume(t *testing.T) {
+	m := NewManager()
+
+	ctx := context.Background()
+	exec1 := newTestExec(make(chan struct{}))
+	exec2 := newTestExec(make(chan struct{}))
+	exec3 := newTestExec(make(chan struct{}))
+
+	job1ID := m.Add(ctx, "job1", exec1)
+	job2ID := m.Add(ctx, "job2", exec2)
+	m.Add(ctx, "job3", exec3)
+
+	time.Sleep(sleepTime)
+
+	assert := assert.New(t)
+
+	assert.NoError(m.Pause(job2ID))
+	assert.Equal(StatusPaused, m.GetJob(job2ID).Status)
+	assert.ErrorIs(m.Pause(job1ID), ErrJobNotQueued)
+
+	close(exec1.finish)
+	time.Sleep(sleepTime)
+
+	// paused job should be skipped
+	assert.False(isStarted(exec2))
+	assert.True(isStarted(exec3))
+
+	close(exec3.finish)
+	time.Sleep(sleepTime)
+
+	assert.False(isStarted(exec2))
+
+	assert.NoError(m.Resume(job2ID))
+	time.Sleep(sleepTime)
+
+	assert.True(isStarted(exec2))
+	close(exec2.finish)
+}
+
+type testStore struct {
+	mutex sync.Mutex
+	jobs  map[int]PersistedJob
+}
+
+func (s *testStore) Create(ctx context.Context, j PersistedJob) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.jobs[j.ID] = j
+	return nil
+}
+
+func (s *testStore) Update(ctx context.Context, j PersistedJob) error {
+	return s.Create(ctx, j)
+}
+
+func (s *testStore) Delete(ctx context.Context, id int) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	delete(s.jobs, id)
+	return nil
+}
+
+func (s *testStore) get(id int) (PersistedJob, bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	j, ok := s.jobs[id]
+	return j, ok
+}
+
+func TestStore(t *testing.T) {
+	m := NewManager()
+	store := &testStore{jobs: make(map[int]PersistedJob)}
+	m.SetStore(store)
+
+	type testInput struct {
+		Paths []string `json:"paths"`
+	}
+
+	ctx := context.Background()
+	exec1 := newTestExec(make(chan struct{}))
+	exec2 := newTestExec(make(chan struct{}))
+	exec3 := newTestExec(make(chan struct{}))
+
+	job1ID := m.AddWithOptions(ctx, "job1", exec1, Options{Kind: "test", Input: testInput{Paths: []string{"foo"}}})
+	job2ID := m.AddWithOptions(ctx, "job2", exec2, Options{Kind: "test", ResourceClass: ResourceClassIO})
+	job3ID := m.Add(ctx, "job3", exec3)
+
+	time.Sleep(sleepTime)
+
+	assert := assert.New(t)
+
+	pj, ok := store.get(job1ID)
+	assert.True(ok)
+	assert.Equal("test", pj.Kind)
+	assert.Equal("job1", pj.Description)
+	assert.JSONEq(`{"paths":["foo"]}`, string(pj.Input))
+	assert.Equal(ResourceClassGeneral, pj.ResourceClass)
+
+	// jobs without a kind should not be persisted
+	_, ok = store.get(job3ID)
+	assert.False(ok)
+
+	// changes to queued jobs should be persisted
+	assert.NoError(m.SetPriority(job2ID, 3))
+	assert.NoError(m.Pause(job2ID))
+	time.Sleep(sleepTime)
+	pj, _ = store.get(job2ID)
+	assert.Equal(3, pj.Priority)
+	assert.True(pj.Paused)
+
+	// finished jobs should be removed
+	close(exec1.finish)
+	time.Sleep(sleepTime)
+	_, ok = store.get(job1ID)
+	assert.False(ok)
+
+	// stopping the manager should not remove persisted jobs
+	m.Stop()
+	close(exec3.finish)
+	time.Sleep(sleepTime)
+	_, ok = store.get(job2ID)
+	assert.True(ok)
+
+	// restore into a new manager
+	m = NewManager()
+	exec2 = newTestExec(make(chan struct{}))
+	restoredID := m.Restore(ctx, pj, exec2)
+	assert.Equal(job2ID, restoredID)
+
+	j := m.GetJob(job2ID)
+	assert.Equal(StatusPaused, j.Status)
+	assert.Equal(3, j.Priority)
+	assert.Equal(ResourceClassIO, j.ResourceClass)
+
+	// new jobs should not reuse restored IDs
+	assert.Greater(m.Add(ctx, "job4", newTestExec(nil)), job2ID)
+
+	assert.NoError(m.Resume(job2ID))
+	time.Sleep(sleepTime)
+	assert.True(isStarted(exec2))
+	close(exec2.finish)
+}
@@ -0,0 +1,185 @@
+package job
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/session"
+)
+
+// PersistedJob is the stored representation of a queued job.
+type PersistedJob struct {
+	ID int
+	// Kind identifies the type of the job, and is used to recreate the job
+	// when it is restored.
+	Kind          string
+	Description   string
+	Input         []byte
+	Priority      int
+	ResourceClass ResourceClass
+	Paused        bool
+	AddTime       time.Time
+	// UserID is the id of the user that queued the job.
+	UserID *int
+	// Exclusive is not stored. It is determined from the kind of the job
+	// when it is restored.
+	Exclusive bool
+}
+
+// Store persists queued jobs so that unfinished jobs can be restored
+// after a restart.
+type Store interface {
+	Create(ctx context.Context, j PersistedJob) error
+	Update(ctx context.Context, j PersistedJob) error
+	Delete(ctx context.Context, id int) error
+}
+
+func (m *Manager) persistCreate(j *Job) {
+	// assumes lock held
+	if j.kind == "" || m.store == nil {
+		return
+	}
+
+	store := m.store
+	pj := j.persisted()
+	m.persister.add(func() {
+		if err := store.Create(context.Background(), pj); err != nil {
+			logger.Warnf("Error persisting job %d - %s: %v", pj.ID, pj.Description, err)
+		}
+	})
+}
+
+func (m *Manager) persistUpdate(j *Job) {
+	// assumes lock held
+	if j.kind == "" || m.store == nil {
+		return
+	}
+
+	store := m.store
+	pj := j.persisted()
+	m.persister.add(func() {
+		if err := store.Update(context.Background(), pj); err != nil {
+			logger.Warnf("Error persisting job %d - %s: %v", pj.ID, pj.Description, err)
+		}
+	})
+}
+
+func (m *Manager) persistDelete(j *Job) {
+	// assumes lock held
+	if j.kind == "" || m.store == nil {
+		return
+	}
+
+	store := m.store
+	id := j.ID
+	description := j.Description
+	m.persister.add(func() {
+		if err := store.Delete(context.Background(), id); err != nil {
+			logger.Warnf("Error removing persisted job %d - %s: %v", id, description, err)
+		}
+	})
+}
+
+func (j *Job) persisted() PersistedJob {
+	return PersistedJob{
+		ID:            j.ID,
+		Kind:          j.kind,
+		Description:   j.Description,
+		Input:         j.input,
+		Priority:      j.Priority,
+		ResourceClass: j.ResourceClass,
+		Paused:        j.Status == StatusPaused,
+		AddTime:       j.AddTime,
+		UserID:        j.userID,
+		Exclusive:     j.exclusive,
+	}
+}
+
+func currentUserID(ctx context.Context) *int {
+	if u := session.GetCurrentUser(ctx); u != nil {
+		return &u.ID
+	}
+
+	return nil
+}
+
+// persister runs store operations in order, outside of the manager lock.
+// The store may need to wait for running jobs to release the database, and
+// running jobs may need the manager lock to report progress.
+type persister struct {
+	mutex   sync.Mutex
+	cond    *sync.Cond
+	ops     []func()
+	running bool
+	stopped bool
+}
+
+func newPersister() *persister {
+	ret := &persister{}
+	ret.cond = sync.NewCond(&ret.mutex)
+
+	go ret.run()
+
+	return ret
+}
+
+func (p *persister) add(op func()) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if p.stopped {
+		return
+	}
+
+	p.ops = append(p.ops, op)
+	p.cond.Broadcast()
+}
+
+func (p *persister) run() {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	for {
+		for len(p.ops) == 0 && !p.stopped {
+			p.cond.Wait()
+		}
+
+		if len(p.ops) == 0 {
+			// stopped with no pending operations
+			return
+		}
+
+		op := p.ops[0]
+		p.ops = p.ops[1:]
+		p.running = true
+
+		p.mutex.Unlock()
+		op()
+		p.mutex.Lock()
+
+		p.running = false
+		p.cond.Broadcast()
+	}
+}
+
+// flush waits until all queued operations have been run.
+func (p *persister) flush() {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	for len(p.ops) > 0 || p.running {
+		p.cond.Wait()
+	}
+}
+
+// stop stops the persister goroutine once all queued operations have been
+// run. Operations added after stop is called are not run.
+func (p *persister) stop() {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.stopped = true
+	p.cond.Broadcast()
+}
@@ -12,12 +12,41 @@ type FileQueryOptions struct {
 }
 
 type FileFilterType struct {
-	And *FileFilterType `json:"AND"`
-	Or  *FileFilterType `json:"OR"`
-	Not *FileFilterType `json:"NOT"`
+	OperatorFilter[FileFilterType]
 
 	// Filter by path
 	Path *StringCriterionInput `json:"path"`
+	// Filter by basename
+	Basename *StringCriterionInput `json:"basename"`
+	// Filter by parent folder
+	ParentFolder *MultiCriterionInput `json:"parent_folder"`
+	// Filter by containing zip file
+	ZipFile *MultiCriterionInput `json:"zip_file"`
+	// Filter by file size in bytes
+	Size *IntCriterionInput `json:"size"`
+	// Filter by modification time
+	ModTime *TimestampCriterionInput `json:"mod_time"`
+	// Filter by fingerprints. Files must match all provided fingerprints.
+	Fingerprints []*FingerprintFilterInput `json:"fingerprints"`
+	// Filter by video codec
+	VideoCodec *StringCriterionInput `json:"video_codec"`
+	// Filter by audio codec
+	AudioCodec *StringCriterionInput `json:"audio_codec"`
+	// Filter by resolution
+	Resolution *ResolutionCriterionInput `json:"resolution"`
+	// Filter to only include files that are not associated with a scene, image or gallery
+	Orphaned *bool `json:"orphaned"`
+	// Filter by creation time
+	CreatedAt *TimestampCriterionInput `json:"created_at"`
+	// Filter by last update time
+	UpdatedAt *TimestampCriterionInput `json:"updated_at"`
+}
+
+type FingerprintFilterInput struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+	// Hamming distance - only applies to phash fingerprints
+	Distance *int `json:"distance"`
 }
 
 func PathsFileFilter(paths []string) *FileFilterType {
@@ -0,0 +1,53 @@
+package models
+
+import "context"
+
+type FolderQueryOptions struct {
+	QueryOptions
+	FolderFilter *FolderFilterType
+}
+
+type FolderFilterType struct {
+	OperatorFilter[FolderFilterType]
+
+	// Filter by path
+	Path *StringCriterionInput `json:"path"`
+	// Filter by parent folder
+	ParentFolder *MultiCriterionInput `json:"parent_folder"`
+	// Filter by containing zip file
+	ZipFile *MultiCriterionInput `json:"zip_file"`
+	// Filter by modification time
+	ModTime *TimestampCriterionInput `json:"mod_time"`
+	// Filter to only include folders that contain no files or sub-folders,
+	// and are not associated with a gallery
+	Orphaned *bool `json:"orphaned"`
+	// Filter by creation time
+	CreatedAt *TimestampCriterionInput `json:"created_at"`
+	// Filter by last update time
+	UpdatedAt *TimestampCriterionInput `json:"updated_at"`
+}
+
+type FolderQueryResult struct {
+	// can't use QueryResult because id type is wrong
+
+	IDs   []FolderID
+	Count int
+
+	getter     FolderGetter
+	folders    []*Folder
+	resolveErr error
+}
+
+func NewFolderQueryResult(folderGetter FolderGetter) *FolderQueryResult {
+	return &FolderQueryResult{
+		getter: folderGetter,
+	}
+}
+
+func (r *FolderQueryResult) Resolve(ctx context.Context) ([]*Folder, error) {
+	// cache results
+	if r.folders == nil && r.resolveErr == nil {
+		r.folders, r.resolveErr = r.getter.FindMany(ctx, r.IDs)
+	}
+	return r.folders, r.resolveErr
+}
@@ -0,0 +1,36 @@
+package jsonschema
+
+import (
+	"os"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/stashapp/stash/pkg/models"
+)
+
+// CustomFieldDefinition is a custom field definition. The definitions are
+// stored in a single file, and are imported before the objects using them.
+type CustomFieldDefinition struct {
+	EntityType models.CustomFieldEntityType `json:"entity_type"`
+	Name       string                       `json:"name"`
+	Type       models.CustomFieldType       `json:"type"`
+}
+
+func LoadCustomFieldDefinitionsFile(filePath string) ([]CustomFieldDefinition, error) {
+	var definitions []CustomFieldDefinition
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	var json = jsoniter.ConfigCompatibleWithStandardLibrary
+	jsonParser := json.NewDecoder(file)
+	err = jsonParser.Decode(&definitions)
+	if err != nil {
+		return nil, err
+	}
+	return definitions, nil
+}
+
+func SaveCustomFieldDefinitionsFile(filePath string, definitions []CustomFieldDefinition) error {
+	return marshalToFile(filePath, definitions)
+}
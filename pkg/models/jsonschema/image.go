@@ -30,6 +30,8 @@ type Image struct {
 	Files        []string      `json:"files,omitempty"`
 	CreatedAt    json.JSONTime `json:"created_at,omitempty"`
 	UpdatedAt    json.JSONTime `json:"updated_at,omitempty"`
+
+	CustomFields map[string]interface{} `json:"custom_fields,omitempty"`
 }
 
 func (s Image) Filename(basename string, hash string) string {
@@ -0,0 +1,149 @@
+// Code generated by mockery v2.10.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/stashapp/stash/pkg/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// CustomFieldDefinitionReaderWriter is an autogenerated mock type for the CustomFieldDefinitionReaderWriter type
+type CustomFieldDefinitionReaderWriter struct {
+	mock.Mock
+}
+
+// All provides a mock function with given fields: ctx
+func (_m *CustomFieldDefinitionReaderWriter) All(ctx context.Context) ([]*models.CustomFieldDefinition, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []*models.CustomFieldDefinition
+	if rf, ok := ret.Get(0).(func(context.Context) []*models.CustomFieldDefinition); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.CustomFieldDefinition)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Create provides a mock function with given fields: ctx, newDefinition
+func (_m *CustomFieldDefinitionReaderWriter) Create(ctx context.Context, newDefinition *models.CustomFieldDefinition) error {
+	ret := _m.Called(ctx, newDefinition)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.CustomFieldDefinition) error); ok {
+		r0 = rf(ctx, newDefinition)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Destroy provides a mock function with given fields: ctx, id
+func (_m *CustomFieldDefinitionReaderWriter) Destroy(ctx context.Context, id int) error {
+	ret := _m.Called(ctx, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Find provides a mock function with given fields: ctx, id
+func (_m *CustomFieldDefinitionReaderWriter) Find(ctx context.Context, id int) (*models.CustomFieldDefinition, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *models.CustomFieldDefinition
+	if rf, ok := ret.Get(0).(func(context.Context, int) *models.CustomFieldDefinition); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.CustomFieldDefinition)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindByEntityType provides a mock function with given fields: ctx, entityType
+func (_m *CustomFieldDefinitionReaderWriter) FindByEntityType(ctx context.Context, entityType models.CustomFieldEntityType) ([]*models.CustomFieldDefinition, error) {
+	ret := _m.Called(ctx, entityType)
+
+	var r0 []*models.CustomFieldDefinition
+	if rf, ok := ret.Get(0).(func(context.Context, models.CustomFieldEntityType) []*models.CustomFieldDefinition); ok {
+		r0 = rf(ctx, entityType)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.CustomFieldDefinition)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, models.CustomFieldEntityType) error); ok {
+		r1 = rf(ctx, entityType)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindByName provides a mock function with given fields: ctx, entityType, name
+func (_m *CustomFieldDefinitionReaderWriter) FindByName(ctx context.Context, entityType models.CustomFieldEntityType, name string) (*models.CustomFieldDefinition, error) {
+	ret := _m.Called(ctx, entityType, name)
+
+	var r0 *models.CustomFieldDefinition
+	if rf, ok := ret.Get(0).(func(context.Context, models.CustomFieldEntityType, string) *models.CustomFieldDefinition); ok {
+		r0 = rf(ctx, entityType, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.CustomFieldDefinition)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, models.CustomFieldEntityType, string) error); ok {
+		r1 = rf(ctx, entityType, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Update provides a mock function with given fields: ctx, updatedDefinition
+func (_m *CustomFieldDefinitionReaderWriter) Update(ctx context.Context, updatedDefinition *models.CustomFieldDefinition) error {
+	ret := _m.Called(ctx, updatedDefinition)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.CustomFieldDefinition) error); ok {
+		r0 = rf(ctx, updatedDefinition)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
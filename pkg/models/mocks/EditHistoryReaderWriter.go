@@ -0,0 +1,75 @@
+// Code generated by mockery v2.10.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/stashapp/stash/pkg/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// EditHistoryReaderWriter is an autogenerated mock type for the EditHistoryReaderWriter type
+type EditHistoryReaderWriter struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: ctx, newEdit
+func (_m *EditHistoryReaderWriter) Create(ctx context.Context, newEdit *models.EditHistory) error {
+	ret := _m.Called(ctx, newEdit)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.EditHistory) error); ok {
+		r0 = rf(ctx, newEdit)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Find provides a mock function with given fields: ctx, id
+func (_m *EditHistoryReaderWriter) Find(ctx context.Context, id int) (*models.EditHistory, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *models.EditHistory
+	if rf, ok := ret.Get(0).(func(context.Context, int) *models.EditHistory); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.EditHistory)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindByObject provides a mock function with given fields: ctx, objectType, objectID
+func (_m *EditHistoryReaderWriter) FindByObject(ctx context.Context, objectType models.EditObjectType, objectID int) ([]*models.EditHistory, error) {
+	ret := _m.Called(ctx, objectType, objectID)
+
+	var r0 []*models.EditHistory
+	if rf, ok := ret.Get(0).(func(context.Context, models.EditObjectType, int) []*models.EditHistory); ok {
+		r0 = rf(ctx, objectType, objectID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.EditHistory)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, models.EditObjectType, int) error); ok {
+		r1 = rf(ctx, objectType, objectID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
@@ -291,6 +291,52 @@ func (_m *FileReaderWriter) GetCaptions(ctx context.Context, fileID models.FileI
 	return r0, r1
 }
 
+// GetChapters provides a mock function with given fields: ctx, fileID
+func (_m *FileReaderWriter) GetChapters(ctx context.Context, fileID models.FileID) ([]*models.VideoChapter, error) {
+	ret := _m.Called(ctx, fileID)
+
+	var r0 []*models.VideoChapter
+	if rf, ok := ret.Get(0).(func(context.Context, models.FileID) []*models.VideoChapter); ok {
+		r0 = rf(ctx, fileID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.VideoChapter)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, models.FileID) error); ok {
+		r1 = rf(ctx, fileID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetStreams provides a mock function with given fields: ctx, fileID
+func (_m *FileReaderWriter) GetStreams(ctx context.Context, fileID models.FileID) ([]*models.VideoStream, error) {
+	ret := _m.Called(ctx, fileID)
+
+	var r0 []*models.VideoStream
+	if rf, ok := ret.Get(0).(func(context.Context, models.FileID) []*models.VideoStream); ok {
+		r0 = rf(ctx, fileID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.VideoStream)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, models.FileID) error); ok {
+		r1 = rf(ctx, fileID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // IsPrimary provides a mock function with given fields: ctx, fileID
 func (_m *FileReaderWriter) IsPrimary(ctx context.Context, fileID models.FileID) (bool, error) {
 	ret := _m.Called(ctx, fileID)
@@ -0,0 +1,103 @@
+// Code generated by mockery v2.10.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/stashapp/stash/pkg/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// QueuedJobReaderWriter is an autogenerated mock type for the QueuedJobReaderWriter type
+type QueuedJobReaderWriter struct {
+	mock.Mock
+}
+
+// All provides a mock function with given fields: ctx
+func (_m *QueuedJobReaderWriter) All(ctx context.Context) ([]*models.QueuedJob, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []*models.QueuedJob
+	if rf, ok := ret.Get(0).(func(context.Context) []*models.QueuedJob); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.QueuedJob)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Create provides a mock function with given fields: ctx, newJob
+func (_m *QueuedJobReaderWriter) Create(ctx context.Context, newJob *models.QueuedJob) error {
+	ret := _m.Called(ctx, newJob)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.QueuedJob) error); ok {
+		r0 = rf(ctx, newJob)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Destroy provides a mock function with given fields: ctx, id
+func (_m *QueuedJobReaderWriter) Destroy(ctx context.Context, id int) error {
+	ret := _m.Called(ctx, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Find provides a mock function with given fields: ctx, id
+func (_m *QueuedJobReaderWriter) Find(ctx context.Context, id int) (*models.QueuedJob, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *models.QueuedJob
+	if rf, ok := ret.Get(0).(func(context.Context, int) *models.QueuedJob); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.QueuedJob)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Update provides a mock function with given fields: ctx, updatedJob
+func (_m *QueuedJobReaderWriter) Update(ctx context.Context, updatedJob *models.QueuedJob) error {
+	ret := _m.Called(ctx, updatedJob)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.QueuedJob) error); ok {
+		r0 = rf(ctx, updatedJob)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
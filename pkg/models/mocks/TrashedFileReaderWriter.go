@@ -0,0 +1,136 @@
+// Code generated by mockery v2.10.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	time "time"
+
+	models "github.com/stashapp/stash/pkg/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// TrashedFileReaderWriter is an autogenerated mock type for the TrashedFileReaderWriter type
+type TrashedFileReaderWriter struct {
+	mock.Mock
+}
+
+// All provides a mock function with given fields: ctx
+func (_m *TrashedFileReaderWriter) All(ctx context.Context) ([]*models.TrashedFile, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []*models.TrashedFile
+	if rf, ok := ret.Get(0).(func(context.Context) []*models.TrashedFile); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.TrashedFile)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Create provides a mock function with given fields: ctx, newFile
+func (_m *TrashedFileReaderWriter) Create(ctx context.Context, newFile *models.TrashedFile) error {
+	ret := _m.Called(ctx, newFile)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.TrashedFile) error); ok {
+		r0 = rf(ctx, newFile)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Destroy provides a mock function with given fields: ctx, id
+func (_m *TrashedFileReaderWriter) Destroy(ctx context.Context, id int) error {
+	ret := _m.Called(ctx, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Find provides a mock function with given fields: ctx, id
+func (_m *TrashedFileReaderWriter) Find(ctx context.Context, id int) (*models.TrashedFile, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *models.TrashedFile
+	if rf, ok := ret.Get(0).(func(context.Context, int) *models.TrashedFile); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.TrashedFile)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindMany provides a mock function with given fields: ctx, ids
+func (_m *TrashedFileReaderWriter) FindMany(ctx context.Context, ids []int) ([]*models.TrashedFile, error) {
+	ret := _m.Called(ctx, ids)
+
+	var r0 []*models.TrashedFile
+	if rf, ok := ret.Get(0).(func(context.Context, []int) []*models.TrashedFile); ok {
+		r0 = rf(ctx, ids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.TrashedFile)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []int) error); ok {
+		r1 = rf(ctx, ids)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindTrashedBefore provides a mock function with given fields: ctx, t
+func (_m *TrashedFileReaderWriter) FindTrashedBefore(ctx context.Context, t time.Time) ([]*models.TrashedFile, error) {
+	ret := _m.Called(ctx, t)
+
+	var r0 []*models.TrashedFile
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) []*models.TrashedFile); ok {
+		r0 = rf(ctx, t)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.TrashedFile)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, t)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
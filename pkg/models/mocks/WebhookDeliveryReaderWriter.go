@@ -0,0 +1,96 @@
+// Code generated by mockery v2.10.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/stashapp/stash/pkg/models"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// WebhookDeliveryReaderWriter is an autogenerated mock type for the WebhookDeliveryReaderWriter type
+type WebhookDeliveryReaderWriter struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: ctx, newDelivery
+func (_m *WebhookDeliveryReaderWriter) Create(ctx context.Context, newDelivery *models.WebhookDelivery) error {
+	ret := _m.Called(ctx, newDelivery)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.WebhookDelivery) error); ok {
+		r0 = rf(ctx, newDelivery)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FailPending provides a mock function with given fields: ctx, errorMessage, completedAt
+func (_m *WebhookDeliveryReaderWriter) FailPending(ctx context.Context, errorMessage string, completedAt time.Time) error {
+	ret := _m.Called(ctx, errorMessage, completedAt)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) error); ok {
+		r0 = rf(ctx, errorMessage, completedAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FindRecent provides a mock function with given fields: ctx, webhookID, limit
+func (_m *WebhookDeliveryReaderWriter) FindRecent(ctx context.Context, webhookID *int, limit int) ([]*models.WebhookDelivery, error) {
+	ret := _m.Called(ctx, webhookID, limit)
+
+	var r0 []*models.WebhookDelivery
+	if rf, ok := ret.Get(0).(func(context.Context, *int, int) []*models.WebhookDelivery); ok {
+		r0 = rf(ctx, webhookID, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.WebhookDelivery)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *int, int) error); ok {
+		r1 = rf(ctx, webhookID, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Prune provides a mock function with given fields: ctx, keep
+func (_m *WebhookDeliveryReaderWriter) Prune(ctx context.Context, keep int) error {
+	ret := _m.Called(ctx, keep)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) error); ok {
+		r0 = rf(ctx, keep)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Update provides a mock function with given fields: ctx, updatedDelivery
+func (_m *WebhookDeliveryReaderWriter) Update(ctx context.Context, updatedDelivery *models.WebhookDelivery) error {
+	ret := _m.Called(ctx, updatedDelivery)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.WebhookDelivery) error); ok {
+		r0 = rf(ctx, updatedDelivery)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
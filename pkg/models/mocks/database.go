@@ -22,6 +22,13 @@ type Database struct {
 	Studio         *StudioReaderWriter
 	Tag            *TagReaderWriter
 	SavedFilter    *SavedFilterReaderWriter
+	User           *UserReaderWriter
+	APIKey         *APIKeyReaderWriter
+	EditHistory    *EditHistoryReaderWriter
+	TrashedFile    *TrashedFileReaderWriter
+	QueuedJob      *QueuedJobReaderWriter
+	WebhookLog     *WebhookDeliveryReaderWriter
+	CustomField    *CustomFieldDefinitionReaderWriter
 }
 
 func (*Database) Begin(ctx context.Context, exclusive bool) (context.Context, error) {
@@ -71,6 +78,13 @@ func NewDatabase() *Database {
 		Studio:         &StudioReaderWriter{},
 		Tag:            &TagReaderWriter{},
 		SavedFilter:    &SavedFilterReaderWriter{},
+		User:           &UserReaderWriter{},
+		APIKey:         &APIKeyReaderWriter{},
+		EditHistory:    &EditHistoryReaderWriter{},
+		TrashedFile:    &TrashedFileReaderWriter{},
+		QueuedJob:      &QueuedJobReaderWriter{},
+		WebhookLog:     &WebhookDeliveryReaderWriter{},
+		CustomField:    &CustomFieldDefinitionReaderWriter{},
 	}
 }
 
@@ -87,6 +101,13 @@ func (db *Database) AssertExpectations(t mock.TestingT) {
 	db.Studio.AssertExpectations(t)
 	db.Tag.AssertExpectations(t)
 	db.SavedFilter.AssertExpectations(t)
+	db.User.AssertExpectations(t)
+	db.APIKey.AssertExpectations(t)
+	db.EditHistory.AssertExpectations(t)
+	db.TrashedFile.AssertExpectations(t)
+	db.QueuedJob.AssertExpectations(t)
+	db.WebhookLog.AssertExpectations(t)
+	db.CustomField.AssertExpectations(t)
 }
 
 func (db *Database) Repository() models.Repository {
@@ -104,5 +125,12 @@ func (db *Database) Repository() models.Repository {
 		Studio:         db.Studio,
 		Tag:            db.Tag,
 		SavedFilter:    db.SavedFilter,
+		User:           db.User,
+		APIKey:         db.APIKey,
+		EditHistory:    db.EditHistory,
+		TrashedFile:    db.TrashedFile,
+		QueuedJob:      db.QueuedJob,
+		WebhookLog:     db.WebhookLog,
+		CustomField:    db.CustomField,
 	}
 }
@@ -0,0 +1,122 @@
+package models
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+type APIKeyScope string
+
+const (
+	// APIKeyScopeRead allows querying library content. All API keys
+	// include this scope.
+	APIKeyScopeRead APIKeyScope = "READ"
+	// APIKeyScopeMetadata allows modifying library content.
+	APIKeyScopeMetadata APIKeyScope = "METADATA"
+	// APIKeyScopeJobs allows running and stopping tasks and jobs.
+	APIKeyScopeJobs APIKeyScope = "JOBS"
+	// APIKeyScopeAdmin allows all operations, including changing the
+	// system configuration and executing SQL.
+	APIKeyScopeAdmin APIKeyScope = "ADMIN"
+)
+
+var AllAPIKeyScope = []APIKeyScope{
+	APIKeyScopeRead,
+	APIKeyScopeMetadata,
+	APIKeyScopeJobs,
+	APIKeyScopeAdmin,
+}
+
+func (e APIKeyScope) IsValid() bool {
+	switch e {
+	case APIKeyScopeRead, APIKeyScopeMetadata, APIKeyScopeJobs, APIKeyScopeAdmin:
+		return true
+	}
+	return false
+}
+
+func (e APIKeyScope) String() string {
+	return string(e)
+}
+
+func (e *APIKeyScope) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = APIKeyScope(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid APIKeyScope", str)
+	}
+	return nil
+}
+
+func (e APIKeyScope) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type APIKey struct {
+	ID     int    `json:"id"`
+	UserID int    `json:"user_id"`
+	Name   string `json:"name"`
+	// KeyHash is the SHA-256 hash of the key. The key itself is not stored.
+	KeyHash    string        `json:"-"`
+	Scopes     []APIKeyScope `json:"scopes"`
+	ExpiresAt  *time.Time    `json:"expires_at"`
+	LastUsedAt *time.Time    `json:"last_used_at"`
+	RevokedAt  *time.Time    `json:"revoked_at"`
+	CreatedAt  time.Time     `json:"created_at"`
+	UpdatedAt  time.Time     `json:"updated_at"`
+}
+
+func NewAPIKey() APIKey {
+	currentTime := time.Now()
+	return APIKey{
+		CreatedAt: currentTime,
+		UpdatedAt: currentTime,
+	}
+}
+
+// HasScope returns true if the API key grants the provided scope.
+// The admin scope grants all scopes, and all keys grant the read scope.
+func (k *APIKey) HasScope(scope APIKeyScope) bool {
+	if scope == APIKeyScopeRead {
+		return true
+	}
+
+	for _, s := range k.Scopes {
+		if s == scope || s == APIKeyScopeAdmin {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsActive returns true if the API key has not been revoked and has not
+// expired at the provided time.
+func (k *APIKey) IsActive(t time.Time) bool {
+	if k.RevokedAt != nil {
+		return false
+	}
+
+	return k.ExpiresAt == nil || t.Before(*k.ExpiresAt)
+}
+
+// APIKeyPartial represents part of an APIKey object. It is used to update the database entry.
+type APIKeyPartial struct {
+	Name       OptionalString
+	LastUsedAt OptionalTime
+	RevokedAt  OptionalTime
+	UpdatedAt  OptionalTime
+}
+
+func NewAPIKeyPartial() APIKeyPartial {
+	currentTime := time.Now()
+	return APIKeyPartial{
+		UpdatedAt: NewOptionalTime(currentTime),
+	}
+}
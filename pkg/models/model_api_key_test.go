@@ -0,0 +1,61 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAPIKey_HasScope(t *testing.T) {
+	tests := []struct {
+		name   string
+		scopes []APIKeyScope
+		scope  APIKeyScope
+		want   bool
+	}{
+		{"read with no scopes", nil, APIKeyScopeRead, true},
+		{"metadata with no scopes", nil, APIKeyScopeMetadata, false},
+		{"metadata with metadata", []APIKeyScope{APIKeyScopeMetadata}, APIKeyScopeMetadata, true},
+		{"jobs with metadata", []APIKeyScope{APIKeyScopeMetadata}, APIKeyScopeJobs, false},
+		{"admin with jobs", []APIKeyScope{APIKeyScopeJobs}, APIKeyScopeAdmin, false},
+		{"jobs with admin", []APIKeyScope{APIKeyScopeAdmin}, APIKeyScopeJobs, true},
+		{"admin with admin", []APIKeyScope{APIKeyScopeAdmin}, APIKeyScopeAdmin, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k := &APIKey{Scopes: tt.scopes}
+			if got := k.HasScope(tt.scope); got != tt.want {
+				t.Errorf("APIKey.HasScope() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAPIKey_IsActive(t *testing.T) {
+	now := time.Now()
+	past := now.Add(-time.Hour)
+	future := now.Add(time.Hour)
+
+	tests := []struct {
+		name      string
+		expiresAt *time.Time
+		revokedAt *time.Time
+		want      bool
+	}{
+		{"active", nil, nil, true},
+		{"not expired", &future, nil, true},
+		{"expired", &past, nil, false},
+		{"revoked", nil, &past, false},
+		{"revoked and not expired", &future, &past, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k := &APIKey{
+				ExpiresAt: tt.expiresAt,
+				RevokedAt: tt.revokedAt,
+			}
+			if got := k.IsActive(now); got != tt.want {
+				t.Errorf("APIKey.IsActive() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,225 @@
+package models
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// CustomFieldType is the type of the values of a custom field.
+type CustomFieldType string
+
+const (
+	CustomFieldTypeString CustomFieldType = "STRING"
+	CustomFieldTypeInt    CustomFieldType = "INT"
+	CustomFieldTypeFloat  CustomFieldType = "FLOAT"
+	// CustomFieldTypeDate values are stored in YYYY-MM-DD format.
+	CustomFieldTypeDate CustomFieldType = "DATE"
+	CustomFieldTypeBool CustomFieldType = "BOOL"
+	// CustomFieldTypeURL values must be absolute URLs.
+	CustomFieldTypeURL CustomFieldType = "URL"
+)
+
+var AllCustomFieldType = []CustomFieldType{
+	CustomFieldTypeString,
+	CustomFieldTypeInt,
+	CustomFieldTypeFloat,
+	CustomFieldTypeDate,
+	CustomFieldTypeBool,
+	CustomFieldTypeURL,
+}
+
+func (e CustomFieldType) IsValid() bool {
+	switch e {
+	case CustomFieldTypeString, CustomFieldTypeInt, CustomFieldTypeFloat, CustomFieldTypeDate, CustomFieldTypeBool, CustomFieldTypeURL:
+		return true
+	}
+	return false
+}
+
+func (e CustomFieldType) String() string {
+	return string(e)
+}
+
+func (e *CustomFieldType) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = CustomFieldType(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid CustomFieldType", str)
+	}
+	return nil
+}
+
+func (e CustomFieldType) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+var ErrInvalidCustomFieldValue = errors.New("invalid custom field value")
+
+// Normalise converts the provided value into the representation used to
+// store values of this type. Returns an error wrapping
+// ErrInvalidCustomFieldValue if the value is not valid for the type.
+func (e CustomFieldType) Normalise(v interface{}) (interface{}, error) {
+	var ret interface{}
+	ok := false
+
+	switch e {
+	case CustomFieldTypeString:
+		ret, ok = v.(string)
+	case CustomFieldTypeURL:
+		var s string
+		s, ok = v.(string)
+		if ok {
+			u, err := url.Parse(s)
+			ok = err == nil && u.Scheme != "" && u.Host != ""
+			ret = s
+		}
+	case CustomFieldTypeInt:
+		ret, ok = toInt64(v)
+	case CustomFieldTypeFloat:
+		ret, ok = toFloat64(v)
+	case CustomFieldTypeDate:
+		var s string
+		s, ok = v.(string)
+		if ok {
+			d, err := time.Parse(dateFormat, s)
+			ok = err == nil
+			ret = Date{Time: d}.String()
+		}
+	case CustomFieldTypeBool:
+		ret, ok = v.(bool)
+	}
+
+	if !ok {
+		return nil, fmt.Errorf("%w: %v is not a valid %s", ErrInvalidCustomFieldValue, v, e)
+	}
+
+	return ret, nil
+}
+
+func toInt64(v interface{}) (int64, bool) {
+	switch v := v.(type) {
+	case int:
+		return int64(v), true
+	case int64:
+		return v, true
+	case float64:
+		if v != math.Trunc(v) {
+			return 0, false
+		}
+		return int64(v), true
+	case json.Number:
+		i, err := v.Int64()
+		return i, err == nil
+	}
+
+	return 0, false
+}
+
+func toFloat64(v interface{}) (float64, bool) {
+	switch v := v.(type) {
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case float64:
+		return v, true
+	case json.Number:
+		f, err := v.Float64()
+		return f, err == nil
+	}
+
+	return 0, false
+}
+
+// CustomFieldEntityType is the type of object that a custom field is
+// defined for.
+type CustomFieldEntityType string
+
+const (
+	CustomFieldEntityTypeScene     CustomFieldEntityType = "SCENE"
+	CustomFieldEntityTypeImage     CustomFieldEntityType = "IMAGE"
+	CustomFieldEntityTypeGallery   CustomFieldEntityType = "GALLERY"
+	CustomFieldEntityTypePerformer CustomFieldEntityType = "PERFORMER"
+	CustomFieldEntityTypeStudio    CustomFieldEntityType = "STUDIO"
+	CustomFieldEntityTypeTag       CustomFieldEntityType = "TAG"
+	CustomFieldEntityTypeGroup     CustomFieldEntityType = "GROUP"
+)
+
+var AllCustomFieldEntityType = []CustomFieldEntityType{
+	CustomFieldEntityTypeScene,
+	CustomFieldEntityTypeImage,
+	CustomFieldEntityTypeGallery,
+	CustomFieldEntityTypePerformer,
+	CustomFieldEntityTypeStudio,
+	CustomFieldEntityTypeTag,
+	CustomFieldEntityTypeGroup,
+}
+
+func (e CustomFieldEntityType) IsValid() bool {
+	switch e {
+	case CustomFieldEntityTypeScene, CustomFieldEntityTypeImage, CustomFieldEntityTypeGallery, CustomFieldEntityTypePerformer, CustomFieldEntityTypeStudio, CustomFieldEntityTypeTag, CustomFieldEntityTypeGroup:
+		return true
+	}
+	return false
+}
+
+func (e CustomFieldEntityType) String() string {
+	return string(e)
+}
+
+func (e *CustomFieldEntityType) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = CustomFieldEntityType(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid CustomFieldEntityType", str)
+	}
+	return nil
+}
+
+func (e CustomFieldEntityType) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+// CustomFieldDefinition defines a user-defined field for an entity type.
+type CustomFieldDefinition struct {
+	ID         int                   `json:"id"`
+	EntityType CustomFieldEntityType `json:"entity_type"`
+	// Name is unique per entity type.
+	Name      string          `json:"name"`
+	Type      CustomFieldType `json:"type"`
+	CreatedAt time.Time       `json:"created_at"`
+	UpdatedAt time.Time       `json:"updated_at"`
+}
+
+// CustomFieldsInput is used to modify the custom field values of an object.
+// If Full is set, then all existing values are replaced. Otherwise, the
+// values in Partial are set and the fields in Remove are removed.
+type CustomFieldsInput struct {
+	Full    map[string]interface{} `json:"full"`
+	Partial map[string]interface{} `json:"partial"`
+	Remove  []string               `json:"remove"`
+}
+
+type CustomFieldCriterionInput struct {
+	// Field is the name of the custom field.
+	Field string `json:"field"`
+	// Value contains a single value, or two values for the BETWEEN and
+	// NOT_BETWEEN modifiers. It is ignored for the IS_NULL and NOT_NULL
+	// modifiers.
+	Value    []interface{}     `json:"value"`
+	Modifier CriterionModifier `json:"modifier"`
+}
@@ -0,0 +1,51 @@
+package models
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCustomFieldType_Normalise(t *testing.T) {
+	tests := []struct {
+		name      string
+		fieldType CustomFieldType
+		v         interface{}
+		want      interface{}
+		wantErr   bool
+	}{
+		{"string", CustomFieldTypeString, "value", "value", false},
+		{"string from int", CustomFieldTypeString, 1, nil, true},
+		{"int", CustomFieldTypeInt, 1, int64(1), false},
+		{"int from integral float", CustomFieldTypeInt, float64(2), int64(2), false},
+		{"int from json number", CustomFieldTypeInt, json.Number("3"), int64(3), false},
+		{"int from fractional float", CustomFieldTypeInt, 1.5, nil, true},
+		{"int from string", CustomFieldTypeInt, "1", nil, true},
+		{"float", CustomFieldTypeFloat, 1.5, 1.5, false},
+		{"float from int", CustomFieldTypeFloat, 2, float64(2), false},
+		{"float from json number", CustomFieldTypeFloat, json.Number("2.5"), 2.5, false},
+		{"date", CustomFieldTypeDate, "2024-02-03", "2024-02-03", false},
+		{"invalid date", CustomFieldTypeDate, "03/02/2024", nil, true},
+		{"bool", CustomFieldTypeBool, true, true, false},
+		{"bool from int", CustomFieldTypeBool, 1, nil, true},
+		{"url", CustomFieldTypeURL, "https://example.com/a", "https://example.com/a", false},
+		{"url without scheme", CustomFieldTypeURL, "example.com/a", nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.fieldType.Normalise(tt.v)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CustomFieldType.Normalise() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err != nil {
+				assert.True(t, errors.Is(err, ErrInvalidCustomFieldValue))
+				return
+			}
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
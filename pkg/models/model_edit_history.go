@@ -0,0 +1,182 @@
+package models
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+type EditObjectType string
+
+const (
+	EditObjectTypeScene     EditObjectType = "SCENE"
+	EditObjectTypeImage     EditObjectType = "IMAGE"
+	EditObjectTypeGallery   EditObjectType = "GALLERY"
+	EditObjectTypePerformer EditObjectType = "PERFORMER"
+	EditObjectTypeStudio    EditObjectType = "STUDIO"
+	EditObjectTypeTag       EditObjectType = "TAG"
+	EditObjectTypeGroup     EditObjectType = "GROUP"
+)
+
+var AllEditObjectType = []EditObjectType{
+	EditObjectTypeScene,
+	EditObjectTypeImage,
+	EditObjectTypeGallery,
+	EditObjectTypePerformer,
+	EditObjectTypeStudio,
+	EditObjectTypeTag,
+	EditObjectTypeGroup,
+}
+
+func (e EditObjectType) IsValid() bool {
+	switch e {
+	case EditObjectTypeScene, EditObjectTypeImage, EditObjectTypeGallery, EditObjectTypePerformer, EditObjectTypeStudio, EditObjectTypeTag, EditObjectTypeGroup:
+		return true
+	}
+	return false
+}
+
+func (e EditObjectType) String() string {
+	return string(e)
+}
+
+func (e *EditObjectType) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = EditObjectType(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid EditObjectType", str)
+	}
+	return nil
+}
+
+func (e EditObjectType) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type EditSource string
+
+const (
+	// EditSourceUI edits were made through the GraphQL interface.
+	EditSourceUI EditSource = "UI"
+	// EditSourcePlugin edits were made by a plugin.
+	EditSourcePlugin EditSource = "PLUGIN"
+	// EditSourceIdentify edits were made by the identify task.
+	EditSourceIdentify EditSource = "IDENTIFY"
+	// EditSourceAutoTag edits were made by the auto tag task.
+	EditSourceAutoTag EditSource = "AUTOTAG"
+	// EditSourceScraper edits were made from scraped or stash-box data.
+	EditSourceScraper EditSource = "SCRAPER"
+	// EditSourceRevert edits were made by reverting a previous edit.
+	EditSourceRevert EditSource = "REVERT"
+	// EditSourceOther edits were made by any other process.
+	EditSourceOther EditSource = "OTHER"
+)
+
+var AllEditSource = []EditSource{
+	EditSourceUI,
+	EditSourcePlugin,
+	EditSourceIdentify,
+	EditSourceAutoTag,
+	EditSourceScraper,
+	EditSourceRevert,
+	EditSourceOther,
+}
+
+func (e EditSource) IsValid() bool {
+	switch e {
+	case EditSourceUI, EditSourcePlugin, EditSourceIdentify, EditSourceAutoTag, EditSourceScraper, EditSourceRevert, EditSourceOther:
+		return true
+	}
+	return false
+}
+
+func (e EditSource) String() string {
+	return string(e)
+}
+
+func (e *EditSource) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = EditSource(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid EditSource", str)
+	}
+	return nil
+}
+
+func (e EditSource) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type editSourceKey struct{}
+
+// WithEditSource returns a context which attributes edits made with it to
+// the provided source.
+func WithEditSource(ctx context.Context, source EditSource) context.Context {
+	return context.WithValue(ctx, editSourceKey{}, source)
+}
+
+// GetEditSource returns the source of edits made with the provided context.
+// Returns EditSourceOther if no source has been set.
+func GetEditSource(ctx context.Context) EditSource {
+	if source, ok := ctx.Value(editSourceKey{}).(EditSource); ok {
+		return source
+	}
+
+	return EditSourceOther
+}
+
+type skipEditHistoryKey struct{}
+
+// WithoutEditHistory returns a context in which edits are not recorded in
+// the edit history. It is used by the scan task, where snapshotting each
+// object before it is updated is too expensive.
+func WithoutEditHistory(ctx context.Context) context.Context {
+	return context.WithValue(ctx, skipEditHistoryKey{}, true)
+}
+
+// EditHistoryEnabled returns true if edits made with the provided context
+// should be recorded in the edit history.
+func EditHistoryEnabled(ctx context.Context) bool {
+	skip, _ := ctx.Value(skipEditHistoryKey{}).(bool)
+	return !skip
+}
+
+// EditFieldChange represents a change to a single field of an object.
+// Field is the name of the field in the object's update input, and the
+// values are stored in the form accepted by that input.
+type EditFieldChange struct {
+	Field    string      `json:"field"`
+	OldValue interface{} `json:"old_value"`
+	NewValue interface{} `json:"new_value"`
+}
+
+type EditHistory struct {
+	ID         int               `json:"id"`
+	ObjectType EditObjectType    `json:"object_type"`
+	ObjectID   int               `json:"object_id"`
+	UserID     *int              `json:"user_id"`
+	Source     EditSource        `json:"source"`
+	Changes    []EditFieldChange `json:"changes"`
+	CreatedAt  time.Time         `json:"created_at"`
+}
+
+// OldValues returns the values of the changed fields prior to the edit,
+// keyed by field name.
+func (e *EditHistory) OldValues() map[string]interface{} {
+	ret := make(map[string]interface{})
+	for _, c := range e.Changes {
+		ret[c.Field] = c.OldValue
+	}
+
+	return ret
+}
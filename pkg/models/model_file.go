@@ -277,6 +277,16 @@ type VideoFile struct {
 
 	Interactive      bool `json:"interactive"`
 	InteractiveSpeed *int `json:"interactive_speed"`
+
+	// Streams is set when the file is probed, and is written when the file
+	// is created or updated. It is nil for files read from the database -
+	// use GetStreams to load them.
+	Streams []*VideoStream `json:"streams,omitempty"`
+
+	// Chapters is set when the file is probed, and is written when the file
+	// is created or updated. It is nil for files read from the database -
+	// use GetChapters to load them.
+	Chapters []*VideoChapter `json:"chapters,omitempty"`
 }
 
 func (f VideoFile) GetWidth() int {
@@ -298,6 +308,79 @@ func (f VideoFile) Clone() (ret File) {
 	return
 }
 
+type VideoStreamType string
+
+const (
+	VideoStreamTypeVideo    VideoStreamType = "VIDEO"
+	VideoStreamTypeAudio    VideoStreamType = "AUDIO"
+	VideoStreamTypeSubtitle VideoStreamType = "SUBTITLE"
+)
+
+var AllVideoStreamType = []VideoStreamType{
+	VideoStreamTypeVideo,
+	VideoStreamTypeAudio,
+	VideoStreamTypeSubtitle,
+}
+
+func (e VideoStreamType) IsValid() bool {
+	switch e {
+	case VideoStreamTypeVideo, VideoStreamTypeAudio, VideoStreamTypeSubtitle:
+		return true
+	}
+	return false
+}
+
+func (e VideoStreamType) String() string {
+	return string(e)
+}
+
+func (e *VideoStreamType) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = VideoStreamType(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid VideoStreamType", str)
+	}
+	return nil
+}
+
+func (e VideoStreamType) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+// VideoStream is a video, audio or subtitle stream of a video file.
+type VideoStream struct {
+	// Index is the index of the stream within the file
+	Index    int             `json:"index"`
+	Type     VideoStreamType `json:"type"`
+	Codec    string          `json:"codec"`
+	Language string          `json:"language,omitempty"`
+	Title    string          `json:"title,omitempty"`
+	Default  bool            `json:"default"`
+}
+
+// StreamByIndex returns the stream with the provided index, or nil if not found.
+func StreamByIndex(streams []*VideoStream, index int) *VideoStream {
+	for _, s := range streams {
+		if s.Index == index {
+			return s
+		}
+	}
+
+	return nil
+}
+
+// VideoChapter is a chapter embedded in a video file.
+type VideoChapter struct {
+	Title        string  `json:"title,omitempty"`
+	StartSeconds float64 `json:"start_seconds"`
+	// EndSeconds is zero if the end of the chapter is unknown
+	EndSeconds float64 `json:"end_seconds"`
+}
+
 // #1572 - Inf and NaN values cause the JSON marshaller to fail
 // Replace these values with 0 rather than erroring
 
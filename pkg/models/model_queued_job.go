@@ -0,0 +1,21 @@
+package models
+
+import "time"
+
+// QueuedJob is a job that has been queued but not yet completed. Queued
+// jobs are restored when the application is restarted.
+type QueuedJob struct {
+	// ID is the ID of the job in the job queue.
+	ID int `json:"id"`
+	// Kind identifies the type of the job.
+	Kind        string `json:"kind"`
+	Description string `json:"description"`
+	// Input is the JSON-encoded input of the job.
+	Input         []byte    `json:"input"`
+	Priority      int       `json:"priority"`
+	ResourceClass string    `json:"resource_class"`
+	Paused        bool      `json:"paused"`
+	AddedAt       time.Time `json:"added_at"`
+	// UserID is the id of the user that queued the job.
+	UserID *int `json:"user_id"`
+}
@@ -14,6 +14,9 @@ type ScrapedStudio struct {
 	Name         string         `json:"name"`
 	URL          *string        `json:"url"`
 	Parent       *ScrapedStudio `json:"parent"`
+	Aliases      *string        `json:"aliases"`
+	Details      *string        `json:"details"`
+	Tags         []*ScrapedTag  `json:"tags"`
 	Image        *string        `json:"image"`
 	Images       []string       `json:"images"`
 	RemoteSiteID *string        `json:"remote_site_id"`
@@ -39,6 +42,14 @@ func (s *ScrapedStudio) ToStudio(endpoint string, excluded map[string]bool) *Stu
 		ret.URL = *s.URL
 	}
 
+	if s.Aliases != nil && !excluded["aliases"] {
+		ret.Aliases = NewRelatedStrings(stringslice.FromString(*s.Aliases, ","))
+	}
+
+	if s.Details != nil && !excluded["details"] {
+		ret.Details = *s.Details
+	}
+
 	if s.Parent != nil && s.Parent.StoredID != nil && !excluded["parent"] && !excluded["parent_studio"] {
 		parentId, _ := strconv.Atoi(*s.Parent.StoredID)
 		ret.ParentID = &parentId
@@ -74,6 +85,17 @@ func (s *ScrapedStudio) ToPartial(id string, endpoint string, excluded map[strin
 		ret.URL = NewOptionalString(*s.URL)
 	}
 
+	if s.Aliases != nil && !excluded["aliases"] {
+		ret.Aliases = &UpdateStrings{
+			Values: stringslice.FromString(*s.Aliases, ","),
+			Mode:   RelationshipUpdateModeSet,
+		}
+	}
+
+	if s.Details != nil && !excluded["details"] {
+		ret.Details = NewOptionalString(*s.Details)
+	}
+
 	if s.Parent != nil && !excluded["parent"] {
 		if s.Parent.StoredID != nil {
 			parentID, _ := strconv.Atoi(*s.Parent.StoredID)
@@ -14,6 +14,8 @@ func Test_scrapedToStudioInput(t *testing.T) {
 	emptyEndpoint := ""
 	endpoint := "endpoint"
 	remoteSiteID := "remoteSiteID"
+	aliases := "alias1, alias2"
+	details := "details"
 
 	tests := []struct {
 		name     string
@@ -26,12 +28,16 @@ func Test_scrapedToStudioInput(t *testing.T) {
 			&ScrapedStudio{
 				Name:         name,
 				URL:          &url,
+				Aliases:      &aliases,
+				Details:      &details,
 				RemoteSiteID: &remoteSiteID,
 			},
 			endpoint,
 			&Studio{
-				Name: name,
-				URL:  url,
+				Name:    name,
+				URL:     url,
+				Aliases: NewRelatedStrings([]string{"alias1", "alias2"}),
+				Details: details,
 				StashIDs: NewRelatedStashIDs([]StashID{
 					{
 						Endpoint: endpoint,
@@ -0,0 +1,15 @@
+package models
+
+import "time"
+
+// TrashedFile is a library file that has been moved to the trash directory
+// of its library instead of being deleted.
+type TrashedFile struct {
+	ID int `json:"id"`
+	// OriginalPath is the path of the file before it was trashed.
+	OriginalPath string `json:"original_path"`
+	// TrashPath is the current path of the file in the trash directory.
+	TrashPath string    `json:"trash_path"`
+	Size      int64     `json:"size"`
+	TrashedAt time.Time `json:"trashed_at"`
+}
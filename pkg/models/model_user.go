@@ -0,0 +1,113 @@
+package models
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+type UserRole string
+
+const (
+	// UserRoleAdmin users may perform all operations, including managing
+	// users and the system configuration.
+	UserRoleAdmin UserRole = "ADMIN"
+	// UserRoleEditor users may view and modify library content.
+	UserRoleEditor UserRole = "EDITOR"
+	// UserRoleViewer users may view library content and track their own
+	// play history and ratings.
+	UserRoleViewer UserRole = "VIEWER"
+)
+
+var AllUserRole = []UserRole{
+	UserRoleAdmin,
+	UserRoleEditor,
+	UserRoleViewer,
+}
+
+func (e UserRole) IsValid() bool {
+	switch e {
+	case UserRoleAdmin, UserRoleEditor, UserRoleViewer:
+		return true
+	}
+	return false
+}
+
+func (e UserRole) String() string {
+	return string(e)
+}
+
+func (e *UserRole) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = UserRole(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid UserRole", str)
+	}
+	return nil
+}
+
+func (e UserRole) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e UserRole) level() int {
+	switch e {
+	case UserRoleAdmin:
+		return 3
+	case UserRoleEditor:
+		return 2
+	case UserRoleViewer:
+		return 1
+	}
+	return 0
+}
+
+// Includes returns true if the role grants at least the permissions of the
+// provided role.
+func (e UserRole) Includes(role UserRole) bool {
+	return e.level() >= role.level()
+}
+
+type User struct {
+	ID           int       `json:"id"`
+	Username     string    `json:"username"`
+	PasswordHash string    `json:"-"`
+	Role         UserRole  `json:"role"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+func NewUser() User {
+	currentTime := time.Now()
+	return User{
+		CreatedAt: currentTime,
+		UpdatedAt: currentTime,
+	}
+}
+
+// HasRole returns true if the user's role grants at least the permissions of
+// the provided role.
+func (u *User) HasRole(role UserRole) bool {
+	return u.Role.Includes(role)
+}
+
+// UserPartial represents part of a User object. It is used to update the database entry.
+type UserPartial struct {
+	Username     OptionalString
+	PasswordHash OptionalString
+	Role         OptionalString
+	CreatedAt    OptionalTime
+	UpdatedAt    OptionalTime
+}
+
+func NewUserPartial() UserPartial {
+	currentTime := time.Now()
+	return UserPartial{
+		UpdatedAt: NewOptionalTime(currentTime),
+	}
+}
@@ -11,6 +11,8 @@ type JSONPaths struct {
 	Metadata string
 
 	ScrapedFile string
+	// CustomFields is the file containing the custom field definitions
+	CustomFields string
 
 	Performers string
 	Scenes     string
@@ -26,6 +28,7 @@ func newJSONPaths(baseDir string) *JSONPaths {
 	jp := JSONPaths{}
 	jp.Metadata = baseDir
 	jp.ScrapedFile = filepath.Join(baseDir, "scraped.json")
+	jp.CustomFields = filepath.Join(baseDir, "custom_fields.json")
 	jp.Performers = filepath.Join(baseDir, "performers")
 	jp.Scenes = filepath.Join(baseDir, "scenes")
 	jp.Images = filepath.Join(baseDir, "images")
@@ -27,6 +27,13 @@ type Repository struct {
 	Studio         StudioReaderWriter
 	Tag            TagReaderWriter
 	SavedFilter    SavedFilterReaderWriter
+	User           UserReaderWriter
+	APIKey         APIKeyReaderWriter
+	EditHistory    EditHistoryReaderWriter
+	TrashedFile    TrashedFileReaderWriter
+	QueuedJob      QueuedJobReaderWriter
+	WebhookLog     WebhookDeliveryReaderWriter
+	CustomField    CustomFieldDefinitionReaderWriter
 }
 
 func (r *Repository) WithTxn(ctx context.Context, fn txn.TxnFunc) error {
@@ -0,0 +1,49 @@
+package models
+
+import "context"
+
+// APIKeyGetter provides methods to get API keys by ID.
+type APIKeyGetter interface {
+	Find(ctx context.Context, id int) (*APIKey, error)
+}
+
+// APIKeyFinder provides methods to find API keys.
+type APIKeyFinder interface {
+	APIKeyGetter
+	FindByKeyHash(ctx context.Context, keyHash string) (*APIKey, error)
+	FindByUserID(ctx context.Context, userID int) ([]*APIKey, error)
+	All(ctx context.Context) ([]*APIKey, error)
+}
+
+// APIKeyCreator provides methods to create API keys.
+type APIKeyCreator interface {
+	Create(ctx context.Context, newAPIKey *APIKey) error
+}
+
+// APIKeyUpdater provides methods to update API keys.
+type APIKeyUpdater interface {
+	UpdatePartial(ctx context.Context, id int, updatedAPIKey APIKeyPartial) (*APIKey, error)
+}
+
+// APIKeyDestroyer provides methods to destroy API keys.
+type APIKeyDestroyer interface {
+	Destroy(ctx context.Context, id int) error
+}
+
+// APIKeyReader provides all methods to read API keys.
+type APIKeyReader interface {
+	APIKeyFinder
+}
+
+// APIKeyWriter provides all methods to modify API keys.
+type APIKeyWriter interface {
+	APIKeyCreator
+	APIKeyUpdater
+	APIKeyDestroyer
+}
+
+// APIKeyReaderWriter provides all API key methods.
+type APIKeyReaderWriter interface {
+	APIKeyReader
+	APIKeyWriter
+}
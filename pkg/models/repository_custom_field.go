@@ -0,0 +1,80 @@
+package models
+
+import "context"
+
+// CustomFieldDefinitionGetter provides methods to get custom field
+// definitions by ID.
+type CustomFieldDefinitionGetter interface {
+	Find(ctx context.Context, id int) (*CustomFieldDefinition, error)
+}
+
+// CustomFieldDefinitionFinder provides methods to find custom field
+// definitions.
+type CustomFieldDefinitionFinder interface {
+	CustomFieldDefinitionGetter
+	// FindByName returns the definition with the provided name for the
+	// entity type. Returns nil if not found.
+	FindByName(ctx context.Context, entityType CustomFieldEntityType, name string) (*CustomFieldDefinition, error)
+	// FindByEntityType returns the definitions for the entity type, ordered
+	// by name.
+	FindByEntityType(ctx context.Context, entityType CustomFieldEntityType) ([]*CustomFieldDefinition, error)
+	All(ctx context.Context) ([]*CustomFieldDefinition, error)
+}
+
+// CustomFieldDefinitionCreator provides methods to create custom field
+// definitions.
+type CustomFieldDefinitionCreator interface {
+	Create(ctx context.Context, newDefinition *CustomFieldDefinition) error
+}
+
+// CustomFieldDefinitionUpdater provides methods to update custom field
+// definitions.
+type CustomFieldDefinitionUpdater interface {
+	Update(ctx context.Context, updatedDefinition *CustomFieldDefinition) error
+}
+
+// CustomFieldDefinitionDestroyer provides methods to destroy custom field
+// definitions. Destroying a definition removes its values from all objects.
+type CustomFieldDefinitionDestroyer interface {
+	Destroy(ctx context.Context, id int) error
+}
+
+type CustomFieldDefinitionFinderCreator interface {
+	CustomFieldDefinitionFinder
+	CustomFieldDefinitionCreator
+}
+
+// CustomFieldDefinitionReader provides all methods to read custom field
+// definitions.
+type CustomFieldDefinitionReader interface {
+	CustomFieldDefinitionFinder
+}
+
+// CustomFieldDefinitionWriter provides all methods to modify custom field
+// definitions.
+type CustomFieldDefinitionWriter interface {
+	CustomFieldDefinitionCreator
+	CustomFieldDefinitionUpdater
+	CustomFieldDefinitionDestroyer
+}
+
+// CustomFieldDefinitionReaderWriter provides all custom field definition
+// methods.
+type CustomFieldDefinitionReaderWriter interface {
+	CustomFieldDefinitionReader
+	CustomFieldDefinitionWriter
+}
+
+// CustomFieldsReader provides methods to get the custom field values of
+// objects. Values are keyed by field name.
+type CustomFieldsReader interface {
+	GetCustomFields(ctx context.Context, id int) (map[string]interface{}, error)
+	GetCustomFieldsBulk(ctx context.Context, ids []int) ([]map[string]interface{}, error)
+}
+
+// CustomFieldsWriter provides methods to set the custom field values of
+// objects. Returns an error if a field is not defined for the entity type or
+// a value is not valid for the type of the field.
+type CustomFieldsWriter interface {
+	SetCustomFields(ctx context.Context, id int, fields CustomFieldsInput) error
+}
@@ -0,0 +1,36 @@
+package models
+
+import "context"
+
+// EditHistoryGetter provides methods to get edit history entries by ID.
+type EditHistoryGetter interface {
+	Find(ctx context.Context, id int) (*EditHistory, error)
+}
+
+// EditHistoryFinder provides methods to find edit history entries.
+type EditHistoryFinder interface {
+	EditHistoryGetter
+	// FindByObject returns the edits made to the provided object, newest first.
+	FindByObject(ctx context.Context, objectType EditObjectType, objectID int) ([]*EditHistory, error)
+}
+
+// EditHistoryCreator provides methods to create edit history entries.
+type EditHistoryCreator interface {
+	Create(ctx context.Context, newEdit *EditHistory) error
+}
+
+// EditHistoryReader provides all methods to read edit history entries.
+type EditHistoryReader interface {
+	EditHistoryFinder
+}
+
+// EditHistoryWriter provides all methods to modify edit history entries.
+type EditHistoryWriter interface {
+	EditHistoryCreator
+}
+
+// EditHistoryReaderWriter provides all edit history methods.
+type EditHistoryReaderWriter interface {
+	EditHistoryReader
+	EditHistoryWriter
+}
@@ -69,6 +69,9 @@ type FileReader interface {
 	FileCounter
 
 	GetCaptions(ctx context.Context, fileID FileID) ([]*VideoCaption, error)
+	GetStreams(ctx context.Context, fileID FileID) ([]*VideoStream, error)
+	// GetChapters returns nil if the chapters of the file have not been probed.
+	GetChapters(ctx context.Context, fileID FileID) ([]*VideoChapter, error)
 	IsPrimary(ctx context.Context, fileID FileID) (bool, error)
 }
 
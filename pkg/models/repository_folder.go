@@ -5,6 +5,7 @@ import "context"
 // FolderGetter provides methods to get folders by ID.
 type FolderGetter interface {
 	Find(ctx context.Context, id FolderID) (*Folder, error)
+	FindMany(ctx context.Context, ids []FolderID) ([]*Folder, error)
 }
 
 // FolderFinder provides methods to find folders.
@@ -16,6 +17,11 @@ type FolderFinder interface {
 	FindByParentFolderID(ctx context.Context, parentFolderID FolderID) ([]*Folder, error)
 }
 
+// FolderQueryer provides methods to query folders.
+type FolderQueryer interface {
+	Query(ctx context.Context, options FolderQueryOptions) (*FolderQueryResult, error)
+}
+
 type FolderCounter interface {
 	CountAllInPaths(ctx context.Context, p []string) (int, error)
 }
@@ -47,6 +53,7 @@ type FolderFinderDestroyer interface {
 // FolderReader provides all methods to read folders.
 type FolderReader interface {
 	FolderFinder
+	FolderQueryer
 	FolderCounter
 }
 
@@ -64,6 +64,7 @@ type GroupReader interface {
 	GroupFinder
 	GroupQueryer
 	GroupCounter
+	CustomFieldsReader
 	URLLoader
 	TagIDLoader
 	ContainingGroupLoader
@@ -81,6 +82,7 @@ type GroupWriter interface {
 	GroupCreator
 	GroupUpdater
 	GroupDestroyer
+	CustomFieldsWriter
 }
 
 // GroupReaderWriter provides all group methods.
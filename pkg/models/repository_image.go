@@ -19,6 +19,7 @@ type ImageFinder interface {
 	FindByZipFileID(ctx context.Context, zipFileID FileID) ([]*Image, error)
 	FindByGalleryID(ctx context.Context, galleryID int) ([]*Image, error)
 	FindByGalleryIDIndex(ctx context.Context, galleryID int, index uint) (*Image, error)
+	FindDuplicates(ctx context.Context, distance int) ([][]*Image, error)
 }
 
 // ImageQueryer provides methods to query images.
@@ -68,6 +69,7 @@ type ImageReader interface {
 	ImageFinder
 	ImageQueryer
 	ImageCounter
+	CustomFieldsReader
 
 	URLLoader
 	FileIDLoader
@@ -87,6 +89,7 @@ type ImageWriter interface {
 	ImageCreator
 	ImageUpdater
 	ImageDestroyer
+	CustomFieldsWriter
 
 	AddFileID(ctx context.Context, id int, fileID FileID) error
 	RemoveFileID(ctx context.Context, id int, fileID FileID) error
@@ -74,6 +74,7 @@ type PerformerReader interface {
 	PerformerQueryer
 	PerformerAutoTagQueryer
 	PerformerCounter
+	CustomFieldsReader
 
 	AliasLoader
 	StashIDLoader
@@ -90,6 +91,9 @@ type PerformerWriter interface {
 	PerformerCreator
 	PerformerUpdater
 	PerformerDestroyer
+	CustomFieldsWriter
+
+	Merge(ctx context.Context, source []int, destination int) error
 }
 
 // PerformerReaderWriter provides all performer methods.
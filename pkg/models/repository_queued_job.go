@@ -0,0 +1,43 @@
+package models
+
+import "context"
+
+// QueuedJobFinder provides methods to find queued jobs.
+type QueuedJobFinder interface {
+	Find(ctx context.Context, id int) (*QueuedJob, error)
+	// All returns all queued jobs, in the order they were added.
+	All(ctx context.Context) ([]*QueuedJob, error)
+}
+
+// QueuedJobCreator provides methods to create queued jobs.
+type QueuedJobCreator interface {
+	Create(ctx context.Context, newJob *QueuedJob) error
+}
+
+// QueuedJobUpdater provides methods to update queued jobs.
+type QueuedJobUpdater interface {
+	Update(ctx context.Context, updatedJob *QueuedJob) error
+}
+
+// QueuedJobDestroyer provides methods to destroy queued jobs.
+type QueuedJobDestroyer interface {
+	Destroy(ctx context.Context, id int) error
+}
+
+// QueuedJobReader provides all methods to read queued jobs.
+type QueuedJobReader interface {
+	QueuedJobFinder
+}
+
+// QueuedJobWriter provides all methods to modify queued jobs.
+type QueuedJobWriter interface {
+	QueuedJobCreator
+	QueuedJobUpdater
+	QueuedJobDestroyer
+}
+
+// QueuedJobReaderWriter provides all queued job methods.
+type QueuedJobReaderWriter interface {
+	QueuedJobReader
+	QueuedJobWriter
+}
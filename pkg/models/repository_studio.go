@@ -73,6 +73,7 @@ type StudioReader interface {
 	StudioQueryer
 	StudioAutoTagQueryer
 	StudioCounter
+	CustomFieldsReader
 
 	AliasLoader
 	StashIDLoader
@@ -88,6 +89,9 @@ type StudioWriter interface {
 	StudioCreator
 	StudioUpdater
 	StudioDestroyer
+	CustomFieldsWriter
+
+	Merge(ctx context.Context, source []int, destination int) error
 }
 
 // StudioReaderWriter provides all studio methods.
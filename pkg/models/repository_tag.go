@@ -84,6 +84,7 @@ type TagReader interface {
 	TagQueryer
 	TagAutoTagQueryer
 	TagCounter
+	CustomFieldsReader
 
 	AliasLoader
 	TagRelationLoader
@@ -98,6 +99,7 @@ type TagWriter interface {
 	TagCreator
 	TagUpdater
 	TagDestroyer
+	CustomFieldsWriter
 
 	Merge(ctx context.Context, source []int, destination int) error
 }
@@ -0,0 +1,48 @@
+package models
+
+import (
+	"context"
+	"time"
+)
+
+// TrashedFileGetter provides methods to get trashed files by ID.
+type TrashedFileGetter interface {
+	Find(ctx context.Context, id int) (*TrashedFile, error)
+	FindMany(ctx context.Context, ids []int) ([]*TrashedFile, error)
+}
+
+// TrashedFileFinder provides methods to find trashed files.
+type TrashedFileFinder interface {
+	TrashedFileGetter
+	// All returns all trashed files, most recently trashed first.
+	All(ctx context.Context) ([]*TrashedFile, error)
+	// FindTrashedBefore returns the files that were trashed before the provided time.
+	FindTrashedBefore(ctx context.Context, t time.Time) ([]*TrashedFile, error)
+}
+
+// TrashedFileCreator provides methods to create trashed files.
+type TrashedFileCreator interface {
+	Create(ctx context.Context, newFile *TrashedFile) error
+}
+
+// TrashedFileDestroyer provides methods to destroy trashed files.
+type TrashedFileDestroyer interface {
+	Destroy(ctx context.Context, id int) error
+}
+
+// TrashedFileReader provides all methods to read trashed files.
+type TrashedFileReader interface {
+	TrashedFileFinder
+}
+
+// TrashedFileWriter provides all methods to modify trashed files.
+type TrashedFileWriter interface {
+	TrashedFileCreator
+	TrashedFileDestroyer
+}
+
+// TrashedFileReaderWriter provides all trashed file methods.
+type TrashedFileReaderWriter interface {
+	TrashedFileReader
+	TrashedFileWriter
+}
@@ -0,0 +1,59 @@
+package models
+
+import "context"
+
+// UserGetter provides methods to get users by ID.
+type UserGetter interface {
+	Find(ctx context.Context, id int) (*User, error)
+	FindMany(ctx context.Context, ids []int) ([]*User, error)
+}
+
+// UserFinder provides methods to find users.
+type UserFinder interface {
+	UserGetter
+	FindByUsername(ctx context.Context, username string) (*User, error)
+	All(ctx context.Context) ([]*User, error)
+}
+
+// UserCounter provides methods to count users.
+type UserCounter interface {
+	Count(ctx context.Context) (int, error)
+	CountByRole(ctx context.Context, role UserRole) (int, error)
+}
+
+// UserCreator provides methods to create users.
+type UserCreator interface {
+	Create(ctx context.Context, newUser *User) error
+}
+
+// UserUpdater provides methods to update users.
+type UserUpdater interface {
+	UpdatePartial(ctx context.Context, id int, updatedUser UserPartial) (*User, error)
+	// CopySharedState copies the play history, O history, resume times and
+	// ratings that are not associated with any user to the provided user.
+	CopySharedState(ctx context.Context, id int) error
+}
+
+// UserDestroyer provides methods to destroy users.
+type UserDestroyer interface {
+	Destroy(ctx context.Context, id int) error
+}
+
+// UserReader provides all methods to read users.
+type UserReader interface {
+	UserFinder
+	UserCounter
+}
+
+// UserWriter provides all methods to modify users.
+type UserWriter interface {
+	UserCreator
+	UserUpdater
+	UserDestroyer
+}
+
+// UserReaderWriter provides all user methods.
+type UserReaderWriter interface {
+	UserReader
+	UserWriter
+}
@@ -0,0 +1,50 @@
+package models
+
+import (
+	"context"
+	"time"
+)
+
+// WebhookDeliveryFinder provides methods to find webhook deliveries.
+type WebhookDeliveryFinder interface {
+	// FindRecent returns the most recent deliveries, newest first. If
+	// webhookID is not nil, only deliveries to that webhook are returned.
+	FindRecent(ctx context.Context, webhookID *int, limit int) ([]*WebhookDelivery, error)
+}
+
+// WebhookDeliveryCreator provides methods to create webhook deliveries.
+type WebhookDeliveryCreator interface {
+	Create(ctx context.Context, newDelivery *WebhookDelivery) error
+}
+
+// WebhookDeliveryUpdater provides methods to update webhook deliveries.
+type WebhookDeliveryUpdater interface {
+	Update(ctx context.Context, updatedDelivery *WebhookDelivery) error
+	// FailPending marks all pending deliveries as failed with the provided
+	// error. Used for deliveries that were interrupted by a restart.
+	FailPending(ctx context.Context, errorMessage string, completedAt time.Time) error
+}
+
+// WebhookDeliveryDestroyer provides methods to destroy webhook deliveries.
+type WebhookDeliveryDestroyer interface {
+	// Prune destroys all but the most recent keep deliveries.
+	Prune(ctx context.Context, keep int) error
+}
+
+// WebhookDeliveryReader provides all methods to read webhook deliveries.
+type WebhookDeliveryReader interface {
+	WebhookDeliveryFinder
+}
+
+// WebhookDeliveryWriter provides all methods to modify webhook deliveries.
+type WebhookDeliveryWriter interface {
+	WebhookDeliveryCreator
+	WebhookDeliveryUpdater
+	WebhookDeliveryDestroyer
+}
+
+// WebhookDeliveryReaderWriter provides all webhook delivery methods.
+type WebhookDeliveryReaderWriter interface {
+	WebhookDeliveryReader
+	WebhookDeliveryWriter
+}
@@ -0,0 +1,243 @@
+// Package nfo reads and writes the Kodi/Jellyfin style NFO files that are
+// stored alongside video files.
+package nfo
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+	"io/fs"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/stashapp/stash/pkg/fsutil"
+)
+
+const (
+	// Extension is the file extension of NFO files.
+	Extension = ".nfo"
+
+	// MovieFilename is the name of the NFO file describing the single movie
+	// in a folder.
+	MovieFilename = "movie.nfo"
+
+	// posterSuffix is appended to the video basename to get the name of the
+	// poster image.
+	posterSuffix = "-poster.jpg"
+)
+
+// Actor is an actor entry in an NFO file.
+type Actor struct {
+	Name  string `xml:"name"`
+	Role  string `xml:"role,omitempty"`
+	Order *int   `xml:"order,omitempty"`
+	Thumb string `xml:"thumb,omitempty"`
+}
+
+// Rating is an entry in the ratings element of an NFO file.
+type Rating struct {
+	Name    string  `xml:"name,attr,omitempty"`
+	Max     float64 `xml:"max,attr,omitempty"`
+	Default bool    `xml:"default,attr,omitempty"`
+	Value   float64 `xml:"value"`
+}
+
+// Movie is the root element of a movie NFO file. Only the elements used by
+// stash are included.
+type Movie struct {
+	XMLName   xml.Name `xml:"movie"`
+	Title     string   `xml:"title"`
+	Plot      string   `xml:"plot,omitempty"`
+	Outline   string   `xml:"outline,omitempty"`
+	Premiered string   `xml:"premiered,omitempty"`
+	Aired     string   `xml:"aired,omitempty"`
+	Year      int      `xml:"year,omitempty"`
+	Studios   []string `xml:"studio,omitempty"`
+	Actors    []Actor  `xml:"actor,omitempty"`
+	Genres    []string `xml:"genre,omitempty"`
+	Tags      []string `xml:"tag,omitempty"`
+
+	// Rating is the legacy single rating value, out of 10.
+	Rating     float64  `xml:"rating,omitempty"`
+	Ratings    []Rating `xml:"ratings>rating,omitempty"`
+	UserRating float64  `xml:"userrating,omitempty"`
+}
+
+// Details returns the plot of the movie, falling back to the outline.
+func (m Movie) Details() string {
+	if m.Plot != "" {
+		return m.Plot
+	}
+
+	return m.Outline
+}
+
+// Date returns the release date of the movie in YYYY-MM-DD format. It
+// returns an empty string if the movie has no full release date.
+func (m Movie) Date() string {
+	if m.Premiered != "" {
+		return m.Premiered
+	}
+
+	return m.Aired
+}
+
+// Rating100 returns the rating of the movie scaled to 1-100. The user rating
+// is preferred over the default rating. It returns nil if the movie is not
+// rated.
+func (m Movie) Rating100() *int {
+	var rating float64
+	switch {
+	case m.UserRating > 0:
+		rating = m.UserRating * 10
+	case len(m.Ratings) > 0:
+		r := m.Ratings[0]
+		for _, rr := range m.Ratings {
+			if rr.Default {
+				r = rr
+				break
+			}
+		}
+
+		max := r.Max
+		if max <= 0 {
+			max = 10
+		}
+		rating = r.Value / max * 100
+	default:
+		rating = m.Rating * 10
+	}
+
+	if rating <= 0 {
+		return nil
+	}
+
+	ret := int(math.Round(math.Min(rating, 100)))
+	if ret < 1 {
+		ret = 1
+	}
+	return &ret
+}
+
+// TagNames returns the tags and genres of the movie, without duplicates.
+func (m Movie) TagNames() []string {
+	var ret []string
+	seen := make(map[string]bool)
+	for _, t := range append(append([]string{}, m.Tags...), m.Genres...) {
+		t = strings.TrimSpace(t)
+		if t == "" || seen[strings.ToLower(t)] {
+			continue
+		}
+
+		seen[strings.ToLower(t)] = true
+		ret = append(ret, t)
+	}
+
+	return ret
+}
+
+// ActorNames returns the names of the actors of the movie.
+func (m Movie) ActorNames() []string {
+	var ret []string
+	for _, a := range m.Actors {
+		if name := strings.TrimSpace(a.Name); name != "" {
+			ret = append(ret, name)
+		}
+	}
+
+	return ret
+}
+
+// Parse reads a movie NFO from r. Any content after the movie element, such
+// as the trailing URLs of legacy NFO files, is ignored.
+func Parse(r io.Reader) (*Movie, error) {
+	var ret Movie
+	if err := xml.NewDecoder(r).Decode(&ret); err != nil {
+		return nil, err
+	}
+
+	return &ret, nil
+}
+
+// LoadFile reads the movie NFO file at filePath.
+func LoadFile(filePath string) (*Movie, error) {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return Parse(f)
+}
+
+// Write writes m to w as an NFO document.
+func Write(w io.Writer, m *Movie) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(m); err != nil {
+		return err
+	}
+
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// SaveFile writes m to the NFO file at filePath.
+func SaveFile(filePath string, m *Movie) error {
+	if m == nil {
+		return errors.New("movie must not be nil")
+	}
+
+	f, err := os.Create(filePath)
+	if err != nil {
+		return err
+	}
+
+	if err := Write(f, m); err != nil {
+		f.Close()
+		return err
+	}
+
+	return f.Close()
+}
+
+// GetSidecarPath returns the path of the NFO file with the same basename as
+// the video file at videoPath.
+func GetSidecarPath(videoPath string) string {
+	return strings.TrimSuffix(videoPath, filepath.Ext(videoPath)) + Extension
+}
+
+// GetPosterPath returns the path of the poster image for the video file at
+// videoPath.
+func GetPosterPath(videoPath string) string {
+	return strings.TrimSuffix(videoPath, filepath.Ext(videoPath)) + posterSuffix
+}
+
+// FindSidecar returns the path of the NFO file describing the video file at
+// videoPath. A <basename>.nfo file is preferred over a movie.nfo file in the
+// same folder. It returns an empty string if neither exists.
+func FindSidecar(videoPath string) (string, error) {
+	candidates := []string{
+		GetSidecarPath(videoPath),
+		filepath.Join(filepath.Dir(videoPath), MovieFilename),
+	}
+
+	for _, p := range candidates {
+		exists, err := fsutil.FileExists(p)
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return "", err
+		}
+
+		if exists {
+			return p, nil
+		}
+	}
+
+	return "", nil
+}
@@ -0,0 +1,138 @@
+package nfo
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func intPtr(i int) *int {
+	return &i
+}
+
+func TestParse(t *testing.T) {
+	const input = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<movie>
+  <title>Title</title>
+  <outline>Outline</outline>
+  <premiered>2020-01-02</premiered>
+  <studio>Studio</studio>
+  <actor>
+    <name>Actor 1</name>
+    <role>Role</role>
+  </actor>
+  <actor>
+    <name> </name>
+  </actor>
+  <genre>Genre</genre>
+  <genre>tag</genre>
+  <tag>Tag</tag>
+  <ratings>
+    <rating name="imdb" max="10">
+      <value>6.5</value>
+    </rating>
+    <rating name="tmdb" max="5" default="true">
+      <value>4</value>
+    </rating>
+  </ratings>
+</movie>
+https://example.com/movie
+`
+
+	got, err := Parse(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	assert.Equal(t, "Title", got.Title)
+	assert.Equal(t, "Outline", got.Details())
+	assert.Equal(t, "2020-01-02", got.Date())
+	assert.Equal(t, []string{"Studio"}, got.Studios)
+	assert.Equal(t, []string{"Actor 1"}, got.ActorNames())
+	assert.Equal(t, []string{"Tag", "Genre"}, got.TagNames())
+	assert.Equal(t, intPtr(80), got.Rating100())
+}
+
+func TestParseInvalid(t *testing.T) {
+	_, err := Parse(strings.NewReader("not an nfo file"))
+	assert.NotNil(t, err)
+}
+
+func TestMovie_Rating100(t *testing.T) {
+	tests := []struct {
+		name  string
+		movie Movie
+		want  *int
+	}{
+		{"unrated", Movie{}, nil},
+		{"legacy rating", Movie{Rating: 7.25}, intPtr(73)},
+		{"user rating preferred", Movie{Rating: 5, UserRating: 9}, intPtr(90)},
+		{"first rating without default", Movie{Ratings: []Rating{{Value: 6}, {Value: 8}}}, intPtr(60)},
+		{"rating out of 100", Movie{Ratings: []Rating{{Max: 100, Value: 42}}}, intPtr(42)},
+		{"clamped", Movie{Rating: 11}, intPtr(100)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.movie.Rating100())
+		})
+	}
+}
+
+func TestWriteRoundTrip(t *testing.T) {
+	m := &Movie{
+		Title:      "Title & more",
+		Plot:       "Plot",
+		Premiered:  "2020-01-02",
+		Year:       2020,
+		Studios:    []string{"Studio"},
+		Actors:     []Actor{{Name: "Actor"}},
+		Tags:       []string{"Tag"},
+		UserRating: 7.5,
+	}
+
+	var buf bytes.Buffer
+	if err := Write(&buf, m); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	assert.True(t, strings.HasPrefix(buf.String(), "<?xml"))
+
+	got, err := Parse(&buf)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	got.XMLName = m.XMLName
+	assert.Equal(t, m, got)
+}
+
+func TestFindSidecar(t *testing.T) {
+	dir := t.TempDir()
+	videoPath := filepath.Join(dir, "video.mp4")
+
+	got, err := FindSidecar(videoPath)
+	assert.Nil(t, err)
+	assert.Equal(t, "", got)
+
+	moviePath := filepath.Join(dir, MovieFilename)
+	if err := os.WriteFile(moviePath, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err = FindSidecar(videoPath)
+	assert.Nil(t, err)
+	assert.Equal(t, moviePath, got)
+
+	sidecarPath := filepath.Join(dir, "video.nfo")
+	if err := os.WriteFile(sidecarPath, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err = FindSidecar(videoPath)
+	assert.Nil(t, err)
+	assert.Equal(t, sidecarPath, got)
+}
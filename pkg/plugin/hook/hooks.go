@@ -38,10 +38,12 @@ const (
 
 	PerformerCreatePost  TriggerEnum = "Performer.Create.Post"
 	PerformerUpdatePost  TriggerEnum = "Performer.Update.Post"
+	PerformerMergePost   TriggerEnum = "Performer.Merge.Post"
 	PerformerDestroyPost TriggerEnum = "Performer.Destroy.Post"
 
 	StudioCreatePost  TriggerEnum = "Studio.Create.Post"
 	StudioUpdatePost  TriggerEnum = "Studio.Update.Post"
+	StudioMergePost   TriggerEnum = "Studio.Merge.Post"
 	StudioDestroyPost TriggerEnum = "Studio.Destroy.Post"
 
 	TagCreatePost  TriggerEnum = "Tag.Create.Post"
@@ -50,6 +52,46 @@ const (
 	TagDestroyPost TriggerEnum = "Tag.Destroy.Post"
 )
 
+// Pre-hooks are executed synchronously before the operation is performed.
+// They may modify the operation input, or abort the operation by returning
+// an error.
+const (
+	SceneMarkerCreatePre  TriggerEnum = "SceneMarker.Create.Pre"
+	SceneMarkerUpdatePre  TriggerEnum = "SceneMarker.Update.Pre"
+	SceneMarkerDestroyPre TriggerEnum = "SceneMarker.Destroy.Pre"
+
+	SceneCreatePre  TriggerEnum = "Scene.Create.Pre"
+	SceneUpdatePre  TriggerEnum = "Scene.Update.Pre"
+	SceneDestroyPre TriggerEnum = "Scene.Destroy.Pre"
+
+	ImageUpdatePre  TriggerEnum = "Image.Update.Pre"
+	ImageDestroyPre TriggerEnum = "Image.Destroy.Pre"
+
+	GalleryCreatePre  TriggerEnum = "Gallery.Create.Pre"
+	GalleryUpdatePre  TriggerEnum = "Gallery.Update.Pre"
+	GalleryDestroyPre TriggerEnum = "Gallery.Destroy.Pre"
+
+	GalleryChapterCreatePre  TriggerEnum = "GalleryChapter.Create.Pre"
+	GalleryChapterUpdatePre  TriggerEnum = "GalleryChapter.Update.Pre"
+	GalleryChapterDestroyPre TriggerEnum = "GalleryChapter.Destroy.Pre"
+
+	GroupCreatePre  TriggerEnum = "Group.Create.Pre"
+	GroupUpdatePre  TriggerEnum = "Group.Update.Pre"
+	GroupDestroyPre TriggerEnum = "Group.Destroy.Pre"
+
+	PerformerCreatePre  TriggerEnum = "Performer.Create.Pre"
+	PerformerUpdatePre  TriggerEnum = "Performer.Update.Pre"
+	PerformerDestroyPre TriggerEnum = "Performer.Destroy.Pre"
+
+	StudioCreatePre  TriggerEnum = "Studio.Create.Pre"
+	StudioUpdatePre  TriggerEnum = "Studio.Update.Pre"
+	StudioDestroyPre TriggerEnum = "Studio.Destroy.Pre"
+
+	TagCreatePre  TriggerEnum = "Tag.Create.Pre"
+	TagUpdatePre  TriggerEnum = "Tag.Update.Pre"
+	TagDestroyPre TriggerEnum = "Tag.Destroy.Pre"
+)
+
 var AllHookTriggerEnum = []TriggerEnum{
 	SceneMarkerCreatePost,
 	SceneMarkerUpdatePost,
@@ -77,16 +119,53 @@ var AllHookTriggerEnum = []TriggerEnum{
 
 	PerformerCreatePost,
 	PerformerUpdatePost,
+	PerformerMergePost,
 	PerformerDestroyPost,
 
 	StudioCreatePost,
 	StudioUpdatePost,
+	StudioMergePost,
 	StudioDestroyPost,
 
 	TagCreatePost,
 	TagUpdatePost,
 	TagMergePost,
 	TagDestroyPost,
+
+	SceneMarkerCreatePre,
+	SceneMarkerUpdatePre,
+	SceneMarkerDestroyPre,
+
+	SceneCreatePre,
+	SceneUpdatePre,
+	SceneDestroyPre,
+
+	ImageUpdatePre,
+	ImageDestroyPre,
+
+	GalleryCreatePre,
+	GalleryUpdatePre,
+	GalleryDestroyPre,
+
+	GalleryChapterCreatePre,
+	GalleryChapterUpdatePre,
+	GalleryChapterDestroyPre,
+
+	GroupCreatePre,
+	GroupUpdatePre,
+	GroupDestroyPre,
+
+	PerformerCreatePre,
+	PerformerUpdatePre,
+	PerformerDestroyPre,
+
+	StudioCreatePre,
+	StudioUpdatePre,
+	StudioDestroyPre,
+
+	TagCreatePre,
+	TagUpdatePre,
+	TagDestroyPre,
 }
 
 func (e TriggerEnum) IsValid() bool {
@@ -118,15 +197,52 @@ func (e TriggerEnum) IsValid() bool {
 
 		PerformerCreatePost,
 		PerformerUpdatePost,
+		PerformerMergePost,
 		PerformerDestroyPost,
 
 		StudioCreatePost,
 		StudioUpdatePost,
+		StudioMergePost,
 		StudioDestroyPost,
 
 		TagCreatePost,
 		TagUpdatePost,
-		TagDestroyPost:
+		TagDestroyPost,
+
+		SceneMarkerCreatePre,
+		SceneMarkerUpdatePre,
+		SceneMarkerDestroyPre,
+
+		SceneCreatePre,
+		SceneUpdatePre,
+		SceneDestroyPre,
+
+		ImageUpdatePre,
+		ImageDestroyPre,
+
+		GalleryCreatePre,
+		GalleryUpdatePre,
+		GalleryDestroyPre,
+
+		GalleryChapterCreatePre,
+		GalleryChapterUpdatePre,
+		GalleryChapterDestroyPre,
+
+		GroupCreatePre,
+		GroupUpdatePre,
+		GroupDestroyPre,
+
+		PerformerCreatePre,
+		PerformerUpdatePre,
+		PerformerDestroyPre,
+
+		StudioCreatePre,
+		StudioUpdatePre,
+		StudioDestroyPre,
+
+		TagCreatePre,
+		TagUpdatePre,
+		TagDestroyPre:
 		return true
 	}
 	return false
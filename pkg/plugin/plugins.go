@@ -16,6 +16,7 @@ import (
 	"path/filepath"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/stashapp/stash/pkg/fsutil"
 	"github.com/stashapp/stash/pkg/logger"
@@ -104,8 +105,14 @@ type Cache struct {
 	plugins      []Config
 	sessionStore *session.Store
 	gqlHandler   http.Handler
+
+	postHookListeners []PostHookListener
 }
 
+// PostHookListener is called with the details of each post hook that is
+// executed.
+type PostHookListener func(ctx context.Context, id int, hookType hook.TriggerEnum, input interface{}, inputFields []string)
+
 // NewCache returns a new Cache.
 //
 // Plugins configurations are loaded from yml files in the plugin
@@ -123,6 +130,12 @@ func (c *Cache) RegisterGQLHandler(handler http.Handler) {
 	c.gqlHandler = handler
 }
 
+// RegisterPostHookListener registers a listener that is called whenever post
+// hooks are executed, regardless of whether any plugin handles the hook.
+func (c *Cache) RegisterPostHookListener(l PostHookListener) {
+	c.postHookListeners = append(c.postHookListeners, l)
+}
+
 func (c *Cache) RegisterSessionStore(sessionStore *session.Store) {
 	c.sessionStore = sessionStore
 }
@@ -358,6 +371,10 @@ func waitForTask(ctx context.Context, task Task) error {
 }
 
 func (c Cache) ExecutePostHooks(ctx context.Context, id int, hookType hook.TriggerEnum, input interface{}, inputFields []string) {
+	for _, l := range c.postHookListeners {
+		l(ctx, id, hookType, input, inputFields)
+	}
+
 	if err := c.executePostHooks(ctx, hookType, common.HookContext{
 		ID:          id,
 		Type:        hookType.String(),
@@ -401,30 +418,11 @@ func (c Cache) executePostHooks(ctx context.Context, hookType hook.TriggerEnum,
 		}
 
 		for _, h := range hooks {
-			newCtx := session.AddVisitedPluginHook(ctx, p.id, hookType)
-			serverConnection := c.makeServerConnection(newCtx)
-
-			pluginInput := buildPluginInput(&p, &h.OperationConfig, serverConnection, nil)
-			addHookContext(pluginInput.Args, hookContext)
-
-			pt := pluginTask{
-				plugin:       &p,
-				operation:    &h.OperationConfig,
-				input:        pluginInput,
-				gqlHandler:   c.gqlHandler,
-				serverConfig: c.config,
-			}
-
-			task := pt.createTask()
-			if err := task.Start(); err != nil {
-				return err
-			}
-
-			if err := waitForTask(ctx, task); err != nil {
+			output, err := c.executeHook(ctx, &p, h, hookType, hookContext)
+			if err != nil {
 				return err
 			}
 
-			output := task.GetResult()
 			if output == nil {
 				logger.Debugf("%s [%s]: returned no result", hookType.String(), p.Name)
 			} else {
@@ -440,6 +438,97 @@ func (c Cache) executePostHooks(ctx context.Context, hookType hook.TriggerEnum,
 	return nil
 }
 
+// PreHookTimeout is the maximum time that the pre-hooks for a single
+// operation may take to execute before the operation is aborted. Operations
+// that apply to multiple objects should execute the pre-hooks of all objects
+// with a context with this timeout, so that the whole operation is bounded.
+const PreHookTimeout = 30 * time.Second
+
+// ExecutePreHooks synchronously executes the pre-hooks of the provided type.
+// Hooks are executed in turn, with each hook receiving the input returned by
+// the previous hook. Returns the modified input if any hook returned a
+// non-nil output, otherwise returns nil.
+// Returns an error if a hook returned an error or if the hooks did not
+// complete within PreHookTimeout, or before the deadline of ctx if it is
+// earlier. The operation should be aborted if an error is returned.
+func (c Cache) ExecutePreHooks(ctx context.Context, id int, hookType hook.TriggerEnum, input interface{}, inputFields []string) (interface{}, error) {
+	ctx, cancel := context.WithTimeout(ctx, PreHookTimeout)
+	defer cancel()
+
+	hookContext := common.HookContext{
+		ID:          id,
+		Type:        hookType.String(),
+		Input:       input,
+		InputFields: inputFields,
+	}
+
+	var ret interface{}
+	visitedPluginHookCounts := getVisitedPluginHookCounts(ctx)
+
+	for _, p := range c.enabledPlugins() {
+		hooks := p.getHooks(hookType)
+		if len(hooks) > 0 && visitedPluginHookCounts.For(p.id, hookType) >= maxCyclicLoopDepth {
+			logger.Debugf("cyclic loop detected: plugin ID '%s' hook %s, not re-triggering", p.id, hookType)
+			continue
+		}
+
+		for _, h := range hooks {
+			output, err := c.executeHook(ctx, &p, h, hookType, hookContext)
+			if err != nil {
+				if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+					return nil, fmt.Errorf("%s [%s]: timed out after %s", hookType.String(), p.Name, PreHookTimeout)
+				}
+				return nil, fmt.Errorf("%s [%s]: %w", hookType.String(), p.Name, err)
+			}
+
+			if output == nil {
+				logger.Debugf("%s [%s]: returned no result", hookType.String(), p.Name)
+				continue
+			}
+
+			if output.Error != nil {
+				return nil, fmt.Errorf("%s [%s]: %s", hookType.String(), p.Name, *output.Error)
+			}
+
+			if output.Output != nil {
+				logger.Debugf("%s [%s]: returned: %v", hookType.String(), p.Name, output.Output)
+				ret = output.Output
+				hookContext.Input = output.Output
+			}
+		}
+	}
+
+	return ret, nil
+}
+
+// executeHook runs the provided hook operation and waits for it to complete.
+func (c Cache) executeHook(ctx context.Context, p *Config, h *HookConfig, hookType hook.TriggerEnum, hookContext common.HookContext) (*common.PluginOutput, error) {
+	newCtx := session.AddVisitedPluginHook(ctx, p.id, hookType)
+	serverConnection := c.makeServerConnection(newCtx)
+
+	pluginInput := buildPluginInput(p, &h.OperationConfig, serverConnection, nil)
+	addHookContext(pluginInput.Args, hookContext)
+
+	pt := pluginTask{
+		plugin:       p,
+		operation:    &h.OperationConfig,
+		input:        pluginInput,
+		gqlHandler:   c.gqlHandler,
+		serverConfig: c.config,
+	}
+
+	task := pt.createTask()
+	if err := task.Start(); err != nil {
+		return nil, err
+	}
+
+	if err := waitForTask(ctx, task); err != nil {
+		return nil, err
+	}
+
+	return task.GetResult(), nil
+}
+
 type visitedPluginHookCount struct {
 	session.VisitedPluginHook
 	Count int
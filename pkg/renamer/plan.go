@@ -0,0 +1,139 @@
+package renamer
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"strings"
+
+	"github.com/stashapp/stash/pkg/file"
+	"github.com/stashapp/stash/pkg/models"
+)
+
+// Item is a file to be renamed, along with the object it belongs to.
+type Item struct {
+	File   models.File
+	Object Object
+}
+
+// Rename is a planned move of a file.
+type Rename struct {
+	File    models.File
+	OldPath string
+	NewPath string
+	// Collision is true if another file exists at the new path, or if
+	// another file is planned to be moved to the new path.
+	Collision bool
+	// Error is set if the new path could not be determined.
+	Error error
+}
+
+// Valid returns true if the file can be moved to the new path.
+func (r *Rename) Valid() bool {
+	return !r.Collision && r.Error == nil
+}
+
+// Planner determines the new paths of files using a template.
+type Planner struct {
+	Template *Template
+	Statter  file.Statter
+	// LibraryDirFn returns the library directory containing the provided
+	// path. The template path is relative to this directory.
+	LibraryDirFn func(path string) string
+}
+
+// Plan returns the planned moves of the provided items. Files that would not
+// be moved are omitted.
+func (p *Planner) Plan(items []Item) ([]*Rename, error) {
+	var ret []*Rename
+	byNewPath := make(map[string][]*Rename)
+
+	for _, item := range items {
+		r := p.plan(item)
+		if r == nil {
+			continue
+		}
+
+		ret = append(ret, r)
+
+		if r.Error == nil {
+			key := strings.ToLower(r.NewPath)
+			byNewPath[key] = append(byNewPath[key], r)
+		}
+	}
+
+	for _, renames := range byNewPath {
+		if len(renames) > 1 {
+			for _, r := range renames {
+				r.Collision = true
+			}
+			continue
+		}
+
+		r := renames[0]
+
+		// case-only changes will find the file itself
+		if strings.EqualFold(r.OldPath, r.NewPath) {
+			continue
+		}
+
+		_, err := p.Statter.Stat(r.NewPath)
+		switch {
+		case err == nil:
+			r.Collision = true
+		case !errors.Is(err, fs.ErrNotExist):
+			return nil, fmt.Errorf("checking %q: %w", r.NewPath, err)
+		}
+	}
+
+	return ret, nil
+}
+
+func (p *Planner) plan(item Item) *Rename {
+	fBase := item.File.Base()
+	ret := &Rename{
+		File:    item.File,
+		OldPath: fBase.Path,
+	}
+
+	if fBase.ZipFileID != nil {
+		ret.Error = errors.New("file is in a zip file")
+		return ret
+	}
+
+	libraryDir := p.LibraryDirFn(fBase.Path)
+	if libraryDir == "" {
+		ret.Error = errors.New("file is not in a library")
+		return ret
+	}
+
+	rel, err := p.Template.Execute(item.Object.Values(item.File))
+	if err != nil {
+		ret.Error = err
+		return ret
+	}
+
+	ret.NewPath = filepath.Join(libraryDir, rel)
+
+	if !inDirectory(libraryDir, ret.NewPath) {
+		ret.Error = fmt.Errorf("new path %q is outside of the library", ret.NewPath)
+		return ret
+	}
+
+	if ret.NewPath == ret.OldPath {
+		return nil
+	}
+
+	return ret
+}
+
+// inDirectory returns true if path is within dir.
+func inDirectory(dir string, path string) bool {
+	rel, err := filepath.Rel(dir, path)
+	if err != nil {
+		return false
+	}
+
+	return rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
@@ -0,0 +1,160 @@
+package renamer
+
+import (
+	"io/fs"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+type testStatter map[string]bool
+
+func (s testStatter) Stat(name string) (fs.FileInfo, error) {
+	if s[name] {
+		return nil, nil
+	}
+
+	return nil, fs.ErrNotExist
+}
+
+const libraryDir = "library"
+
+func testLibraryDir(path string) string {
+	if strings.HasPrefix(path, libraryDir+string(filepath.Separator)) {
+		return libraryDir
+	}
+
+	return ""
+}
+
+func testVideoFile(id int, path string, zipFileID *models.FileID) *models.VideoFile {
+	return &models.VideoFile{
+		BaseFile: &models.BaseFile{
+			DirEntry: models.DirEntry{
+				ZipFileID: zipFileID,
+			},
+			ID:       models.FileID(id),
+			Path:     path,
+			Basename: filepath.Base(path),
+		},
+		Width:  1920,
+		Height: 1080,
+	}
+}
+
+func TestPlanner_Plan(t *testing.T) {
+	tmpl, err := ParseTemplate("{studio}/{title} [{resolution}]")
+	if err != nil {
+		t.Fatalf("ParseTemplate() error = %v", err)
+	}
+
+	studio := &models.Studio{Name: "Studio"}
+	zipFileID := models.FileID(100)
+
+	var (
+		unchanged    = testVideoFile(1, filepath.Join(libraryDir, "Studio", "Unchanged [1080p].mp4"), nil)
+		moved        = testVideoFile(2, filepath.Join(libraryDir, "moved.mp4"), nil)
+		caseOnly     = testVideoFile(3, filepath.Join(libraryDir, "Studio", "case only [1080p].mp4"), nil)
+		existing     = testVideoFile(4, filepath.Join(libraryDir, "existing.mp4"), nil)
+		duplicate1   = testVideoFile(5, filepath.Join(libraryDir, "duplicate1.mp4"), nil)
+		duplicate2   = testVideoFile(6, filepath.Join(libraryDir, "duplicate2.mp4"), nil)
+		inZip        = testVideoFile(7, filepath.Join(libraryDir, "zip.zip", "zipped.mp4"), &zipFileID)
+		notInLibrary = testVideoFile(8, filepath.Join("elsewhere", "file.mp4"), nil)
+	)
+
+	item := func(f models.File, title string) Item {
+		return Item{
+			File: f,
+			Object: Object{
+				Title:  title,
+				Studio: studio,
+			},
+		}
+	}
+
+	existingPath := filepath.Join(libraryDir, "Studio", "Existing [1080p].mp4")
+
+	p := &Planner{
+		Template: tmpl,
+		Statter: testStatter{
+			existingPath:  true,
+			caseOnly.Path: true,
+		},
+		LibraryDirFn: testLibraryDir,
+	}
+
+	got, err := p.Plan([]Item{
+		item(unchanged, "Unchanged"),
+		item(moved, "Moved"),
+		item(caseOnly, "Case Only"),
+		item(existing, "Existing"),
+		item(duplicate1, "Duplicate"),
+		item(duplicate2, "duplicate"),
+		item(inZip, "Zipped"),
+		item(notInLibrary, "Elsewhere"),
+	})
+	if err != nil {
+		t.Fatalf("Planner.Plan() error = %v", err)
+	}
+
+	byID := make(map[models.FileID]*Rename)
+	for _, r := range got {
+		byID[r.File.Base().ID] = r
+	}
+
+	assert.Len(t, got, 7)
+	assert.NotContains(t, byID, unchanged.ID)
+
+	if r := byID[moved.ID]; assert.NotNil(t, r) {
+		assert.Equal(t, filepath.Join(libraryDir, "Studio", "Moved [1080p].mp4"), r.NewPath)
+		assert.True(t, r.Valid())
+	}
+
+	if r := byID[caseOnly.ID]; assert.NotNil(t, r) {
+		assert.Equal(t, filepath.Join(libraryDir, "Studio", "Case Only [1080p].mp4"), r.NewPath)
+		assert.True(t, r.Valid())
+	}
+
+	if r := byID[existing.ID]; assert.NotNil(t, r) {
+		assert.Equal(t, existingPath, r.NewPath)
+		assert.True(t, r.Collision)
+	}
+
+	for _, id := range []models.FileID{duplicate1.ID, duplicate2.ID} {
+		if r := byID[id]; assert.NotNil(t, r) {
+			assert.True(t, r.Collision)
+		}
+	}
+
+	for _, id := range []models.FileID{inZip.ID, notInLibrary.ID} {
+		if r := byID[id]; assert.NotNil(t, r) {
+			assert.Error(t, r.Error)
+			assert.False(t, r.Valid())
+		}
+	}
+}
+
+func TestInDirectory(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+		want bool
+	}{
+		{"file", filepath.Join(libraryDir, "file.mp4"), true},
+		{"nested", filepath.Join(libraryDir, "a", "file.mp4"), true},
+		{"dotted filename", filepath.Join(libraryDir, "..file.mp4"), true},
+		{"library", libraryDir, true},
+		{"parent", filepath.Join(libraryDir, ".."), false},
+		{"sibling", filepath.Join(libraryDir, "..", "other", "file.mp4"), false},
+		{"sibling prefix", libraryDir + "2" + string(filepath.Separator) + "file.mp4", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, inDirectory(libraryDir, tt.path))
+		})
+	}
+}
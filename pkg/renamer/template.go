@@ -0,0 +1,187 @@
+// Package renamer provides functionality to rename and move files based on
+// the metadata of the objects they belong to.
+package renamer
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// Fields that may be used in a template.
+const (
+	FieldID           = "id"
+	FieldTitle        = "title"
+	FieldCode         = "code"
+	FieldDate         = "date"
+	FieldYear         = "year"
+	FieldStudio       = "studio"
+	FieldStudioParent = "studio.parent"
+	FieldPerformers   = "performers"
+	FieldResolution   = "resolution"
+	FieldBasename     = "basename"
+	FieldExt          = "ext"
+)
+
+var validFields = []string{
+	FieldID,
+	FieldTitle,
+	FieldCode,
+	FieldDate,
+	FieldYear,
+	FieldStudio,
+	FieldStudioParent,
+	FieldPerformers,
+	FieldResolution,
+	FieldBasename,
+	FieldExt,
+}
+
+// Values maps template fields to their values.
+type Values map[string]string
+
+type templatePart struct {
+	literal string
+	field   string
+}
+
+// Template is a parsed path template. Fields are enclosed in braces, for
+// example {studio}/{date} - {title}{ext}. Directories are separated using
+// forward slashes. The resulting path is relative to the library containing
+// the file.
+type Template struct {
+	parts  []templatePart
+	hasExt bool
+}
+
+// ParseTemplate parses the provided path template. Returns an error if the
+// template is empty, contains an unknown field or is not a relative path.
+func ParseTemplate(s string) (*Template, error) {
+	if strings.TrimSpace(s) == "" {
+		return nil, errors.New("template is empty")
+	}
+
+	if strings.HasPrefix(s, "/") || strings.HasPrefix(s, `\`) || filepath.VolumeName(s) != "" {
+		return nil, fmt.Errorf("template %q must be a relative path", s)
+	}
+
+	ret := &Template{}
+	remaining := s
+	for remaining != "" {
+		start := strings.Index(remaining, "{")
+		if start == -1 {
+			ret.parts = append(ret.parts, templatePart{literal: remaining})
+			break
+		}
+
+		if start > 0 {
+			ret.parts = append(ret.parts, templatePart{literal: remaining[:start]})
+		}
+
+		end := strings.Index(remaining[start:], "}")
+		if end == -1 {
+			return nil, fmt.Errorf("unterminated field in template %q", s)
+		}
+
+		field := remaining[start+1 : start+end]
+		if !isValidField(field) {
+			return nil, fmt.Errorf("unknown field %q in template %q", field, s)
+		}
+
+		if field == FieldExt {
+			ret.hasExt = true
+		}
+
+		ret.parts = append(ret.parts, templatePart{field: field})
+		remaining = remaining[start+end+1:]
+	}
+
+	for _, p := range ret.parts {
+		for _, segment := range splitPath(p.literal) {
+			if segment == ".." {
+				return nil, fmt.Errorf("template %q must not contain parent directory references", s)
+			}
+		}
+	}
+
+	return ret, nil
+}
+
+func isValidField(field string) bool {
+	for _, f := range validFields {
+		if f == field {
+			return true
+		}
+	}
+
+	return false
+}
+
+func splitPath(p string) []string {
+	return strings.FieldsFunc(p, func(r rune) bool {
+		return r == '/' || r == '\\'
+	})
+}
+
+// Execute returns the relative path produced by applying the provided values
+// to the template. Field values are sanitised so that they do not contain
+// path separators or characters that are invalid in filenames. Directories
+// that are empty after applying the values are omitted. If the template does
+// not include the ext field, then the ext value is appended. Returns an error
+// if a directory produced by the values refers to the current or parent
+// directory.
+func (t *Template) Execute(v Values) (string, error) {
+	var sb strings.Builder
+	for _, p := range t.parts {
+		if p.field != "" {
+			sb.WriteString(sanitise(v[p.field]))
+		} else {
+			sb.WriteString(p.literal)
+		}
+	}
+
+	var segments []string
+	for _, segment := range splitPath(sb.String()) {
+		segment = strings.TrimSpace(segment)
+		if segment == "." || segment == ".." {
+			return "", fmt.Errorf("template produced an invalid directory %q", segment)
+		}
+		if segment != "" {
+			segments = append(segments, segment)
+		}
+	}
+
+	if len(segments) == 0 {
+		return "", errors.New("template produced an empty path")
+	}
+
+	last := len(segments) - 1
+	if !t.hasExt {
+		segments[last] += v[FieldExt]
+	}
+
+	if strings.TrimSpace(strings.TrimSuffix(segments[last], v[FieldExt])) == "" {
+		return "", errors.New("template produced an empty filename")
+	}
+
+	return filepath.Join(segments...), nil
+}
+
+// sanitise replaces characters in field values that are not valid in
+// filenames.
+func sanitise(s string) string {
+	s = strings.Map(func(r rune) rune {
+		switch {
+		case r == '/' || r == '\\':
+			return '-'
+		case r < 32 || strings.ContainsRune(`<>:"|?*`, r):
+			return -1
+		default:
+			return r
+		}
+	}, s)
+
+	// trailing dots are not permitted in Windows filenames
+	return strings.TrimRight(strings.TrimSpace(s), ".")
+}
@@ -0,0 +1,140 @@
+package renamer
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTemplate(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		wantErr  bool
+	}{
+		{"valid", "{studio.parent}/{studio}/{date} - {title} [{resolution}]{ext}", false},
+		{"literal only", "renamed", false},
+		{"empty", " ", true},
+		{"absolute", "/{title}", true},
+		{"absolute backslash", `\{title}`, true},
+		{"unknown field", "{studio}/{name}", true},
+		{"unterminated field", "{studio}/{title", true},
+		{"parent directory", "../{title}", true},
+		{"nested parent directory", "{studio}/../{title}", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseTemplate(tt.template)
+			assert.Equal(t, tt.wantErr, err != nil, "ParseTemplate() error = %v", err)
+		})
+	}
+}
+
+func TestTemplate_Execute(t *testing.T) {
+	values := Values{
+		FieldTitle:      "Title",
+		FieldDate:       "2024-01-02",
+		FieldStudio:     "Studio",
+		FieldResolution: "1080p",
+		FieldExt:        ".mp4",
+	}
+
+	tests := []struct {
+		name     string
+		template string
+		values   Values
+		want     string
+		wantErr  bool
+	}{
+		{
+			"full",
+			"{studio.parent}/{studio}/{date} - {title} [{resolution}]{ext}",
+			values,
+			filepath.Join("Studio", "2024-01-02 - Title [1080p].mp4"),
+			false,
+		},
+		{
+			"ext appended",
+			"{studio}/{title}",
+			values,
+			filepath.Join("Studio", "Title.mp4"),
+			false,
+		},
+		{
+			"sanitised value",
+			"{studio}/{title}{ext}",
+			Values{
+				FieldStudio: "AC/DC",
+				FieldTitle:  `What? "Now": <1> | 2*...`,
+				FieldExt:    ".mp4",
+			},
+			filepath.Join("AC-DC", "What Now 1  2.mp4"),
+			false,
+		},
+		{
+			"empty directory omitted",
+			"{studio}/ {code} /{title}{ext}",
+			values,
+			filepath.Join("Studio", "Title.mp4"),
+			false,
+		},
+		{
+			"empty filename",
+			"{studio}/{code}{ext}",
+			values,
+			"",
+			true,
+		},
+		{
+			"empty field produces parent directory",
+			".{code}./{title}{ext}",
+			values,
+			"",
+			true,
+		},
+		{
+			"value produces parent directory",
+			".{title}/{studio}{ext}",
+			Values{
+				FieldTitle:  ". .",
+				FieldStudio: "Studio",
+				FieldExt:    ".mp4",
+			},
+			"",
+			true,
+		},
+		{
+			"empty field produces current directory",
+			"{code}./{title}{ext}",
+			values,
+			"",
+			true,
+		},
+		{
+			"empty path",
+			"{code}/{year}",
+			Values{},
+			"",
+			true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpl, err := ParseTemplate(tt.template)
+			if err != nil {
+				t.Fatalf("ParseTemplate() error = %v", err)
+			}
+
+			got, err := tmpl.Execute(tt.values)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Template.Execute() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package renamer
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+// Object contains the metadata of a scene, image or gallery that is used to
+// populate a template.
+type Object struct {
+	ID           int
+	Title        string
+	Code         string
+	Date         *models.Date
+	Studio       *models.Studio
+	ParentStudio *models.Studio
+	Performers   []*models.Performer
+}
+
+// Values returns the template values for the provided file of the object.
+func (o Object) Values(f models.File) Values {
+	basename := f.Base().Basename
+	ext := filepath.Ext(basename)
+
+	ret := Values{
+		FieldID:         strconv.Itoa(o.ID),
+		FieldTitle:      o.Title,
+		FieldCode:       o.Code,
+		FieldPerformers: performerNames(o.Performers),
+		FieldResolution: resolution(f),
+		FieldBasename:   strings.TrimSuffix(basename, ext),
+		FieldExt:        ext,
+	}
+
+	if o.Date != nil {
+		ret[FieldDate] = o.Date.String()
+		ret[FieldYear] = strconv.Itoa(o.Date.Year())
+	}
+
+	if o.Studio != nil {
+		ret[FieldStudio] = o.Studio.Name
+	}
+
+	if o.ParentStudio != nil {
+		ret[FieldStudioParent] = o.ParentStudio.Name
+	}
+
+	return ret
+}
+
+func performerNames(performers []*models.Performer) string {
+	names := make([]string, len(performers))
+	for i, p := range performers {
+		names[i] = p.Name
+	}
+
+	sort.Strings(names)
+
+	return strings.Join(names, ", ")
+}
+
+// resolution returns the resolution of video files as the size of the
+// shortest side, such as 1080p, and the resolution of images as the width
+// and height.
+func resolution(f models.File) string {
+	switch f := f.(type) {
+	case *models.VideoFile:
+		if f.Height == 0 {
+			return ""
+		}
+		return strconv.Itoa(models.GetMinResolution(f)) + "p"
+	case *models.ImageFile:
+		if f.Height == 0 {
+			return ""
+		}
+		return fmt.Sprintf("%dx%d", f.Width, f.Height)
+	default:
+		return ""
+	}
+}
@@ -443,8 +443,12 @@ const (
 
 	markerSeconds1Str = "1.0"
 	markerSeconds2Str = "2.3"
+
+	markerEndSeconds2Str = "5.5"
 )
 
+var markerEndSeconds2 = 5.5
+
 type sceneMarkersTestScenario struct {
 	input    models.Scene
 	expected []jsonschema.SceneMarker
@@ -474,6 +478,7 @@ var getSceneMarkersJSONScenarios = []sceneMarkersTestScenario{
 				Title:      markerTitle2,
 				PrimaryTag: validTagName2,
 				Seconds:    markerSeconds2Str,
+				EndSeconds: markerEndSeconds2Str,
 				Tags: []string{
 					validTagName2,
 				},
@@ -523,6 +528,7 @@ var validMarkers = []*models.SceneMarker{
 		Title:        markerTitle2,
 		PrimaryTagID: validTagID2,
 		Seconds:      markerSeconds2,
+		EndSeconds:   &markerEndSeconds2,
 		CreatedAt:    createTime,
 		UpdatedAt:    updateTime,
 	},
@@ -20,7 +20,10 @@ const (
 	markerScreenshotQuality = 2
 )
 
-func (g Generator) MarkerPreviewVideo(ctx context.Context, input string, hash string, seconds int, includeAudio bool) error {
+// MarkerPreviewVideo generates a preview video for the marker starting at
+// seconds. If endSeconds is set, the preview covers the marker range,
+// otherwise a fixed duration is used.
+func (g Generator) MarkerPreviewVideo(ctx context.Context, input string, hash string, seconds int, endSeconds *float64, includeAudio bool) error {
 	lockCtx := g.LockManager.ReadLock(ctx, input)
 	defer lockCtx.Cancel()
 
@@ -32,8 +35,9 @@ func (g Generator) MarkerPreviewVideo(ctx context.Context, input string, hash st
 	}
 
 	if err := g.generateFile(lockCtx, g.MarkerPaths, mp4Pattern, output, g.markerPreviewVideo(input, sceneMarkerOptions{
-		Seconds: seconds,
-		Audio:   includeAudio,
+		Seconds:  seconds,
+		Duration: markerDuration(seconds, endSeconds, markerPreviewDuration),
+		Audio:    includeAudio,
 	})); err != nil {
 		return err
 	}
@@ -44,8 +48,23 @@ func (g Generator) MarkerPreviewVideo(ctx context.Context, input string, hash st
 }
 
 type sceneMarkerOptions struct {
-	Seconds int
-	Audio   bool
+	Seconds  int
+	Duration float64
+	Audio    bool
+}
+
+// markerDuration returns the duration of the marker range starting at
+// seconds. Returns defaultDuration if endSeconds is not set.
+func markerDuration(seconds int, endSeconds *float64, defaultDuration float64) float64 {
+	if endSeconds == nil {
+		return defaultDuration
+	}
+
+	if d := *endSeconds - float64(seconds); d > 0 {
+		return d
+	}
+
+	return defaultDuration
 }
 
 func (g Generator) markerPreviewVideo(input string, options sceneMarkerOptions) generateFn {
@@ -69,7 +88,7 @@ func (g Generator) markerPreviewVideo(input string, options sceneMarkerOptions)
 		)
 
 		trimOptions := transcoder.TranscodeOptions{
-			Duration:   markerPreviewDuration,
+			Duration:   options.Duration,
 			StartTime:  float64(options.Seconds),
 			OutputPath: tmpFn,
 			VideoCodec: ffmpeg.VideoCodecLibX264,
@@ -90,7 +109,9 @@ func (g Generator) markerPreviewVideo(input string, options sceneMarkerOptions)
 	}
 }
 
-func (g Generator) SceneMarkerWebp(ctx context.Context, input string, hash string, seconds int) error {
+// SceneMarkerWebp generates an animated webp preview for the marker starting at
+// seconds. The preview is limited to the marker range if endSeconds is set.
+func (g Generator) SceneMarkerWebp(ctx context.Context, input string, hash string, seconds int, endSeconds *float64) error {
 	lockCtx := g.LockManager.ReadLock(ctx, input)
 	defer lockCtx.Cancel()
 
@@ -101,8 +122,14 @@ func (g Generator) SceneMarkerWebp(ctx context.Context, input string, hash strin
 		}
 	}
 
+	duration := markerDuration(seconds, endSeconds, markerImageDuration)
+	if duration > markerImageDuration {
+		duration = markerImageDuration
+	}
+
 	if err := g.generateFile(lockCtx, g.MarkerPaths, webpPattern, output, g.sceneMarkerWebp(input, sceneMarkerOptions{
-		Seconds: seconds,
+		Seconds:  seconds,
+		Duration: duration,
 	})); err != nil {
 		return err
 	}
@@ -130,7 +157,7 @@ func (g Generator) sceneMarkerWebp(input string, options sceneMarkerOptions) gen
 		)
 
 		trimOptions := transcoder.TranscodeOptions{
-			Duration:   markerImageDuration,
+			Duration:   options.Duration,
 			StartTime:  float64(options.Seconds),
 			OutputPath: tmpFn,
 			VideoCodec: ffmpeg.VideoCodecLibWebP,
@@ -12,6 +12,62 @@ import (
 type TranscodeOptions struct {
 	Width  int
 	Height int
+
+	// VideoCodec is the codec to encode the video with. May be a hardware
+	// codec. Defaults to libx264 if not set.
+	VideoCodec ffmpeg.VideoCodec
+
+	// SourceWidth and SourceHeight are the dimensions of the input video.
+	// They are used to scale the video for hardware codecs.
+	SourceWidth  int
+	SourceHeight int
+}
+
+// videoArgs returns the video codec and encoder arguments for the transcode,
+// and the input arguments required by hardware codecs.
+func (g Generator) videoArgs(o TranscodeOptions) (ffmpeg.VideoCodec, ffmpeg.Args, ffmpeg.Args) {
+	switch o.VideoCodec {
+	case ffmpeg.VideoCodecLibX265:
+		videoArgs := append(o.scaleArgs(),
+			"-pix_fmt", "yuv420p",
+			"-preset", "superfast",
+			"-crf", "28",
+			"-tag:v", "hvc1",
+		)
+		return o.VideoCodec, videoArgs, nil
+	case ffmpeg.VideoCodecLibSVTAV1:
+		videoArgs := append(o.scaleArgs(),
+			"-pix_fmt", "yuv420p",
+			"-preset", "8",
+			"-crf", "32",
+		)
+		return o.VideoCodec, videoArgs, nil
+	case ffmpeg.VideoCodecLibX264, ffmpeg.VideoCodec{}:
+		videoArgs := append(o.scaleArgs(),
+			"-pix_fmt", "yuv420p",
+			"-profile:v", "high",
+			"-level", "4.2",
+			"-preset", "superfast",
+			"-crf", "23",
+		)
+		return ffmpeg.VideoCodecLibX264, videoArgs, nil
+	}
+
+	// hardware codec
+	inputArgs, videoArgs := g.Encoder.HWEncodeArgs(o.VideoCodec, o.SourceWidth, o.SourceHeight, o.Width, o.Height)
+	return o.VideoCodec, videoArgs, inputArgs
+}
+
+// scaleArgs returns the software scale filter arguments, if the video is
+// scaled.
+func (o TranscodeOptions) scaleArgs() ffmpeg.Args {
+	var videoArgs ffmpeg.Args
+	if o.Width != 0 && o.Height != 0 {
+		var videoFilter ffmpeg.VideoFilter
+		videoFilter = videoFilter.ScaleDimensions(o.Width, o.Height)
+		videoArgs = videoArgs.VideoFilter(videoFilter)
+	}
+	return videoArgs
 }
 
 func (g Generator) Transcode(ctx context.Context, input string, hash string, options TranscodeOptions) error {
@@ -66,28 +122,15 @@ func (g Generator) makeTranscode(lockCtx *fsutil.LockContext, hash string, gener
 
 func (g Generator) transcode(input string, options TranscodeOptions) generateFn {
 	return func(lockCtx *fsutil.LockContext, tmpFn string) error {
-		var videoArgs ffmpeg.Args
-		if options.Width != 0 && options.Height != 0 {
-			var videoFilter ffmpeg.VideoFilter
-			videoFilter = videoFilter.ScaleDimensions(options.Width, options.Height)
-			videoArgs = videoArgs.VideoFilter(videoFilter)
-		}
-
-		videoArgs = append(videoArgs,
-			"-pix_fmt", "yuv420p",
-			"-profile:v", "high",
-			"-level", "4.2",
-			"-preset", "superfast",
-			"-crf", "23",
-		)
+		videoCodec, videoArgs, inputArgs := g.videoArgs(options)
 
 		args := transcoder.Transcode(input, transcoder.TranscodeOptions{
 			OutputPath: tmpFn,
-			VideoCodec: ffmpeg.VideoCodecLibX264,
+			VideoCodec: videoCodec,
 			VideoArgs:  videoArgs,
 			AudioCodec: ffmpeg.AudioCodecAAC,
 
-			ExtraInputArgs:  g.FFMpegConfig.GetTranscodeInputArgs(),
+			ExtraInputArgs:  append(inputArgs, g.FFMpegConfig.GetTranscodeInputArgs()...),
 			ExtraOutputArgs: g.FFMpegConfig.GetTranscodeOutputArgs(),
 		})
 
@@ -97,31 +140,18 @@ func (g Generator) transcode(input string, options TranscodeOptions) generateFn
 
 func (g Generator) transcodeVideo(input string, options TranscodeOptions) generateFn {
 	return func(lockCtx *fsutil.LockContext, tmpFn string) error {
-		var videoArgs ffmpeg.Args
-		if options.Width != 0 && options.Height != 0 {
-			var videoFilter ffmpeg.VideoFilter
-			videoFilter = videoFilter.ScaleDimensions(options.Width, options.Height)
-			videoArgs = videoArgs.VideoFilter(videoFilter)
-		}
-
-		videoArgs = append(videoArgs,
-			"-pix_fmt", "yuv420p",
-			"-profile:v", "high",
-			"-level", "4.2",
-			"-preset", "superfast",
-			"-crf", "23",
-		)
+		videoCodec, videoArgs, inputArgs := g.videoArgs(options)
 
 		var audioArgs ffmpeg.Args
 		audioArgs = audioArgs.SkipAudio()
 
 		args := transcoder.Transcode(input, transcoder.TranscodeOptions{
 			OutputPath: tmpFn,
-			VideoCodec: ffmpeg.VideoCodecLibX264,
+			VideoCodec: videoCodec,
 			VideoArgs:  videoArgs,
 			AudioArgs:  audioArgs,
 
-			ExtraInputArgs:  g.FFMpegConfig.GetTranscodeInputArgs(),
+			ExtraInputArgs:  append(inputArgs, g.FFMpegConfig.GetTranscodeInputArgs()...),
 			ExtraOutputArgs: g.FFMpegConfig.GetTranscodeOutputArgs(),
 		})
 
@@ -15,6 +15,7 @@ import (
 
 type ImporterReaderWriter interface {
 	models.SceneCreatorUpdater
+	models.CustomFieldsWriter
 	models.ViewHistoryWriter
 	models.OHistoryWriter
 	FindByFileID(ctx context.Context, fileID models.FileID) ([]*models.Scene, error)
@@ -446,6 +447,14 @@ func (i *Importer) PostImport(ctx context.Context, id int) error {
 		return err
 	}
 
+	if len(i.Input.CustomFields) > 0 {
+		if err := i.ReaderWriter.SetCustomFields(ctx, id, models.CustomFieldsInput{
+			Full: i.Input.CustomFields,
+		}); err != nil {
+			return fmt.Errorf("error setting custom fields: %v", err)
+		}
+	}
+
 	return nil
 }
 
@@ -0,0 +1,39 @@
+package scene
+
+import (
+	"fmt"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+// MarkersFromChapters returns a new marker for each chapter of a video file,
+// with the provided primary tag. Chapters without a title are titled by
+// their position. Chapters with an invalid time range are skipped.
+func MarkersFromChapters(sceneID int, primaryTagID int, chapters []*models.VideoChapter) []*models.SceneMarker {
+	var ret []*models.SceneMarker
+	for i, c := range chapters {
+		if c.StartSeconds < 0 {
+			continue
+		}
+
+		title := c.Title
+		if title == "" {
+			title = fmt.Sprintf("Chapter %d", i+1)
+		}
+
+		newMarker := models.NewSceneMarker()
+		newMarker.Title = title
+		newMarker.Seconds = c.StartSeconds
+		newMarker.PrimaryTagID = primaryTagID
+		newMarker.SceneID = sceneID
+
+		if c.EndSeconds > c.StartSeconds {
+			end := c.EndSeconds
+			newMarker.EndSeconds = &end
+		}
+
+		ret = append(ret, &newMarker)
+	}
+
+	return ret
+}
@@ -0,0 +1,40 @@
+package scene
+
+import (
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMarkersFromChapters(t *testing.T) {
+	const (
+		sceneID      = 1
+		primaryTagID = 2
+	)
+
+	chapters := []*models.VideoChapter{
+		{Title: "Intro", StartSeconds: 0, EndSeconds: 10.5},
+		{StartSeconds: 10.5, EndSeconds: 10.5},
+		{Title: "Invalid", StartSeconds: -1, EndSeconds: 5},
+	}
+
+	got := MarkersFromChapters(sceneID, primaryTagID, chapters)
+
+	if !assert.Len(t, got, 2) {
+		return
+	}
+
+	end := 10.5
+	assert.Equal(t, "Intro", got[0].Title)
+	assert.Equal(t, 0.0, got[0].Seconds)
+	assert.Equal(t, &end, got[0].EndSeconds)
+	assert.Equal(t, sceneID, got[0].SceneID)
+	assert.Equal(t, primaryTagID, got[0].PrimaryTagID)
+
+	// untitled chapters are titled by position, and zero-length chapters
+	// have no end time
+	assert.Equal(t, "Chapter 2", got[1].Title)
+	assert.Equal(t, 10.5, got[1].Seconds)
+	assert.Nil(t, got[1].EndSeconds)
+}
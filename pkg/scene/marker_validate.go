@@ -0,0 +1,18 @@
+package scene
+
+import (
+	"errors"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+var ErrInvalidMarkerEnd = errors.New("marker end seconds must be greater than seconds")
+
+// ValidateMarker returns an error if the marker has an invalid time range.
+func ValidateMarker(marker *models.SceneMarker) error {
+	if marker.EndSeconds != nil && *marker.EndSeconds <= marker.Seconds {
+		return ErrInvalidMarkerEnd
+	}
+
+	return nil
+}
@@ -0,0 +1,36 @@
+package scene
+
+import (
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+func TestValidateMarker(t *testing.T) {
+	before := 5.0
+	same := 10.0
+	after := 15.5
+
+	tests := []struct {
+		name       string
+		endSeconds *float64
+		wantErr    bool
+	}{
+		{"no end", nil, false},
+		{"end after start", &after, false},
+		{"end equal to start", &same, true},
+		{"end before start", &before, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			marker := &models.SceneMarker{
+				Seconds:    10,
+				EndSeconds: tt.endSeconds,
+			}
+
+			if err := ValidateMarker(marker); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateMarker() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
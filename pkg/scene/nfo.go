@@ -0,0 +1,138 @@
+package scene
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/models/jsonschema"
+	"github.com/stashapp/stash/pkg/nfo"
+)
+
+// NFOImporter sets the metadata of new scenes from the NFO files alongside
+// their video files.
+type NFOImporter struct {
+	StudioWriter    models.StudioFinderCreator
+	PerformerWriter models.PerformerFinderCreator
+	TagWriter       models.TagFinderCreator
+}
+
+// Apply sets the title, details, date, rating, studio, performers and tags
+// of s from the NFO file describing the video file at videoPath. Studios,
+// performers and tags that do not exist are created. s is left unchanged if
+// there is no NFO file.
+func (i *NFOImporter) Apply(ctx context.Context, s *models.Scene, videoPath string) error {
+	nfoPath, err := nfo.FindSidecar(videoPath)
+	if err != nil {
+		return fmt.Errorf("finding NFO file: %w", err)
+	}
+
+	if nfoPath == "" {
+		return nil
+	}
+
+	m, err := nfo.LoadFile(nfoPath)
+	if err != nil {
+		return fmt.Errorf("reading NFO file %s: %w", nfoPath, err)
+	}
+
+	logger.Infof("Reading scene metadata from %s", nfoPath)
+
+	// reuse the JSON importer to resolve the studio, performers and tags
+	importer := Importer{
+		StudioWriter:        i.StudioWriter,
+		PerformerWriter:     i.PerformerWriter,
+		TagWriter:           i.TagWriter,
+		Input:               nfoToJSON(m),
+		MissingRefBehaviour: models.ImportMissingRefEnumCreate,
+	}
+	importer.scene = importer.sceneJSONToScene(importer.Input)
+
+	if err := importer.populateStudio(ctx); err != nil {
+		return err
+	}
+
+	if err := importer.populatePerformers(ctx); err != nil {
+		return err
+	}
+
+	if err := importer.populateTags(ctx); err != nil {
+		return err
+	}
+
+	imported := importer.scene
+	s.Title = imported.Title
+	s.Details = imported.Details
+	s.Date = imported.Date
+	s.Rating = imported.Rating
+	s.StudioID = imported.StudioID
+	s.PerformerIDs = imported.PerformerIDs
+	s.TagIDs = imported.TagIDs
+
+	return nil
+}
+
+func nfoToJSON(m *nfo.Movie) jsonschema.Scene {
+	ret := jsonschema.Scene{
+		Title:      m.Title,
+		Details:    m.Details(),
+		Date:       m.Date(),
+		Performers: m.ActorNames(),
+		Tags:       m.TagNames(),
+	}
+
+	if len(m.Studios) > 0 {
+		ret.Studio = m.Studios[0]
+	}
+
+	if rating := m.Rating100(); rating != nil {
+		ret.Rating = *rating
+	}
+
+	return ret
+}
+
+// ToNFO converts a scene into its NFO equivalent.
+func ToNFO(ctx context.Context, studioReader models.StudioGetter, performerReader models.PerformerFinder, tagReader TagFinder, scene *models.Scene) (*nfo.Movie, error) {
+	ret := nfo.Movie{
+		Title: scene.GetTitle(),
+		Plot:  scene.Details,
+	}
+
+	if scene.Date != nil {
+		ret.Premiered = scene.Date.String()
+		ret.Year = scene.Date.Year()
+	}
+
+	if scene.Rating != nil {
+		ret.UserRating = float64(*scene.Rating) / 10
+	}
+
+	studioName, err := GetStudioName(ctx, studioReader, scene)
+	if err != nil {
+		return nil, fmt.Errorf("error getting scene studio name: %v", err)
+	}
+
+	if studioName != "" {
+		ret.Studios = []string{studioName}
+	}
+
+	performers, err := performerReader.FindBySceneID(ctx, scene.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting scene performers: %v", err)
+	}
+
+	for _, p := range performers {
+		ret.Actors = append(ret.Actors, nfo.Actor{
+			Name: p.Name,
+		})
+	}
+
+	ret.Tags, err = GetTagNames(ctx, tagReader, scene)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ret, nil
+}
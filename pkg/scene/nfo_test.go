@@ -0,0 +1,132 @@
+package scene
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/models/mocks"
+	"github.com/stashapp/stash/pkg/nfo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const testNFO = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<movie>
+  <title>NFO Title</title>
+  <plot>NFO plot</plot>
+  <premiered>2021-03-04</premiered>
+  <studio>existingStudioName</studio>
+  <actor>
+    <name>missingPerformerName</name>
+  </actor>
+  <genre>existingTagName</genre>
+  <userrating>8</userrating>
+</movie>
+`
+
+func TestNFOImporterApply(t *testing.T) {
+	dir := t.TempDir()
+	videoPath := filepath.Join(dir, "video.mp4")
+	if err := os.WriteFile(nfo.GetSidecarPath(videoPath), []byte(testNFO), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	db := mocks.NewDatabase()
+
+	i := NFOImporter{
+		StudioWriter:    db.Studio,
+		PerformerWriter: db.Performer,
+		TagWriter:       db.Tag,
+	}
+
+	db.Studio.On("FindByName", testCtx, existingStudioName, false).Return(&models.Studio{
+		ID: existingStudioID,
+	}, nil).Once()
+	db.Performer.On("FindByNames", testCtx, []string{missingPerformerName}, false).Return(nil, nil).Once()
+	db.Performer.On("Create", testCtx, mock.AnythingOfType("*models.Performer")).Run(func(args mock.Arguments) {
+		p := args.Get(1).(*models.Performer)
+		p.ID = existingPerformerID
+	}).Return(nil).Once()
+	db.Tag.On("FindByNames", testCtx, []string{existingTagName}, false).Return([]*models.Tag{
+		{
+			ID:   existingTagID,
+			Name: existingTagName,
+		},
+	}, nil).Once()
+
+	s := models.NewScene()
+	err := i.Apply(testCtx, &s, videoPath)
+	assert.Nil(t, err)
+
+	nfoDate, _ := models.ParseDate("2021-03-04")
+	nfoRating := 80
+	assert.Equal(t, "NFO Title", s.Title)
+	assert.Equal(t, "NFO plot", s.Details)
+	assert.Equal(t, &nfoDate, s.Date)
+	assert.Equal(t, &nfoRating, s.Rating)
+	assert.Equal(t, &existingStudioID, s.StudioID)
+	assert.Equal(t, []int{existingPerformerID}, s.PerformerIDs.List())
+	assert.Equal(t, []int{existingTagID}, s.TagIDs.List())
+
+	db.AssertExpectations(t)
+}
+
+func TestNFOImporterApplyWithoutNFO(t *testing.T) {
+	db := mocks.NewDatabase()
+
+	i := NFOImporter{
+		StudioWriter:    db.Studio,
+		PerformerWriter: db.Performer,
+		TagWriter:       db.Tag,
+	}
+
+	s := models.NewScene()
+	err := i.Apply(testCtx, &s, filepath.Join(t.TempDir(), "video.mp4"))
+	assert.Nil(t, err)
+	assert.Equal(t, "", s.Title)
+	assert.False(t, s.PerformerIDs.Loaded())
+
+	db.AssertExpectations(t)
+}
+
+func TestToNFO(t *testing.T) {
+	db := mocks.NewDatabase()
+
+	sceneRating := 75
+	sceneStudioID := studioID
+	s := &models.Scene{
+		ID:       sceneID,
+		Title:    title,
+		Details:  details,
+		Date:     &dateObj,
+		Rating:   &sceneRating,
+		StudioID: &sceneStudioID,
+	}
+
+	db.Studio.On("Find", testCtx, studioID).Return(&models.Studio{
+		Name: studioName,
+	}, nil).Once()
+	db.Performer.On("FindBySceneID", testCtx, sceneID).Return([]*models.Performer{
+		{Name: "performer"},
+	}, nil).Once()
+	db.Tag.On("FindBySceneID", testCtx, sceneID).Return([]*models.Tag{
+		{Name: "tag"},
+	}, nil).Once()
+
+	got, err := ToNFO(testCtx, db.Studio, db.Performer, db.Tag, s)
+	assert.Nil(t, err)
+	assert.Equal(t, &nfo.Movie{
+		Title:      title,
+		Plot:       details,
+		Premiered:  date,
+		Year:       2001,
+		UserRating: 7.5,
+		Studios:    []string{studioName},
+		Actors:     []nfo.Actor{{Name: "performer"}},
+		Tags:       []string{"tag"},
+	}, got)
+
+	db.AssertExpectations(t)
+}
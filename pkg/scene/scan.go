@@ -39,6 +39,9 @@ type ScanHandler struct {
 	CaptionUpdater video.CaptionUpdater
 	PluginCache    *plugin.Cache
 
+	// NFOImporter sets the metadata of new scenes from NFO files, if set.
+	NFOImporter *NFOImporter
+
 	FileNamingAlgorithm models.HashAlgorithm
 	Paths               *paths.Paths
 }
@@ -104,6 +107,14 @@ func (h *ScanHandler) Handle(ctx context.Context, f models.File, oldFile models.
 
 		logger.Infof("%s doesn't exist. Creating new scene...", f.Base().Path)
 
+		// NFO files are not read from within zip files
+		if h.NFOImporter != nil && f.Base().ZipFileID == nil {
+			if err := h.NFOImporter.Apply(ctx, &newScene, f.Base().Path); err != nil {
+				// just log if the NFO file can't be read. The scene is still created
+				logger.Warnf("Error reading NFO file for %s: %v", f.Base().Path, err)
+			}
+		}
+
 		if err := h.CreatorUpdater.Create(ctx, &newScene, []models.FileID{videoFile.ID}); err != nil {
 			return fmt.Errorf("creating new scene: %w", err)
 		}
@@ -27,12 +27,21 @@ type config struct {
 	// Configuration for querying a performer by a URL
 	PerformerByURL []*scrapeByURLConfig `yaml:"performerByURL"`
 
+	// Configuration for querying studios by name
+	StudioByName *scraperTypeConfig `yaml:"studioByName"`
+
+	// Configuration for querying a studio by a URL
+	StudioByURL []*scrapeByURLConfig `yaml:"studioByURL"`
+
 	// Configuration for querying scenes by a Scene fragment
 	SceneByFragment *scraperTypeConfig `yaml:"sceneByFragment"`
 
 	// Configuration for querying gallery by a Gallery fragment
 	GalleryByFragment *scraperTypeConfig `yaml:"galleryByFragment"`
 
+	// Configuration for querying an image by an Image fragment
+	ImageByFragment *scraperTypeConfig `yaml:"imageByFragment"`
+
 	// Configuration for querying scenes by name
 	SceneByName *scraperTypeConfig `yaml:"sceneByName"`
 
@@ -45,6 +54,9 @@ type config struct {
 	// Configuration for querying a gallery by a URL
 	GalleryByURL []*scrapeByURLConfig `yaml:"galleryByURL"`
 
+	// Configuration for querying an image by a URL
+	ImageByURL []*scrapeByURLConfig `yaml:"imageByURL"`
+
 	// Configuration for querying a movie by a URL - deprecated, use GroupByURL
 	MovieByURL []*scrapeByURLConfig `yaml:"movieByURL"`
 	GroupByURL []*scrapeByURLConfig `yaml:"groupByURL"`
@@ -88,6 +100,12 @@ func (c config) validate() error {
 		}
 	}
 
+	if c.StudioByName != nil {
+		if err := c.StudioByName.validate(); err != nil {
+			return err
+		}
+	}
+
 	for _, s := range c.PerformerByURL {
 		if err := s.validate(); err != nil {
 			return err
@@ -100,6 +118,24 @@ func (c config) validate() error {
 		}
 	}
 
+	for _, s := range c.StudioByURL {
+		if err := s.validate(); err != nil {
+			return err
+		}
+	}
+
+	if c.ImageByFragment != nil {
+		if err := c.ImageByFragment.validate(); err != nil {
+			return err
+		}
+	}
+
+	for _, s := range c.ImageByURL {
+		if err := s.validate(); err != nil {
+			return err
+		}
+	}
+
 	if len(c.MovieByURL) > 0 && len(c.GroupByURL) > 0 {
 		return errors.New("movieByURL disallowed if groupByURL is present")
 	}
@@ -294,6 +330,21 @@ func (c config) spec() Scraper {
 		ret.Gallery = &gallery
 	}
 
+	image := ScraperSpec{}
+	if c.ImageByFragment != nil {
+		image.SupportedScrapes = append(image.SupportedScrapes, ScrapeTypeFragment)
+	}
+	if len(c.ImageByURL) > 0 {
+		image.SupportedScrapes = append(image.SupportedScrapes, ScrapeTypeURL)
+		for _, v := range c.ImageByURL {
+			image.Urls = append(image.Urls, v.URL...)
+		}
+	}
+
+	if len(image.SupportedScrapes) > 0 {
+		ret.Image = &image
+	}
+
 	group := ScraperSpec{}
 	if len(c.MovieByURL) > 0 || len(c.GroupByURL) > 0 {
 		group.SupportedScrapes = append(group.SupportedScrapes, ScrapeTypeURL)
@@ -307,6 +358,21 @@ func (c config) spec() Scraper {
 		ret.Group = &group
 	}
 
+	studio := ScraperSpec{}
+	if c.StudioByName != nil {
+		studio.SupportedScrapes = append(studio.SupportedScrapes, ScrapeTypeName)
+	}
+	if len(c.StudioByURL) > 0 {
+		studio.SupportedScrapes = append(studio.SupportedScrapes, ScrapeTypeURL)
+		for _, v := range c.StudioByURL {
+			studio.Urls = append(studio.Urls, v.URL...)
+		}
+	}
+
+	if len(studio.SupportedScrapes) > 0 {
+		ret.Studio = &studio
+	}
+
 	return ret
 }
 
@@ -318,8 +384,12 @@ func (c config) supports(ty ScrapeContentType) bool {
 		return (c.SceneByName != nil && c.SceneByQueryFragment != nil) || c.SceneByFragment != nil || len(c.SceneByURL) > 0
 	case ScrapeContentTypeGallery:
 		return c.GalleryByFragment != nil || len(c.GalleryByURL) > 0
+	case ScrapeContentTypeImage:
+		return c.ImageByFragment != nil || len(c.ImageByURL) > 0
 	case ScrapeContentTypeMovie, ScrapeContentTypeGroup:
 		return len(c.MovieByURL) > 0 || len(c.GroupByURL) > 0
+	case ScrapeContentTypeStudio:
+		return c.StudioByName != nil || len(c.StudioByURL) > 0
 	}
 
 	panic("Unhandled ScrapeContentType")
@@ -345,12 +415,24 @@ func (c config) matchesURL(url string, ty ScrapeContentType) bool {
 				return true
 			}
 		}
+	case ScrapeContentTypeImage:
+		for _, scraper := range c.ImageByURL {
+			if scraper.matchesURL(url) {
+				return true
+			}
+		}
 	case ScrapeContentTypeMovie, ScrapeContentTypeGroup:
 		for _, scraper := range c.MovieByURL {
 			if scraper.matchesURL(url) {
 				return true
 			}
 		}
+	case ScrapeContentTypeStudio:
+		for _, scraper := range c.StudioByURL {
+			if scraper.matchesURL(url) {
+				return true
+			}
+		}
 	}
 
 	return false
@@ -11,6 +11,33 @@ import (
 	"github.com/stashapp/stash/pkg/utils"
 )
 
+type ScrapedImage struct {
+	Title        *string                    `json:"title"`
+	Code         *string                    `json:"code"`
+	Details      *string                    `json:"details"`
+	Photographer *string                    `json:"photographer"`
+	URLs         []string                   `json:"urls"`
+	Date         *string                    `json:"date"`
+	Studio       *models.ScrapedStudio      `json:"studio"`
+	Tags         []*models.ScrapedTag       `json:"tags"`
+	Performers   []*models.ScrapedPerformer `json:"performers"`
+
+	// URL is appended to URLs during post-processing. Mapped scrapers
+	// can only set single-valued fields, so they populate this field.
+	URL *string `json:"url"`
+}
+
+func (ScrapedImage) IsScrapedContent() {}
+
+type ScrapedImageInput struct {
+	Title        *string  `json:"title"`
+	Code         *string  `json:"code"`
+	Details      *string  `json:"details"`
+	Photographer *string  `json:"photographer"`
+	URLs         []string `json:"urls"`
+	Date         *string  `json:"date"`
+}
+
 func setPerformerImage(ctx context.Context, client *http.Client, p *models.ScrapedPerformer, globalConfig GlobalConfig) error {
 	// backwards compatibility: we fetch the image if it's a URL and set it to the first image
 	// Image is deprecated, so only do this if Images is unset
@@ -37,6 +64,30 @@ func setPerformerImage(ctx context.Context, client *http.Client, p *models.Scrap
 	return nil
 }
 
+func setStudioImage(ctx context.Context, client *http.Client, s *models.ScrapedStudio, globalConfig GlobalConfig) error {
+	// fetch the image if it's a URL and set it to the first image
+	if s.Image == nil || len(s.Images) > 0 {
+		// nothing to do
+		return nil
+	}
+
+	// don't try to get the image if it doesn't appear to be a URL
+	if !strings.HasPrefix(*s.Image, "http") {
+		s.Images = []string{*s.Image}
+		return nil
+	}
+
+	img, err := getImage(ctx, *s.Image, client, globalConfig)
+	if err != nil {
+		return err
+	}
+
+	s.Image = img
+	s.Images = []string{*img}
+
+	return nil
+}
+
 func setSceneImage(ctx context.Context, client *http.Client, s *ScrapedScene, globalConfig GlobalConfig) error {
 	// don't try to get the image if it doesn't appear to be a URL
 	if s.Image == nil || !strings.HasPrefix(*s.Image, "http") {
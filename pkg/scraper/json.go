@@ -102,12 +102,24 @@ func (s *jsonScraper) scrapeByURL(ctx context.Context, url string, ty ScrapeCont
 			return nil, err
 		}
 		return ret, nil
+	case ScrapeContentTypeImage:
+		ret, err := scraper.scrapeImage(ctx, q)
+		if err != nil || ret == nil {
+			return nil, err
+		}
+		return ret, nil
 	case ScrapeContentTypeMovie, ScrapeContentTypeGroup:
 		ret, err := scraper.scrapeGroup(ctx, q)
 		if err != nil || ret == nil {
 			return nil, err
 		}
 		return ret, nil
+	case ScrapeContentTypeStudio:
+		ret, err := scraper.scrapeStudio(ctx, q)
+		if err != nil || ret == nil {
+			return nil, err
+		}
+		return ret, nil
 	}
 
 	return nil, ErrNotSupported
@@ -160,6 +172,17 @@ func (s *jsonScraper) scrapeByName(ctx context.Context, name string, ty ScrapeCo
 			content = append(content, s)
 		}
 
+		return content, nil
+	case ScrapeContentTypeStudio:
+		studios, err := scraper.scrapeStudios(ctx, q)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, s := range studios {
+			content = append(content, s)
+		}
+
 		return content, nil
 	}
 
@@ -194,6 +217,8 @@ func (s *jsonScraper) scrapeByFragment(ctx context.Context, input Input) (Scrape
 	switch {
 	case input.Gallery != nil:
 		return nil, fmt.Errorf("%w: cannot use a json scraper as a gallery fragment scraper", ErrNotSupported)
+	case input.Image != nil:
+		return nil, fmt.Errorf("%w: cannot use a json scraper as an image fragment scraper", ErrNotSupported)
 	case input.Performer != nil:
 		return nil, fmt.Errorf("%w: cannot use a json scraper as a performer fragment scraper", ErrNotSupported)
 	case input.Scene == nil:
@@ -249,6 +274,30 @@ func (s *jsonScraper) scrapeGalleryByGallery(ctx context.Context, gallery *model
 	return scraper.scrapeGallery(ctx, q)
 }
 
+func (s *jsonScraper) scrapeImageByImage(ctx context.Context, image *models.Image) (*ScrapedImage, error) {
+	// construct the URL
+	queryURL := queryURLParametersFromImage(image)
+	if s.scraper.QueryURLReplacements != nil {
+		queryURL.applyReplacements(s.scraper.QueryURLReplacements)
+	}
+	url := queryURL.constructURL(s.scraper.QueryURL)
+
+	scraper := s.getJsonScraper()
+
+	if scraper == nil {
+		return nil, errors.New("json scraper with name " + s.scraper.Scraper + " not found in config")
+	}
+
+	doc, err := s.loadURL(ctx, url)
+
+	if err != nil {
+		return nil, err
+	}
+
+	q := s.getJsonQuery(doc)
+	return scraper.scrapeImage(ctx, q)
+}
+
 func (s *jsonScraper) getJsonQuery(doc string) *jsonQuery {
 	return &jsonQuery{
 		doc:     doc,
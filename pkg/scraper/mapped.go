@@ -228,6 +228,60 @@ func (s *mappedGalleryScraperConfig) UnmarshalYAML(unmarshal func(interface{}) e
 	return nil
 }
 
+type mappedImageScraperConfig struct {
+	mappedConfig
+
+	Tags       mappedConfig `yaml:"Tags"`
+	Performers mappedConfig `yaml:"Performers"`
+	Studio     mappedConfig `yaml:"Studio"`
+}
+type _mappedImageScraperConfig mappedImageScraperConfig
+
+func (s *mappedImageScraperConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	// HACK - unmarshal to map first, then remove known scene sub-fields, then
+	// remarshal to yaml and pass that down to the base map
+	parentMap := make(map[string]interface{})
+	if err := unmarshal(parentMap); err != nil {
+		return err
+	}
+
+	// move the known sub-fields to a separate map
+	thisMap := make(map[string]interface{})
+
+	thisMap[mappedScraperConfigSceneTags] = parentMap[mappedScraperConfigSceneTags]
+	thisMap[mappedScraperConfigScenePerformers] = parentMap[mappedScraperConfigScenePerformers]
+	thisMap[mappedScraperConfigSceneStudio] = parentMap[mappedScraperConfigSceneStudio]
+
+	delete(parentMap, mappedScraperConfigSceneTags)
+	delete(parentMap, mappedScraperConfigScenePerformers)
+	delete(parentMap, mappedScraperConfigSceneStudio)
+
+	// re-unmarshal the sub-fields
+	yml, err := yaml.Marshal(thisMap)
+	if err != nil {
+		return err
+	}
+
+	// needs to be a different type to prevent infinite recursion
+	c := _mappedImageScraperConfig{}
+	if err := yaml.Unmarshal(yml, &c); err != nil {
+		return err
+	}
+
+	*s = mappedImageScraperConfig(c)
+
+	yml, err = yaml.Marshal(parentMap)
+	if err != nil {
+		return err
+	}
+
+	if err := yaml.Unmarshal(yml, &s.mappedConfig); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 type mappedPerformerScraperConfig struct {
 	mappedConfig
 
@@ -280,6 +334,62 @@ func (s *mappedPerformerScraperConfig) UnmarshalYAML(unmarshal func(interface{})
 	return nil
 }
 
+type mappedStudioScraperConfig struct {
+	mappedConfig
+
+	Tags   mappedConfig `yaml:"Tags"`
+	Parent mappedConfig `yaml:"Parent"`
+}
+type _mappedStudioScraperConfig mappedStudioScraperConfig
+
+const (
+	mappedScraperConfigStudioTags   = "Tags"
+	mappedScraperConfigStudioParent = "Parent"
+)
+
+func (s *mappedStudioScraperConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	// HACK - unmarshal to map first, then remove known studio sub-fields, then
+	// remarshal to yaml and pass that down to the base map
+	parentMap := make(map[string]interface{})
+	if err := unmarshal(parentMap); err != nil {
+		return err
+	}
+
+	// move the known sub-fields to a separate map
+	thisMap := make(map[string]interface{})
+
+	thisMap[mappedScraperConfigStudioTags] = parentMap[mappedScraperConfigStudioTags]
+	thisMap[mappedScraperConfigStudioParent] = parentMap[mappedScraperConfigStudioParent]
+
+	delete(parentMap, mappedScraperConfigStudioTags)
+	delete(parentMap, mappedScraperConfigStudioParent)
+
+	// re-unmarshal the sub-fields
+	yml, err := yaml.Marshal(thisMap)
+	if err != nil {
+		return err
+	}
+
+	// needs to be a different type to prevent infinite recursion
+	c := _mappedStudioScraperConfig{}
+	if err := yaml.Unmarshal(yml, &c); err != nil {
+		return err
+	}
+
+	*s = mappedStudioScraperConfig(c)
+
+	yml, err = yaml.Marshal(parentMap)
+	if err != nil {
+		return err
+	}
+
+	if err := yaml.Unmarshal(yml, &s.mappedConfig); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 type mappedMovieScraperConfig struct {
 	mappedConfig
 
@@ -785,7 +895,9 @@ type mappedScraper struct {
 	Common    commonMappedConfig            `yaml:"common"`
 	Scene     *mappedSceneScraperConfig     `yaml:"scene"`
 	Gallery   *mappedGalleryScraperConfig   `yaml:"gallery"`
+	Image     *mappedImageScraperConfig     `yaml:"image"`
 	Performer *mappedPerformerScraperConfig `yaml:"performer"`
+	Studio    *mappedStudioScraperConfig    `yaml:"studio"`
 	Movie     *mappedMovieScraperConfig     `yaml:"movie"`
 }
 
@@ -882,6 +994,65 @@ func (s mappedScraper) scrapePerformers(ctx context.Context, q mappedQuery) ([]*
 	return ret, nil
 }
 
+func (s mappedScraper) scrapeStudio(ctx context.Context, q mappedQuery) (*models.ScrapedStudio, error) {
+	var ret models.ScrapedStudio
+
+	studioMap := s.Studio
+	if studioMap == nil {
+		return nil, nil
+	}
+
+	studioTagsMap := studioMap.Tags
+	studioParentMap := studioMap.Parent
+
+	results := studioMap.process(ctx, q, s.Common)
+
+	// now apply the tags and parent
+	if studioTagsMap != nil {
+		logger.Debug(`Processing studio tags:`)
+		ret.Tags = processRelationships[models.ScrapedTag](ctx, s, studioTagsMap, q)
+	}
+
+	if studioParentMap != nil {
+		logger.Debug(`Processing studio parent:`)
+		parentResults := studioParentMap.process(ctx, q, s.Common)
+
+		if len(parentResults) > 0 {
+			parent := &models.ScrapedStudio{}
+			parentResults[0].apply(parent)
+			ret.Parent = parent
+		}
+	}
+
+	if len(results) == 0 && len(ret.Tags) == 0 && ret.Parent == nil {
+		return nil, nil
+	}
+
+	if len(results) > 0 {
+		results[0].apply(&ret)
+	}
+
+	return &ret, nil
+}
+
+func (s mappedScraper) scrapeStudios(ctx context.Context, q mappedQuery) ([]*models.ScrapedStudio, error) {
+	var ret []*models.ScrapedStudio
+
+	studioMap := s.Studio
+	if studioMap == nil {
+		return nil, nil
+	}
+
+	results := studioMap.process(ctx, q, s.Common)
+	for _, r := range results {
+		var st models.ScrapedStudio
+		r.apply(&st)
+		ret = append(ret, &st)
+	}
+
+	return ret, nil
+}
+
 // processSceneRelationships sets the relationships on the ScrapedScene. It returns true if any relationships were set.
 func (s mappedScraper) processSceneRelationships(ctx context.Context, q mappedQuery, resultIndex int, ret *ScrapedScene) bool {
 	sceneScraperConfig := s.Scene
@@ -1079,6 +1250,69 @@ func (s mappedScraper) scrapeGallery(ctx context.Context, q mappedQuery) (*Scrap
 	return &ret, nil
 }
 
+func (s mappedScraper) scrapeImage(ctx context.Context, q mappedQuery) (*ScrapedImage, error) {
+	var ret ScrapedImage
+
+	imageScraperConfig := s.Image
+	if imageScraperConfig == nil {
+		return nil, nil
+	}
+
+	imageMap := imageScraperConfig.mappedConfig
+
+	imagePerformersMap := imageScraperConfig.Performers
+	imageTagsMap := imageScraperConfig.Tags
+	imageStudioMap := imageScraperConfig.Studio
+
+	logger.Debug(`Processing image:`)
+	results := imageMap.process(ctx, q, s.Common)
+
+	// now apply the performers and tags
+	if imagePerformersMap != nil {
+		logger.Debug(`Processing image performers:`)
+		performerResults := imagePerformersMap.process(ctx, q, s.Common)
+
+		for _, p := range performerResults {
+			performer := &models.ScrapedPerformer{}
+			p.apply(performer)
+			ret.Performers = append(ret.Performers, performer)
+		}
+	}
+
+	if imageTagsMap != nil {
+		logger.Debug(`Processing image tags:`)
+		tagResults := imageTagsMap.process(ctx, q, s.Common)
+
+		for _, p := range tagResults {
+			tag := &models.ScrapedTag{}
+			p.apply(tag)
+			ret.Tags = append(ret.Tags, tag)
+		}
+	}
+
+	if imageStudioMap != nil {
+		logger.Debug(`Processing image studio:`)
+		studioResults := imageStudioMap.process(ctx, q, s.Common)
+
+		if len(studioResults) > 0 {
+			studio := &models.ScrapedStudio{}
+			studioResults[0].apply(studio)
+			ret.Studio = studio
+		}
+	}
+
+	// if no basic fields are populated, and no relationships, then return nil
+	if len(results) == 0 && len(ret.Performers) == 0 && len(ret.Tags) == 0 && ret.Studio == nil {
+		return nil, nil
+	}
+
+	if len(results) > 0 {
+		results[0].apply(&ret)
+	}
+
+	return &ret, nil
+}
+
 func (s mappedScraper) scrapeGroup(ctx context.Context, q mappedQuery) (*models.ScrapedMovie, error) {
 	var ret models.ScrapedMovie
 
@@ -6,6 +6,7 @@ import (
 	"github.com/stashapp/stash/pkg/logger"
 	"github.com/stashapp/stash/pkg/match"
 	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/sliceutil"
 	"github.com/stashapp/stash/pkg/utils"
 )
 
@@ -33,6 +34,12 @@ func (c Cache) postScrape(ctx context.Context, content ScrapedContent) (ScrapedC
 		}
 	case ScrapedGallery:
 		return c.postScrapeGallery(ctx, v)
+	case *ScrapedImage:
+		if v != nil {
+			return c.postScrapeImage(ctx, *v)
+		}
+	case ScrapedImage:
+		return c.postScrapeImage(ctx, v)
 	case *models.ScrapedMovie:
 		if v != nil {
 			return c.postScrapeMovie(ctx, *v)
@@ -45,6 +52,12 @@ func (c Cache) postScrape(ctx context.Context, content ScrapedContent) (ScrapedC
 		}
 	case models.ScrapedGroup:
 		return c.postScrapeGroup(ctx, v)
+	case *models.ScrapedStudio:
+		if v != nil {
+			return c.postScrapeStudio(ctx, *v)
+		}
+	case models.ScrapedStudio:
+		return c.postScrapeStudio(ctx, v)
 	}
 
 	// If nothing matches, pass the content through
@@ -102,6 +115,41 @@ func (c Cache) postScrapePerformer(ctx context.Context, p models.ScrapedPerforme
 	return p, nil
 }
 
+func (c Cache) postScrapeStudio(ctx context.Context, s models.ScrapedStudio) (ScrapedContent, error) {
+	r := c.repository
+	if err := r.WithReadTxn(ctx, func(ctx context.Context) error {
+		tqb := r.TagFinder
+		sqb := r.StudioFinder
+
+		tags, err := postProcessTags(ctx, tqb, s.Tags)
+		if err != nil {
+			return err
+		}
+		s.Tags = tags
+
+		if err := match.ScrapedStudio(ctx, sqb, &s, nil); err != nil {
+			return err
+		}
+
+		if s.Parent != nil {
+			if err := match.ScrapedStudio(ctx, sqb, s.Parent, nil); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	// post-process - set the image if applicable
+	if err := setStudioImage(ctx, c.client, &s, c.globalConfig); err != nil {
+		logger.Warnf("Could not set image using URL %s: %s", *s.Image, err.Error())
+	}
+
+	return s, nil
+}
+
 func (c Cache) postScrapeMovie(ctx context.Context, m models.ScrapedMovie) (ScrapedContent, error) {
 	r := c.repository
 	if err := r.WithReadTxn(ctx, func(ctx context.Context) error {
@@ -315,6 +363,49 @@ func (c Cache) postScrapeGallery(ctx context.Context, g ScrapedGallery) (Scraped
 	return g, nil
 }
 
+func (c Cache) postScrapeImage(ctx context.Context, img ScrapedImage) (ScrapedContent, error) {
+	// add the URL field to URLs
+	if img.URL != nil {
+		if !sliceutil.Contains(img.URLs, *img.URL) {
+			img.URLs = append([]string{*img.URL}, img.URLs...)
+		}
+		img.URL = nil
+	}
+
+	r := c.repository
+	if err := r.WithReadTxn(ctx, func(ctx context.Context) error {
+		pqb := r.PerformerFinder
+		tqb := r.TagFinder
+		sqb := r.StudioFinder
+
+		for _, p := range img.Performers {
+			err := match.ScrapedPerformer(ctx, pqb, p, nil)
+			if err != nil {
+				return err
+			}
+		}
+
+		tags, err := postProcessTags(ctx, tqb, img.Tags)
+		if err != nil {
+			return err
+		}
+		img.Tags = tags
+
+		if img.Studio != nil {
+			err := match.ScrapedStudio(ctx, sqb, img.Studio, nil)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return img, nil
+}
+
 func postProcessTags(ctx context.Context, tqb models.TagQueryer, scrapedTags []*models.ScrapedTag) ([]*models.ScrapedTag, error) {
 	var ret []*models.ScrapedTag
 
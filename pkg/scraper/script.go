@@ -205,6 +205,50 @@ func galleryInputFromGallery(gallery *models.Gallery) galleryInput {
 	return ret
 }
 
+type imageInput struct {
+	ID      string   `json:"id"`
+	Title   string   `json:"title"`
+	Urls    []string `json:"urls"`
+	Date    *string  `json:"date"`
+	Details string   `json:"details"`
+
+	Code         string `json:"code,omitempty"`
+	Photographer string `json:"photographer,omitempty"`
+
+	Files []fileInput `json:"files,omitempty"`
+}
+
+func imageInputFromImage(image *models.Image) imageInput {
+	dateToStringPtr := func(s *models.Date) *string {
+		if s != nil {
+			v := s.String()
+			return &v
+		}
+
+		return nil
+	}
+
+	// fallback to file basename if title is empty
+	title := image.GetTitle()
+
+	ret := imageInput{
+		ID:           strconv.Itoa(image.ID),
+		Title:        title,
+		Details:      image.Details,
+		Urls:         image.URLs.List(),
+		Date:         dateToStringPtr(image.Date),
+		Code:         image.Code,
+		Photographer: image.Photographer,
+	}
+
+	for _, f := range image.Files.List() {
+		fi := fileInputFromFile(*f.Base())
+		ret.Files = append(ret.Files, fi)
+	}
+
+	return ret
+}
+
 var ErrScraperScript = errors.New("scraper script error")
 
 type scriptScraper struct {
@@ -336,6 +380,15 @@ func (s *scriptScraper) scrapeByName(ctx context.Context, name string, ty Scrape
 				ret = append(ret, &v)
 			}
 		}
+	case ScrapeContentTypeStudio:
+		var studios []models.ScrapedStudio
+		err = s.runScraperScript(ctx, input, &studios)
+		if err == nil {
+			for _, s := range studios {
+				v := s
+				ret = append(ret, &v)
+			}
+		}
 	default:
 		return nil, ErrNotSupported
 	}
@@ -354,6 +407,9 @@ func (s *scriptScraper) scrapeByFragment(ctx context.Context, input Input) (Scra
 	case input.Gallery != nil:
 		inString, err = json.Marshal(*input.Gallery)
 		ty = ScrapeContentTypeGallery
+	case input.Image != nil:
+		inString, err = json.Marshal(*input.Image)
+		ty = ScrapeContentTypeImage
 	case input.Scene != nil:
 		inString, err = json.Marshal(*input.Scene)
 		ty = ScrapeContentTypeScene
@@ -380,6 +436,10 @@ func (s *scriptScraper) scrape(ctx context.Context, input string, ty ScrapeConte
 		var gallery *ScrapedGallery
 		err := s.runScraperScript(ctx, input, &gallery)
 		return gallery, err
+	case ScrapeContentTypeImage:
+		var image *ScrapedImage
+		err := s.runScraperScript(ctx, input, &image)
+		return image, err
 	case ScrapeContentTypeScene:
 		var scene *ScrapedScene
 		err := s.runScraperScript(ctx, input, &scene)
@@ -388,6 +448,10 @@ func (s *scriptScraper) scrape(ctx context.Context, input string, ty ScrapeConte
 		var movie *models.ScrapedMovie
 		err := s.runScraperScript(ctx, input, &movie)
 		return movie, err
+	case ScrapeContentTypeStudio:
+		var studio *models.ScrapedStudio
+		err := s.runScraperScript(ctx, input, &studio)
+		return studio, err
 	}
 
 	return nil, ErrNotSupported
@@ -421,6 +485,20 @@ func (s *scriptScraper) scrapeGalleryByGallery(ctx context.Context, gallery *mod
 	return ret, err
 }
 
+func (s *scriptScraper) scrapeImageByImage(ctx context.Context, image *models.Image) (*ScrapedImage, error) {
+	inString, err := json.Marshal(imageInputFromImage(image))
+
+	if err != nil {
+		return nil, err
+	}
+
+	var ret *ScrapedImage
+
+	err = s.runScraperScript(ctx, string(inString), &ret)
+
+	return ret, err
+}
+
 func handleScraperStderr(name string, scraperOutputReader io.ReadCloser) {
 	const scraperPrefix = "[Scrape / %s] "
 
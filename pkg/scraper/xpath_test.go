@@ -788,6 +788,160 @@ xPathScrapers:
 	assert.Equal(t, "January 2, 2006", string(*parseDate))
 }
 
+func TestApplyImageXPathConfig(t *testing.T) {
+	const yamlStr = `name: Test
+imageByURL:
+  - action: scrapeXPath
+    url:
+      - test.com
+    scraper: imageScraper
+xPathScrapers:
+  imageScraper:
+    image:
+      Title: //h1
+      Photographer: //span[@class="photographer"]
+      Tags:
+        Name: //a[@class="tag"]
+      Performers:
+        Name: //a[@class="performer"]
+      Studio:
+        Name: //a[@class="studio"]
+`
+
+	const html = `<html><body>
+<h1>Test Image</h1>
+<span class="photographer">Photographer</span>
+<a class="tag">Tag 1</a><a class="tag">Tag 2</a>
+<a class="performer">Performer</a>
+<a class="studio">Studio</a>
+</body></html>`
+
+	c := &config{}
+	if err := yaml.Unmarshal([]byte(yamlStr), &c); err != nil {
+		t.Errorf("Error loading yaml: %s", err.Error())
+		return
+	}
+
+	assert.True(t, c.supports(ScrapeContentTypeImage))
+	assert.True(t, c.matchesURL("https://test.com/image/1", ScrapeContentTypeImage))
+	assert.False(t, c.matchesURL("https://test.com/image/1", ScrapeContentTypeGallery))
+
+	spec := c.spec()
+	if assert.NotNil(t, spec.Image) {
+		assert.Equal(t, []ScrapeType{ScrapeTypeURL}, spec.Image.SupportedScrapes)
+		assert.Equal(t, []string{"test.com"}, spec.Image.Urls)
+	}
+
+	doc, err := htmlquery.Parse(strings.NewReader(html))
+	if err != nil {
+		t.Errorf("Error loading document: %s", err.Error())
+		return
+	}
+
+	q := &xpathQuery{
+		doc: doc,
+	}
+	image, err := c.XPathScrapers["imageScraper"].scrapeImage(context.Background(), q)
+	if err != nil {
+		t.Errorf("Error scraping image: %s", err.Error())
+		return
+	}
+
+	verifyField(t, "Test Image", image.Title, "Title")
+	verifyField(t, "Photographer", image.Photographer, "Photographer")
+	verifyTags(t, []string{"Tag 1", "Tag 2"}, image.Tags)
+	verifyPerformers(t, []string{"Performer"}, nil, image.Performers)
+	verifyField(t, "Studio", &image.Studio.Name, "Studio.Name")
+}
+
+func TestApplyStudioXPathConfig(t *testing.T) {
+	const yamlStr = `name: Test
+studioByName:
+  action: scrapeXPath
+  queryURL: https://test.com/search?q={}
+  scraper: studioSearch
+studioByURL:
+  - action: scrapeXPath
+    url:
+      - test.com/studio
+    scraper: studioScraper
+xPathScrapers:
+  studioSearch:
+    studio:
+      Name: //a[@class="result"]
+  studioScraper:
+    studio:
+      Name: //h1
+      Aliases: //span[@class="aliases"]
+      Details: //p[@class="details"]
+      Image: //img/@src
+      Tags:
+        Name: //a[@class="tag"]
+      Parent:
+        Name: //a[@class="parent"]
+`
+
+	const html = `<html><body>
+<h1>Test Studio</h1>
+<span class="aliases">Alias 1, Alias 2</span>
+<p class="details">Studio details</p>
+<img src="https://test.com/logo.png"/>
+<a class="tag">Tag 1</a><a class="tag">Tag 2</a>
+<a class="parent">Parent Studio</a>
+<a class="result">Result 1</a><a class="result">Result 2</a>
+</body></html>`
+
+	c := &config{}
+	if err := yaml.Unmarshal([]byte(yamlStr), &c); err != nil {
+		t.Errorf("Error loading yaml: %s", err.Error())
+		return
+	}
+
+	assert.True(t, c.supports(ScrapeContentTypeStudio))
+	assert.True(t, c.matchesURL("https://test.com/studio/1", ScrapeContentTypeStudio))
+
+	spec := c.spec()
+	if assert.NotNil(t, spec.Studio) {
+		assert.Equal(t, []ScrapeType{ScrapeTypeName, ScrapeTypeURL}, spec.Studio.SupportedScrapes)
+		assert.Equal(t, []string{"test.com/studio"}, spec.Studio.Urls)
+	}
+
+	doc, err := htmlquery.Parse(strings.NewReader(html))
+	if err != nil {
+		t.Errorf("Error loading document: %s", err.Error())
+		return
+	}
+
+	q := &xpathQuery{
+		doc: doc,
+	}
+	studio, err := c.XPathScrapers["studioScraper"].scrapeStudio(context.Background(), q)
+	if err != nil {
+		t.Errorf("Error scraping studio: %s", err.Error())
+		return
+	}
+
+	assert.Equal(t, "Test Studio", studio.Name)
+	verifyField(t, "Alias 1, Alias 2", studio.Aliases, "Aliases")
+	verifyField(t, "Studio details", studio.Details, "Details")
+	verifyField(t, "https://test.com/logo.png", studio.Image, "Image")
+	verifyTags(t, []string{"Tag 1", "Tag 2"}, studio.Tags)
+	if assert.NotNil(t, studio.Parent) {
+		assert.Equal(t, "Parent Studio", studio.Parent.Name)
+	}
+
+	studios, err := c.XPathScrapers["studioSearch"].scrapeStudios(context.Background(), q)
+	if err != nil {
+		t.Errorf("Error scraping studios: %s", err.Error())
+		return
+	}
+
+	if assert.Len(t, studios, 2) {
+		assert.Equal(t, "Result 1", studios[0].Name)
+		assert.Equal(t, "Result 2", studios[1].Name)
+	}
+}
+
 func TestLoadInvalidXPath(t *testing.T) {
 	config := make(mappedConfig)
 
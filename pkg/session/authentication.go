@@ -17,26 +17,13 @@ func (e ExternalAccessError) Error() string {
 
 func CheckAllowPublicWithoutAuth(c ExternalAccessConfig, r *http.Request) error {
 	if !c.HasCredentials() && !c.GetDangerousAllowPublicWithoutAuth() && !c.IsNewSystem() {
-		requestIPString, _, err := net.SplitHostPort(r.RemoteAddr)
+		requestIP, err := remoteIP(r)
 		if err != nil {
-			return fmt.Errorf("error parsing remote host (%s): %w", r.RemoteAddr, err)
+			return err
 		}
 
-		// presence of scope ID in IPv6 addresses prevents parsing. Remove if present
-		scopeIDIndex := strings.Index(requestIPString, "%")
-		if scopeIDIndex != -1 {
-			requestIPString = requestIPString[0:scopeIDIndex]
-		}
-
-		requestIP := net.ParseIP(requestIPString)
-		if requestIP == nil {
-			return fmt.Errorf("unable to parse remote host (%s)", requestIPString)
-		}
-
-		if r.Header.Get("X-FORWARDED-FOR") != "" {
+		if proxyChain := forwardedFor(r); len(proxyChain) > 0 {
 			// Request was proxied
-			proxyChain := strings.Split(r.Header.Get("X-FORWARDED-FOR"), ", ")
-
 			// validate proxies against local network only
 			if !isLocalIP(requestIP) {
 				return ExternalAccessError(requestIP)
@@ -59,6 +46,79 @@ func CheckAllowPublicWithoutAuth(c ExternalAccessConfig, r *http.Request) error
 	return nil
 }
 
+// ClientAddress returns the address of the client that made the request.
+// If the request was proxied from the local network, X-Forwarded-For is
+// walked from the right, skipping proxies on the local network, and the
+// first address outside the local network is returned. Entries to the left
+// of that address are set by the client and are not trusted. Otherwise, the
+// remote address of the request is returned.
+func ClientAddress(r *http.Request) string {
+	requestIP, err := remoteIP(r)
+	if err != nil {
+		return r.RemoteAddr
+	}
+
+	// only trust X-Forwarded-For from proxies on the local network
+	if !isLocalIP(requestIP) {
+		return requestIP.String()
+	}
+
+	ret := requestIP
+	proxyChain := forwardedFor(r)
+	for i := len(proxyChain) - 1; i >= 0; i-- {
+		ip := net.ParseIP(proxyChain[i])
+		if ip == nil {
+			// cannot trust anything to the left of an invalid entry
+			break
+		}
+
+		ret = ip
+		if !isLocalIP(ip) {
+			break
+		}
+	}
+
+	return ret.String()
+}
+
+// remoteIP returns the IP address of the remote end of the request.
+func remoteIP(r *http.Request) (net.IP, error) {
+	requestIPString, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing remote host (%s): %w", r.RemoteAddr, err)
+	}
+
+	// presence of scope ID in IPv6 addresses prevents parsing. Remove if present
+	scopeIDIndex := strings.Index(requestIPString, "%")
+	if scopeIDIndex != -1 {
+		requestIPString = requestIPString[0:scopeIDIndex]
+	}
+
+	requestIP := net.ParseIP(requestIPString)
+	if requestIP == nil {
+		return nil, fmt.Errorf("unable to parse remote host (%s)", requestIPString)
+	}
+
+	return requestIP, nil
+}
+
+// forwardedFor returns the proxy chain from the X-Forwarded-For headers, in
+// the order that the entries were added. Each proxy appends the address it
+// received the request from, so only the rightmost entries added by trusted
+// proxies can be relied upon. Entries from multiple header lines are joined.
+func forwardedFor(r *http.Request) []string {
+	var ret []string
+	for _, header := range r.Header.Values("X-Forwarded-For") {
+		for _, entry := range strings.Split(header, ",") {
+			if entry = strings.TrimSpace(entry); entry != "" {
+				ret = append(ret, entry)
+			}
+		}
+	}
+
+	return ret
+}
+
 func CheckExternalAccessTripwire(c ExternalAccessConfig) *ExternalAccessError {
 	if !c.HasCredentials() && !c.GetDangerousAllowPublicWithoutAuth() {
 		if remoteIP := c.GetSecurityTripwireAccessedFromPublicInternet(); remoteIP != "" {
@@ -186,3 +186,42 @@ func TestCheckExternalAccessTripwire(t *testing.T) {
 		t.Errorf("unexpected error %v", err)
 	}
 }
+
+func TestClientAddress(t *testing.T) {
+	tests := []struct {
+		name         string
+		remoteAddr   string
+		forwardedFor []string
+		expectedAddr string
+	}{
+		{"direct", "4.4.4.4:8080", nil, "4.4.4.4"},
+		{"ipv6 scope id", "[fe80::1%eth0]:8080", nil, "fe80::1"},
+		{"local proxy", "192.168.1.1:8080", []string{"4.4.4.4, 192.168.1.2"}, "4.4.4.4"},
+		{"external proxy", "8.8.8.8:8080", []string{"4.4.4.4"}, "8.8.8.8"},
+		{"local proxy chain", "192.168.1.1:8080", []string{"192.168.1.3, 192.168.1.2"}, "192.168.1.3"},
+		{"spoofed forwarded address", "192.168.1.1:8080", []string{"1.2.3.4, 4.4.4.4, 192.168.1.2"}, "4.4.4.4"},
+		{"spoofed local forwarded address", "192.168.1.1:8080", []string{"127.0.0.1, 4.4.4.4"}, "4.4.4.4"},
+		{"invalid forwarded address", "127.0.0.1:8080", []string{"invalid"}, "127.0.0.1"},
+		{"invalid spoofed address", "192.168.1.1:8080", []string{"invalid, 4.4.4.4"}, "4.4.4.4"},
+		{"invalid remote address", "invalid", nil, "invalid"},
+		{"multiple headers", "192.168.1.1:8080", []string{"1.2.3.4", "4.4.4.4, 192.168.1.2"}, "4.4.4.4"},
+		{"spoofed multiple headers", "192.168.1.1:8080", []string{"1.2.3.4", "4.4.4.4"}, "4.4.4.4"},
+		{"entries without spaces", "192.168.1.1:8080", []string{"1.2.3.4,4.4.4.4,192.168.1.2"}, "4.4.4.4"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &http.Request{
+				RemoteAddr: tt.remoteAddr,
+				Header:     make(http.Header),
+			}
+			for _, v := range tt.forwardedFor {
+				r.Header.Add("X-Forwarded-For", v)
+			}
+
+			if got := ClientAddress(r); got != tt.expectedAddr {
+				t.Errorf("ClientAddress() = %v, want %v", got, tt.expectedAddr)
+			}
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package session
+
+import (
+	"sync"
+	"time"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+// authCacheTTL is the time that an authenticated user is cached for.
+// Changes to users and API keys should call ClearCache, so this only
+// bounds the time that stale entries may be used if they do not.
+const authCacheTTL = 30 * time.Second
+
+type authCacheEntry struct {
+	user    *models.User
+	apiKey  *models.APIKey
+	expires time.Time
+}
+
+// authCache caches the results of successful authentications, so that the
+// database is not read on every request. Failed authentications are not
+// cached.
+type authCache struct {
+	mutex   sync.Mutex
+	entries map[string]authCacheEntry
+}
+
+func newAuthCache() *authCache {
+	return &authCache{
+		entries: make(map[string]authCacheEntry),
+	}
+}
+
+func (c *authCache) get(key string, now time.Time) (*models.User, *models.APIKey, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	e, found := c.entries[key]
+	if !found {
+		return nil, nil, false
+	}
+
+	if now.After(e.expires) || (e.apiKey != nil && !e.apiKey.IsActive(now)) {
+		delete(c.entries, key)
+		return nil, nil, false
+	}
+
+	return e.user, e.apiKey, true
+}
+
+func (c *authCache) set(key string, user *models.User, apiKey *models.APIKey, now time.Time) {
+	if user == nil {
+		return
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	// remove expired entries so that the cache does not grow unbounded
+	for k, e := range c.entries {
+		if now.After(e.expires) {
+			delete(c.entries, k)
+		}
+	}
+
+	c.entries[key] = authCacheEntry{
+		user:    user,
+		apiKey:  apiKey,
+		expires: now.Add(authCacheTTL),
+	}
+}
+
+func (c *authCache) clear() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.entries = make(map[string]authCacheEntry)
+}
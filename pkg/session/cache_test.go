@@ -0,0 +1,50 @@
+package session
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+func TestAuthCache(t *testing.T) {
+	now := time.Now()
+	expires := now.Add(time.Hour)
+
+	user := &models.User{ID: 1}
+	apiKey := &models.APIKey{ID: 2, UserID: 1, ExpiresAt: &expires}
+
+	c := newAuthCache()
+	c.set("user:1", user, nil, now)
+	c.set("apikey:key", user, apiKey, now)
+	c.set("user:2", nil, nil, now)
+
+	if u, _, found := c.get("user:1", now); !found || u != user {
+		t.Errorf("expected cached user")
+	}
+
+	if _, k, found := c.get("apikey:key", now); !found || k != apiKey {
+		t.Errorf("expected cached api key")
+	}
+
+	if _, _, found := c.get("user:2", now); found {
+		t.Errorf("failed authentications should not be cached")
+	}
+
+	if _, _, found := c.get("user:1", now.Add(authCacheTTL+time.Second)); found {
+		t.Errorf("expired entries should not be returned")
+	}
+
+	// the api key expires before the cache entry
+	keyExpires := now.Add(authCacheTTL / 2)
+	c.set("apikey:expiring", user, &models.APIKey{ID: 3, UserID: 1, ExpiresAt: &keyExpires}, now)
+	if _, _, found := c.get("apikey:expiring", keyExpires.Add(time.Second)); found {
+		t.Errorf("expired api keys should not be returned")
+	}
+
+	c.set("user:1", user, nil, now)
+	c.clear()
+	if _, _, found := c.get("user:1", now); found {
+		t.Errorf("cleared entries should not be returned")
+	}
+}
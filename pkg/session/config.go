@@ -1,5 +1,11 @@
 package session
 
+import (
+	"context"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
 type ExternalAccessConfig interface {
 	HasCredentials() bool
 	GetDangerousAllowPublicWithoutAuth() bool
@@ -13,5 +19,16 @@ type SessionConfig interface {
 
 	GetSessionStoreKey() []byte
 	GetMaxSessionAge() int
-	ValidateCredentials(username string, password string) bool
+}
+
+// UserStore provides methods to authenticate and find users.
+type UserStore interface {
+	// Authenticate returns the user with the provided credentials.
+	// Returns nil if the credentials are invalid.
+	Authenticate(ctx context.Context, username string, password string) (*models.User, error)
+	Find(ctx context.Context, id int) (*models.User, error)
+	FindByUsername(ctx context.Context, username string) (*models.User, error)
+	// AuthenticateAPIKey returns the API key matching the provided key, and
+	// the user that owns it. Returns nil if the key is invalid.
+	AuthenticateAPIKey(ctx context.Context, key string) (*models.APIKey, *models.User, error)
 }
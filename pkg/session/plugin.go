@@ -33,6 +33,11 @@ func (s *Store) VisitedPluginHandler() func(http.Handler) http.Handler {
 				visitedPlugins, _ := val.([]VisitedPluginHook)
 
 				ctx := setVisitedPluginHooks(r.Context(), visitedPlugins)
+
+				if isPlugin, _ := session.Values[pluginRequestKey].(bool); isPlugin {
+					ctx = context.WithValue(ctx, contextPluginRequest, true)
+				}
+
 				r = r.WithContext(ctx)
 			}
 
@@ -50,6 +55,13 @@ func GetVisitedPluginHooks(ctx context.Context) []VisitedPluginHook {
 	return nil
 }
 
+// IsPluginRequest returns true if the current request was made by a plugin
+// using the session cookie provided to it.
+func IsPluginRequest(ctx context.Context) bool {
+	isPlugin, _ := ctx.Value(contextPluginRequest).(bool)
+	return isPlugin
+}
+
 func AddVisitedPluginHook(ctx context.Context, pluginID string, hookType hook.TriggerEnum) context.Context {
 	curVal := GetVisitedPluginHooks(ctx)
 	curVal = append(curVal, VisitedPluginHook{PluginID: pluginID, HookType: hookType})
@@ -70,6 +82,7 @@ func (s *Store) MakePluginCookie(ctx context.Context) *http.Cookie {
 	}
 
 	session.Values[visitedPluginHooksKey] = visitedPlugins
+	session.Values[pluginRequestKey] = true
 
 	encoded, err := securecookie.EncodeMulti(session.Name(), session.Values,
 		s.sessionStore.Codecs...)
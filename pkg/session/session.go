@@ -5,9 +5,12 @@ import (
 	"context"
 	"errors"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/gorilla/sessions"
 	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
 )
 
 type key int
@@ -15,11 +18,14 @@ type key int
 const (
 	contextUser key = iota
 	contextVisitedPlugins
+	contextAPIKey
+	contextPluginRequest
 )
 
 const (
 	userIDKey             = "userID"
 	visitedPluginHooksKey = "visitedPluginsHooks"
+	pluginRequestKey      = "pluginRequest"
 )
 
 const (
@@ -47,12 +53,16 @@ var ErrUnauthorized = errors.New("unauthorized")
 type Store struct {
 	sessionStore *sessions.CookieStore
 	config       SessionConfig
+	users        UserStore
+	cache        *authCache
 }
 
-func NewStore(c SessionConfig) *Store {
+func NewStore(c SessionConfig, users UserStore) *Store {
 	ret := &Store{
 		sessionStore: sessions.NewCookieStore(c.GetSessionStoreKey()),
 		config:       c,
+		users:        users,
+		cache:        newAuthCache(),
 	}
 
 	ret.sessionStore.MaxAge(c.GetMaxSessionAge())
@@ -69,16 +79,20 @@ func (s *Store) Login(w http.ResponseWriter, r *http.Request) error {
 	password := r.FormValue(passwordFormKey)
 
 	// authenticate the user
-	if !s.config.ValidateCredentials(username, password) {
+	user, err := s.users.Authenticate(r.Context(), username, password)
+	if err != nil {
+		return err
+	}
+
+	if user == nil {
 		return &InvalidCredentialsError{Username: username}
 	}
 
-	// since we only have one user, don't leak the name
-	logger.Info("User logged in")
+	logger.Infof("User %s logged in", user.Username)
 
-	newSession.Values[userIDKey] = username
+	newSession.Values[userIDKey] = strconv.Itoa(user.ID)
 
-	err := newSession.Save(r, w)
+	err = newSession.Save(r, w)
 	if err != nil {
 		return err
 	}
@@ -100,7 +114,6 @@ func (s *Store) Logout(w http.ResponseWriter, r *http.Request) error {
 		return err
 	}
 
-	// since we only have one user, don't leak the name
 	logger.Infof("User logged out")
 
 	return nil
@@ -131,23 +144,57 @@ func (s *Store) GetSessionUserID(w http.ResponseWriter, r *http.Request) (string
 	return "", nil
 }
 
-func SetCurrentUserID(ctx context.Context, userID string) context.Context {
-	return context.WithValue(ctx, contextUser, userID)
+// SetCurrentUser sets the current user in the provided context.
+func SetCurrentUser(ctx context.Context, user *models.User) context.Context {
+	return context.WithValue(ctx, contextUser, user)
 }
 
-// GetCurrentUserID gets the current user id from the provided context
-func GetCurrentUserID(ctx context.Context) *string {
+// GetCurrentUser gets the current user from the provided context.
+// Returns nil if there is no authenticated user.
+func GetCurrentUser(ctx context.Context) *models.User {
 	userCtxVal := ctx.Value(contextUser)
 	if userCtxVal != nil {
-		currentUser := userCtxVal.(string)
+		return userCtxVal.(*models.User)
+	}
+
+	return nil
+}
+
+// SetCurrentAPIKey sets the API key used to authenticate the current request
+// in the provided context.
+func SetCurrentAPIKey(ctx context.Context, apiKey *models.APIKey) context.Context {
+	return context.WithValue(ctx, contextAPIKey, apiKey)
+}
+
+// GetCurrentAPIKey gets the API key used to authenticate the current request
+// from the provided context. Returns nil if the request was not authenticated
+// with a stored API key.
+func GetCurrentAPIKey(ctx context.Context) *models.APIKey {
+	apiKeyCtxVal := ctx.Value(contextAPIKey)
+	if apiKeyCtxVal != nil {
+		return apiKeyCtxVal.(*models.APIKey)
+	}
+
+	return nil
+}
+
+// GetCurrentUserID gets the current user id from the provided context
+func GetCurrentUserID(ctx context.Context) *string {
+	user := GetCurrentUser(ctx)
+	if user != nil {
+		currentUser := strconv.Itoa(user.ID)
 		return &currentUser
 	}
 
 	return nil
 }
 
-func (s *Store) Authenticate(w http.ResponseWriter, r *http.Request) (userID string, err error) {
-	c := s.config
+// Authenticate returns the user authenticated by the request, either by
+// API key or by session cookie. Returns nil if the request is not
+// authenticated. If the request was authenticated by a stored API key, then
+// the API key is also returned.
+func (s *Store) Authenticate(w http.ResponseWriter, r *http.Request) (*models.User, *models.APIKey, error) {
+	ctx := r.Context()
 
 	// translate api key into current user, if present
 	apiKey := r.Header.Get(ApiKeyHeader)
@@ -158,22 +205,77 @@ func (s *Store) Authenticate(w http.ResponseWriter, r *http.Request) (userID str
 	}
 
 	if apiKey != "" {
-		// match against configured API and set userID to the
-		// configured username. In future, we'll want to
-		// get the username from the key.
-		if c.GetAPIKey() != apiKey {
-			return "", ErrUnauthorized
+		return s.authenticateAPIKey(ctx, apiKey)
+	}
+
+	// handle session
+	userID, err := s.GetSessionUserID(w, r)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if userID == "" {
+		return nil, nil, nil
+	}
+
+	id, err := strconv.Atoi(userID)
+	if err != nil {
+		// session predates user accounts - treat as unauthenticated
+		return nil, nil, nil
+	}
+
+	cacheKey := "user:" + userID
+	now := time.Now()
+	if user, _, found := s.cache.get(cacheKey, now); found {
+		return user, nil, nil
+	}
+
+	user, err := s.users.Find(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	s.cache.set(cacheKey, user, nil, now)
+	return user, nil, nil
+}
+
+func (s *Store) authenticateAPIKey(ctx context.Context, apiKey string) (*models.User, *models.APIKey, error) {
+	cacheKey := "apikey:" + apiKey
+	now := time.Now()
+	if user, storedKey, found := s.cache.get(cacheKey, now); found {
+		return user, storedKey, nil
+	}
+
+	// match against configured API key and resolve to the
+	// configured user.
+	c := s.config
+	if configuredKey := c.GetAPIKey(); configuredKey != "" && configuredKey == apiKey {
+		user, err := s.users.FindByUsername(ctx, c.GetUsername())
+		if err != nil {
+			return nil, nil, err
 		}
 
-		userID = c.GetUsername()
-	} else {
-		// handle session
-		userID, err = s.GetSessionUserID(w, r)
+		s.cache.set(cacheKey, user, nil, now)
+		return user, nil, nil
 	}
 
+	// otherwise match against the stored API keys
+	storedKey, user, err := s.users.AuthenticateAPIKey(ctx, apiKey)
 	if err != nil {
-		return "", err
+		return nil, nil, err
+	}
+
+	if storedKey == nil {
+		return nil, nil, ErrUnauthorized
 	}
 
-	return
+	s.cache.set(cacheKey, user, storedKey, now)
+	return user, storedKey, nil
+}
+
+// ClearCache clears the cached authentication results. It must be called
+// when users or API keys are changed, so that the changes apply to the
+// next request.
+func (s *Store) ClearCache() {
+	s.cache.clear()
 }
@@ -49,9 +49,14 @@ func (db *Anonymiser) Anonymise(ctx context.Context) error {
 			func() error { return db.deleteStashIDs() },
 			func() error { return db.clearOHistory() },
 			func() error { return db.clearWatchHistory() },
+			func() error { return db.clearCustomFields() },
+			func() error { return db.clearUsers() },
+			func() error { return db.clearWebhookDeliveries() },
 			func() error { return db.anonymiseFolders(ctx) },
 			func() error { return db.anonymiseFiles(ctx) },
 			func() error { return db.anonymiseCaptions(ctx) },
+			func() error { return db.anonymiseVideoStreams(ctx) },
+			func() error { return db.anonymiseVideoChapters(ctx) },
 			func() error { return db.anonymiseFingerprints(ctx) },
 			func() error { return db.anonymiseScenes(ctx) },
 			func() error { return db.anonymiseMarkers(ctx) },
@@ -62,6 +67,9 @@ func (db *Anonymiser) Anonymise(ctx context.Context) error {
 			func() error { return db.anonymiseTags(ctx) },
 			func() error { return db.anonymiseGroups(ctx) },
 			func() error { return db.anonymiseSavedFilters(ctx) },
+			// edit history must be cleared after anonymising, since anonymising
+			// records the original values in the history
+			func() error { return db.clearEditHistory() },
 			func() error { return db.Optimise(ctx) },
 		})
 	}(); err != nil {
@@ -117,6 +125,46 @@ func (db *Anonymiser) clearWatchHistory() error {
 	})
 }
 
+func (db *Anonymiser) clearCustomFields() error {
+	return utils.Do([]func() error{
+		func() error { return db.truncateTable(sceneCustomFieldsTable) },
+		func() error { return db.truncateTable(imageCustomFieldsTable) },
+		func() error { return db.truncateTable(galleryCustomFieldsTable) },
+		func() error { return db.truncateTable(performerCustomFieldsTable) },
+		func() error { return db.truncateTable(studioCustomFieldsTable) },
+		func() error { return db.truncateTable(tagCustomFieldsTable) },
+		func() error { return db.truncateTable(groupCustomFieldsTable) },
+		func() error { return db.truncateTable(customFieldDefinitionTable) },
+	})
+}
+
+func (db *Anonymiser) clearEditHistory() error {
+	return utils.Do([]func() error{
+		func() error { return db.truncateTable(editHistoryTable) },
+	})
+}
+
+func (db *Anonymiser) clearUsers() error {
+	return utils.Do([]func() error{
+		func() error { return db.truncateTable(sceneResumeTimesTable) },
+		func() error { return db.truncateTable(sceneRatingsTable) },
+		func() error { return db.truncateTable(imageRatingsTable) },
+		func() error { return db.truncateTable(galleryRatingsTable) },
+		func() error { return db.truncateTable(groupRatingsTable) },
+		func() error { return db.truncateTable(performerRatingsTable) },
+		func() error { return db.truncateTable(studioRatingsTable) },
+		func() error { return db.truncateTable(apiKeyScopesTable) },
+		func() error { return db.truncateTable(apiKeyTable) },
+		func() error { return db.truncateTable(userTable) },
+	})
+}
+
+func (db *Anonymiser) clearWebhookDeliveries() error {
+	return utils.Do([]func() error{
+		func() error { return db.truncateTable(webhookDeliveryTable) },
+	})
+}
+
 func (db *Anonymiser) anonymiseFolders(ctx context.Context) error {
 	logger.Infof("Anonymising folders")
 	return txn.WithTxn(ctx, db, func(ctx context.Context) error {
@@ -189,6 +237,34 @@ func (db *Anonymiser) anonymiseCaptions(ctx context.Context) error {
 	})
 }
 
+func (db *Anonymiser) anonymiseVideoStreams(ctx context.Context) error {
+	logger.Infof("Anonymising video streams")
+	return txn.WithTxn(ctx, db, func(ctx context.Context) error {
+		table := goqu.T(videoStreamsTable)
+		stmt := dialect.Update(table).Set(goqu.Record{"title": ""})
+
+		if _, err := exec(ctx, stmt); err != nil {
+			return fmt.Errorf("anonymising %s: %w", table.GetTable(), err)
+		}
+
+		return nil
+	})
+}
+
+func (db *Anonymiser) anonymiseVideoChapters(ctx context.Context) error {
+	logger.Infof("Anonymising video chapters")
+	return txn.WithTxn(ctx, db, func(ctx context.Context) error {
+		table := goqu.T(videoChaptersTable)
+		stmt := dialect.Update(table).Set(goqu.Record{"title": ""})
+
+		if _, err := exec(ctx, stmt); err != nil {
+			return fmt.Errorf("anonymising %s: %w", table.GetTable(), err)
+		}
+
+		return nil
+	})
+}
+
 func (db *Anonymiser) anonymiseFingerprints(ctx context.Context) error {
 	logger.Infof("Anonymising fingerprints")
 	table := fingerprintTableMgr.table
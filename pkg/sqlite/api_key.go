@@ -0,0 +1,229 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/doug-martin/goqu/v9/exp"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+const (
+	apiKeyTable         = "api_keys"
+	apiKeyIDColumn      = "api_key_id"
+	apiKeyScopesTable   = "api_key_scopes"
+	apiKeyScopeColumn   = "scope"
+	apiKeyKeyHashColumn = "key_hash"
+)
+
+type apiKeyRow struct {
+	ID         int           `db:"id" goqu:"skipinsert"`
+	UserID     int           `db:"user_id"`
+	Name       string        `db:"name"`
+	KeyHash    string        `db:"key_hash"`
+	ExpiresAt  NullTimestamp `db:"expires_at"`
+	LastUsedAt NullTimestamp `db:"last_used_at"`
+	RevokedAt  NullTimestamp `db:"revoked_at"`
+	CreatedAt  Timestamp     `db:"created_at"`
+	UpdatedAt  Timestamp     `db:"updated_at"`
+}
+
+func (r *apiKeyRow) fromAPIKey(o models.APIKey) {
+	r.ID = o.ID
+	r.UserID = o.UserID
+	r.Name = o.Name
+	r.KeyHash = o.KeyHash
+	r.ExpiresAt = NullTimestampFromTimePtr(o.ExpiresAt)
+	r.LastUsedAt = NullTimestampFromTimePtr(o.LastUsedAt)
+	r.RevokedAt = NullTimestampFromTimePtr(o.RevokedAt)
+	r.CreatedAt = Timestamp{Timestamp: o.CreatedAt}
+	r.UpdatedAt = Timestamp{Timestamp: o.UpdatedAt}
+}
+
+func (r *apiKeyRow) resolve() *models.APIKey {
+	return &models.APIKey{
+		ID:         r.ID,
+		UserID:     r.UserID,
+		Name:       r.Name,
+		KeyHash:    r.KeyHash,
+		ExpiresAt:  r.ExpiresAt.TimePtr(),
+		LastUsedAt: r.LastUsedAt.TimePtr(),
+		RevokedAt:  r.RevokedAt.TimePtr(),
+		CreatedAt:  r.CreatedAt.Timestamp,
+		UpdatedAt:  r.UpdatedAt.Timestamp,
+	}
+}
+
+type apiKeyRowRecord struct {
+	updateRecord
+}
+
+func (r *apiKeyRowRecord) fromPartial(o models.APIKeyPartial) {
+	r.setString("name", o.Name)
+	r.setNullTimestamp("last_used_at", o.LastUsedAt)
+	r.setNullTimestamp("revoked_at", o.RevokedAt)
+	r.setTimestamp("updated_at", o.UpdatedAt)
+}
+
+type APIKeyStore struct {
+	repository
+	tableMgr *table
+}
+
+func NewAPIKeyStore() *APIKeyStore {
+	return &APIKeyStore{
+		repository: repository{
+			tableName: apiKeyTable,
+			idColumn:  idColumn,
+		},
+		tableMgr: apiKeyTableMgr,
+	}
+}
+
+func (qb *APIKeyStore) table() exp.IdentifierExpression {
+	return qb.tableMgr.table
+}
+
+func (qb *APIKeyStore) selectDataset() *goqu.SelectDataset {
+	return dialect.From(qb.table()).Select(qb.table().All())
+}
+
+func (qb *APIKeyStore) Create(ctx context.Context, newObject *models.APIKey) error {
+	var r apiKeyRow
+	r.fromAPIKey(*newObject)
+
+	id, err := qb.tableMgr.insertID(ctx, r)
+	if err != nil {
+		return err
+	}
+
+	scopes := make([]string, len(newObject.Scopes))
+	for i, s := range newObject.Scopes {
+		scopes[i] = s.String()
+	}
+
+	if err := apiKeysScopesTableMgr.insertJoins(ctx, id, scopes); err != nil {
+		return err
+	}
+
+	updated, err := qb.find(ctx, id)
+	if err != nil {
+		return fmt.Errorf("finding after create: %w", err)
+	}
+
+	*newObject = *updated
+
+	return nil
+}
+
+func (qb *APIKeyStore) UpdatePartial(ctx context.Context, id int, partial models.APIKeyPartial) (*models.APIKey, error) {
+	r := apiKeyRowRecord{
+		updateRecord{
+			Record: make(exp.Record),
+		},
+	}
+
+	r.fromPartial(partial)
+
+	if len(r.Record) > 0 {
+		if err := qb.tableMgr.updateByID(ctx, id, r.Record); err != nil {
+			return nil, err
+		}
+	}
+
+	return qb.find(ctx, id)
+}
+
+func (qb *APIKeyStore) Destroy(ctx context.Context, id int) error {
+	return qb.tableMgr.destroyExisting(ctx, []int{id})
+}
+
+// returns nil, nil if not found
+func (qb *APIKeyStore) Find(ctx context.Context, id int) (*models.APIKey, error) {
+	ret, err := qb.find(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	return ret, err
+}
+
+// returns nil, sql.ErrNoRows if not found
+func (qb *APIKeyStore) find(ctx context.Context, id int) (*models.APIKey, error) {
+	q := qb.selectDataset().Where(qb.tableMgr.byID(id))
+
+	ret, err := qb.get(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+// returns nil, sql.ErrNoRows if not found
+func (qb *APIKeyStore) get(ctx context.Context, q *goqu.SelectDataset) (*models.APIKey, error) {
+	ret, err := qb.getMany(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ret) == 0 {
+		return nil, sql.ErrNoRows
+	}
+
+	return ret[0], nil
+}
+
+func (qb *APIKeyStore) getMany(ctx context.Context, q *goqu.SelectDataset) ([]*models.APIKey, error) {
+	const single = false
+	var ret []*models.APIKey
+	if err := queryFunc(ctx, q, single, func(r *sqlx.Rows) error {
+		var f apiKeyRow
+		if err := r.StructScan(&f); err != nil {
+			return err
+		}
+
+		ret = append(ret, f.resolve())
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	for _, k := range ret {
+		scopes, err := apiKeysScopesTableMgr.get(ctx, k.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		k.Scopes = make([]models.APIKeyScope, len(scopes))
+		for i, s := range scopes {
+			k.Scopes[i] = models.APIKeyScope(s)
+		}
+	}
+
+	return ret, nil
+}
+
+// returns nil, nil if not found
+func (qb *APIKeyStore) FindByKeyHash(ctx context.Context, keyHash string) (*models.APIKey, error) {
+	q := qb.selectDataset().Where(qb.table().Col(apiKeyKeyHashColumn).Eq(keyHash))
+
+	ret, err := qb.get(ctx, q)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	return ret, err
+}
+
+func (qb *APIKeyStore) FindByUserID(ctx context.Context, userID int) ([]*models.APIKey, error) {
+	q := qb.selectDataset().Where(qb.table().Col(userIDColumn).Eq(userID)).Order(qb.table().Col(idColumn).Asc())
+	return qb.getMany(ctx, q)
+}
+
+func (qb *APIKeyStore) All(ctx context.Context) ([]*models.APIKey, error) {
+	return qb.getMany(ctx, qb.selectDataset().Order(qb.table().Col(idColumn).Asc()))
+}
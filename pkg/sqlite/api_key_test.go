@@ -0,0 +1,123 @@
+//go:build integration
+// +build integration
+
+package sqlite_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIKeyCreateFind(t *testing.T) {
+	withRollbackTxn(func(ctx context.Context) error {
+		qb := db.APIKey
+
+		u := createTestUser(ctx, t, "user", models.UserRoleEditor)
+
+		expiresAt := time.Now().Add(time.Hour).Truncate(time.Second)
+		newAPIKey := models.NewAPIKey()
+		newAPIKey.UserID = u.ID
+		newAPIKey.Name = "script"
+		newAPIKey.KeyHash = "hash"
+		newAPIKey.Scopes = []models.APIKeyScope{models.APIKeyScopeMetadata, models.APIKeyScopeJobs}
+		newAPIKey.ExpiresAt = &expiresAt
+
+		if err := qb.Create(ctx, &newAPIKey); err != nil {
+			t.Errorf("APIKeyStore.Create() error = %v", err)
+			return nil
+		}
+
+		found, err := qb.FindByKeyHash(ctx, "hash")
+		if err != nil {
+			t.Errorf("APIKeyStore.FindByKeyHash() error = %v", err)
+			return nil
+		}
+
+		assert.Equal(t, newAPIKey.ID, found.ID)
+		assert.Equal(t, u.ID, found.UserID)
+		assert.Equal(t, "script", found.Name)
+		assert.ElementsMatch(t, newAPIKey.Scopes, found.Scopes)
+		assert.True(t, expiresAt.Equal(*found.ExpiresAt))
+		assert.Nil(t, found.LastUsedAt)
+		assert.Nil(t, found.RevokedAt)
+
+		found, err = qb.FindByKeyHash(ctx, "other")
+		if err != nil {
+			t.Errorf("APIKeyStore.FindByKeyHash() error = %v", err)
+			return nil
+		}
+		assert.Nil(t, found)
+
+		return nil
+	})
+}
+
+func TestAPIKeyRevoke(t *testing.T) {
+	withRollbackTxn(func(ctx context.Context) error {
+		qb := db.APIKey
+
+		u := createTestUser(ctx, t, "user", models.UserRoleEditor)
+
+		newAPIKey := models.NewAPIKey()
+		newAPIKey.UserID = u.ID
+		newAPIKey.Name = "script"
+		newAPIKey.KeyHash = "hash"
+
+		if err := qb.Create(ctx, &newAPIKey); err != nil {
+			t.Errorf("APIKeyStore.Create() error = %v", err)
+			return nil
+		}
+
+		now := time.Now()
+		partial := models.NewAPIKeyPartial()
+		partial.RevokedAt = models.NewOptionalTime(now)
+
+		updated, err := qb.UpdatePartial(ctx, newAPIKey.ID, partial)
+		if err != nil {
+			t.Errorf("APIKeyStore.UpdatePartial() error = %v", err)
+			return nil
+		}
+
+		assert.NotNil(t, updated.RevokedAt)
+		assert.False(t, updated.IsActive(now))
+
+		return nil
+	})
+}
+
+func TestAPIKeyDestroyUser(t *testing.T) {
+	withRollbackTxn(func(ctx context.Context) error {
+		qb := db.APIKey
+
+		u := createTestUser(ctx, t, "user", models.UserRoleEditor)
+
+		newAPIKey := models.NewAPIKey()
+		newAPIKey.UserID = u.ID
+		newAPIKey.Name = "script"
+		newAPIKey.KeyHash = "hash"
+		newAPIKey.Scopes = []models.APIKeyScope{models.APIKeyScopeRead}
+
+		if err := qb.Create(ctx, &newAPIKey); err != nil {
+			t.Errorf("APIKeyStore.Create() error = %v", err)
+			return nil
+		}
+
+		if err := db.User.Destroy(ctx, u.ID); err != nil {
+			t.Errorf("UserStore.Destroy() error = %v", err)
+			return nil
+		}
+
+		keys, err := qb.FindByUserID(ctx, u.ID)
+		if err != nil {
+			t.Errorf("APIKeyStore.FindByUserID() error = %v", err)
+			return nil
+		}
+		assert.Len(t, keys, 0)
+
+		return nil
+	})
+}
@@ -0,0 +1,520 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/doug-martin/goqu/v9/exp"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+const (
+	customFieldDefinitionTable = "custom_field_definitions"
+
+	customFieldDefinitionIDColumn = "definition_id"
+	customFieldValueColumn        = "value"
+)
+
+type customFieldDefinitionRow struct {
+	ID         int                          `db:"id" goqu:"skipinsert"`
+	EntityType models.CustomFieldEntityType `db:"entity_type"`
+	Name       string                       `db:"name"`
+	Type       models.CustomFieldType       `db:"type"`
+	CreatedAt  Timestamp                    `db:"created_at"`
+	UpdatedAt  Timestamp                    `db:"updated_at"`
+}
+
+func (r *customFieldDefinitionRow) fromCustomFieldDefinition(o models.CustomFieldDefinition) {
+	r.ID = o.ID
+	r.EntityType = o.EntityType
+	r.Name = o.Name
+	r.Type = o.Type
+	r.CreatedAt = Timestamp{Timestamp: o.CreatedAt}
+	r.UpdatedAt = Timestamp{Timestamp: o.UpdatedAt}
+}
+
+func (r *customFieldDefinitionRow) resolve() *models.CustomFieldDefinition {
+	return &models.CustomFieldDefinition{
+		ID:         r.ID,
+		EntityType: r.EntityType,
+		Name:       r.Name,
+		Type:       r.Type,
+		CreatedAt:  r.CreatedAt.Timestamp,
+		UpdatedAt:  r.UpdatedAt.Timestamp,
+	}
+}
+
+type CustomFieldDefinitionStore struct {
+	repository
+	tableMgr *table
+}
+
+func NewCustomFieldDefinitionStore() *CustomFieldDefinitionStore {
+	return &CustomFieldDefinitionStore{
+		repository: repository{
+			tableName: customFieldDefinitionTable,
+			idColumn:  idColumn,
+		},
+		tableMgr: customFieldDefinitionTableMgr,
+	}
+}
+
+func (qb *CustomFieldDefinitionStore) table() exp.IdentifierExpression {
+	return qb.tableMgr.table
+}
+
+func (qb *CustomFieldDefinitionStore) selectDataset() *goqu.SelectDataset {
+	return dialect.From(qb.table()).Select(qb.table().All())
+}
+
+func (qb *CustomFieldDefinitionStore) Create(ctx context.Context, newObject *models.CustomFieldDefinition) error {
+	var r customFieldDefinitionRow
+	r.fromCustomFieldDefinition(*newObject)
+
+	id, err := qb.tableMgr.insertID(ctx, r)
+	if err != nil {
+		return err
+	}
+
+	updated, err := qb.find(ctx, id)
+	if err != nil {
+		return fmt.Errorf("finding after create: %w", err)
+	}
+
+	*newObject = *updated
+
+	return nil
+}
+
+func (qb *CustomFieldDefinitionStore) Update(ctx context.Context, updatedObject *models.CustomFieldDefinition) error {
+	var r customFieldDefinitionRow
+	r.fromCustomFieldDefinition(*updatedObject)
+
+	return qb.tableMgr.updateByID(ctx, updatedObject.ID, r)
+}
+
+func (qb *CustomFieldDefinitionStore) Destroy(ctx context.Context, id int) error {
+	return qb.tableMgr.destroyExisting(ctx, []int{id})
+}
+
+// returns nil, nil if not found
+func (qb *CustomFieldDefinitionStore) Find(ctx context.Context, id int) (*models.CustomFieldDefinition, error) {
+	ret, err := qb.find(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	return ret, err
+}
+
+// returns nil, sql.ErrNoRows if not found
+func (qb *CustomFieldDefinitionStore) find(ctx context.Context, id int) (*models.CustomFieldDefinition, error) {
+	q := qb.selectDataset().Where(qb.tableMgr.byID(id))
+
+	ret, err := qb.getMany(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ret) == 0 {
+		return nil, sql.ErrNoRows
+	}
+
+	return ret[0], nil
+}
+
+// returns nil, nil if not found
+func (qb *CustomFieldDefinitionStore) FindByName(ctx context.Context, entityType models.CustomFieldEntityType, name string) (*models.CustomFieldDefinition, error) {
+	table := qb.table()
+	q := qb.selectDataset().Where(
+		table.Col("entity_type").Eq(entityType),
+		table.Col("name").Eq(name),
+	)
+
+	ret, err := qb.getMany(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ret) == 0 {
+		return nil, nil
+	}
+
+	return ret[0], nil
+}
+
+func (qb *CustomFieldDefinitionStore) FindByEntityType(ctx context.Context, entityType models.CustomFieldEntityType) ([]*models.CustomFieldDefinition, error) {
+	table := qb.table()
+	q := qb.selectDataset().Where(
+		table.Col("entity_type").Eq(entityType),
+	).Order(table.Col("name").Asc())
+
+	return qb.getMany(ctx, q)
+}
+
+func (qb *CustomFieldDefinitionStore) All(ctx context.Context) ([]*models.CustomFieldDefinition, error) {
+	table := qb.table()
+	q := qb.selectDataset().Order(
+		table.Col("entity_type").Asc(),
+		table.Col("name").Asc(),
+	)
+
+	return qb.getMany(ctx, q)
+}
+
+func (qb *CustomFieldDefinitionStore) getMany(ctx context.Context, q *goqu.SelectDataset) ([]*models.CustomFieldDefinition, error) {
+	const single = false
+	var ret []*models.CustomFieldDefinition
+	if err := queryFunc(ctx, q, single, func(r *sqlx.Rows) error {
+		var f customFieldDefinitionRow
+		if err := r.StructScan(&f); err != nil {
+			return err
+		}
+
+		ret = append(ret, f.resolve())
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+// customFieldsTable stores the custom field values of an entity type.
+type customFieldsTable struct {
+	table
+	entityType models.CustomFieldEntityType
+}
+
+func (t *customFieldsTable) valueColumn() exp.IdentifierExpression {
+	return t.table.table.Col(customFieldValueColumn)
+}
+
+func (t *customFieldsTable) definitionIDColumn() exp.IdentifierExpression {
+	return t.table.table.Col(customFieldDefinitionIDColumn)
+}
+
+// definitions returns the custom field definitions for the entity type,
+// keyed by name.
+func (t *customFieldsTable) definitions(ctx context.Context) (map[string]*models.CustomFieldDefinition, error) {
+	defs, err := NewCustomFieldDefinitionStore().FindByEntityType(ctx, t.entityType)
+	if err != nil {
+		return nil, fmt.Errorf("getting custom field definitions: %w", err)
+	}
+
+	ret := make(map[string]*models.CustomFieldDefinition)
+	for _, d := range defs {
+		ret[d.Name] = d
+	}
+
+	return ret, nil
+}
+
+func (t *customFieldsTable) getMany(ctx context.Context, ids []int) ([]map[string]interface{}, error) {
+	defTable := customFieldDefinitionTableMgr.table
+
+	ret := make([]map[string]interface{}, len(ids))
+	for i := range ret {
+		ret[i] = make(map[string]interface{})
+	}
+
+	idToIndex := idToIndexMap(ids)
+
+	if err := batchExec(ids, defaultBatchSize, func(batch []int) error {
+		q := dialect.Select(
+			t.idColumn,
+			defTable.Col("name"),
+			defTable.Col("type"),
+			t.valueColumn(),
+		).From(t.table.table).InnerJoin(
+			defTable,
+			goqu.On(defTable.Col(idColumn).Eq(t.definitionIDColumn())),
+		).Where(t.idColumn.In(batch))
+
+		const single = false
+		return queryFunc(ctx, q, single, func(rows *sqlx.Rows) error {
+			var (
+				id        int
+				name      string
+				fieldType models.CustomFieldType
+				value     interface{}
+			)
+			if err := rows.Scan(&id, &name, &fieldType, &value); err != nil {
+				return err
+			}
+
+			ret[idToIndex[id]][name] = resolveCustomFieldValue(fieldType, value)
+			return nil
+		})
+	}); err != nil {
+		return nil, fmt.Errorf("getting custom fields from %s: %w", t.table.table.GetTable(), err)
+	}
+
+	return ret, nil
+}
+
+// resolveCustomFieldValue converts a value read from the database into the
+// representation of the field type.
+func resolveCustomFieldValue(fieldType models.CustomFieldType, v interface{}) interface{} {
+	if b, ok := v.([]byte); ok {
+		v = string(b)
+	}
+
+	switch fieldType {
+	case models.CustomFieldTypeBool:
+		if i, ok := v.(int64); ok {
+			return i != 0
+		}
+	case models.CustomFieldTypeFloat:
+		if i, ok := v.(int64); ok {
+			return float64(i)
+		}
+	}
+
+	return v
+}
+
+// storedCustomFieldValue converts a normalised value into the value stored in
+// the database.
+func storedCustomFieldValue(v interface{}) interface{} {
+	if b, ok := v.(bool); ok {
+		if b {
+			return 1
+		}
+		return 0
+	}
+
+	return v
+}
+
+func (t *customFieldsTable) setValue(ctx context.Context, id int, def *models.CustomFieldDefinition, v interface{}) error {
+	if v == nil {
+		return t.removeValue(ctx, id, def)
+	}
+
+	normalised, err := def.Type.Normalise(v)
+	if err != nil {
+		return fmt.Errorf("custom field %q: %w", def.Name, err)
+	}
+
+	idCol := t.idColumn.GetCol().(string)
+	value := storedCustomFieldValue(normalised)
+
+	q := dialect.Insert(t.table.table).Rows(goqu.Record{
+		idCol:                         id,
+		customFieldDefinitionIDColumn: def.ID,
+		customFieldValueColumn:        value,
+	}).OnConflict(goqu.DoUpdate(idCol+", "+customFieldDefinitionIDColumn, goqu.Record{customFieldValueColumn: value}))
+
+	if _, err := exec(ctx, q); err != nil {
+		return fmt.Errorf("setting custom field in %s: %w", t.table.table.GetTable(), err)
+	}
+
+	return nil
+}
+
+func (t *customFieldsTable) removeValue(ctx context.Context, id int, def *models.CustomFieldDefinition) error {
+	q := dialect.Delete(t.table.table).Where(
+		t.idColumn.Eq(id),
+		t.definitionIDColumn().Eq(def.ID),
+	)
+
+	if _, err := exec(ctx, q); err != nil {
+		return fmt.Errorf("removing custom field from %s: %w", t.table.table.GetTable(), err)
+	}
+
+	return nil
+}
+
+func (t *customFieldsTable) set(ctx context.Context, id int, input models.CustomFieldsInput) error {
+	defs, err := t.definitions(ctx)
+	if err != nil {
+		return err
+	}
+
+	getDef := func(name string) (*models.CustomFieldDefinition, error) {
+		def := defs[name]
+		if def == nil {
+			return nil, fmt.Errorf("custom field %q is not defined for %s", name, t.entityType)
+		}
+		return def, nil
+	}
+
+	values := input.Partial
+	if input.Full != nil {
+		if err := t.destroy(ctx, []int{id}); err != nil {
+			return err
+		}
+
+		values = input.Full
+	}
+
+	for name, v := range values {
+		def, err := getDef(name)
+		if err != nil {
+			return err
+		}
+
+		if err := t.setValue(ctx, id, def, v); err != nil {
+			return err
+		}
+	}
+
+	if input.Full == nil {
+		for _, name := range input.Remove {
+			def, err := getDef(name)
+			if err != nil {
+				return err
+			}
+
+			if err := t.removeValue(ctx, id, def); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// customFieldsStore provides the custom field methods of a store.
+type customFieldsStore struct {
+	tableMgr *customFieldsTable
+}
+
+func (s *customFieldsStore) GetCustomFields(ctx context.Context, id int) (map[string]interface{}, error) {
+	ret, err := s.tableMgr.getMany(ctx, []int{id})
+	if err != nil {
+		return nil, err
+	}
+
+	return ret[0], nil
+}
+
+func (s *customFieldsStore) GetCustomFieldsBulk(ctx context.Context, ids []int) ([]map[string]interface{}, error) {
+	return s.tableMgr.getMany(ctx, ids)
+}
+
+func (s *customFieldsStore) SetCustomFields(ctx context.Context, id int, fields models.CustomFieldsInput) error {
+	return s.tableMgr.set(ctx, id, fields)
+}
+
+type customFieldsFilterHandler struct {
+	criteria    []models.CustomFieldCriterionInput
+	tableMgr    *customFieldsTable
+	parentIDCol string
+}
+
+// customFieldFilterValue converts a criterion value into a value that can be
+// compared with the stored values.
+func customFieldFilterValue(v interface{}) interface{} {
+	if n, ok := v.(json.Number); ok {
+		if i, err := n.Int64(); err == nil {
+			return i
+		}
+		f, _ := n.Float64()
+		return f
+	}
+
+	return storedCustomFieldValue(v)
+}
+
+func (h *customFieldsFilterHandler) handle(ctx context.Context, f *filterBuilder) {
+	for _, c := range h.criteria {
+		h.handleCriterion(f, c)
+	}
+}
+
+func (h *customFieldsFilterHandler) handleCriterion(f *filterBuilder, c models.CustomFieldCriterionInput) {
+	const valueCol = "cf.value"
+
+	wantValues := 1
+	switch c.Modifier {
+	case models.CriterionModifierIsNull, models.CriterionModifierNotNull:
+		wantValues = 0
+	case models.CriterionModifierBetween, models.CriterionModifierNotBetween:
+		wantValues = 2
+	}
+
+	if len(c.Value) < wantValues {
+		f.setError(fmt.Errorf("custom field %q: %s modifier requires %d value(s)", c.Field, c.Modifier, wantValues))
+		return
+	}
+
+	values := make([]interface{}, wantValues)
+	for i := range values {
+		values[i] = customFieldFilterValue(c.Value[i])
+	}
+
+	var (
+		condition string
+		not       bool
+	)
+
+	switch c.Modifier {
+	case models.CriterionModifierIsNull:
+		not = true
+	case models.CriterionModifierNotNull:
+	case models.CriterionModifierEquals, models.CriterionModifierNotEquals:
+		if _, isString := values[0].(string); isString {
+			condition = valueCol + " LIKE ?"
+		} else {
+			condition = valueCol + " = ?"
+		}
+		not = c.Modifier == models.CriterionModifierNotEquals
+	case models.CriterionModifierIncludes, models.CriterionModifierExcludes:
+		s, isString := values[0].(string)
+		if !isString {
+			f.setError(fmt.Errorf("custom field %q: %s modifier requires a string value", c.Field, c.Modifier))
+			return
+		}
+		condition = valueCol + " LIKE ?"
+		values[0] = "%" + s + "%"
+		not = c.Modifier == models.CriterionModifierExcludes
+	case models.CriterionModifierMatchesRegex, models.CriterionModifierNotMatchesRegex:
+		s, isString := values[0].(string)
+		if !isString {
+			f.setError(fmt.Errorf("custom field %q: %s modifier requires a string value", c.Field, c.Modifier))
+			return
+		}
+		if _, err := regexp.Compile(s); err != nil {
+			f.setError(err)
+			return
+		}
+		condition = valueCol + " regexp ?"
+		not = c.Modifier == models.CriterionModifierNotMatchesRegex
+	case models.CriterionModifierGreaterThan:
+		condition = valueCol + " > ?"
+	case models.CriterionModifierLessThan:
+		condition = valueCol + " < ?"
+	case models.CriterionModifierBetween:
+		condition = valueCol + " BETWEEN ? AND ?"
+	case models.CriterionModifierNotBetween:
+		condition = valueCol + " NOT BETWEEN ? AND ?"
+	default:
+		f.setError(fmt.Errorf("custom field %q: unsupported modifier %s", c.Field, c.Modifier))
+		return
+	}
+
+	t := h.tableMgr
+	clause := fmt.Sprintf(
+		"EXISTS (SELECT 1 FROM %s AS cf INNER JOIN %s AS cfd ON cfd.id = cf.%s WHERE cf.%s = %s AND cfd.name = ?",
+		t.table.table.GetTable(), customFieldDefinitionTable, customFieldDefinitionIDColumn, t.idColumn.GetCol(), h.parentIDCol,
+	)
+	if condition != "" {
+		clause += " AND " + condition
+	}
+	clause += ")"
+
+	if not {
+		clause = "NOT " + clause
+	}
+
+	args := append([]interface{}{c.Field}, values...)
+	f.addWhere(clause, args...)
+}
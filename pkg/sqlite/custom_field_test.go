@@ -0,0 +1,418 @@
+//go:build integration
+// +build integration
+
+package sqlite_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func createTestCustomFieldDefinition(ctx context.Context, t *testing.T, entityType models.CustomFieldEntityType, name string, fieldType models.CustomFieldType) *models.CustomFieldDefinition {
+	now := time.Now()
+	d := &models.CustomFieldDefinition{
+		EntityType: entityType,
+		Name:       name,
+		Type:       fieldType,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+
+	if err := db.CustomField.Create(ctx, d); err != nil {
+		t.Fatalf("CustomFieldDefinitionStore.Create() error = %v", err)
+	}
+
+	return d
+}
+
+func TestCustomFieldDefinitionStore(t *testing.T) {
+	withRollbackTxn(func(ctx context.Context) error {
+		qb := db.CustomField
+
+		sceneField := createTestCustomFieldDefinition(ctx, t, models.CustomFieldEntityTypeScene, "source", models.CustomFieldTypeString)
+		performerField := createTestCustomFieldDefinition(ctx, t, models.CustomFieldEntityTypePerformer, "source", models.CustomFieldTypeURL)
+
+		got, err := qb.FindByName(ctx, models.CustomFieldEntityTypePerformer, "source")
+		if err != nil {
+			t.Errorf("CustomFieldDefinitionStore.FindByName() error = %v", err)
+			return nil
+		}
+		if assert.NotNil(t, got) {
+			assert.Equal(t, performerField.ID, got.ID)
+			assert.Equal(t, models.CustomFieldTypeURL, got.Type)
+		}
+
+		got, err = qb.FindByName(ctx, models.CustomFieldEntityTypeTag, "source")
+		if err != nil {
+			t.Errorf("CustomFieldDefinitionStore.FindByName() error = %v", err)
+			return nil
+		}
+		assert.Nil(t, got)
+
+		sceneFields, err := qb.FindByEntityType(ctx, models.CustomFieldEntityTypeScene)
+		if err != nil {
+			t.Errorf("CustomFieldDefinitionStore.FindByEntityType() error = %v", err)
+			return nil
+		}
+		if assert.Len(t, sceneFields, 1) {
+			assert.Equal(t, sceneField.ID, sceneFields[0].ID)
+		}
+
+		sceneField.Name = "origin"
+		if err := qb.Update(ctx, sceneField); err != nil {
+			t.Errorf("CustomFieldDefinitionStore.Update() error = %v", err)
+			return nil
+		}
+
+		got, err = qb.Find(ctx, sceneField.ID)
+		if err != nil {
+			t.Errorf("CustomFieldDefinitionStore.Find() error = %v", err)
+			return nil
+		}
+		assert.Equal(t, "origin", got.Name)
+
+		// destroying the definition removes its values
+		sceneID := sceneIDs[sceneIdxWithGallery]
+		if err := db.Scene.SetCustomFields(ctx, sceneID, models.CustomFieldsInput{
+			Partial: map[string]interface{}{"origin": "web"},
+		}); err != nil {
+			t.Errorf("SceneStore.SetCustomFields() error = %v", err)
+			return nil
+		}
+
+		if err := qb.Destroy(ctx, sceneField.ID); err != nil {
+			t.Errorf("CustomFieldDefinitionStore.Destroy() error = %v", err)
+			return nil
+		}
+
+		values, err := db.Scene.GetCustomFields(ctx, sceneID)
+		if err != nil {
+			t.Errorf("SceneStore.GetCustomFields() error = %v", err)
+			return nil
+		}
+		assert.Empty(t, values)
+
+		return nil
+	})
+}
+
+func TestSetCustomFields(t *testing.T) {
+	performerID := performerIDs[performerIdxWithScene]
+
+	setup := func(ctx context.Context, t *testing.T) {
+		createTestCustomFieldDefinition(ctx, t, models.CustomFieldEntityTypePerformer, "string", models.CustomFieldTypeString)
+		createTestCustomFieldDefinition(ctx, t, models.CustomFieldEntityTypePerformer, "int", models.CustomFieldTypeInt)
+		createTestCustomFieldDefinition(ctx, t, models.CustomFieldEntityTypePerformer, "float", models.CustomFieldTypeFloat)
+		createTestCustomFieldDefinition(ctx, t, models.CustomFieldEntityTypePerformer, "date", models.CustomFieldTypeDate)
+		createTestCustomFieldDefinition(ctx, t, models.CustomFieldEntityTypePerformer, "bool", models.CustomFieldTypeBool)
+		createTestCustomFieldDefinition(ctx, t, models.CustomFieldEntityTypePerformer, "url", models.CustomFieldTypeURL)
+
+		if err := db.Performer.SetCustomFields(ctx, performerID, models.CustomFieldsInput{
+			Full: map[string]interface{}{
+				"string": "value",
+				"int":    1,
+			},
+		}); err != nil {
+			t.Fatalf("PerformerStore.SetCustomFields() error = %v", err)
+		}
+	}
+
+	tests := []struct {
+		name    string
+		input   models.CustomFieldsInput
+		want    map[string]interface{}
+		wantErr bool
+	}{
+		{
+			"full",
+			models.CustomFieldsInput{
+				Full: map[string]interface{}{
+					"float": 1.5,
+					"date":  "2024-02-03",
+					"bool":  true,
+					"url":   "https://example.com/a",
+				},
+			},
+			map[string]interface{}{
+				"float": 1.5,
+				"date":  "2024-02-03",
+				"bool":  true,
+				"url":   "https://example.com/a",
+			},
+			false,
+		},
+		{
+			"partial",
+			models.CustomFieldsInput{
+				Partial: map[string]interface{}{
+					"int":  float64(2),
+					"bool": false,
+				},
+			},
+			map[string]interface{}{
+				"string": "value",
+				"int":    int64(2),
+				"bool":   false,
+			},
+			false,
+		},
+		{
+			"partial nil removes",
+			models.CustomFieldsInput{
+				Partial: map[string]interface{}{
+					"string": nil,
+				},
+			},
+			map[string]interface{}{
+				"int": int64(1),
+			},
+			false,
+		},
+		{
+			"remove",
+			models.CustomFieldsInput{
+				Remove: []string{"int"},
+			},
+			map[string]interface{}{
+				"string": "value",
+			},
+			false,
+		},
+		{
+			"integral float",
+			models.CustomFieldsInput{
+				Partial: map[string]interface{}{
+					"float": 3,
+				},
+			},
+			map[string]interface{}{
+				"string": "value",
+				"int":    int64(1),
+				"float":  float64(3),
+			},
+			false,
+		},
+		{
+			"invalid int",
+			models.CustomFieldsInput{
+				Partial: map[string]interface{}{
+					"int": 1.5,
+				},
+			},
+			nil,
+			true,
+		},
+		{
+			"invalid date",
+			models.CustomFieldsInput{
+				Partial: map[string]interface{}{
+					"date": "03/02/2024",
+				},
+			},
+			nil,
+			true,
+		},
+		{
+			"invalid url",
+			models.CustomFieldsInput{
+				Partial: map[string]interface{}{
+					"url": "example.com",
+				},
+			},
+			nil,
+			true,
+		},
+		{
+			"undefined field",
+			models.CustomFieldsInput{
+				Partial: map[string]interface{}{
+					"undefined": "value",
+				},
+			},
+			nil,
+			true,
+		},
+	}
+
+	for _, tt := range tests {
+		runWithRollbackTxn(t, tt.name, func(t *testing.T, ctx context.Context) {
+			setup(ctx, t)
+
+			qb := db.Performer
+			err := qb.SetCustomFields(ctx, performerID, tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PerformerStore.SetCustomFields() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+
+			got, err := qb.GetCustomFields(ctx, performerID)
+			if err != nil {
+				t.Errorf("PerformerStore.GetCustomFields() error = %v", err)
+				return
+			}
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestSetCustomFieldsInvalidValue(t *testing.T) {
+	withRollbackTxn(func(ctx context.Context) error {
+		createTestCustomFieldDefinition(ctx, t, models.CustomFieldEntityTypeTag, "count", models.CustomFieldTypeInt)
+
+		err := db.Tag.SetCustomFields(ctx, tagIDs[tagIdx1WithScene], models.CustomFieldsInput{
+			Partial: map[string]interface{}{"count": "one"},
+		})
+		assert.True(t, errors.Is(err, models.ErrInvalidCustomFieldValue))
+
+		return nil
+	})
+}
+
+func TestGetCustomFieldsBulk(t *testing.T) {
+	withRollbackTxn(func(ctx context.Context) error {
+		createTestCustomFieldDefinition(ctx, t, models.CustomFieldEntityTypeStudio, "founded", models.CustomFieldTypeDate)
+
+		studioID := studioIDs[studioIdxWithScene]
+		if err := db.Studio.SetCustomFields(ctx, studioID, models.CustomFieldsInput{
+			Full: map[string]interface{}{"founded": "2001-01-01"},
+		}); err != nil {
+			t.Errorf("StudioStore.SetCustomFields() error = %v", err)
+			return nil
+		}
+
+		otherID := studioIDs[studioIdxWithTwoScenes]
+		got, err := db.Studio.GetCustomFieldsBulk(ctx, []int{otherID, studioID})
+		if err != nil {
+			t.Errorf("StudioStore.GetCustomFieldsBulk() error = %v", err)
+			return nil
+		}
+
+		assert.Equal(t, []map[string]interface{}{
+			{},
+			{"founded": "2001-01-01"},
+		}, got)
+
+		return nil
+	})
+}
+
+func TestSceneQueryCustomFields(t *testing.T) {
+	withRollbackTxn(func(ctx context.Context) error {
+		createTestCustomFieldDefinition(ctx, t, models.CustomFieldEntityTypeScene, "source", models.CustomFieldTypeString)
+		createTestCustomFieldDefinition(ctx, t, models.CustomFieldEntityTypeScene, "count", models.CustomFieldTypeInt)
+		createTestCustomFieldDefinition(ctx, t, models.CustomFieldEntityTypeScene, "checked", models.CustomFieldTypeBool)
+
+		scene1 := sceneIDs[sceneIdxWithGallery]
+		scene2 := sceneIDs[sceneIdxWithTwoTags]
+
+		for id, values := range map[int]map[string]interface{}{
+			scene1: {"source": "Web Rip", "count": 3, "checked": true},
+			scene2: {"source": "disc", "count": 10},
+		} {
+			if err := db.Scene.SetCustomFields(ctx, id, models.CustomFieldsInput{Full: values}); err != nil {
+				t.Errorf("SceneStore.SetCustomFields() error = %v", err)
+				return nil
+			}
+		}
+
+		tests := []struct {
+			name      string
+			criterion models.CustomFieldCriterionInput
+			included  []int
+			excluded  []int
+		}{
+			{
+				"equals",
+				models.CustomFieldCriterionInput{Field: "source", Value: []interface{}{"web rip"}, Modifier: models.CriterionModifierEquals},
+				[]int{scene1},
+				[]int{scene2},
+			},
+			{
+				"not equals",
+				models.CustomFieldCriterionInput{Field: "source", Value: []interface{}{"web rip"}, Modifier: models.CriterionModifierNotEquals},
+				[]int{scene2},
+				[]int{scene1},
+			},
+			{
+				"includes",
+				models.CustomFieldCriterionInput{Field: "source", Value: []interface{}{"rip"}, Modifier: models.CriterionModifierIncludes},
+				[]int{scene1},
+				[]int{scene2},
+			},
+			{
+				"greater than",
+				models.CustomFieldCriterionInput{Field: "count", Value: []interface{}{5}, Modifier: models.CriterionModifierGreaterThan},
+				[]int{scene2},
+				[]int{scene1},
+			},
+			{
+				"between",
+				models.CustomFieldCriterionInput{Field: "count", Value: []interface{}{1, 5}, Modifier: models.CriterionModifierBetween},
+				[]int{scene1},
+				[]int{scene2},
+			},
+			{
+				"bool",
+				models.CustomFieldCriterionInput{Field: "checked", Value: []interface{}{true}, Modifier: models.CriterionModifierEquals},
+				[]int{scene1},
+				[]int{scene2},
+			},
+			{
+				"not null",
+				models.CustomFieldCriterionInput{Field: "checked", Modifier: models.CriterionModifierNotNull},
+				[]int{scene1},
+				[]int{scene2},
+			},
+			{
+				"is null",
+				models.CustomFieldCriterionInput{Field: "checked", Modifier: models.CriterionModifierIsNull},
+				[]int{scene2},
+				[]int{scene1},
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				sceneFilter := &models.SceneFilterType{
+					CustomFields: []models.CustomFieldCriterionInput{tt.criterion},
+				}
+
+				scenes := queryScene(ctx, t, db.Scene, sceneFilter, nil)
+				ids := scenesToIDs(scenes)
+
+				for _, id := range tt.included {
+					assert.Contains(t, ids, id)
+				}
+				for _, id := range tt.excluded {
+					assert.NotContains(t, ids, id)
+				}
+			})
+		}
+
+		return nil
+	})
+}
+
+func TestPerformerQueryCustomFieldsMissingValue(t *testing.T) {
+	withRollbackTxn(func(ctx context.Context) error {
+		performerFilter := &models.PerformerFilterType{
+			CustomFields: []models.CustomFieldCriterionInput{
+				{Field: "count", Modifier: models.CriterionModifierBetween, Value: []interface{}{1}},
+			},
+		}
+
+		_, _, err := db.Performer.Query(ctx, performerFilter, nil)
+		assert.Error(t, err)
+
+		return nil
+	})
+}
@@ -30,7 +30,7 @@ const (
 	dbConnTimeout = 30
 )
 
-var appSchemaVersion uint = 67
+var appSchemaVersion uint = 76
 
 //go:embed migrations/*.sql
 var migrationsBox embed.FS
@@ -75,6 +75,13 @@ type storeRepository struct {
 	Studio         *StudioStore
 	Tag            *TagStore
 	Group          *GroupStore
+	User           *UserStore
+	APIKey         *APIKeyStore
+	EditHistory    *EditHistoryStore
+	TrashedFile    *TrashedFileStore
+	QueuedJob      *QueuedJobStore
+	WebhookLog     *WebhookDeliveryStore
+	CustomField    *CustomFieldDefinitionStore
 }
 
 type Database struct {
@@ -112,6 +119,13 @@ func NewDatabase() *Database {
 		Tag:            tagStore,
 		Group:          NewGroupStore(blobStore),
 		SavedFilter:    NewSavedFilterStore(),
+		User:           NewUserStore(),
+		APIKey:         NewAPIKeyStore(),
+		EditHistory:    NewEditHistoryStore(),
+		TrashedFile:    NewTrashedFileStore(),
+		QueuedJob:      NewQueuedJobStore(),
+		WebhookLog:     NewWebhookDeliveryStore(),
+		CustomField:    NewCustomFieldDefinitionStore(),
 	}
 
 	ret := &Database{
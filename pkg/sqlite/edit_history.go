@@ -0,0 +1,607 @@
+package sqlite
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/doug-martin/goqu/v9/exp"
+	"github.com/jmoiron/sqlx"
+	"gopkg.in/guregu/null.v4"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+const (
+	editHistoryTable            = "edit_history"
+	editHistoryObjectTypeColumn = "object_type"
+	editHistoryObjectIDColumn   = "object_id"
+)
+
+type editHistoryRow struct {
+	ID         int       `db:"id" goqu:"skipinsert"`
+	ObjectType string    `db:"object_type"`
+	ObjectID   int       `db:"object_id"`
+	UserID     null.Int  `db:"user_id"`
+	Source     string    `db:"source"`
+	Changes    string    `db:"changes"`
+	CreatedAt  Timestamp `db:"created_at"`
+}
+
+func (r *editHistoryRow) fromEditHistory(o models.EditHistory) {
+	r.ID = o.ID
+	r.ObjectType = o.ObjectType.String()
+	r.ObjectID = o.ObjectID
+	r.UserID = intFromPtr(o.UserID)
+	r.Source = o.Source.String()
+	r.Changes = encodeJSONOrEmpty(o.Changes)
+	r.CreatedAt = Timestamp{Timestamp: o.CreatedAt}
+}
+
+func (r *editHistoryRow) resolve() *models.EditHistory {
+	ret := &models.EditHistory{
+		ID:         r.ID,
+		ObjectType: models.EditObjectType(r.ObjectType),
+		ObjectID:   r.ObjectID,
+		UserID:     nullIntPtr(r.UserID),
+		Source:     models.EditSource(r.Source),
+		CreatedAt:  r.CreatedAt.Timestamp,
+	}
+
+	decodeJSON(r.Changes, &ret.Changes)
+
+	return ret
+}
+
+type EditHistoryStore struct {
+	repository
+	tableMgr *table
+}
+
+func NewEditHistoryStore() *EditHistoryStore {
+	return &EditHistoryStore{
+		repository: repository{
+			tableName: editHistoryTable,
+			idColumn:  idColumn,
+		},
+		tableMgr: editHistoryTableMgr,
+	}
+}
+
+func (qb *EditHistoryStore) table() exp.IdentifierExpression {
+	return qb.tableMgr.table
+}
+
+func (qb *EditHistoryStore) selectDataset() *goqu.SelectDataset {
+	return dialect.From(qb.table()).Select(qb.table().All())
+}
+
+func (qb *EditHistoryStore) Create(ctx context.Context, newObject *models.EditHistory) error {
+	var r editHistoryRow
+	r.fromEditHistory(*newObject)
+
+	id, err := qb.tableMgr.insertID(ctx, r)
+	if err != nil {
+		return err
+	}
+
+	updated, err := qb.find(ctx, id)
+	if err != nil {
+		return fmt.Errorf("finding after create: %w", err)
+	}
+
+	*newObject = *updated
+
+	return nil
+}
+
+// returns nil, nil if not found
+func (qb *EditHistoryStore) Find(ctx context.Context, id int) (*models.EditHistory, error) {
+	ret, err := qb.find(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	return ret, err
+}
+
+// returns nil, sql.ErrNoRows if not found
+func (qb *EditHistoryStore) find(ctx context.Context, id int) (*models.EditHistory, error) {
+	q := qb.selectDataset().Where(qb.tableMgr.byID(id))
+
+	ret, err := qb.getMany(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ret) == 0 {
+		return nil, sql.ErrNoRows
+	}
+
+	return ret[0], nil
+}
+
+func (qb *EditHistoryStore) getMany(ctx context.Context, q *goqu.SelectDataset) ([]*models.EditHistory, error) {
+	const single = false
+	var ret []*models.EditHistory
+	if err := queryFunc(ctx, q, single, func(r *sqlx.Rows) error {
+		var f editHistoryRow
+		if err := r.StructScan(&f); err != nil {
+			return err
+		}
+
+		ret = append(ret, f.resolve())
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (qb *EditHistoryStore) FindByObject(ctx context.Context, objectType models.EditObjectType, objectID int) ([]*models.EditHistory, error) {
+	table := qb.table()
+	q := qb.selectDataset().Where(
+		table.Col(editHistoryObjectTypeColumn).Eq(objectType.String()),
+		table.Col(editHistoryObjectIDColumn).Eq(objectID),
+	).Order(table.Col(idColumn).Desc())
+
+	return qb.getMany(ctx, q)
+}
+
+// editSnapshot contains the editable fields of an object, keyed by the
+// field name in the object's update input. Values are stored in the form
+// accepted by the update input, so that they can be used to revert an edit.
+// Ratings are per-user state and are not included in snapshots.
+type editSnapshot map[string]interface{}
+
+type editSnapshotFunc func(ctx context.Context, id int) (editSnapshot, error)
+
+// diffEditSnapshots returns the fields that differ between the two snapshots,
+// ordered by field name.
+func diffEditSnapshots(before, after editSnapshot) ([]models.EditFieldChange, error) {
+	fields := make(map[string]struct{})
+	for k := range before {
+		fields[k] = struct{}{}
+	}
+	for k := range after {
+		fields[k] = struct{}{}
+	}
+
+	var fieldNames []string
+	for k := range fields {
+		fieldNames = append(fieldNames, k)
+	}
+	sort.Strings(fieldNames)
+
+	var ret []models.EditFieldChange
+	for _, f := range fieldNames {
+		oldValue, err := json.Marshal(before[f])
+		if err != nil {
+			return nil, fmt.Errorf("encoding %s: %w", f, err)
+		}
+		newValue, err := json.Marshal(after[f])
+		if err != nil {
+			return nil, fmt.Errorf("encoding %s: %w", f, err)
+		}
+
+		if !bytes.Equal(oldValue, newValue) {
+			ret = append(ret, models.EditFieldChange{
+				Field:    f,
+				OldValue: before[f],
+				NewValue: after[f],
+			})
+		}
+	}
+
+	return ret, nil
+}
+
+// takeEditSnapshot returns a snapshot of the object before it is updated.
+// Returns nil if edits made with the provided context are not recorded.
+func takeEditSnapshot(ctx context.Context, id int, snapshot editSnapshotFunc) (editSnapshot, error) {
+	if !models.EditHistoryEnabled(ctx) {
+		return nil, nil
+	}
+
+	return snapshot(ctx, id)
+}
+
+// recordEdit compares the provided snapshot of an object, taken before it
+// was updated, with its current state, and records any changes in the edit
+// history. The edit is attributed to the current user and to the edit
+// source set in the context.
+func recordEdit(ctx context.Context, objectType models.EditObjectType, id int, before editSnapshot, snapshot editSnapshotFunc) error {
+	if before == nil {
+		return nil
+	}
+
+	after, err := snapshot(ctx, id)
+	if err != nil {
+		return fmt.Errorf("getting %s snapshot: %w", objectType, err)
+	}
+
+	changes, err := diffEditSnapshots(before, after)
+	if err != nil {
+		return err
+	}
+
+	if len(changes) == 0 {
+		return nil
+	}
+
+	edit := models.EditHistory{
+		ObjectType: objectType,
+		ObjectID:   id,
+		UserID:     currentUserID(ctx),
+		Source:     models.GetEditSource(ctx),
+		Changes:    changes,
+		CreatedAt:  time.Now(),
+	}
+
+	var r editHistoryRow
+	r.fromEditHistory(edit)
+	if _, err := editHistoryTableMgr.insertID(ctx, r); err != nil {
+		return fmt.Errorf("recording %s edit: %w", objectType, err)
+	}
+
+	return nil
+}
+
+func editID(id *int) interface{} {
+	if id == nil {
+		return nil
+	}
+
+	return strconv.Itoa(*id)
+}
+
+func editIDs(ids []int) []string {
+	sorted := make([]int, len(ids))
+	copy(sorted, ids)
+	sort.Ints(sorted)
+
+	ret := make([]string, len(sorted))
+	for i, id := range sorted {
+		ret[i] = strconv.Itoa(id)
+	}
+
+	return ret
+}
+
+func editDate(d *models.Date) interface{} {
+	if d == nil {
+		return nil
+	}
+
+	return d.String()
+}
+
+func editStrings(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+
+	return s
+}
+
+func editStashIDs(ids []models.StashID) []map[string]interface{} {
+	ret := make([]map[string]interface{}, len(ids))
+	for i, id := range ids {
+		ret[i] = map[string]interface{}{
+			"endpoint": id.Endpoint,
+			"stash_id": id.StashID,
+		}
+	}
+
+	return ret
+}
+
+func editGroupDescriptions(groups []models.GroupIDDescription) []map[string]interface{} {
+	ret := make([]map[string]interface{}, len(groups))
+	for i, g := range groups {
+		ret[i] = map[string]interface{}{
+			"group_id":    strconv.Itoa(g.GroupID),
+			"description": g.Description,
+		}
+	}
+
+	return ret
+}
+
+// returns nil, nil if the scene does not exist
+func (qb *SceneStore) editSnapshot(ctx context.Context, id int) (editSnapshot, error) {
+	s, err := qb.Find(ctx, id)
+	if err != nil || s == nil {
+		return nil, err
+	}
+
+	urls, err := qb.GetURLs(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	galleryIDs, err := qb.GetGalleryIDs(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	performerIDs, err := qb.GetPerformerIDs(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	tagIDs, err := qb.GetTagIDs(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	groups, err := qb.GetGroups(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	stashIDs, err := qb.GetStashIDs(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	groupInputs := make([]map[string]interface{}, len(groups))
+	for i, g := range groups {
+		groupInputs[i] = map[string]interface{}{
+			"group_id":    strconv.Itoa(g.GroupID),
+			"scene_index": g.SceneIndex,
+		}
+	}
+
+	return editSnapshot{
+		"title":         s.Title,
+		"code":          s.Code,
+		"details":       s.Details,
+		"director":      s.Director,
+		"urls":          editStrings(urls),
+		"date":          editDate(s.Date),
+		"organized":     s.Organized,
+		"studio_id":     editID(s.StudioID),
+		"gallery_ids":   editIDs(galleryIDs),
+		"performer_ids": editIDs(performerIDs),
+		"tag_ids":       editIDs(tagIDs),
+		"groups":        groupInputs,
+		"stash_ids":     editStashIDs(stashIDs),
+	}, nil
+}
+
+// returns nil, nil if the image does not exist
+func (qb *ImageStore) editSnapshot(ctx context.Context, id int) (editSnapshot, error) {
+	i, err := qb.Find(ctx, id)
+	if err != nil || i == nil {
+		return nil, err
+	}
+
+	urls, err := qb.GetURLs(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	galleryIDs, err := qb.GetGalleryIDs(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	performerIDs, err := qb.GetPerformerIDs(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	tagIDs, err := qb.GetTagIDs(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return editSnapshot{
+		"title":         i.Title,
+		"code":          i.Code,
+		"urls":          editStrings(urls),
+		"date":          editDate(i.Date),
+		"details":       i.Details,
+		"photographer":  i.Photographer,
+		"organized":     i.Organized,
+		"studio_id":     editID(i.StudioID),
+		"performer_ids": editIDs(performerIDs),
+		"tag_ids":       editIDs(tagIDs),
+		"gallery_ids":   editIDs(galleryIDs),
+	}, nil
+}
+
+// returns nil, nil if the gallery does not exist
+func (qb *GalleryStore) editSnapshot(ctx context.Context, id int) (editSnapshot, error) {
+	g, err := qb.Find(ctx, id)
+	if err != nil || g == nil {
+		return nil, err
+	}
+
+	urls, err := qb.GetURLs(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	sceneIDs, err := qb.GetSceneIDs(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	performerIDs, err := qb.GetPerformerIDs(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	tagIDs, err := qb.GetTagIDs(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return editSnapshot{
+		"title":         g.Title,
+		"code":          g.Code,
+		"urls":          editStrings(urls),
+		"date":          editDate(g.Date),
+		"details":       g.Details,
+		"photographer":  g.Photographer,
+		"organized":     g.Organized,
+		"scene_ids":     editIDs(sceneIDs),
+		"studio_id":     editID(g.StudioID),
+		"tag_ids":       editIDs(tagIDs),
+		"performer_ids": editIDs(performerIDs),
+	}, nil
+}
+
+// returns nil, nil if the performer does not exist
+func (qb *PerformerStore) editSnapshot(ctx context.Context, id int) (editSnapshot, error) {
+	p, err := qb.Find(ctx, id)
+	if err != nil || p == nil {
+		return nil, err
+	}
+
+	aliases, err := qb.GetAliases(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	urls, err := qb.GetURLs(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	tagIDs, err := qb.GetTagIDs(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	stashIDs, err := qb.GetStashIDs(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return editSnapshot{
+		"name":            p.Name,
+		"disambiguation":  p.Disambiguation,
+		"urls":            editStrings(urls),
+		"gender":          p.Gender,
+		"birthdate":       editDate(p.Birthdate),
+		"ethnicity":       p.Ethnicity,
+		"country":         p.Country,
+		"eye_color":       p.EyeColor,
+		"height_cm":       p.Height,
+		"measurements":    p.Measurements,
+		"fake_tits":       p.FakeTits,
+		"penis_length":    p.PenisLength,
+		"circumcised":     p.Circumcised,
+		"career_length":   p.CareerLength,
+		"tattoos":         p.Tattoos,
+		"piercings":       p.Piercings,
+		"alias_list":      editStrings(aliases),
+		"favorite":        p.Favorite,
+		"tag_ids":         editIDs(tagIDs),
+		"stash_ids":       editStashIDs(stashIDs),
+		"details":         p.Details,
+		"death_date":      editDate(p.DeathDate),
+		"hair_color":      p.HairColor,
+		"weight":          p.Weight,
+		"ignore_auto_tag": p.IgnoreAutoTag,
+	}, nil
+}
+
+// returns nil, nil if the studio does not exist
+func (qb *StudioStore) editSnapshot(ctx context.Context, id int) (editSnapshot, error) {
+	s, err := qb.Find(ctx, id)
+	if err != nil || s == nil {
+		return nil, err
+	}
+
+	aliases, err := qb.GetAliases(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	tagIDs, err := qb.GetTagIDs(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	stashIDs, err := qb.GetStashIDs(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return editSnapshot{
+		"name":            s.Name,
+		"url":             s.URL,
+		"parent_id":       editID(s.ParentID),
+		"stash_ids":       editStashIDs(stashIDs),
+		"favorite":        s.Favorite,
+		"details":         s.Details,
+		"aliases":         editStrings(aliases),
+		"tag_ids":         editIDs(tagIDs),
+		"ignore_auto_tag": s.IgnoreAutoTag,
+	}, nil
+}
+
+// returns nil, nil if the tag does not exist
+func (qb *TagStore) editSnapshot(ctx context.Context, id int) (editSnapshot, error) {
+	t, err := qb.Find(ctx, id)
+	if err != nil || t == nil {
+		return nil, err
+	}
+
+	aliases, err := qb.GetAliases(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	parentIDs, err := qb.GetParentIDs(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	childIDs, err := qb.GetChildIDs(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return editSnapshot{
+		"name":            t.Name,
+		"description":     t.Description,
+		"aliases":         editStrings(aliases),
+		"ignore_auto_tag": t.IgnoreAutoTag,
+		"favorite":        t.Favorite,
+		"parent_ids":      editIDs(parentIDs),
+		"child_ids":       editIDs(childIDs),
+	}, nil
+}
+
+// returns nil, nil if the group does not exist
+func (qb *GroupStore) editSnapshot(ctx context.Context, id int) (editSnapshot, error) {
+	g, err := qb.Find(ctx, id)
+	if err != nil || g == nil {
+		return nil, err
+	}
+
+	urls, err := qb.GetURLs(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	tagIDs, err := qb.GetTagIDs(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	containingGroups, err := qb.GetContainingGroupDescriptions(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	subGroups, err := qb.GetSubGroupDescriptions(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return editSnapshot{
+		"name":              g.Name,
+		"aliases":           g.Aliases,
+		"duration":          g.Duration,
+		"date":              editDate(g.Date),
+		"studio_id":         editID(g.StudioID),
+		"director":          g.Director,
+		"synopsis":          g.Synopsis,
+		"urls":              editStrings(urls),
+		"tag_ids":           editIDs(tagIDs),
+		"containing_groups": editGroupDescriptions(containingGroups),
+		"sub_groups":        editGroupDescriptions(subGroups),
+	}, nil
+}
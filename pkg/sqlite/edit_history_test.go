@@ -0,0 +1,187 @@
+//go:build integration
+// +build integration
+
+package sqlite_test
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/session"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEditHistorySceneUpdatePartial(t *testing.T) {
+	withRollbackTxn(func(ctx context.Context) error {
+		qb := db.Scene
+		sceneID := sceneIDs[sceneIdxWithTag]
+
+		original, err := qb.Find(ctx, sceneID)
+		if err != nil {
+			t.Errorf("SceneStore.Find() error = %v", err)
+			return nil
+		}
+
+		u := createTestUser(ctx, t, "user", models.UserRoleEditor)
+		editCtx := session.SetCurrentUser(ctx, u)
+		editCtx = models.WithEditSource(editCtx, models.EditSourceIdentify)
+
+		const newTitle = "new title"
+		newTagID := tagIDs[tagIdxWithImage]
+
+		partial := models.NewScenePartial()
+		partial.Title = models.NewOptionalString(newTitle)
+		partial.TagIDs = &models.UpdateIDs{
+			IDs:  []int{newTagID},
+			Mode: models.RelationshipUpdateModeSet,
+		}
+
+		if _, err := qb.UpdatePartial(editCtx, sceneID, partial); err != nil {
+			t.Errorf("SceneStore.UpdatePartial() error = %v", err)
+			return nil
+		}
+
+		edits, err := db.EditHistory.FindByObject(ctx, models.EditObjectTypeScene, sceneID)
+		if err != nil {
+			t.Errorf("EditHistoryStore.FindByObject() error = %v", err)
+			return nil
+		}
+
+		if !assert.Len(t, edits, 1) {
+			return nil
+		}
+
+		edit := edits[0]
+		assert.Equal(t, models.EditSourceIdentify, edit.Source)
+		assert.Equal(t, &u.ID, edit.UserID)
+
+		// changes are ordered by field name
+		assert.Equal(t, []models.EditFieldChange{
+			{
+				Field:    "tag_ids",
+				OldValue: []interface{}{strconv.Itoa(tagIDs[tagIdxWithScene])},
+				NewValue: []interface{}{strconv.Itoa(newTagID)},
+			},
+			{
+				Field:    "title",
+				OldValue: original.Title,
+				NewValue: newTitle,
+			},
+		}, edit.Changes)
+
+		return nil
+	})
+}
+
+func TestEditHistoryNoChanges(t *testing.T) {
+	withRollbackTxn(func(ctx context.Context) error {
+		qb := db.Performer
+		performerID := performerIDs[performerIdx1WithScene]
+
+		p, err := qb.Find(ctx, performerID)
+		if err != nil {
+			t.Errorf("PerformerStore.Find() error = %v", err)
+			return nil
+		}
+
+		// setting the existing values does not record an edit
+		partial := models.NewPerformerPartial()
+		partial.Name = models.NewOptionalString(p.Name)
+		if _, err := qb.UpdatePartial(ctx, performerID, partial); err != nil {
+			t.Errorf("PerformerStore.UpdatePartial() error = %v", err)
+			return nil
+		}
+
+		edits, err := db.EditHistory.FindByObject(ctx, models.EditObjectTypePerformer, performerID)
+		if err != nil {
+			t.Errorf("EditHistoryStore.FindByObject() error = %v", err)
+			return nil
+		}
+		assert.Len(t, edits, 0)
+
+		// edits without a source are recorded as other
+		partial.Name = models.NewOptionalString("new name")
+		if _, err := qb.UpdatePartial(ctx, performerID, partial); err != nil {
+			t.Errorf("PerformerStore.UpdatePartial() error = %v", err)
+			return nil
+		}
+
+		edits, err = db.EditHistory.FindByObject(ctx, models.EditObjectTypePerformer, performerID)
+		if err != nil {
+			t.Errorf("EditHistoryStore.FindByObject() error = %v", err)
+			return nil
+		}
+
+		if !assert.Len(t, edits, 1) {
+			return nil
+		}
+
+		assert.Equal(t, models.EditSourceOther, edits[0].Source)
+		assert.Nil(t, edits[0].UserID)
+
+		return nil
+	})
+}
+
+func TestEditHistoryDisabled(t *testing.T) {
+	withRollbackTxn(func(ctx context.Context) error {
+		qb := db.Tag
+		tagID := tagIDs[tagIdxWithScene]
+
+		editCtx := models.WithoutEditHistory(ctx)
+
+		partial := models.NewTagPartial()
+		partial.Name = models.NewOptionalString("new name")
+		if _, err := qb.UpdatePartial(editCtx, tagID, partial); err != nil {
+			t.Errorf("TagStore.UpdatePartial() error = %v", err)
+			return nil
+		}
+
+		edits, err := db.EditHistory.FindByObject(ctx, models.EditObjectTypeTag, tagID)
+		if err != nil {
+			t.Errorf("EditHistoryStore.FindByObject() error = %v", err)
+			return nil
+		}
+		assert.Len(t, edits, 0)
+
+		return nil
+	})
+}
+
+func TestEditHistoryUserDestroy(t *testing.T) {
+	withRollbackTxn(func(ctx context.Context) error {
+		tagID := tagIDs[tagIdxWithScene]
+
+		u := createTestUser(ctx, t, "user", models.UserRoleEditor)
+		editCtx := session.SetCurrentUser(ctx, u)
+
+		partial := models.NewTagPartial()
+		partial.Description = models.NewOptionalString("new description")
+		if _, err := db.Tag.UpdatePartial(editCtx, tagID, partial); err != nil {
+			t.Errorf("TagStore.UpdatePartial() error = %v", err)
+			return nil
+		}
+
+		if err := db.User.Destroy(ctx, u.ID); err != nil {
+			t.Errorf("UserStore.Destroy() error = %v", err)
+			return nil
+		}
+
+		// the edit is retained without a user
+		edits, err := db.EditHistory.FindByObject(ctx, models.EditObjectTypeTag, tagID)
+		if err != nil {
+			t.Errorf("EditHistoryStore.FindByObject() error = %v", err)
+			return nil
+		}
+
+		if !assert.Len(t, edits, 1) {
+			return nil
+		}
+
+		assert.Nil(t, edits[0].UserID)
+
+		return nil
+	})
+}
@@ -27,6 +27,11 @@ const (
 	captionCodeColumn     = "language_code"
 	captionFilenameColumn = "filename"
 	captionTypeColumn     = "caption_type"
+
+	videoStreamsTable  = "video_streams"
+	videoChaptersTable = "video_chapters"
+
+	videoFileChaptersProbedColumn = "chapters_probed"
 )
 
 type basicFileRow struct {
@@ -384,6 +389,18 @@ func (qb *FileStore) createVideoFile(ctx context.Context, id models.FileID, f mo
 		return err
 	}
 
+	if f.Streams != nil {
+		if err := qb.videoStreamRepository().replace(ctx, id, f.Streams); err != nil {
+			return err
+		}
+	}
+
+	if f.Chapters != nil {
+		if err := qb.replaceChapters(ctx, id, f.Chapters); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -404,6 +421,20 @@ func (qb *FileStore) updateOrCreateVideoFile(ctx context.Context, id models.File
 		return err
 	}
 
+	// streams and chapters are only replaced if they were set by probing
+	// the file
+	if f.Streams != nil {
+		if err := qb.videoStreamRepository().replace(ctx, id, f.Streams); err != nil {
+			return err
+		}
+	}
+
+	if f.Chapters != nil {
+		if err := qb.replaceChapters(ctx, id, f.Chapters); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -786,55 +817,6 @@ func (qb *FileStore) IsPrimary(ctx context.Context, fileID models.FileID) (bool,
 	return ret > 0, nil
 }
 
-func (qb *FileStore) validateFilter(fileFilter *models.FileFilterType) error {
-	const and = "AND"
-	const or = "OR"
-	const not = "NOT"
-
-	if fileFilter.And != nil {
-		if fileFilter.Or != nil {
-			return illegalFilterCombination(and, or)
-		}
-		if fileFilter.Not != nil {
-			return illegalFilterCombination(and, not)
-		}
-
-		return qb.validateFilter(fileFilter.And)
-	}
-
-	if fileFilter.Or != nil {
-		if fileFilter.Not != nil {
-			return illegalFilterCombination(or, not)
-		}
-
-		return qb.validateFilter(fileFilter.Or)
-	}
-
-	if fileFilter.Not != nil {
-		return qb.validateFilter(fileFilter.Not)
-	}
-
-	return nil
-}
-
-func (qb *FileStore) makeFilter(ctx context.Context, fileFilter *models.FileFilterType) *filterBuilder {
-	query := &filterBuilder{}
-
-	if fileFilter.And != nil {
-		query.and(qb.makeFilter(ctx, fileFilter.And))
-	}
-	if fileFilter.Or != nil {
-		query.or(qb.makeFilter(ctx, fileFilter.Or))
-	}
-	if fileFilter.Not != nil {
-		query.not(qb.makeFilter(ctx, fileFilter.Not))
-	}
-
-	query.handleCriterion(ctx, pathCriterionHandler(fileFilter.Path, "folders.path", "files.basename", nil))
-
-	return query
-}
-
 func (qb *FileStore) Query(ctx context.Context, options models.FileQueryOptions) (*models.FileQueryResult, error) {
 	fileFilter := options.FileFilter
 	findFilter := options.FindFilter
@@ -857,10 +839,9 @@ func (qb *FileStore) Query(ctx context.Context, options models.FileQueryOptions)
 		query.parseQueryString(searchColumns, *q)
 	}
 
-	if err := qb.validateFilter(fileFilter); err != nil {
-		return nil, err
-	}
-	filter := qb.makeFilter(ctx, fileFilter)
+	filter := filterBuilderFromHandler(ctx, &fileFilterHandler{
+		fileFilter: fileFilter,
+	})
 
 	if err := query.addFilter(filter); err != nil {
 		return nil, err
@@ -918,10 +899,13 @@ func (qb *FileStore) queryGroupedFields(ctx context.Context, options models.File
 }
 
 var fileSortOptions = sortOptions{
+	"basename",
 	"created_at",
 	"id",
+	"mod_time",
 	"path",
 	"random",
+	"size",
 	"updated_at",
 }
 
@@ -964,3 +948,71 @@ func (qb *FileStore) GetCaptions(ctx context.Context, fileID models.FileID) ([]*
 func (qb *FileStore) UpdateCaptions(ctx context.Context, fileID models.FileID, captions []*models.VideoCaption) error {
 	return qb.captionRepository().replace(ctx, fileID, captions)
 }
+
+func (qb *FileStore) videoStreamRepository() *videoStreamRepository {
+	return &videoStreamRepository{
+		repository: repository{
+			tableName: videoStreamsTable,
+			idColumn:  fileIDColumn,
+		},
+	}
+}
+
+func (qb *FileStore) GetStreams(ctx context.Context, fileID models.FileID) ([]*models.VideoStream, error) {
+	return qb.videoStreamRepository().get(ctx, fileID)
+}
+
+func (qb *FileStore) videoChapterRepository() *videoChapterRepository {
+	return &videoChapterRepository{
+		repository: repository{
+			tableName: videoChaptersTable,
+			idColumn:  fileIDColumn,
+		},
+	}
+}
+
+// replaceChapters replaces the chapters of the file, and marks its chapters
+// as probed.
+func (qb *FileStore) replaceChapters(ctx context.Context, id models.FileID, chapters []*models.VideoChapter) error {
+	if err := qb.videoChapterRepository().replace(ctx, id, chapters); err != nil {
+		return err
+	}
+
+	q := dialect.Update(videoFileTableMgr.table).Set(goqu.Record{
+		videoFileChaptersProbedColumn: true,
+	}).Where(videoFileTableMgr.byID(id))
+	if _, err := exec(ctx, q); err != nil {
+		return fmt.Errorf("setting chapters probed: %w", err)
+	}
+
+	return nil
+}
+
+// GetChapters returns the chapters stored for the file. Returns nil if the
+// chapters of the file have not been probed, which is the case for files
+// scanned before chapters were stored.
+func (qb *FileStore) GetChapters(ctx context.Context, fileID models.FileID) ([]*models.VideoChapter, error) {
+	q := dialect.Select(videoFileTableMgr.table.Col(videoFileChaptersProbedColumn)).
+		From(videoFileTableMgr.table).
+		Where(videoFileTableMgr.byID(fileID))
+
+	var probed bool
+	if err := querySimple(ctx, q, &probed); err != nil {
+		return nil, err
+	}
+
+	if !probed {
+		return nil, nil
+	}
+
+	ret, err := qb.videoChapterRepository().get(ctx, fileID)
+	if err != nil {
+		return nil, err
+	}
+
+	if ret == nil {
+		ret = []*models.VideoChapter{}
+	}
+
+	return ret, nil
+}
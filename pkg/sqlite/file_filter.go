@@ -0,0 +1,173 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/utils"
+)
+
+type fileFilterHandler struct {
+	fileFilter *models.FileFilterType
+}
+
+func (qb *fileFilterHandler) validate() error {
+	fileFilter := qb.fileFilter
+	if fileFilter == nil {
+		return nil
+	}
+
+	if err := validateFilterCombination(fileFilter.OperatorFilter); err != nil {
+		return err
+	}
+
+	if subFilter := fileFilter.SubFilter(); subFilter != nil {
+		sqb := &fileFilterHandler{fileFilter: subFilter}
+		if err := sqb.validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (qb *fileFilterHandler) handle(ctx context.Context, f *filterBuilder) {
+	fileFilter := qb.fileFilter
+	if fileFilter == nil {
+		return
+	}
+
+	if err := qb.validate(); err != nil {
+		f.setError(err)
+		return
+	}
+
+	sf := fileFilter.SubFilter()
+	if sf != nil {
+		sub := &fileFilterHandler{sf}
+		handleSubFilter(ctx, sub, f, fileFilter.OperatorFilter)
+	}
+
+	f.handleCriterion(ctx, qb.criterionHandler())
+}
+
+func (qb *fileFilterHandler) criterionHandler() criterionHandler {
+	fileFilter := qb.fileFilter
+	return compoundHandler{
+		pathCriterionHandler(fileFilter.Path, "folders.path", "files.basename", nil),
+		stringCriterionHandler(fileFilter.Basename, "files.basename"),
+		qb.parentFolderCriterionHandler(fileFilter.ParentFolder),
+		qb.zipFileCriterionHandler(fileFilter.ZipFile),
+		intCriterionHandler(fileFilter.Size, "files.size", nil),
+		&timestampCriterionHandler{fileFilter.ModTime, "files.mod_time", nil},
+		qb.fingerprintsCriterionHandler(fileFilter.Fingerprints),
+		qb.codecCriterionHandler(fileFilter.VideoCodec, "video_files.video_codec"),
+		qb.codecCriterionHandler(fileFilter.AudioCodec, "video_files.audio_codec"),
+		resolutionCriterionHandler(fileFilter.Resolution, "COALESCE(video_files.height, image_files.height)", "COALESCE(video_files.width, image_files.width)", qb.addMediaFilesTables),
+		qb.orphanedCriterionHandler(fileFilter.Orphaned),
+		&timestampCriterionHandler{fileFilter.CreatedAt, "files.created_at", nil},
+		&timestampCriterionHandler{fileFilter.UpdatedAt, "files.updated_at", nil},
+	}
+}
+
+func (qb *fileFilterHandler) addVideoFilesTable(f *filterBuilder) {
+	f.addLeftJoin(videoFileTable, "", "video_files.file_id = files.id")
+}
+
+func (qb *fileFilterHandler) addMediaFilesTables(f *filterBuilder) {
+	qb.addVideoFilesTable(f)
+	f.addLeftJoin(imageFileTable, "", "image_files.file_id = files.id")
+}
+
+func (qb *fileFilterHandler) parentFolderCriterionHandler(parentFolder *models.MultiCriterionInput) criterionHandlerFunc {
+	// folders table is always joined in the file query
+	h := multiCriterionHandlerBuilder{
+		primaryTable: fileTable,
+		foreignTable: folderTable,
+		joinTable:    "",
+		primaryFK:    fileIDColumn,
+		foreignFK:    "parent_folder_id",
+	}
+	return h.handler(parentFolder)
+}
+
+func (qb *fileFilterHandler) zipFileCriterionHandler(zipFile *models.MultiCriterionInput) criterionHandlerFunc {
+	addJoinsFunc := func(f *filterBuilder) {
+		f.addLeftJoin(fileTable, "zip_files", "zip_files.id = files.zip_file_id")
+	}
+	h := multiCriterionHandlerBuilder{
+		primaryTable: fileTable,
+		foreignTable: "zip_files",
+		joinTable:    "",
+		primaryFK:    fileIDColumn,
+		foreignFK:    "zip_file_id",
+		addJoinsFunc: addJoinsFunc,
+	}
+	return h.handler(zipFile)
+}
+
+func (qb *fileFilterHandler) codecCriterionHandler(codec *models.StringCriterionInput, codecColumn string) criterionHandlerFunc {
+	return func(ctx context.Context, f *filterBuilder) {
+		if codec != nil {
+			qb.addVideoFilesTable(f)
+			stringCriterionHandler(codec, codecColumn)(ctx, f)
+		}
+	}
+}
+
+// fingerprintsCriterionHandler filters files that have all of the provided
+// fingerprints. Phash fingerprints may be matched within a distance.
+func (qb *fileFilterHandler) fingerprintsCriterionHandler(fingerprints []*models.FingerprintFilterInput) criterionHandlerFunc {
+	return func(ctx context.Context, f *filterBuilder) {
+		for _, fp := range fingerprints {
+			if fp == nil {
+				continue
+			}
+
+			fpType := fp.Type
+			if !strings.EqualFold(fpType, models.FingerprintTypePhash) {
+				f.addWhere(fmt.Sprintf("files.id IN (SELECT file_id FROM %s WHERE type = ? AND fingerprint = ?)", fingerprintTable), fpType, fp.Value)
+				continue
+			}
+
+			value, err := utils.StringToPhash(fp.Value)
+			if err != nil {
+				f.setError(fmt.Errorf("invalid phash value %q: %w", fp.Value, err))
+				return
+			}
+
+			distance := 0
+			if fp.Distance != nil {
+				distance = *fp.Distance
+			}
+
+			if distance > 0 {
+				// typeof check is needed to avoid a type mismatch
+				f.addWhere(fmt.Sprintf("files.id IN (SELECT file_id FROM %s WHERE type = ? AND typeof(fingerprint) = 'integer' AND phash_distance(fingerprint, ?) < ?)", fingerprintTable), fpType, value, distance)
+			} else {
+				f.addWhere(fmt.Sprintf("files.id IN (SELECT file_id FROM %s WHERE type = ? AND fingerprint = ?)", fingerprintTable), fpType, value)
+			}
+		}
+	}
+}
+
+// orphanedCriterionHandler filters files that are not associated with any
+// scene, image or gallery.
+func (qb *fileFilterHandler) orphanedCriterionHandler(orphaned *bool) criterionHandlerFunc {
+	return func(ctx context.Context, f *filterBuilder) {
+		if orphaned == nil {
+			return
+		}
+
+		clause := fmt.Sprintf("EXISTS (SELECT 1 FROM %s WHERE file_id = files.id) OR EXISTS (SELECT 1 FROM %s WHERE file_id = files.id) OR EXISTS (SELECT 1 FROM %s WHERE file_id = files.id)",
+			scenesFilesTable, imagesFilesTable, galleriesFilesTable)
+
+		if *orphaned {
+			f.addWhere("NOT (" + clause + ")")
+		} else {
+			f.addWhere("(" + clause + ")")
+		}
+	}
+}
@@ -662,3 +662,286 @@ func TestFileStore_IsPrimary(t *testing.T) {
 		})
 	}
 }
+
+func TestFileStore_Streams(t *testing.T) {
+	streams := []*models.VideoStream{
+		{Index: 0, Type: models.VideoStreamTypeVideo, Codec: "h264", Default: true},
+		{Index: 1, Type: models.VideoStreamTypeAudio, Codec: "aac", Language: "eng", Title: "Stereo", Default: true},
+		{Index: 2, Type: models.VideoStreamTypeAudio, Codec: "ac3", Language: "fre"},
+		{Index: 3, Type: models.VideoStreamTypeSubtitle, Codec: "subrip", Language: "eng"},
+	}
+
+	runWithRollbackTxn(t, "streams", func(t *testing.T, ctx context.Context) {
+		assert := assert.New(t)
+		qb := db.File
+
+		f := &models.VideoFile{
+			BaseFile: &models.BaseFile{
+				Path:           getFilePath(folderIdxWithFiles, "streams"),
+				ParentFolderID: folderIDs[folderIdxWithFiles],
+				Basename:       "streams",
+			},
+			Streams: streams,
+		}
+
+		if err := qb.Create(ctx, f); err != nil {
+			t.Errorf("FileStore.Create() error = %v", err)
+			return
+		}
+
+		got, err := qb.GetStreams(ctx, f.ID)
+		if err != nil {
+			t.Errorf("FileStore.GetStreams() error = %v", err)
+			return
+		}
+		assert.Equal(streams, got)
+
+		// streams are not replaced if not set
+		f.Streams = nil
+		if err := qb.Update(ctx, f); err != nil {
+			t.Errorf("FileStore.Update() error = %v", err)
+			return
+		}
+
+		got, err = qb.GetStreams(ctx, f.ID)
+		if err != nil {
+			t.Errorf("FileStore.GetStreams() error = %v", err)
+			return
+		}
+		assert.Equal(streams, got)
+
+		f.Streams = streams[:2]
+		if err := qb.Update(ctx, f); err != nil {
+			t.Errorf("FileStore.Update() error = %v", err)
+			return
+		}
+
+		got, err = qb.GetStreams(ctx, f.ID)
+		if err != nil {
+			t.Errorf("FileStore.GetStreams() error = %v", err)
+			return
+		}
+		assert.Equal(streams[:2], got)
+	})
+}
+
+func TestFileStore_Chapters(t *testing.T) {
+	chapters := []*models.VideoChapter{
+		{Title: "Intro", StartSeconds: 0, EndSeconds: 10.5},
+		{StartSeconds: 10.5},
+	}
+
+	runWithRollbackTxn(t, "chapters", func(t *testing.T, ctx context.Context) {
+		assert := assert.New(t)
+		qb := db.File
+
+		f := &models.VideoFile{
+			BaseFile: &models.BaseFile{
+				Path:           getFilePath(folderIdxWithFiles, "chapters"),
+				ParentFolderID: folderIDs[folderIdxWithFiles],
+				Basename:       "chapters",
+			},
+		}
+
+		if err := qb.Create(ctx, f); err != nil {
+			t.Errorf("FileStore.Create() error = %v", err)
+			return
+		}
+
+		// chapters of files that were not probed are nil
+		got, err := qb.GetChapters(ctx, f.ID)
+		if err != nil {
+			t.Errorf("FileStore.GetChapters() error = %v", err)
+			return
+		}
+		assert.Nil(got)
+
+		f.Chapters = chapters
+		if err := qb.Update(ctx, f); err != nil {
+			t.Errorf("FileStore.Update() error = %v", err)
+			return
+		}
+
+		got, err = qb.GetChapters(ctx, f.ID)
+		if err != nil {
+			t.Errorf("FileStore.GetChapters() error = %v", err)
+			return
+		}
+		assert.Equal(chapters, got)
+
+		// chapters are not replaced if not set
+		f.Chapters = nil
+		if err := qb.Update(ctx, f); err != nil {
+			t.Errorf("FileStore.Update() error = %v", err)
+			return
+		}
+
+		got, err = qb.GetChapters(ctx, f.ID)
+		if err != nil {
+			t.Errorf("FileStore.GetChapters() error = %v", err)
+			return
+		}
+		assert.Equal(chapters, got)
+
+		f.Chapters = []*models.VideoChapter{}
+		if err := qb.Update(ctx, f); err != nil {
+			t.Errorf("FileStore.Update() error = %v", err)
+			return
+		}
+
+		got, err = qb.GetChapters(ctx, f.ID)
+		if err != nil {
+			t.Errorf("FileStore.GetChapters() error = %v", err)
+			return
+		}
+		assert.NotNil(got)
+		assert.Len(got, 0)
+	})
+}
+
+func TestFileStore_Query(t *testing.T) {
+	tests := []struct {
+		name       string
+		filter     *models.FileFilterType
+		includeIDs []models.FileID
+		excludeIDs []models.FileID
+		wantErr    bool
+	}{
+		{
+			"basename",
+			&models.FileFilterType{
+				Basename: &models.StringCriterionInput{
+					Value:    getFileBaseName(fileIdxZip),
+					Modifier: models.CriterionModifierEquals,
+				},
+			},
+			[]models.FileID{fileIDs[fileIdxZip]},
+			[]models.FileID{fileIDs[fileIdxInZip]},
+			false,
+		},
+		{
+			"parent folder",
+			&models.FileFilterType{
+				ParentFolder: &models.MultiCriterionInput{
+					Value:    []string{folderIDs[folderIdxInZip].String()},
+					Modifier: models.CriterionModifierIncludes,
+				},
+			},
+			[]models.FileID{fileIDs[fileIdxInZip]},
+			[]models.FileID{fileIDs[fileIdxZip]},
+			false,
+		},
+		{
+			"zip file",
+			&models.FileFilterType{
+				ZipFile: &models.MultiCriterionInput{
+					Value:    []string{fileIDs[fileIdxZip].String()},
+					Modifier: models.CriterionModifierIncludes,
+				},
+			},
+			[]models.FileID{fileIDs[fileIdxInZip]},
+			[]models.FileID{fileIDs[fileIdxZip]},
+			false,
+		},
+		{
+			"size",
+			&models.FileFilterType{
+				Size: &models.IntCriterionInput{
+					Value:    int(getFileSize(fileIdxInZip)),
+					Modifier: models.CriterionModifierEquals,
+				},
+			},
+			[]models.FileID{fileIDs[fileIdxInZip]},
+			[]models.FileID{fileIDs[fileIdxZip]},
+			false,
+		},
+		{
+			"fingerprint",
+			&models.FileFilterType{
+				Fingerprints: []*models.FingerprintFilterInput{
+					{
+						Type:  "MD5",
+						Value: getPrefixedStringValue("file", fileIdxZip, "md5"),
+					},
+				},
+			},
+			[]models.FileID{fileIDs[fileIdxZip]},
+			[]models.FileID{fileIDs[fileIdxInZip]},
+			false,
+		},
+		{
+			"video codec",
+			&models.FileFilterType{
+				VideoCodec: &models.StringCriterionInput{
+					Value:    getFileStringValue(fileIdxStartVideoFiles, "videoCodec"),
+					Modifier: models.CriterionModifierEquals,
+				},
+			},
+			[]models.FileID{fileIDs[fileIdxStartVideoFiles]},
+			[]models.FileID{fileIDs[fileIdxZip], fileIDs[fileIdxStartImageFiles]},
+			false,
+		},
+		{
+			"orphaned",
+			&models.FileFilterType{
+				Orphaned: &[]bool{true}[0],
+			},
+			[]models.FileID{fileIDs[fileIdxZip]},
+			[]models.FileID{sceneFileIDs[sceneIdx1WithPerformer], imageFileIDs[imageIdx1WithGallery], galleryFileIDs[galleryIdx1WithImage]},
+			false,
+		},
+		{
+			"not orphaned",
+			&models.FileFilterType{
+				Orphaned: &[]bool{false}[0],
+			},
+			[]models.FileID{sceneFileIDs[sceneIdx1WithPerformer], imageFileIDs[imageIdx1WithGallery], galleryFileIDs[galleryIdx1WithImage]},
+			[]models.FileID{fileIDs[fileIdxZip]},
+			false,
+		},
+		{
+			"invalid combination",
+			&models.FileFilterType{
+				OperatorFilter: models.OperatorFilter[models.FileFilterType]{
+					And: &models.FileFilterType{},
+					Or:  &models.FileFilterType{},
+				},
+			},
+			nil,
+			nil,
+			true,
+		},
+	}
+
+	qb := db.File
+
+	for _, tt := range tests {
+		runWithRollbackTxn(t, tt.name, func(t *testing.T, ctx context.Context) {
+			assert := assert.New(t)
+			perPage := -1
+			result, err := qb.Query(ctx, models.FileQueryOptions{
+				QueryOptions: models.QueryOptions{
+					FindFilter: &models.FindFilterType{
+						PerPage: &perPage,
+					},
+				},
+				FileFilter: tt.filter,
+			})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("FileStore.Query() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if err != nil {
+				return
+			}
+
+			for _, id := range tt.includeIDs {
+				assert.Contains(result.IDs, id)
+			}
+			for _, id := range tt.excludeIDs {
+				assert.NotContains(result.IDs, id)
+			}
+		})
+	}
+}
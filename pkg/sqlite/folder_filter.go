@@ -0,0 +1,114 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+type folderFilterHandler struct {
+	folderFilter *models.FolderFilterType
+}
+
+func (qb *folderFilterHandler) validate() error {
+	folderFilter := qb.folderFilter
+	if folderFilter == nil {
+		return nil
+	}
+
+	if err := validateFilterCombination(folderFilter.OperatorFilter); err != nil {
+		return err
+	}
+
+	if subFilter := folderFilter.SubFilter(); subFilter != nil {
+		sqb := &folderFilterHandler{folderFilter: subFilter}
+		if err := sqb.validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (qb *folderFilterHandler) handle(ctx context.Context, f *filterBuilder) {
+	folderFilter := qb.folderFilter
+	if folderFilter == nil {
+		return
+	}
+
+	if err := qb.validate(); err != nil {
+		f.setError(err)
+		return
+	}
+
+	sf := folderFilter.SubFilter()
+	if sf != nil {
+		sub := &folderFilterHandler{sf}
+		handleSubFilter(ctx, sub, f, folderFilter.OperatorFilter)
+	}
+
+	f.handleCriterion(ctx, qb.criterionHandler())
+}
+
+func (qb *folderFilterHandler) criterionHandler() criterionHandler {
+	folderFilter := qb.folderFilter
+	return compoundHandler{
+		stringCriterionHandler(folderFilter.Path, "folders.path"),
+		qb.parentFolderCriterionHandler(folderFilter.ParentFolder),
+		qb.zipFileCriterionHandler(folderFilter.ZipFile),
+		&timestampCriterionHandler{folderFilter.ModTime, "folders.mod_time", nil},
+		qb.orphanedCriterionHandler(folderFilter.Orphaned),
+		&timestampCriterionHandler{folderFilter.CreatedAt, "folders.created_at", nil},
+		&timestampCriterionHandler{folderFilter.UpdatedAt, "folders.updated_at", nil},
+	}
+}
+
+func (qb *folderFilterHandler) parentFolderCriterionHandler(parentFolder *models.MultiCriterionInput) criterionHandlerFunc {
+	addJoinsFunc := func(f *filterBuilder) {
+		f.addLeftJoin(folderTable, "parent_folders", "parent_folders.id = folders.parent_folder_id")
+	}
+	h := multiCriterionHandlerBuilder{
+		primaryTable: folderTable,
+		foreignTable: "parent_folders",
+		joinTable:    "",
+		primaryFK:    "folder_id",
+		foreignFK:    "parent_folder_id",
+		addJoinsFunc: addJoinsFunc,
+	}
+	return h.handler(parentFolder)
+}
+
+func (qb *folderFilterHandler) zipFileCriterionHandler(zipFile *models.MultiCriterionInput) criterionHandlerFunc {
+	addJoinsFunc := func(f *filterBuilder) {
+		f.addLeftJoin(fileTable, "zip_files", "zip_files.id = folders.zip_file_id")
+	}
+	h := multiCriterionHandlerBuilder{
+		primaryTable: folderTable,
+		foreignTable: "zip_files",
+		joinTable:    "",
+		primaryFK:    "folder_id",
+		foreignFK:    "zip_file_id",
+		addJoinsFunc: addJoinsFunc,
+	}
+	return h.handler(zipFile)
+}
+
+// orphanedCriterionHandler filters folders that contain no files or
+// sub-folders, and are not associated with a gallery.
+func (qb *folderFilterHandler) orphanedCriterionHandler(orphaned *bool) criterionHandlerFunc {
+	return func(ctx context.Context, f *filterBuilder) {
+		if orphaned == nil {
+			return
+		}
+
+		clause := fmt.Sprintf("EXISTS (SELECT 1 FROM %s WHERE parent_folder_id = folders.id) OR EXISTS (SELECT 1 FROM %s AS sub_folders WHERE sub_folders.parent_folder_id = folders.id) OR EXISTS (SELECT 1 FROM %s WHERE folder_id = folders.id)",
+			fileTable, folderTable, galleryTable)
+
+		if *orphaned {
+			f.addWhere("NOT (" + clause + ")")
+		} else {
+			f.addWhere("(" + clause + ")")
+		}
+	}
+}
@@ -239,3 +239,79 @@ func Test_FolderStore_FindByPath(t *testing.T) {
 		})
 	}
 }
+
+func TestFolderStore_Query(t *testing.T) {
+	tests := []struct {
+		name       string
+		filter     *models.FolderFilterType
+		includeIDs []models.FolderID
+		excludeIDs []models.FolderID
+		wantErr    bool
+	}{
+		{
+			"path",
+			&models.FolderFilterType{
+				Path: &models.StringCriterionInput{
+					Value:    folderPaths[folderIdxWithFiles],
+					Modifier: models.CriterionModifierEquals,
+				},
+			},
+			[]models.FolderID{folderIDs[folderIdxWithFiles]},
+			[]models.FolderID{folderIDs[folderIdxInZip]},
+			false,
+		},
+		{
+			"parent folder",
+			&models.FolderFilterType{
+				ParentFolder: &models.MultiCriterionInput{
+					Value:    []string{folderIDs[folderIdxWithSubFolder].String()},
+					Modifier: models.CriterionModifierIncludes,
+				},
+			},
+			[]models.FolderID{folderIDs[folderIdxWithParentFolder]},
+			[]models.FolderID{folderIDs[folderIdxWithSubFolder]},
+			false,
+		},
+		{
+			"orphaned",
+			&models.FolderFilterType{
+				Orphaned: &[]bool{true}[0],
+			},
+			[]models.FolderID{folderIDs[folderIdxWithParentFolder]},
+			[]models.FolderID{folderIDs[folderIdxWithSubFolder], folderIDs[folderIdxWithFiles]},
+			false,
+		},
+	}
+
+	qb := db.Folder
+
+	for _, tt := range tests {
+		runWithRollbackTxn(t, tt.name, func(t *testing.T, ctx context.Context) {
+			assert := assert.New(t)
+			perPage := -1
+			result, err := qb.Query(ctx, models.FolderQueryOptions{
+				QueryOptions: models.QueryOptions{
+					FindFilter: &models.FindFilterType{
+						PerPage: &perPage,
+					},
+				},
+				FolderFilter: tt.filter,
+			})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("FolderStore.Query() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if err != nil {
+				return
+			}
+
+			for _, id := range tt.includeIDs {
+				assert.Contains(result.IDs, id)
+			}
+			for _, id := range tt.excludeIDs {
+				assert.NotContains(result.IDs, id)
+			}
+		})
+	}
+}
@@ -27,6 +27,8 @@ const (
 	galleryIDColumn          = "gallery_id"
 	galleriesURLsTable       = "gallery_urls"
 	galleriesURLColumn       = "url"
+
+	galleryCustomFieldsTable = "gallery_custom_fields"
 )
 
 type galleryRow struct {
@@ -181,6 +183,8 @@ var (
 )
 
 type GalleryStore struct {
+	customFieldsStore
+
 	tableMgr *table
 
 	fileStore   *FileStore
@@ -189,6 +193,10 @@ type GalleryStore struct {
 
 func NewGalleryStore(fileStore *FileStore, folderStore *FolderStore) *GalleryStore {
 	return &GalleryStore{
+		customFieldsStore: customFieldsStore{
+			tableMgr: galleryCustomFieldsTableMgr,
+		},
+
 		tableMgr:    galleryTableMgr,
 		fileStore:   fileStore,
 		folderStore: folderStore,
@@ -233,11 +241,20 @@ func (qb *GalleryStore) Create(ctx context.Context, newObject *models.Gallery, f
 	var r galleryRow
 	r.fromGallery(*newObject)
 
+	if currentUserID(ctx) != nil {
+		// rating is stored for the current user
+		r.Rating = null.Int{}
+	}
+
 	id, err := qb.tableMgr.insertID(ctx, r)
 	if err != nil {
 		return err
 	}
 
+	if _, err := setUserRating(ctx, galleryRatingsTableMgr, id, newObject.Rating); err != nil {
+		return err
+	}
+
 	if len(fileIDs) > 0 {
 		const firstPrimary = true
 		if err := galleriesFilesTableMgr.insertJoins(ctx, id, firstPrimary, fileIDs); err != nil {
@@ -278,13 +295,29 @@ func (qb *GalleryStore) Create(ctx context.Context, newObject *models.Gallery, f
 }
 
 func (qb *GalleryStore) Update(ctx context.Context, updatedObject *models.Gallery) error {
+	editBefore, err := takeEditSnapshot(ctx, updatedObject.ID, qb.editSnapshot)
+	if err != nil {
+		return err
+	}
+
 	var r galleryRow
 	r.fromGallery(*updatedObject)
 
+	if currentUserID(ctx) != nil {
+		// retain the shared rating
+		if err := getSharedValue(ctx, qb.tableMgr, updatedObject.ID, userRatingColumn, &r.Rating); err != nil {
+			return err
+		}
+	}
+
 	if err := qb.tableMgr.updateByID(ctx, updatedObject.ID, r); err != nil {
 		return err
 	}
 
+	if _, err := setUserRating(ctx, galleryRatingsTableMgr, updatedObject.ID, updatedObject.Rating); err != nil {
+		return err
+	}
+
 	if updatedObject.URLs.Loaded() {
 		if err := galleriesURLsTableMgr.replaceJoins(ctx, updatedObject.ID, updatedObject.URLs.List()); err != nil {
 			return err
@@ -317,10 +350,19 @@ func (qb *GalleryStore) Update(ctx context.Context, updatedObject *models.Galler
 		}
 	}
 
+	if err := recordEdit(ctx, models.EditObjectTypeGallery, updatedObject.ID, editBefore, qb.editSnapshot); err != nil {
+		return err
+	}
+
 	return nil
 }
 
 func (qb *GalleryStore) UpdatePartial(ctx context.Context, id int, partial models.GalleryPartial) (*models.Gallery, error) {
+	editBefore, err := takeEditSnapshot(ctx, id, qb.editSnapshot)
+	if err != nil {
+		return nil, err
+	}
+
 	r := galleryRowRecord{
 		updateRecord{
 			Record: make(exp.Record),
@@ -329,6 +371,10 @@ func (qb *GalleryStore) UpdatePartial(ctx context.Context, id int, partial model
 
 	r.fromPartial(partial)
 
+	if err := setUserRatingPartial(ctx, galleryRatingsTableMgr, id, partial.Rating, &r.updateRecord); err != nil {
+		return nil, err
+	}
+
 	if len(r.Record) > 0 {
 		if err := qb.tableMgr.updateByID(ctx, id, r.Record); err != nil {
 			return nil, err
@@ -362,6 +408,10 @@ func (qb *GalleryStore) UpdatePartial(ctx context.Context, id int, partial model
 		}
 	}
 
+	if err := recordEdit(ctx, models.EditObjectTypeGallery, id, editBefore, qb.editSnapshot); err != nil {
+		return nil, err
+	}
+
 	return qb.find(ctx, id)
 }
 
@@ -491,9 +541,33 @@ func (qb *GalleryStore) getMany(ctx context.Context, q *goqu.SelectDataset) ([]*
 		return nil, err
 	}
 
+	if err := qb.applyUserRatings(ctx, ret); err != nil {
+		return nil, err
+	}
+
 	return ret, nil
 }
 
+// applyUserRatings replaces the ratings of the provided galleries with those of
+// the current user.
+func (qb *GalleryStore) applyUserRatings(ctx context.Context, galleries []*models.Gallery) error {
+	ids := make([]int, len(galleries))
+	for i, o := range galleries {
+		ids[i] = o.ID
+	}
+
+	ratings, err := getUserRatings(ctx, galleryRatingsTableMgr, ids)
+	if err != nil || ratings == nil {
+		return err
+	}
+
+	for _, o := range galleries {
+		o.Rating = userRating(ratings, o.ID)
+	}
+
+	return nil
+}
+
 func (qb *GalleryStore) FindByFileID(ctx context.Context, fileID models.FileID) ([]*models.Gallery, error) {
 	sq := dialect.From(galleriesFilesJoinTable).Select(galleriesFilesJoinTable.Col(galleryIDColumn)).Where(
 		galleriesFilesJoinTable.Col(fileIDColumn).Eq(fileID),
@@ -732,7 +806,7 @@ func (qb *GalleryStore) makeQuery(ctx context.Context, galleryFilter *models.Gal
 		return nil, err
 	}
 
-	if err := qb.setGallerySort(&query, findFilter); err != nil {
+	if err := qb.setGallerySort(ctx, &query, findFilter); err != nil {
 		return nil, err
 	}
 	query.sortAndPagination += getPagination(findFilter)
@@ -784,7 +858,7 @@ var gallerySortOptions = sortOptions{
 	"updated_at",
 }
 
-func (qb *GalleryStore) setGallerySort(query *queryBuilder, findFilter *models.FindFilterType) error {
+func (qb *GalleryStore) setGallerySort(ctx context.Context, query *queryBuilder, findFilter *models.FindFilterType) error {
 	if findFilter == nil || findFilter.Sort == nil || *findFilter.Sort == "" {
 		return nil
 	}
@@ -846,6 +920,8 @@ func (qb *GalleryStore) setGallerySort(query *queryBuilder, findFilter *models.F
 		addFileTable()
 		addFolderTable()
 		query.sortAndPagination += " ORDER BY COALESCE(galleries.title, files.basename, basename(COALESCE(folders.path, ''))) COLLATE NATURAL_CI " + direction + ", file_folder.path COLLATE NATURAL_CI " + direction
+	case "rating":
+		query.sortAndPagination += getRatingSort(ctx, galleryRatingsTableMgr, galleryTable, direction)
 	default:
 		query.sortAndPagination += getSort(sort, direction, "galleries")
 	}
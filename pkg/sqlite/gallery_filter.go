@@ -85,7 +85,7 @@ func (qb *galleryFilterHandler) criterionHandler() criterionHandler {
 
 		qb.pathCriterionHandler(filter.Path),
 		qb.fileCountCriterionHandler(filter.FileCount),
-		intCriterionHandler(filter.Rating100, "galleries.rating", nil),
+		ratingCriterionHandler(filter.Rating100, galleryRatingsTableMgr, galleryTable),
 		qb.urlsCriterionHandler(filter.URL),
 		boolCriterionHandler(filter.Organized, "galleries.organized", nil),
 		qb.missingCriterionHandler(filter.IsMissing),
@@ -104,6 +104,11 @@ func (qb *galleryFilterHandler) criterionHandler() criterionHandler {
 		&dateCriterionHandler{filter.Date, "galleries.date", nil},
 		&timestampCriterionHandler{filter.CreatedAt, "galleries.created_at", nil},
 		&timestampCriterionHandler{filter.UpdatedAt, "galleries.updated_at", nil},
+		&customFieldsFilterHandler{
+			criteria:    filter.CustomFields,
+			tableMgr:    galleryCustomFieldsTableMgr,
+			parentIDCol: "galleries.id",
+		},
 
 		&relatedFilterHandler{
 			relatedIDCol:   "scenes_galleries.scene_id",
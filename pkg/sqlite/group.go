@@ -29,6 +29,8 @@ const (
 	groupURLColumn = "url"
 
 	groupRelationsTable = "groups_relations"
+
+	groupCustomFieldsTable = "group_custom_fields"
 )
 
 type groupRow struct {
@@ -131,6 +133,7 @@ type GroupStore struct {
 	blobJoinQueryBuilder
 	tagRelationshipStore
 	groupRelationshipStore
+	customFieldsStore
 
 	tableMgr *table
 }
@@ -149,6 +152,9 @@ func NewGroupStore(blobStore *BlobStore) *GroupStore {
 		groupRelationshipStore: groupRelationshipStore{
 			table: groupRelationshipTableMgr,
 		},
+		customFieldsStore: customFieldsStore{
+			tableMgr: groupCustomFieldsTableMgr,
+		},
 
 		tableMgr: groupTableMgr,
 	}
@@ -166,11 +172,20 @@ func (qb *GroupStore) Create(ctx context.Context, newObject *models.Group) error
 	var r groupRow
 	r.fromGroup(*newObject)
 
+	if currentUserID(ctx) != nil {
+		// rating is stored for the current user
+		r.Rating = null.Int{}
+	}
+
 	id, err := qb.tableMgr.insertID(ctx, r)
 	if err != nil {
 		return err
 	}
 
+	if _, err := setUserRating(ctx, groupRatingsTableMgr, id, newObject.Rating); err != nil {
+		return err
+	}
+
 	if newObject.URLs.Loaded() {
 		const startPos = 0
 		if err := groupsURLsTableMgr.insertJoins(ctx, id, startPos, newObject.URLs.List()); err != nil {
@@ -201,6 +216,11 @@ func (qb *GroupStore) Create(ctx context.Context, newObject *models.Group) error
 }
 
 func (qb *GroupStore) UpdatePartial(ctx context.Context, id int, partial models.GroupPartial) (*models.Group, error) {
+	editBefore, err := takeEditSnapshot(ctx, id, qb.editSnapshot)
+	if err != nil {
+		return nil, err
+	}
+
 	r := groupRowRecord{
 		updateRecord{
 			Record: make(exp.Record),
@@ -209,6 +229,10 @@ func (qb *GroupStore) UpdatePartial(ctx context.Context, id int, partial models.
 
 	r.fromPartial(partial)
 
+	if err := setUserRatingPartial(ctx, groupRatingsTableMgr, id, partial.Rating, &r.updateRecord); err != nil {
+		return nil, err
+	}
+
 	if len(r.Record) > 0 {
 		if err := qb.tableMgr.updateByID(ctx, id, r.Record); err != nil {
 			return nil, err
@@ -233,17 +257,37 @@ func (qb *GroupStore) UpdatePartial(ctx context.Context, id int, partial models.
 		return nil, err
 	}
 
+	if err := recordEdit(ctx, models.EditObjectTypeGroup, id, editBefore, qb.editSnapshot); err != nil {
+		return nil, err
+	}
+
 	return qb.find(ctx, id)
 }
 
 func (qb *GroupStore) Update(ctx context.Context, updatedObject *models.Group) error {
+	editBefore, err := takeEditSnapshot(ctx, updatedObject.ID, qb.editSnapshot)
+	if err != nil {
+		return err
+	}
+
 	var r groupRow
 	r.fromGroup(*updatedObject)
 
+	if currentUserID(ctx) != nil {
+		// retain the shared rating
+		if err := getSharedValue(ctx, qb.tableMgr, updatedObject.ID, userRatingColumn, &r.Rating); err != nil {
+			return err
+		}
+	}
+
 	if err := qb.tableMgr.updateByID(ctx, updatedObject.ID, r); err != nil {
 		return err
 	}
 
+	if _, err := setUserRating(ctx, groupRatingsTableMgr, updatedObject.ID, updatedObject.Rating); err != nil {
+		return err
+	}
+
 	if updatedObject.URLs.Loaded() {
 		if err := groupsURLsTableMgr.replaceJoins(ctx, updatedObject.ID, updatedObject.URLs.List()); err != nil {
 			return err
@@ -262,6 +306,10 @@ func (qb *GroupStore) Update(ctx context.Context, updatedObject *models.Group) e
 		return err
 	}
 
+	if err := recordEdit(ctx, models.EditObjectTypeGroup, updatedObject.ID, editBefore, qb.editSnapshot); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -356,9 +404,33 @@ func (qb *GroupStore) getMany(ctx context.Context, q *goqu.SelectDataset) ([]*mo
 		return nil, err
 	}
 
+	if err := qb.applyUserRatings(ctx, ret); err != nil {
+		return nil, err
+	}
+
 	return ret, nil
 }
 
+// applyUserRatings replaces the ratings of the provided groups with those of
+// the current user.
+func (qb *GroupStore) applyUserRatings(ctx context.Context, groups []*models.Group) error {
+	ids := make([]int, len(groups))
+	for i, o := range groups {
+		ids[i] = o.ID
+	}
+
+	ratings, err := getUserRatings(ctx, groupRatingsTableMgr, ids)
+	if err != nil || ratings == nil {
+		return err
+	}
+
+	for _, o := range groups {
+		o.Rating = userRating(ratings, o.ID)
+	}
+
+	return nil
+}
+
 func (qb *GroupStore) FindByName(ctx context.Context, name string, nocase bool) (*models.Group, error) {
 	// query := "SELECT * FROM groups WHERE name = ?"
 	// if nocase {
@@ -442,7 +514,7 @@ func (qb *GroupStore) makeQuery(ctx context.Context, groupFilter *models.GroupFi
 		return nil, err
 	}
 
-	if err := qb.setGroupSort(&query, findFilter); err != nil {
+	if err := qb.setGroupSort(ctx, &query, findFilter); err != nil {
 		return nil, err
 	}
 
@@ -493,7 +565,7 @@ var groupSortOptions = sortOptions{
 	"updated_at",
 }
 
-func (qb *GroupStore) setGroupSort(query *queryBuilder, findFilter *models.FindFilterType) error {
+func (qb *GroupStore) setGroupSort(ctx context.Context, query *queryBuilder, findFilter *models.FindFilterType) error {
 	var sort string
 	var direction string
 	if findFilter == nil {
@@ -524,6 +596,8 @@ func (qb *GroupStore) setGroupSort(query *queryBuilder, findFilter *models.FindF
 		query.sortAndPagination += getCountSort(groupTable, groupsTagsTable, groupIDColumn, direction)
 	case "scenes_count": // generic getSort won't work for this
 		query.sortAndPagination += getCountSort(groupTable, groupsScenesTable, groupIDColumn, direction)
+	case "rating":
+		query.sortAndPagination += getRatingSort(ctx, groupRatingsTableMgr, groupTable, direction)
 	default:
 		query.sortAndPagination += getSort(sort, direction, "groups")
 	}
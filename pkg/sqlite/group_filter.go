@@ -65,7 +65,7 @@ func (qb *groupFilterHandler) criterionHandler() criterionHandler {
 		stringCriterionHandler(groupFilter.Name, "groups.name"),
 		stringCriterionHandler(groupFilter.Director, "groups.director"),
 		stringCriterionHandler(groupFilter.Synopsis, "groups.description"),
-		intCriterionHandler(groupFilter.Rating100, "groups.rating", nil),
+		ratingCriterionHandler(groupFilter.Rating100, groupRatingsTableMgr, groupTable),
 		floatIntCriterionHandler(groupFilter.Duration, "groups.duration", nil),
 		qb.missingCriterionHandler(groupFilter.IsMissing),
 		qb.urlsCriterionHandler(groupFilter.URL),
@@ -80,6 +80,11 @@ func (qb *groupFilterHandler) criterionHandler() criterionHandler {
 		groupHierarchyHandler.ChildCountCriterionHandler(groupFilter.SubGroupCount),
 		&timestampCriterionHandler{groupFilter.CreatedAt, "groups.created_at", nil},
 		&timestampCriterionHandler{groupFilter.UpdatedAt, "groups.updated_at", nil},
+		&customFieldsFilterHandler{
+			criteria:    groupFilter.CustomFields,
+			tableMgr:    groupCustomFieldsTableMgr,
+			parentIDCol: "groups.id",
+		},
 
 		&relatedFilterHandler{
 			relatedIDCol:   "groups_scenes.scene_id",
@@ -6,10 +6,14 @@ import (
 	"errors"
 	"fmt"
 	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
 
 	"github.com/jmoiron/sqlx"
 	"github.com/stashapp/stash/pkg/models"
 	"github.com/stashapp/stash/pkg/sliceutil"
+	"github.com/stashapp/stash/pkg/utils"
 	"gopkg.in/guregu/null.v4"
 	"gopkg.in/guregu/null.v4/zero"
 
@@ -26,8 +30,31 @@ const (
 	imagesFilesTable      = "images_files"
 	imagesURLsTable       = "image_urls"
 	imageURLColumn        = "url"
+
+	imageCustomFieldsTable = "image_custom_fields"
 )
 
+var findExactDuplicateImagesQuery = `
+SELECT GROUP_CONCAT(DISTINCT images.id) as ids
+FROM images
+INNER JOIN images_files ON (images.id = images_files.image_id)
+INNER JOIN files ON (images_files.file_id = files.id)
+INNER JOIN files_fingerprints ON (images_files.file_id = files_fingerprints.file_id AND files_fingerprints.type = 'phash')
+GROUP BY files_fingerprints.fingerprint
+HAVING COUNT(DISTINCT images.id) > 1
+ORDER BY SUM(files.size) DESC;
+`
+
+var findAllImagePhashesQuery = `
+SELECT images.id as id
+    , files_fingerprints.fingerprint as phash
+FROM images
+INNER JOIN images_files ON (images.id = images_files.image_id)
+INNER JOIN files ON (images_files.file_id = files.id)
+INNER JOIN files_fingerprints ON (images_files.file_id = files_fingerprints.file_id AND files_fingerprints.type = 'phash')
+ORDER BY files.size DESC;
+`
+
 type imageRow struct {
 	ID    int         `db:"id" goqu:"skipinsert"`
 	Title zero.String `db:"title"`
@@ -182,6 +209,8 @@ var (
 )
 
 type ImageStore struct {
+	customFieldsStore
+
 	tableMgr *table
 	oCounterManager
 
@@ -190,6 +219,10 @@ type ImageStore struct {
 
 func NewImageStore(r *storeRepository) *ImageStore {
 	return &ImageStore{
+		customFieldsStore: customFieldsStore{
+			tableMgr: imageCustomFieldsTableMgr,
+		},
+
 		tableMgr:        imageTableMgr,
 		oCounterManager: oCounterManager{imageTableMgr},
 		repo:            r,
@@ -237,11 +270,20 @@ func (qb *ImageStore) Create(ctx context.Context, newObject *models.Image, fileI
 	var r imageRow
 	r.fromImage(*newObject)
 
+	if currentUserID(ctx) != nil {
+		// rating is stored for the current user
+		r.Rating = null.Int{}
+	}
+
 	id, err := qb.tableMgr.insertID(ctx, r)
 	if err != nil {
 		return err
 	}
 
+	if _, err := setUserRating(ctx, imageRatingsTableMgr, id, newObject.Rating); err != nil {
+		return err
+	}
+
 	if len(fileIDs) > 0 {
 		const firstPrimary = true
 		if err := imagesFilesTableMgr.insertJoins(ctx, id, firstPrimary, fileIDs); err != nil {
@@ -284,6 +326,11 @@ func (qb *ImageStore) Create(ctx context.Context, newObject *models.Image, fileI
 }
 
 func (qb *ImageStore) UpdatePartial(ctx context.Context, id int, partial models.ImagePartial) (*models.Image, error) {
+	editBefore, err := takeEditSnapshot(ctx, id, qb.editSnapshot)
+	if err != nil {
+		return nil, err
+	}
+
 	r := imageRowRecord{
 		updateRecord{
 			Record: make(exp.Record),
@@ -292,6 +339,10 @@ func (qb *ImageStore) UpdatePartial(ctx context.Context, id int, partial models.
 
 	r.fromPartial(partial)
 
+	if err := setUserRatingPartial(ctx, imageRatingsTableMgr, id, partial.Rating, &r.updateRecord); err != nil {
+		return nil, err
+	}
+
 	if len(r.Record) > 0 {
 		if err := qb.tableMgr.updateByID(ctx, id, r.Record); err != nil {
 			return nil, err
@@ -326,17 +377,37 @@ func (qb *ImageStore) UpdatePartial(ctx context.Context, id int, partial models.
 		}
 	}
 
+	if err := recordEdit(ctx, models.EditObjectTypeImage, id, editBefore, qb.editSnapshot); err != nil {
+		return nil, err
+	}
+
 	return qb.find(ctx, id)
 }
 
 func (qb *ImageStore) Update(ctx context.Context, updatedObject *models.Image) error {
+	editBefore, err := takeEditSnapshot(ctx, updatedObject.ID, qb.editSnapshot)
+	if err != nil {
+		return err
+	}
+
 	var r imageRow
 	r.fromImage(*updatedObject)
 
+	if currentUserID(ctx) != nil {
+		// retain the shared rating
+		if err := getSharedValue(ctx, qb.tableMgr, updatedObject.ID, userRatingColumn, &r.Rating); err != nil {
+			return err
+		}
+	}
+
 	if err := qb.tableMgr.updateByID(ctx, updatedObject.ID, r); err != nil {
 		return err
 	}
 
+	if _, err := setUserRating(ctx, imageRatingsTableMgr, updatedObject.ID, updatedObject.Rating); err != nil {
+		return err
+	}
+
 	if updatedObject.URLs.Loaded() {
 		if err := imagesURLsTableMgr.replaceJoins(ctx, updatedObject.ID, updatedObject.URLs.List()); err != nil {
 			return err
@@ -371,6 +442,10 @@ func (qb *ImageStore) Update(ctx context.Context, updatedObject *models.Image) e
 			return err
 		}
 	}
+	if err := recordEdit(ctx, models.EditObjectTypeImage, updatedObject.ID, editBefore, qb.editSnapshot); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -477,9 +552,33 @@ func (qb *ImageStore) getMany(ctx context.Context, q *goqu.SelectDataset) ([]*mo
 		return nil, err
 	}
 
+	if err := qb.applyUserRatings(ctx, ret); err != nil {
+		return nil, err
+	}
+
 	return ret, nil
 }
 
+// applyUserRatings replaces the ratings of the provided images with those of
+// the current user.
+func (qb *ImageStore) applyUserRatings(ctx context.Context, images []*models.Image) error {
+	ids := make([]int, len(images))
+	for i, o := range images {
+		ids[i] = o.ID
+	}
+
+	ratings, err := getUserRatings(ctx, imageRatingsTableMgr, ids)
+	if err != nil || ratings == nil {
+		return err
+	}
+
+	for _, o := range images {
+		o.Rating = userRating(ratings, o.ID)
+	}
+
+	return nil
+}
+
 // Returns the custom cover for the gallery, if one has been set.
 func (qb *ImageStore) CoverByGalleryID(ctx context.Context, galleryID int) (*models.Image, error) {
 	table := qb.table()
@@ -658,6 +757,81 @@ func (qb *ImageStore) FindByGalleryIDIndex(ctx context.Context, galleryID int, i
 	return ret[0], nil
 }
 
+func (qb *ImageStore) FindDuplicates(ctx context.Context, distance int) ([][]*models.Image, error) {
+	var dupeIds [][]int
+	if distance == 0 {
+		var ids []string
+		if err := dbWrapper.Select(ctx, &ids, findExactDuplicateImagesQuery); err != nil {
+			return nil, err
+		}
+
+		for _, id := range ids {
+			strIds := strings.Split(id, ",")
+			var imageIds []int
+			for _, strId := range strIds {
+				if intId, err := strconv.Atoi(strId); err == nil {
+					imageIds = sliceutil.AppendUnique(imageIds, intId)
+				}
+			}
+			// filter out
+			if len(imageIds) > 1 {
+				dupeIds = append(dupeIds, imageIds)
+			}
+		}
+	} else {
+		var hashes []*utils.Phash
+
+		if err := imageRepository.queryFunc(ctx, findAllImagePhashesQuery, nil, false, func(rows *sqlx.Rows) error {
+			phash := utils.Phash{
+				Bucket:   -1,
+				Duration: -1,
+			}
+			if err := rows.StructScan(&phash); err != nil {
+				return err
+			}
+
+			hashes = append(hashes, &phash)
+			return nil
+		}); err != nil {
+			return nil, err
+		}
+
+		// images have no duration. Libraries may contain many images, so
+		// avoid comparing every pair of hashes.
+		dupeIds = utils.FindHashDuplicates(hashes, distance)
+	}
+
+	var duplicates [][]*models.Image
+	for _, imageIds := range dupeIds {
+		if images, err := qb.FindMany(ctx, imageIds); err == nil {
+			duplicates = append(duplicates, images)
+		}
+	}
+
+	sortImagesByPath(duplicates)
+
+	return duplicates, nil
+}
+
+func sortImagesByPath(images [][]*models.Image) {
+	lessFunc := func(i int, j int) bool {
+		firstPathI := getFirstImagePath(images[i])
+		firstPathJ := getFirstImagePath(images[j])
+		return firstPathI < firstPathJ
+	}
+	sort.SliceStable(images, lessFunc)
+}
+
+func getFirstImagePath(images []*models.Image) string {
+	var firstPath string
+	for i, image := range images {
+		if i == 0 || image.Path < firstPath {
+			firstPath = image.Path
+		}
+	}
+	return firstPath
+}
+
 func (qb *ImageStore) CountByGalleryID(ctx context.Context, galleryID int) (int, error) {
 	joinTable := goqu.T(galleriesImagesTable)
 
@@ -813,7 +987,7 @@ func (qb *ImageStore) makeQuery(ctx context.Context, imageFilter *models.ImageFi
 		return nil, err
 	}
 
-	if err := qb.setImageSortAndPagination(&query, findFilter); err != nil {
+	if err := qb.setImageSortAndPagination(ctx, &query, findFilter); err != nil {
 		return nil, err
 	}
 
@@ -927,7 +1101,7 @@ var imageSortOptions = sortOptions{
 	"updated_at",
 }
 
-func (qb *ImageStore) setImageSortAndPagination(q *queryBuilder, findFilter *models.FindFilterType) error {
+func (qb *ImageStore) setImageSortAndPagination(ctx context.Context, q *queryBuilder, findFilter *models.FindFilterType) error {
 	sortClause := ""
 
 	if findFilter != nil && findFilter.Sort != nil && *findFilter.Sort != "" {
@@ -982,6 +1156,8 @@ func (qb *ImageStore) setImageSortAndPagination(q *queryBuilder, findFilter *mod
 			addFilesJoin()
 			addFolderJoin()
 			sortClause = " ORDER BY COALESCE(images.title, files.basename) COLLATE NATURAL_CI " + direction + ", folders.path COLLATE NATURAL_CI " + direction
+		case "rating":
+			sortClause = getRatingSort(ctx, imageRatingsTableMgr, imageTable, direction)
 		default:
 			sortClause = getSort(sort, direction, "images")
 		}
@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/utils"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -1656,6 +1657,70 @@ func verifyImagePath(t *testing.T, pathCriterion models.StringCriterionInput, ex
 	})
 }
 
+func TestImageQueryPhashDistance(t *testing.T) {
+	const imageIdx = 1
+	// the last image shares the phash of the image at imageIdx
+	const dupeIdx = totalImages - dupeImagePhashes + imageIdx
+
+	phash := utils.PhashToString(getImagePhash(imageIdx))
+	distance := 1
+
+	tests := []struct {
+		name     string
+		filter   *models.PhashDistanceCriterionInput
+		included []int
+		excluded []int
+	}{
+		{
+			"equals",
+			&models.PhashDistanceCriterionInput{
+				Value:    phash,
+				Modifier: models.CriterionModifierEquals,
+			},
+			[]int{imageIdx, dupeIdx},
+			[]int{imageIdx + 1},
+		},
+		{
+			"equals with distance",
+			&models.PhashDistanceCriterionInput{
+				Value:    phash,
+				Modifier: models.CriterionModifierEquals,
+				Distance: &distance,
+			},
+			[]int{imageIdx, dupeIdx},
+			[]int{imageIdx + 1},
+		},
+		{
+			"not equals",
+			&models.PhashDistanceCriterionInput{
+				Value:    phash,
+				Modifier: models.CriterionModifierNotEquals,
+			},
+			[]int{imageIdx + 1},
+			[]int{imageIdx, dupeIdx},
+		},
+	}
+
+	for _, tt := range tests {
+		runWithRollbackTxn(t, tt.name, func(t *testing.T, ctx context.Context) {
+			assert := assert.New(t)
+
+			images := queryImages(ctx, t, db.Image, &models.ImageFilterType{
+				PhashDistance: tt.filter,
+			}, nil)
+
+			ids := imagesToIDs(images)
+
+			for _, idx := range tt.included {
+				assert.Contains(ids, imageIDs[idx])
+			}
+			for _, idx := range tt.excluded {
+				assert.NotContains(ids, imageIDs[idx])
+			}
+		})
+	}
+}
+
 func TestImageQueryPathOr(t *testing.T) {
 	const image1Idx = 1
 	const image2Idx = 2
@@ -3021,3 +3086,29 @@ func TestImageQueryPagination(t *testing.T) {
 // TODO Count
 // TODO SizeCount
 // TODO All
+
+func TestImageStore_FindDuplicates(t *testing.T) {
+	qb := db.Image
+
+	withRollbackTxn(func(ctx context.Context) error {
+		distance := 0
+		got, err := qb.FindDuplicates(ctx, distance)
+		if err != nil {
+			t.Errorf("ImageStore.FindDuplicates() error = %v", err)
+			return nil
+		}
+
+		assert.Len(t, got, dupeImagePhashes)
+
+		distance = 1
+		got, err = qb.FindDuplicates(ctx, distance)
+		if err != nil {
+			t.Errorf("ImageStore.FindDuplicates() error = %v", err)
+			return nil
+		}
+
+		assert.Len(t, got, dupeImagePhashes)
+
+		return nil
+	})
+}
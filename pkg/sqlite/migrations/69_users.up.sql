@@ -0,0 +1,88 @@
+CREATE TABLE `users` (
+  `id` integer not null primary key autoincrement,
+  `username` varchar(255) not null,
+  `password_hash` varchar(255) not null,
+  `role` varchar(255) not null,
+  `created_at` datetime not null,
+  `updated_at` datetime not null
+);
+
+CREATE UNIQUE INDEX `index_users_on_username_unique` ON `users` (`username`);
+
+-- history rows with a null user_id belong to the shared, unauthenticated state
+ALTER TABLE `scenes_view_dates` ADD COLUMN `user_id` integer REFERENCES `users`(`id`) ON DELETE CASCADE;
+ALTER TABLE `scenes_o_dates` ADD COLUMN `user_id` integer REFERENCES `users`(`id`) ON DELETE CASCADE;
+
+CREATE INDEX `index_scenes_view_dates_user_id` ON `scenes_view_dates` (`user_id`);
+CREATE INDEX `index_scenes_o_dates_user_id` ON `scenes_o_dates` (`user_id`);
+
+CREATE TABLE `scene_resume_times` (
+  `scene_id` integer not null,
+  `user_id` integer not null,
+  `resume_time` float not null,
+  primary key (`scene_id`, `user_id`),
+  foreign key (`scene_id`) references `scenes`(`id`) on delete cascade,
+  foreign key (`user_id`) references `users`(`id`) on delete cascade
+);
+
+CREATE TABLE `scene_ratings` (
+  `scene_id` integer not null,
+  `user_id` integer not null,
+  `rating` tinyint not null,
+  primary key (`scene_id`, `user_id`),
+  foreign key (`scene_id`) references `scenes`(`id`) on delete cascade,
+  foreign key (`user_id`) references `users`(`id`) on delete cascade
+);
+
+CREATE TABLE `image_ratings` (
+  `image_id` integer not null,
+  `user_id` integer not null,
+  `rating` tinyint not null,
+  primary key (`image_id`, `user_id`),
+  foreign key (`image_id`) references `images`(`id`) on delete cascade,
+  foreign key (`user_id`) references `users`(`id`) on delete cascade
+);
+
+CREATE TABLE `gallery_ratings` (
+  `gallery_id` integer not null,
+  `user_id` integer not null,
+  `rating` tinyint not null,
+  primary key (`gallery_id`, `user_id`),
+  foreign key (`gallery_id`) references `galleries`(`id`) on delete cascade,
+  foreign key (`user_id`) references `users`(`id`) on delete cascade
+);
+
+CREATE TABLE `group_ratings` (
+  `group_id` integer not null,
+  `user_id` integer not null,
+  `rating` tinyint not null,
+  primary key (`group_id`, `user_id`),
+  foreign key (`group_id`) references `groups`(`id`) on delete cascade,
+  foreign key (`user_id`) references `users`(`id`) on delete cascade
+);
+
+CREATE TABLE `performer_ratings` (
+  `performer_id` integer not null,
+  `user_id` integer not null,
+  `rating` tinyint not null,
+  primary key (`performer_id`, `user_id`),
+  foreign key (`performer_id`) references `performers`(`id`) on delete cascade,
+  foreign key (`user_id`) references `users`(`id`) on delete cascade
+);
+
+CREATE TABLE `studio_ratings` (
+  `studio_id` integer not null,
+  `user_id` integer not null,
+  `rating` tinyint not null,
+  primary key (`studio_id`, `user_id`),
+  foreign key (`studio_id`) references `studios`(`id`) on delete cascade,
+  foreign key (`user_id`) references `users`(`id`) on delete cascade
+);
+
+CREATE INDEX `index_scene_resume_times_user_id` ON `scene_resume_times` (`user_id`);
+CREATE INDEX `index_scene_ratings_user_id` ON `scene_ratings` (`user_id`);
+CREATE INDEX `index_image_ratings_user_id` ON `image_ratings` (`user_id`);
+CREATE INDEX `index_gallery_ratings_user_id` ON `gallery_ratings` (`user_id`);
+CREATE INDEX `index_group_ratings_user_id` ON `group_ratings` (`user_id`);
+CREATE INDEX `index_performer_ratings_user_id` ON `performer_ratings` (`user_id`);
+CREATE INDEX `index_studio_ratings_user_id` ON `studio_ratings` (`user_id`);
@@ -0,0 +1,22 @@
+CREATE TABLE `api_keys` (
+  `id` integer not null primary key autoincrement,
+  `user_id` integer not null,
+  `name` varchar(255) not null,
+  `key_hash` varchar(255) not null,
+  `expires_at` datetime,
+  `last_used_at` datetime,
+  `revoked_at` datetime,
+  `created_at` datetime not null,
+  `updated_at` datetime not null,
+  foreign key(`user_id`) references `users`(`id`) on delete CASCADE
+);
+
+CREATE UNIQUE INDEX `index_api_keys_on_key_hash_unique` ON `api_keys` (`key_hash`);
+CREATE INDEX `index_api_keys_on_user_id` ON `api_keys` (`user_id`);
+
+CREATE TABLE `api_key_scopes` (
+  `api_key_id` integer not null,
+  `scope` varchar(255) not null,
+  foreign key(`api_key_id`) references `api_keys`(`id`) on delete CASCADE,
+  PRIMARY KEY(`api_key_id`, `scope`)
+);
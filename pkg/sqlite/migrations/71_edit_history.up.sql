@@ -0,0 +1,13 @@
+CREATE TABLE `edit_history` (
+  `id` integer not null primary key autoincrement,
+  `object_type` varchar(255) not null,
+  `object_id` integer not null,
+  `user_id` integer,
+  `source` varchar(255) not null,
+  `changes` text not null,
+  `created_at` datetime not null,
+  foreign key(`user_id`) references `users`(`id`) on delete SET NULL
+);
+
+CREATE INDEX `index_edit_history_on_object` ON `edit_history` (`object_type`, `object_id`);
+CREATE INDEX `index_edit_history_on_user_id` ON `edit_history` (`user_id`);
@@ -0,0 +1,9 @@
+CREATE TABLE `trashed_files` (
+  `id` integer not null primary key autoincrement,
+  `original_path` varchar(255) not null,
+  `trash_path` varchar(255) not null,
+  `size` integer not null default 0,
+  `trashed_at` datetime not null
+);
+
+CREATE INDEX `index_trashed_files_on_trashed_at` ON `trashed_files` (`trashed_at`);
@@ -0,0 +1,11 @@
+CREATE TABLE `queued_jobs` (
+  `id` integer not null primary key,
+  `kind` varchar(255) not null,
+  `description` varchar(255) not null,
+  `input` blob,
+  `priority` integer not null default 0,
+  `resource_class` varchar(255) not null,
+  `paused` boolean not null default '0',
+  `added_at` datetime not null,
+  `user_id` integer references `users`(`id`) on delete set null
+);
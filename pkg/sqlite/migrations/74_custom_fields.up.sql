@@ -0,0 +1,87 @@
+CREATE TABLE `custom_field_definitions` (
+  `id` integer not null primary key autoincrement,
+  `entity_type` varchar(255) not null,
+  `name` varchar(255) not null,
+  `type` varchar(255) not null,
+  `created_at` datetime not null,
+  `updated_at` datetime not null
+);
+
+CREATE UNIQUE INDEX `index_custom_field_definitions_on_entity_type_name` ON `custom_field_definitions` (`entity_type`, `name`);
+
+CREATE TABLE `scene_custom_fields` (
+  `scene_id` integer not null,
+  `definition_id` integer not null,
+  `value` blob not null,
+  foreign key(`scene_id`) references `scenes`(`id`) on delete CASCADE,
+  foreign key(`definition_id`) references `custom_field_definitions`(`id`) on delete CASCADE,
+  PRIMARY KEY(`scene_id`, `definition_id`)
+);
+
+CREATE INDEX `index_scene_custom_fields_on_definition_id` ON `scene_custom_fields` (`definition_id`);
+
+CREATE TABLE `image_custom_fields` (
+  `image_id` integer not null,
+  `definition_id` integer not null,
+  `value` blob not null,
+  foreign key(`image_id`) references `images`(`id`) on delete CASCADE,
+  foreign key(`definition_id`) references `custom_field_definitions`(`id`) on delete CASCADE,
+  PRIMARY KEY(`image_id`, `definition_id`)
+);
+
+CREATE INDEX `index_image_custom_fields_on_definition_id` ON `image_custom_fields` (`definition_id`);
+
+CREATE TABLE `gallery_custom_fields` (
+  `gallery_id` integer not null,
+  `definition_id` integer not null,
+  `value` blob not null,
+  foreign key(`gallery_id`) references `galleries`(`id`) on delete CASCADE,
+  foreign key(`definition_id`) references `custom_field_definitions`(`id`) on delete CASCADE,
+  PRIMARY KEY(`gallery_id`, `definition_id`)
+);
+
+CREATE INDEX `index_gallery_custom_fields_on_definition_id` ON `gallery_custom_fields` (`definition_id`);
+
+CREATE TABLE `performer_custom_fields` (
+  `performer_id` integer not null,
+  `definition_id` integer not null,
+  `value` blob not null,
+  foreign key(`performer_id`) references `performers`(`id`) on delete CASCADE,
+  foreign key(`definition_id`) references `custom_field_definitions`(`id`) on delete CASCADE,
+  PRIMARY KEY(`performer_id`, `definition_id`)
+);
+
+CREATE INDEX `index_performer_custom_fields_on_definition_id` ON `performer_custom_fields` (`definition_id`);
+
+CREATE TABLE `studio_custom_fields` (
+  `studio_id` integer not null,
+  `definition_id` integer not null,
+  `value` blob not null,
+  foreign key(`studio_id`) references `studios`(`id`) on delete CASCADE,
+  foreign key(`definition_id`) references `custom_field_definitions`(`id`) on delete CASCADE,
+  PRIMARY KEY(`studio_id`, `definition_id`)
+);
+
+CREATE INDEX `index_studio_custom_fields_on_definition_id` ON `studio_custom_fields` (`definition_id`);
+
+CREATE TABLE `tag_custom_fields` (
+  `tag_id` integer not null,
+  `definition_id` integer not null,
+  `value` blob not null,
+  foreign key(`tag_id`) references `tags`(`id`) on delete CASCADE,
+  foreign key(`definition_id`) references `custom_field_definitions`(`id`) on delete CASCADE,
+  PRIMARY KEY(`tag_id`, `definition_id`)
+);
+
+CREATE INDEX `index_tag_custom_fields_on_definition_id` ON `tag_custom_fields` (`definition_id`);
+
+CREATE TABLE `group_custom_fields` (
+  `group_id` integer not null,
+  `definition_id` integer not null,
+  `value` blob not null,
+  foreign key(`group_id`) references `groups`(`id`) on delete CASCADE,
+  foreign key(`definition_id`) references `custom_field_definitions`(`id`) on delete CASCADE,
+  PRIMARY KEY(`group_id`, `definition_id`)
+);
+
+CREATE INDEX `index_group_custom_fields_on_definition_id` ON `group_custom_fields` (`definition_id`);
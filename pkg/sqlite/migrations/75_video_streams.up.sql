@@ -0,0 +1,25 @@
+CREATE TABLE `video_streams` (
+  `file_id` integer NOT NULL,
+  `stream_index` integer NOT NULL,
+  `stream_type` varchar(255) NOT NULL,
+  `codec` varchar(255) NOT NULL,
+  `language` varchar(255) NOT NULL DEFAULT '',
+  `title` varchar(255) NOT NULL DEFAULT '',
+  `is_default` boolean NOT NULL DEFAULT '0',
+  primary key (`file_id`, `stream_index`),
+  foreign key(`file_id`) references `video_files`(`file_id`) on delete CASCADE
+);
+
+CREATE TABLE `video_chapters` (
+  `file_id` integer NOT NULL,
+  `chapter_index` integer NOT NULL,
+  `title` varchar(255) NOT NULL DEFAULT '',
+  `start_seconds` float NOT NULL,
+  `end_seconds` float NOT NULL DEFAULT 0,
+  primary key (`file_id`, `chapter_index`),
+  foreign key(`file_id`) references `video_files`(`file_id`) on delete CASCADE
+);
+
+-- set when the chapters of a file are stored. Existing files are probed
+-- again on the next scan to store their chapters.
+ALTER TABLE `video_files` ADD COLUMN `chapters_probed` boolean NOT NULL DEFAULT '0';
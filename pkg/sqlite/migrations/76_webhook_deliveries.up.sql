@@ -0,0 +1,15 @@
+CREATE TABLE `webhook_deliveries` (
+  `id` integer not null primary key autoincrement,
+  `webhook_id` integer not null,
+  `webhook_name` varchar(255) not null,
+  `url` varchar(255) not null,
+  `event` varchar(255) not null,
+  `status` varchar(255) not null,
+  `attempts` integer not null default 0,
+  `status_code` integer,
+  `error` text,
+  `created_at` datetime not null,
+  `completed_at` datetime
+);
+
+CREATE INDEX `index_webhook_deliveries_on_webhook_id` ON `webhook_deliveries` (`webhook_id`);
@@ -5,12 +5,14 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/doug-martin/goqu/v9"
 	"github.com/doug-martin/goqu/v9/exp"
 	"github.com/jmoiron/sqlx"
 	"github.com/stashapp/stash/pkg/models"
 	"github.com/stashapp/stash/pkg/sliceutil"
+	"github.com/stashapp/stash/pkg/sliceutil/stringslice"
 	"github.com/stashapp/stash/pkg/utils"
 	"gopkg.in/guregu/null.v4"
 	"gopkg.in/guregu/null.v4/zero"
@@ -27,6 +29,8 @@ const (
 	performerURLColumn = "url"
 
 	performerImageBlobColumn = "image_blob"
+
+	performerCustomFieldsTable = "performer_custom_fields"
 )
 
 type performerRow struct {
@@ -226,6 +230,7 @@ var (
 
 type PerformerStore struct {
 	blobJoinQueryBuilder
+	customFieldsStore
 
 	tableMgr *table
 }
@@ -236,6 +241,10 @@ func NewPerformerStore(blobStore *BlobStore) *PerformerStore {
 			blobStore: blobStore,
 			joinTable: performerTable,
 		},
+		customFieldsStore: customFieldsStore{
+			tableMgr: performerCustomFieldsTableMgr,
+		},
+
 		tableMgr: performerTableMgr,
 	}
 }
@@ -252,11 +261,20 @@ func (qb *PerformerStore) Create(ctx context.Context, newObject *models.Performe
 	var r performerRow
 	r.fromPerformer(*newObject)
 
+	if currentUserID(ctx) != nil {
+		// rating is stored for the current user
+		r.Rating = null.Int{}
+	}
+
 	id, err := qb.tableMgr.insertID(ctx, r)
 	if err != nil {
 		return err
 	}
 
+	if _, err := setUserRating(ctx, performerRatingsTableMgr, id, newObject.Rating); err != nil {
+		return err
+	}
+
 	if newObject.Aliases.Loaded() {
 		if err := performersAliasesTableMgr.insertJoins(ctx, id, newObject.Aliases.List()); err != nil {
 			return err
@@ -293,6 +311,11 @@ func (qb *PerformerStore) Create(ctx context.Context, newObject *models.Performe
 }
 
 func (qb *PerformerStore) UpdatePartial(ctx context.Context, id int, partial models.PerformerPartial) (*models.Performer, error) {
+	editBefore, err := takeEditSnapshot(ctx, id, qb.editSnapshot)
+	if err != nil {
+		return nil, err
+	}
+
 	r := performerRowRecord{
 		updateRecord{
 			Record: make(exp.Record),
@@ -301,6 +324,10 @@ func (qb *PerformerStore) UpdatePartial(ctx context.Context, id int, partial mod
 
 	r.fromPartial(partial)
 
+	if err := setUserRatingPartial(ctx, performerRatingsTableMgr, id, partial.Rating, &r.updateRecord); err != nil {
+		return nil, err
+	}
+
 	if len(r.Record) > 0 {
 		if err := qb.tableMgr.updateByID(ctx, id, r.Record); err != nil {
 			return nil, err
@@ -330,17 +357,37 @@ func (qb *PerformerStore) UpdatePartial(ctx context.Context, id int, partial mod
 		}
 	}
 
+	if err := recordEdit(ctx, models.EditObjectTypePerformer, id, editBefore, qb.editSnapshot); err != nil {
+		return nil, err
+	}
+
 	return qb.find(ctx, id)
 }
 
 func (qb *PerformerStore) Update(ctx context.Context, updatedObject *models.Performer) error {
+	editBefore, err := takeEditSnapshot(ctx, updatedObject.ID, qb.editSnapshot)
+	if err != nil {
+		return err
+	}
+
 	var r performerRow
 	r.fromPerformer(*updatedObject)
 
+	if currentUserID(ctx) != nil {
+		// retain the shared rating
+		if err := getSharedValue(ctx, qb.tableMgr, updatedObject.ID, userRatingColumn, &r.Rating); err != nil {
+			return err
+		}
+	}
+
 	if err := qb.tableMgr.updateByID(ctx, updatedObject.ID, r); err != nil {
 		return err
 	}
 
+	if _, err := setUserRating(ctx, performerRatingsTableMgr, updatedObject.ID, updatedObject.Rating); err != nil {
+		return err
+	}
+
 	if updatedObject.Aliases.Loaded() {
 		if err := performersAliasesTableMgr.replaceJoins(ctx, updatedObject.ID, updatedObject.Aliases.List()); err != nil {
 			return err
@@ -365,6 +412,10 @@ func (qb *PerformerStore) Update(ctx context.Context, updatedObject *models.Perf
 		}
 	}
 
+	if err := recordEdit(ctx, models.EditObjectTypePerformer, updatedObject.ID, editBefore, qb.editSnapshot); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -471,9 +522,33 @@ func (qb *PerformerStore) getMany(ctx context.Context, q *goqu.SelectDataset) ([
 		return nil, err
 	}
 
+	if err := qb.applyUserRatings(ctx, ret); err != nil {
+		return nil, err
+	}
+
 	return ret, nil
 }
 
+// applyUserRatings replaces the ratings of the provided performers with those of
+// the current user.
+func (qb *PerformerStore) applyUserRatings(ctx context.Context, performers []*models.Performer) error {
+	ids := make([]int, len(performers))
+	for i, o := range performers {
+		ids[i] = o.ID
+	}
+
+	ratings, err := getUserRatings(ctx, performerRatingsTableMgr, ids)
+	if err != nil || ratings == nil {
+		return err
+	}
+
+	for _, o := range performers {
+		o.Rating = userRating(ratings, o.ID)
+	}
+
+	return nil
+}
+
 func (qb *PerformerStore) FindBySceneID(ctx context.Context, sceneID int) ([]*models.Performer, error) {
 	sq := dialect.From(scenesPerformersJoinTable).Select(scenesPerformersJoinTable.Col(performerIDColumn)).Where(
 		scenesPerformersJoinTable.Col(sceneIDColumn).Eq(sceneID),
@@ -613,7 +688,7 @@ func (qb *PerformerStore) makeQuery(ctx context.Context, performerFilter *models
 	}
 
 	var err error
-	query.sortAndPagination, err = qb.getPerformerSort(findFilter)
+	query.sortAndPagination, err = qb.getPerformerSort(ctx, findFilter)
 	if err != nil {
 		return nil, err
 	}
@@ -650,62 +725,68 @@ func (qb *PerformerStore) QueryCount(ctx context.Context, performerFilter *model
 	return query.executeCount(ctx)
 }
 
-func (qb *PerformerStore) sortByOCounter(direction string) string {
+func (qb *PerformerStore) sortByOCounter(ctx context.Context, direction string) string {
 	// need to sum the o_counter from scenes and images
-	return " ORDER BY (" + selectPerformerOCountSQL + ") " + direction
+	return " ORDER BY (" + selectPerformerOCountSQL(ctx) + ") " + direction
 }
 
-func (qb *PerformerStore) sortByPlayCount(direction string) string {
+func (qb *PerformerStore) sortByPlayCount(ctx context.Context, direction string) string {
 	// need to sum the o_counter from scenes and images
-	return " ORDER BY (" + selectPerformerPlayCountSQL + ") " + direction
+	return " ORDER BY (" + selectPerformerPlayCountSQL(ctx) + ") " + direction
 }
 
 // used for sorting on performer last o_date
-var selectPerformerLastOAtSQL = utils.StrFormat(
-	"SELECT MAX(o_date) FROM ("+
-		"SELECT {o_date} FROM {performers_scenes} s "+
-		"LEFT JOIN {scenes} ON {scenes}.id = s.{scene_id} "+
-		"LEFT JOIN {scenes_o_dates} ON {scenes_o_dates}.{scene_id} = {scenes}.id "+
-		"WHERE s.{performer_id} = {performers}.id"+
-		")",
-	map[string]interface{}{
-		"performer_id":      performerIDColumn,
-		"performers":        performerTable,
-		"performers_scenes": performersScenesTable,
-		"scenes":            sceneTable,
-		"scene_id":          sceneIDColumn,
-		"scenes_o_dates":    scenesODatesTable,
-		"o_date":            sceneODateColumn,
-	},
-)
+func selectPerformerLastOAtSQL(ctx context.Context) string {
+	return utils.StrFormat(
+		"SELECT MAX(o_date) FROM ("+
+			"SELECT {o_date} FROM {performers_scenes} s "+
+			"LEFT JOIN {scenes} ON {scenes}.id = s.{scene_id} "+
+			"LEFT JOIN {scenes_o_dates} ON {scenes_o_dates}.{scene_id} = {scenes}.id AND {user_condition} "+
+			"WHERE s.{performer_id} = {performers}.id"+
+			")",
+		map[string]interface{}{
+			"performer_id":      performerIDColumn,
+			"performers":        performerTable,
+			"performers_scenes": performersScenesTable,
+			"scenes":            sceneTable,
+			"scene_id":          sceneIDColumn,
+			"scenes_o_dates":    scenesODatesTable,
+			"o_date":            sceneODateColumn,
+			"user_condition":    historyUserCondition(ctx, scenesODatesTable),
+		},
+	)
+}
 
-func (qb *PerformerStore) sortByLastOAt(direction string) string {
+func (qb *PerformerStore) sortByLastOAt(ctx context.Context, direction string) string {
 	// need to get the o_dates from scenes
-	return " ORDER BY (" + selectPerformerLastOAtSQL + ") " + direction
+	return " ORDER BY (" + selectPerformerLastOAtSQL(ctx) + ") " + direction
 }
 
 // used for sorting on performer last view_date
-var selectPerformerLastPlayedAtSQL = utils.StrFormat(
-	"SELECT MAX(view_date) FROM ("+
-		"SELECT {view_date} FROM {performers_scenes} s "+
-		"LEFT JOIN {scenes} ON {scenes}.id = s.{scene_id} "+
-		"LEFT JOIN {scenes_view_dates} ON {scenes_view_dates}.{scene_id} = {scenes}.id "+
-		"WHERE s.{performer_id} = {performers}.id"+
-		")",
-	map[string]interface{}{
-		"performer_id":      performerIDColumn,
-		"performers":        performerTable,
-		"performers_scenes": performersScenesTable,
-		"scenes":            sceneTable,
-		"scene_id":          sceneIDColumn,
-		"scenes_view_dates": scenesViewDatesTable,
-		"view_date":         sceneViewDateColumn,
-	},
-)
+func selectPerformerLastPlayedAtSQL(ctx context.Context) string {
+	return utils.StrFormat(
+		"SELECT MAX(view_date) FROM ("+
+			"SELECT {view_date} FROM {performers_scenes} s "+
+			"LEFT JOIN {scenes} ON {scenes}.id = s.{scene_id} "+
+			"LEFT JOIN {scenes_view_dates} ON {scenes_view_dates}.{scene_id} = {scenes}.id AND {user_condition} "+
+			"WHERE s.{performer_id} = {performers}.id"+
+			")",
+		map[string]interface{}{
+			"performer_id":      performerIDColumn,
+			"performers":        performerTable,
+			"performers_scenes": performersScenesTable,
+			"scenes":            sceneTable,
+			"scene_id":          sceneIDColumn,
+			"scenes_view_dates": scenesViewDatesTable,
+			"view_date":         sceneViewDateColumn,
+			"user_condition":    historyUserCondition(ctx, scenesViewDatesTable),
+		},
+	)
+}
 
-func (qb *PerformerStore) sortByLastPlayedAt(direction string) string {
+func (qb *PerformerStore) sortByLastPlayedAt(ctx context.Context, direction string) string {
 	// need to get the view_dates from scenes
-	return " ORDER BY (" + selectPerformerLastPlayedAtSQL + ") " + direction
+	return " ORDER BY (" + selectPerformerLastPlayedAtSQL(ctx) + ") " + direction
 }
 
 var performerSortOptions = sortOptions{
@@ -731,7 +812,7 @@ var performerSortOptions = sortOptions{
 	"weight",
 }
 
-func (qb *PerformerStore) getPerformerSort(findFilter *models.FindFilterType) (string, error) {
+func (qb *PerformerStore) getPerformerSort(ctx context.Context, findFilter *models.FindFilterType) (string, error) {
 	var sort string
 	var direction string
 	if findFilter == nil {
@@ -758,13 +839,15 @@ func (qb *PerformerStore) getPerformerSort(findFilter *models.FindFilterType) (s
 	case "galleries_count":
 		sortQuery += getCountSort(performerTable, performersGalleriesTable, performerIDColumn, direction)
 	case "play_count":
-		sortQuery += qb.sortByPlayCount(direction)
+		sortQuery += qb.sortByPlayCount(ctx, direction)
 	case "o_counter":
-		sortQuery += qb.sortByOCounter(direction)
+		sortQuery += qb.sortByOCounter(ctx, direction)
 	case "last_played_at":
-		sortQuery += qb.sortByLastPlayedAt(direction)
+		sortQuery += qb.sortByLastPlayedAt(ctx, direction)
 	case "last_o_at":
-		sortQuery += qb.sortByLastOAt(direction)
+		sortQuery += qb.sortByLastOAt(ctx, direction)
+	case "rating":
+		sortQuery += getRatingSort(ctx, performerRatingsTableMgr, performerTable, direction)
 	default:
 		sortQuery += getSort(sort, direction, "performers")
 	}
@@ -846,3 +929,118 @@ func (qb *PerformerStore) FindByStashIDStatus(ctx context.Context, hasStashID bo
 
 	return ret, nil
 }
+
+// Merge merges the source performers into the destination performer.
+// Scene, image, gallery and tag relationships are reassigned to the
+// destination. The names and aliases of the source performers are added as
+// aliases of the destination, and their URLs and stash IDs are added to the
+// destination. The source performers are then destroyed.
+func (qb *PerformerStore) Merge(ctx context.Context, source []int, destination int) error {
+	if len(source) == 0 {
+		return nil
+	}
+
+	inBinding := getInBinding(len(source))
+
+	args := []interface{}{destination}
+	srcArgs := make([]interface{}, len(source))
+	for i, id := range source {
+		if id == destination {
+			return errors.New("cannot merge where source == destination")
+		}
+		srcArgs[i] = id
+	}
+
+	args = append(args, srcArgs...)
+
+	joinTables := map[string]string{
+		performersScenesTable:    sceneIDColumn,
+		performersImagesTable:    imageIDColumn,
+		performersGalleriesTable: galleryIDColumn,
+		performersTagsTable:      tagIDColumn,
+	}
+
+	args = append(args, destination)
+	for table, idColumn := range joinTables {
+		_, err := dbWrapper.Exec(ctx, `UPDATE OR IGNORE `+table+`
+SET performer_id = ?
+WHERE performer_id IN `+inBinding+`
+AND NOT EXISTS(SELECT 1 FROM `+table+` o WHERE o.`+idColumn+` = `+table+`.`+idColumn+` AND o.performer_id = ?)`,
+			args...,
+		)
+		if err != nil {
+			return err
+		}
+
+		// delete source performer ids from the table where they couldn't be set
+		if _, err := dbWrapper.Exec(ctx, `DELETE FROM `+table+` WHERE performer_id IN `+inBinding, srcArgs...); err != nil {
+			return err
+		}
+	}
+
+	dest, err := qb.find(ctx, destination)
+	if err != nil {
+		return fmt.Errorf("finding destination performer: %w", err)
+	}
+
+	aliases, err := qb.GetAliases(ctx, destination)
+	if err != nil {
+		return err
+	}
+	urls, err := qb.GetURLs(ctx, destination)
+	if err != nil {
+		return err
+	}
+	stashIDs, err := qb.GetStashIDs(ctx, destination)
+	if err != nil {
+		return err
+	}
+
+	for _, id := range source {
+		src, err := qb.find(ctx, id)
+		if err != nil {
+			return fmt.Errorf("finding source performer %d: %w", id, err)
+		}
+
+		srcAliases, err := qb.GetAliases(ctx, id)
+		if err != nil {
+			return err
+		}
+		srcURLs, err := qb.GetURLs(ctx, id)
+		if err != nil {
+			return err
+		}
+		srcStashIDs, err := qb.GetStashIDs(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		aliases = append(aliases, src.Name)
+		aliases = append(aliases, srcAliases...)
+		urls = sliceutil.AppendUniques(urls, srcURLs)
+		stashIDs = sliceutil.AppendUniques(stashIDs, srcStashIDs)
+	}
+
+	// aliases must not match the name of the destination performer
+	aliases = sliceutil.Filter(stringslice.UniqueFold(aliases), func(a string) bool {
+		return !strings.EqualFold(a, dest.Name)
+	})
+
+	if err := performersAliasesTableMgr.replaceJoins(ctx, destination, aliases); err != nil {
+		return err
+	}
+	if err := performersURLsTableMgr.replaceJoins(ctx, destination, urls); err != nil {
+		return err
+	}
+	if err := performersStashIDsTableMgr.replaceJoins(ctx, destination, stashIDs); err != nil {
+		return err
+	}
+
+	for _, id := range source {
+		if err := qb.Destroy(ctx, id); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -132,7 +132,7 @@ func (qb *performerFilterHandler) criterionHandler() criterionHandler {
 		stringCriterionHandler(filter.CareerLength, tableName+".career_length"),
 		stringCriterionHandler(filter.Tattoos, tableName+".tattoos"),
 		stringCriterionHandler(filter.Piercings, tableName+".piercings"),
-		intCriterionHandler(filter.Rating100, tableName+".rating", nil),
+		ratingCriterionHandler(filter.Rating100, performerRatingsTableMgr, tableName),
 		stringCriterionHandler(filter.HairColor, tableName+".hair_color"),
 		qb.urlsCriterionHandler(filter.URL),
 		intCriterionHandler(filter.Weight, tableName+".weight", nil),
@@ -167,6 +167,11 @@ func (qb *performerFilterHandler) criterionHandler() criterionHandler {
 		&dateCriterionHandler{filter.DeathDate, tableName + ".death_date", nil},
 		&timestampCriterionHandler{filter.CreatedAt, tableName + ".created_at", nil},
 		&timestampCriterionHandler{filter.UpdatedAt, tableName + ".updated_at", nil},
+		&customFieldsFilterHandler{
+			criteria:    filter.CustomFields,
+			tableMgr:    performerCustomFieldsTableMgr,
+			parentIDCol: tableName + ".id",
+		},
 
 		&relatedFilterHandler{
 			relatedIDCol:   "performers_scenes.scene_id",
@@ -328,50 +333,56 @@ func (qb *performerFilterHandler) galleryCountCriterionHandler(count *models.Int
 }
 
 // used for sorting and filtering on performer o-count
-var selectPerformerOCountSQL = utils.StrFormat(
-	"SELECT SUM(o_counter) "+
-		"FROM ("+
-		"SELECT SUM(o_counter) as o_counter from {performers_images} s "+
-		"LEFT JOIN {images} ON {images}.id = s.{images_id} "+
-		"WHERE s.{performer_id} = {performers}.id "+
-		"UNION ALL "+
-		"SELECT COUNT({scenes_o_dates}.{o_date}) as o_counter from {performers_scenes} s "+
-		"LEFT JOIN {scenes} ON {scenes}.id = s.{scene_id} "+
-		"LEFT JOIN {scenes_o_dates} ON {scenes_o_dates}.{scene_id} = {scenes}.id "+
-		"WHERE s.{performer_id} = {performers}.id "+
-		")",
-	map[string]interface{}{
-		"performers_images": performersImagesTable,
-		"images":            imageTable,
-		"performer_id":      performerIDColumn,
-		"images_id":         imageIDColumn,
-		"performers":        performerTable,
-		"performers_scenes": performersScenesTable,
-		"scenes":            sceneTable,
-		"scene_id":          sceneIDColumn,
-		"scenes_o_dates":    scenesODatesTable,
-		"o_date":            sceneODateColumn,
-	},
-)
+func selectPerformerOCountSQL(ctx context.Context) string {
+	return utils.StrFormat(
+		"SELECT SUM(o_counter) "+
+			"FROM ("+
+			"SELECT SUM(o_counter) as o_counter from {performers_images} s "+
+			"LEFT JOIN {images} ON {images}.id = s.{images_id} "+
+			"WHERE s.{performer_id} = {performers}.id "+
+			"UNION ALL "+
+			"SELECT COUNT({scenes_o_dates}.{o_date}) as o_counter from {performers_scenes} s "+
+			"LEFT JOIN {scenes} ON {scenes}.id = s.{scene_id} "+
+			"LEFT JOIN {scenes_o_dates} ON {scenes_o_dates}.{scene_id} = {scenes}.id AND {user_condition} "+
+			"WHERE s.{performer_id} = {performers}.id "+
+			")",
+		map[string]interface{}{
+			"performers_images": performersImagesTable,
+			"images":            imageTable,
+			"performer_id":      performerIDColumn,
+			"images_id":         imageIDColumn,
+			"performers":        performerTable,
+			"performers_scenes": performersScenesTable,
+			"scenes":            sceneTable,
+			"scene_id":          sceneIDColumn,
+			"scenes_o_dates":    scenesODatesTable,
+			"o_date":            sceneODateColumn,
+			"user_condition":    historyUserCondition(ctx, scenesODatesTable),
+		},
+	)
+}
 
 // used for sorting and filtering play count on performer view count
-var selectPerformerPlayCountSQL = utils.StrFormat(
-	"SELECT COUNT(DISTINCT {view_date}) FROM ("+
-		"SELECT {view_date} FROM {performers_scenes} s "+
-		"LEFT JOIN {scenes} ON {scenes}.id = s.{scene_id} "+
-		"LEFT JOIN {scenes_view_dates} ON {scenes_view_dates}.{scene_id} = {scenes}.id "+
-		"WHERE s.{performer_id} = {performers}.id"+
-		")",
-	map[string]interface{}{
-		"performer_id":      performerIDColumn,
-		"performers":        performerTable,
-		"performers_scenes": performersScenesTable,
-		"scenes":            sceneTable,
-		"scene_id":          sceneIDColumn,
-		"scenes_view_dates": scenesViewDatesTable,
-		"view_date":         sceneViewDateColumn,
-	},
-)
+func selectPerformerPlayCountSQL(ctx context.Context) string {
+	return utils.StrFormat(
+		"SELECT COUNT(DISTINCT {view_date}) FROM ("+
+			"SELECT {view_date} FROM {performers_scenes} s "+
+			"LEFT JOIN {scenes} ON {scenes}.id = s.{scene_id} "+
+			"LEFT JOIN {scenes_view_dates} ON {scenes_view_dates}.{scene_id} = {scenes}.id AND {user_condition} "+
+			"WHERE s.{performer_id} = {performers}.id"+
+			")",
+		map[string]interface{}{
+			"performer_id":      performerIDColumn,
+			"performers":        performerTable,
+			"performers_scenes": performersScenesTable,
+			"scenes":            sceneTable,
+			"scene_id":          sceneIDColumn,
+			"scenes_view_dates": scenesViewDatesTable,
+			"view_date":         sceneViewDateColumn,
+			"user_condition":    historyUserCondition(ctx, scenesViewDatesTable),
+		},
+	)
+}
 
 func (qb *performerFilterHandler) oCounterCriterionHandler(count *models.IntCriterionInput) criterionHandlerFunc {
 	return func(ctx context.Context, f *filterBuilder) {
@@ -379,7 +390,7 @@ func (qb *performerFilterHandler) oCounterCriterionHandler(count *models.IntCrit
 			return
 		}
 
-		lhs := "(" + selectPerformerOCountSQL + ")"
+		lhs := "(" + selectPerformerOCountSQL(ctx) + ")"
 		clause, args := getIntCriterionWhereClause(lhs, *count)
 
 		f.addWhere(clause, args...)
@@ -392,7 +403,7 @@ func (qb *performerFilterHandler) playCounterCriterionHandler(count *models.IntC
 			return
 		}
 
-		lhs := "(" + selectPerformerPlayCountSQL + ")"
+		lhs := "(" + selectPerformerPlayCountSQL(ctx) + ")"
 		clause, args := getIntCriterionWhereClause(lhs, *count)
 
 		f.addWhere(clause, args...)
@@ -2051,6 +2051,117 @@ func TestPerformerStore_FindByStashIDStatus(t *testing.T) {
 	}
 }
 
+func TestPerformerMerge(t *testing.T) {
+	assert := assert.New(t)
+
+	// merge tests - perform these in a transaction that we'll rollback
+	if err := withRollbackTxn(func(ctx context.Context) error {
+		qb := db.Performer
+
+		// try merging into same performer
+		err := qb.Merge(ctx, []int{performerIDs[performerIdxWithScene]}, performerIDs[performerIdxWithScene])
+		assert.NotNil(err)
+
+		srcIdxs := []int{
+			performerIdx1WithScene,
+			performerIdxWithImage,
+			performerIdxWithGallery,
+			performerIdxWithTag,
+		}
+		var srcIDs []int
+		for _, idx := range srcIdxs {
+			srcIDs = append(srcIDs, performerIDs[idx])
+		}
+
+		destID := performerIDs[performerIdxWithScene]
+
+		srcTagIDs, err := qb.GetTagIDs(ctx, performerIDs[performerIdxWithTag])
+		if err != nil {
+			return err
+		}
+
+		var srcURLs []string
+		var srcStashIDs []models.StashID
+		for _, id := range srcIDs {
+			urls, err := qb.GetURLs(ctx, id)
+			if err != nil {
+				return err
+			}
+			srcURLs = append(srcURLs, urls...)
+
+			stashIDs, err := qb.GetStashIDs(ctx, id)
+			if err != nil {
+				return err
+			}
+			srcStashIDs = append(srcStashIDs, stashIDs...)
+		}
+
+		if err = qb.Merge(ctx, srcIDs, destID); err != nil {
+			return err
+		}
+
+		// ensure source performers are deleted
+		for _, id := range srcIDs {
+			p, err := qb.Find(ctx, id)
+			if err != nil {
+				return err
+			}
+
+			assert.Nil(p)
+		}
+
+		// ensure source names are set as aliases on the destination
+		destAliases, err := qb.GetAliases(ctx, destID)
+		if err != nil {
+			return err
+		}
+		for _, idx := range srcIdxs {
+			assert.Contains(destAliases, performerNames[idx])
+		}
+
+		destURLs, err := qb.GetURLs(ctx, destID)
+		if err != nil {
+			return err
+		}
+		assert.Subset(destURLs, srcURLs)
+
+		destStashIDs, err := qb.GetStashIDs(ctx, destID)
+		if err != nil {
+			return err
+		}
+		assert.Subset(destStashIDs, srcStashIDs)
+
+		destTagIDs, err := qb.GetTagIDs(ctx, destID)
+		if err != nil {
+			return err
+		}
+		assert.Subset(destTagIDs, srcTagIDs)
+
+		// ensure scene, image and gallery point to the destination
+		scenePerformerIDs, err := db.Scene.GetPerformerIDs(ctx, sceneIDs[sceneIdxWithTwoPerformers])
+		if err != nil {
+			return err
+		}
+		assert.Contains(scenePerformerIDs, destID)
+
+		imagePerformerIDs, err := db.Image.GetPerformerIDs(ctx, imageIDs[imageIdxWithPerformer])
+		if err != nil {
+			return err
+		}
+		assert.Contains(imagePerformerIDs, destID)
+
+		galleryPerformerIDs, err := db.Gallery.GetPerformerIDs(ctx, galleryIDs[galleryIdxWithPerformer])
+		if err != nil {
+			return err
+		}
+		assert.Contains(galleryPerformerIDs, destID)
+
+		return nil
+	}); err != nil {
+		t.Error(err.Error())
+	}
+}
+
 // TODO Update
 // TODO Destroy
 // TODO Find
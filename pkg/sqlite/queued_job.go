@@ -0,0 +1,157 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/doug-martin/goqu/v9/exp"
+	"github.com/jmoiron/sqlx"
+	"gopkg.in/guregu/null.v4"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+const (
+	queuedJobTable         = "queued_jobs"
+	queuedJobAddedAtColumn = "added_at"
+)
+
+type queuedJobRow struct {
+	// ID is the ID of the job in the job queue, and is not generated
+	ID            int       `db:"id"`
+	Kind          string    `db:"kind"`
+	Description   string    `db:"description"`
+	Input         []byte    `db:"input"`
+	Priority      int       `db:"priority"`
+	ResourceClass string    `db:"resource_class"`
+	Paused        bool      `db:"paused"`
+	AddedAt       Timestamp `db:"added_at"`
+	UserID        null.Int  `db:"user_id"`
+}
+
+func (r *queuedJobRow) fromQueuedJob(o models.QueuedJob) {
+	r.ID = o.ID
+	r.Kind = o.Kind
+	r.Description = o.Description
+	r.Input = o.Input
+	r.Priority = o.Priority
+	r.ResourceClass = o.ResourceClass
+	r.Paused = o.Paused
+	r.AddedAt = Timestamp{Timestamp: o.AddedAt}
+	r.UserID = intFromPtr(o.UserID)
+}
+
+func (r *queuedJobRow) resolve() *models.QueuedJob {
+	return &models.QueuedJob{
+		ID:            r.ID,
+		Kind:          r.Kind,
+		Description:   r.Description,
+		Input:         r.Input,
+		Priority:      r.Priority,
+		ResourceClass: r.ResourceClass,
+		Paused:        r.Paused,
+		AddedAt:       r.AddedAt.Timestamp,
+		UserID:        nullIntPtr(r.UserID),
+	}
+}
+
+type QueuedJobStore struct {
+	repository
+	tableMgr *table
+}
+
+func NewQueuedJobStore() *QueuedJobStore {
+	return &QueuedJobStore{
+		repository: repository{
+			tableName: queuedJobTable,
+			idColumn:  idColumn,
+		},
+		tableMgr: queuedJobTableMgr,
+	}
+}
+
+func (qb *QueuedJobStore) table() exp.IdentifierExpression {
+	return qb.tableMgr.table
+}
+
+func (qb *QueuedJobStore) selectDataset() *goqu.SelectDataset {
+	return dialect.From(qb.table()).Select(qb.table().All())
+}
+
+func (qb *QueuedJobStore) Create(ctx context.Context, newObject *models.QueuedJob) error {
+	var r queuedJobRow
+	r.fromQueuedJob(*newObject)
+
+	if _, err := qb.tableMgr.insert(ctx, r); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (qb *QueuedJobStore) Update(ctx context.Context, updatedObject *models.QueuedJob) error {
+	var r queuedJobRow
+	r.fromQueuedJob(*updatedObject)
+
+	return qb.tableMgr.updateByID(ctx, updatedObject.ID, r)
+}
+
+func (qb *QueuedJobStore) Destroy(ctx context.Context, id int) error {
+	return qb.tableMgr.destroyExisting(ctx, []int{id})
+}
+
+// returns nil, nil if not found
+func (qb *QueuedJobStore) Find(ctx context.Context, id int) (*models.QueuedJob, error) {
+	ret, err := qb.find(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	return ret, err
+}
+
+// returns nil, sql.ErrNoRows if not found
+func (qb *QueuedJobStore) find(ctx context.Context, id int) (*models.QueuedJob, error) {
+	q := qb.selectDataset().Where(qb.tableMgr.byID(id))
+
+	ret, err := qb.getMany(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ret) == 0 {
+		return nil, sql.ErrNoRows
+	}
+
+	return ret[0], nil
+}
+
+func (qb *QueuedJobStore) getMany(ctx context.Context, q *goqu.SelectDataset) ([]*models.QueuedJob, error) {
+	const single = false
+	var ret []*models.QueuedJob
+	if err := queryFunc(ctx, q, single, func(r *sqlx.Rows) error {
+		var f queuedJobRow
+		if err := r.StructScan(&f); err != nil {
+			return err
+		}
+
+		ret = append(ret, f.resolve())
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("getting queued jobs: %w", err)
+	}
+
+	return ret, nil
+}
+
+func (qb *QueuedJobStore) All(ctx context.Context) ([]*models.QueuedJob, error) {
+	table := qb.table()
+	q := qb.selectDataset().Order(
+		table.Col(queuedJobAddedAtColumn).Asc(),
+		table.Col(idColumn).Asc(),
+	)
+
+	return qb.getMany(ctx, q)
+}
@@ -0,0 +1,96 @@
+//go:build integration
+// +build integration
+
+package sqlite_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQueuedJobStore(t *testing.T) {
+	withRollbackTxn(func(ctx context.Context) error {
+		qb := db.QueuedJob
+		now := time.Now().Truncate(time.Second)
+
+		second := &models.QueuedJob{
+			ID:            12,
+			Kind:          "scan",
+			Description:   "Scanning...",
+			Input:         []byte(`{"paths":["/foo"]}`),
+			ResourceClass: "IO",
+			AddedAt:       now,
+		}
+		first := &models.QueuedJob{
+			ID:            13,
+			Kind:          "generate",
+			Description:   "Generating...",
+			Priority:      1,
+			ResourceClass: "CPU",
+			Paused:        true,
+			AddedAt:       now.Add(-time.Minute),
+		}
+
+		for _, j := range []*models.QueuedJob{second, first} {
+			if err := qb.Create(ctx, j); err != nil {
+				t.Errorf("QueuedJobStore.Create() error = %v", err)
+				return nil
+			}
+		}
+
+		got, err := qb.Find(ctx, second.ID)
+		if err != nil {
+			t.Errorf("QueuedJobStore.Find() error = %v", err)
+			return nil
+		}
+		assert.Equal(t, second.Kind, got.Kind)
+		assert.Equal(t, second.Input, got.Input)
+		assert.Equal(t, second.ResourceClass, got.ResourceClass)
+		assert.True(t, second.AddedAt.Equal(got.AddedAt))
+
+		// creating with an existing id should fail
+		if err := qb.Create(ctx, &models.QueuedJob{ID: second.ID, Kind: "scan", AddedAt: now}); err == nil {
+			t.Error("QueuedJobStore.Create() expected error for duplicate id")
+		}
+
+		second.Priority = 5
+		second.Paused = true
+		if err := qb.Update(ctx, second); err != nil {
+			t.Errorf("QueuedJobStore.Update() error = %v", err)
+			return nil
+		}
+
+		all, err := qb.All(ctx)
+		if err != nil {
+			t.Errorf("QueuedJobStore.All() error = %v", err)
+			return nil
+		}
+
+		// ordered by time added
+		if assert.Len(t, all, 2) {
+			assert.Equal(t, first.ID, all[0].ID)
+			assert.True(t, all[0].Paused)
+			assert.Equal(t, second.ID, all[1].ID)
+			assert.Equal(t, 5, all[1].Priority)
+			assert.True(t, all[1].Paused)
+		}
+
+		if err := qb.Destroy(ctx, first.ID); err != nil {
+			t.Errorf("QueuedJobStore.Destroy() error = %v", err)
+			return nil
+		}
+
+		got, err = qb.Find(ctx, first.ID)
+		if err != nil {
+			t.Errorf("QueuedJobStore.Find() error = %v", err)
+			return nil
+		}
+		assert.Nil(t, got)
+
+		return nil
+	})
+}
@@ -375,6 +375,116 @@ func (r *captionRepository) replace(ctx context.Context, id models.FileID, capti
 	return nil
 }
 
+type videoStreamRow struct {
+	Index     int    `db:"stream_index"`
+	Type      string `db:"stream_type"`
+	Codec     string `db:"codec"`
+	Language  string `db:"language"`
+	Title     string `db:"title"`
+	IsDefault bool   `db:"is_default"`
+}
+
+func (r *videoStreamRow) resolve() *models.VideoStream {
+	return &models.VideoStream{
+		Index:    r.Index,
+		Type:     models.VideoStreamType(r.Type),
+		Codec:    r.Codec,
+		Language: r.Language,
+		Title:    r.Title,
+		Default:  r.IsDefault,
+	}
+}
+
+type videoStreamRepository struct {
+	repository
+}
+
+func (r *videoStreamRepository) get(ctx context.Context, id models.FileID) ([]*models.VideoStream, error) {
+	query := fmt.Sprintf("SELECT stream_index, stream_type, codec, language, title, is_default from %s WHERE %s = ? ORDER BY stream_index", r.tableName, r.idColumn)
+	var ret []*models.VideoStream
+	err := r.queryFunc(ctx, query, []interface{}{id}, false, func(rows *sqlx.Rows) error {
+		var row videoStreamRow
+		if err := rows.StructScan(&row); err != nil {
+			return err
+		}
+
+		ret = append(ret, row.resolve())
+		return nil
+	})
+	return ret, err
+}
+
+func (r *videoStreamRepository) insert(ctx context.Context, id models.FileID, s *models.VideoStream) (sql.Result, error) {
+	stmt := fmt.Sprintf("INSERT INTO %s (%s, stream_index, stream_type, codec, language, title, is_default) VALUES (?, ?, ?, ?, ?, ?, ?)", r.tableName, r.idColumn)
+	return dbWrapper.Exec(ctx, stmt, id, s.Index, s.Type.String(), s.Codec, s.Language, s.Title, s.Default)
+}
+
+func (r *videoStreamRepository) replace(ctx context.Context, id models.FileID, streams []*models.VideoStream) error {
+	if err := r.destroy(ctx, []int{int(id)}); err != nil {
+		return err
+	}
+
+	for _, s := range streams {
+		if _, err := r.insert(ctx, id, s); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+type videoChapterRow struct {
+	Title        string  `db:"title"`
+	StartSeconds float64 `db:"start_seconds"`
+	EndSeconds   float64 `db:"end_seconds"`
+}
+
+func (r *videoChapterRow) resolve() *models.VideoChapter {
+	return &models.VideoChapter{
+		Title:        r.Title,
+		StartSeconds: r.StartSeconds,
+		EndSeconds:   r.EndSeconds,
+	}
+}
+
+type videoChapterRepository struct {
+	repository
+}
+
+func (r *videoChapterRepository) get(ctx context.Context, id models.FileID) ([]*models.VideoChapter, error) {
+	query := fmt.Sprintf("SELECT title, start_seconds, end_seconds from %s WHERE %s = ? ORDER BY chapter_index", r.tableName, r.idColumn)
+	var ret []*models.VideoChapter
+	err := r.queryFunc(ctx, query, []interface{}{id}, false, func(rows *sqlx.Rows) error {
+		var row videoChapterRow
+		if err := rows.StructScan(&row); err != nil {
+			return err
+		}
+
+		ret = append(ret, row.resolve())
+		return nil
+	})
+	return ret, err
+}
+
+func (r *videoChapterRepository) insert(ctx context.Context, id models.FileID, index int, c *models.VideoChapter) (sql.Result, error) {
+	stmt := fmt.Sprintf("INSERT INTO %s (%s, chapter_index, title, start_seconds, end_seconds) VALUES (?, ?, ?, ?, ?)", r.tableName, r.idColumn)
+	return dbWrapper.Exec(ctx, stmt, id, index, c.Title, c.StartSeconds, c.EndSeconds)
+}
+
+func (r *videoChapterRepository) replace(ctx context.Context, id models.FileID, chapters []*models.VideoChapter) error {
+	if err := r.destroy(ctx, []int{int(id)}); err != nil {
+		return err
+	}
+
+	for i, c := range chapters {
+		if _, err := r.insert(ctx, id, i, c); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 type stringRepository struct {
 	repository
 	stringColumn string
@@ -37,6 +37,8 @@ const (
 	sceneODateColumn      = "o_date"
 
 	sceneCoverBlobColumn = "cover_blob"
+
+	sceneCustomFieldsTable = "scene_custom_fields"
 )
 
 var findExactDuplicateQuery = `
@@ -230,6 +232,7 @@ var (
 
 type SceneStore struct {
 	blobJoinQueryBuilder
+	customFieldsStore
 
 	tableMgr *table
 	oDateManager
@@ -244,6 +247,9 @@ func NewSceneStore(r *storeRepository, blobStore *BlobStore) *SceneStore {
 			blobStore: blobStore,
 			joinTable: sceneTable,
 		},
+		customFieldsStore: customFieldsStore{
+			tableMgr: sceneCustomFieldsTableMgr,
+		},
 
 		tableMgr:        sceneTableMgr,
 		viewDateManager: viewDateManager{scenesViewTableMgr},
@@ -301,11 +307,21 @@ func (qb *SceneStore) Create(ctx context.Context, newObject *models.Scene, fileI
 	var r sceneRow
 	r.fromScene(*newObject)
 
+	if currentUserID(ctx) != nil {
+		// rating and resume time are stored for the current user
+		r.Rating = null.Int{}
+		r.ResumeTime = 0
+	}
+
 	id, err := qb.tableMgr.insertID(ctx, r)
 	if err != nil {
 		return err
 	}
 
+	if err := qb.setUserState(ctx, id, newObject.Rating, newObject.ResumeTime); err != nil {
+		return err
+	}
+
 	if len(fileIDs) > 0 {
 		const firstPrimary = true
 		if err := scenesFilesTableMgr.insertJoins(ctx, id, firstPrimary, fileIDs); err != nil {
@@ -360,6 +376,11 @@ func (qb *SceneStore) Create(ctx context.Context, newObject *models.Scene, fileI
 }
 
 func (qb *SceneStore) UpdatePartial(ctx context.Context, id int, partial models.ScenePartial) (*models.Scene, error) {
+	editBefore, err := takeEditSnapshot(ctx, id, qb.editSnapshot)
+	if err != nil {
+		return nil, err
+	}
+
 	r := sceneRowRecord{
 		updateRecord{
 			Record: make(exp.Record),
@@ -368,6 +389,17 @@ func (qb *SceneStore) UpdatePartial(ctx context.Context, id int, partial models.
 
 	r.fromPartial(partial)
 
+	if err := setUserRatingPartial(ctx, sceneRatingsTableMgr, id, partial.Rating, &r.updateRecord); err != nil {
+		return nil, err
+	}
+
+	if userID := currentUserID(ctx); userID != nil && partial.ResumeTime.Set {
+		if err := sceneResumeTimesTableMgr.set(ctx, id, *userID, partial.ResumeTime.Value); err != nil {
+			return nil, err
+		}
+		delete(r.Record, sceneResumeTimeColumn)
+	}
+
 	if len(r.Record) > 0 {
 		if err := qb.tableMgr.updateByID(ctx, id, r.Record); err != nil {
 			return nil, err
@@ -410,17 +442,40 @@ func (qb *SceneStore) UpdatePartial(ctx context.Context, id int, partial models.
 		}
 	}
 
+	if err := recordEdit(ctx, models.EditObjectTypeScene, id, editBefore, qb.editSnapshot); err != nil {
+		return nil, err
+	}
+
 	return qb.find(ctx, id)
 }
 
 func (qb *SceneStore) Update(ctx context.Context, updatedObject *models.Scene) error {
+	editBefore, err := takeEditSnapshot(ctx, updatedObject.ID, qb.editSnapshot)
+	if err != nil {
+		return err
+	}
+
 	var r sceneRow
 	r.fromScene(*updatedObject)
 
+	if currentUserID(ctx) != nil {
+		// retain the shared rating and resume time
+		if err := getSharedValue(ctx, qb.tableMgr, updatedObject.ID, userRatingColumn, &r.Rating); err != nil {
+			return err
+		}
+		if err := getSharedValue(ctx, qb.tableMgr, updatedObject.ID, sceneResumeTimeColumn, &r.ResumeTime); err != nil {
+			return err
+		}
+	}
+
 	if err := qb.tableMgr.updateByID(ctx, updatedObject.ID, r); err != nil {
 		return err
 	}
 
+	if err := qb.setUserState(ctx, updatedObject.ID, updatedObject.Rating, updatedObject.ResumeTime); err != nil {
+		return err
+	}
+
 	if updatedObject.URLs.Loaded() {
 		if err := scenesURLsTableMgr.replaceJoins(ctx, updatedObject.ID, updatedObject.URLs.List()); err != nil {
 			return err
@@ -468,6 +523,10 @@ func (qb *SceneStore) Update(ctx context.Context, updatedObject *models.Scene) e
 		}
 	}
 
+	if err := recordEdit(ctx, models.EditObjectTypeScene, updatedObject.ID, editBefore, qb.editSnapshot); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -582,9 +641,68 @@ func (qb *SceneStore) getMany(ctx context.Context, q *goqu.SelectDataset) ([]*mo
 		return nil, err
 	}
 
+	if err := qb.applyUserState(ctx, ret); err != nil {
+		return nil, err
+	}
+
 	return ret, nil
 }
 
+// applyUserState replaces the rating and resume time of the provided scenes
+// with those of the current user.
+func (qb *SceneStore) applyUserState(ctx context.Context, scenes []*models.Scene) error {
+	userID := currentUserID(ctx)
+	if userID == nil || len(scenes) == 0 {
+		return nil
+	}
+
+	ids := make([]int, len(scenes))
+	for i, s := range scenes {
+		ids[i] = s.ID
+	}
+
+	ratings, err := sceneRatingsTableMgr.getMany(ctx, *userID, ids)
+	if err != nil {
+		return fmt.Errorf("getting user ratings: %w", err)
+	}
+
+	resumeTimes, err := sceneResumeTimesTableMgr.getMany(ctx, *userID, ids)
+	if err != nil {
+		return fmt.Errorf("getting user resume times: %w", err)
+	}
+
+	for _, s := range scenes {
+		s.Rating = userRating(ratings, s.ID)
+		s.ResumeTime = resumeTimes[s.ID]
+	}
+
+	return nil
+}
+
+// setUserState sets the rating and resume time of the scene for the current
+// user. Does nothing if there is no current user.
+func (qb *SceneStore) setUserState(ctx context.Context, id int, rating *int, resumeTime float64) error {
+	set, err := setUserRating(ctx, sceneRatingsTableMgr, id, rating)
+	if err != nil || !set {
+		return err
+	}
+
+	return qb.setUserResumeTime(ctx, id, resumeTime)
+}
+
+func (qb *SceneStore) setUserResumeTime(ctx context.Context, id int, resumeTime float64) error {
+	userID := currentUserID(ctx)
+	if userID == nil {
+		return nil
+	}
+
+	if resumeTime == 0 {
+		return sceneResumeTimesTableMgr.delete(ctx, id, *userID)
+	}
+
+	return sceneResumeTimesTableMgr.set(ctx, id, *userID, resumeTime)
+}
+
 func (qb *SceneStore) GetFiles(ctx context.Context, id int) ([]*models.VideoFile, error) {
 	fileIDs, err := sceneRepository.files.get(ctx, id)
 	if err != nil {
@@ -768,7 +886,10 @@ func (qb *SceneStore) OCountByPerformerID(ctx context.Context, performerID int)
 		goqu.On(
 			table.Col(idColumn).Eq(joinTable.Col(sceneIDColumn)),
 		),
-	).Where(joinTable.Col(performerIDColumn).Eq(performerID))
+	).Where(
+		joinTable.Col(performerIDColumn).Eq(performerID),
+		scenesOTableMgr.userCondition(ctx),
+	)
 
 	var ret int
 	if err := querySimple(ctx, q, &ret); err != nil {
@@ -954,7 +1075,7 @@ func (qb *SceneStore) makeQuery(ctx context.Context, sceneFilter *models.SceneFi
 		return nil, err
 	}
 
-	if err := qb.setSceneSort(&query, findFilter); err != nil {
+	if err := qb.setSceneSort(ctx, &query, findFilter); err != nil {
 		return nil, err
 	}
 	query.sortAndPagination += getPagination(findFilter)
@@ -1083,7 +1204,7 @@ var sceneSortOptions = sortOptions{
 	"updated_at",
 }
 
-func (qb *SceneStore) setSceneSort(query *queryBuilder, findFilter *models.FindFilterType) error {
+func (qb *SceneStore) setSceneSort(ctx context.Context, query *queryBuilder, findFilter *models.FindFilterType) error {
 	if findFilter == nil || findFilter.Sort == nil || *findFilter.Sort == "" {
 		return nil
 	}
@@ -1180,13 +1301,17 @@ func (qb *SceneStore) setSceneSort(query *queryBuilder, findFilter *models.FindF
 		addFolderTable()
 		query.sortAndPagination += " ORDER BY COALESCE(scenes.title, files.basename) COLLATE NATURAL_CI " + direction + ", folders.path COLLATE NATURAL_CI " + direction
 	case "play_count":
-		query.sortAndPagination += getCountSort(sceneTable, scenesViewDatesTable, sceneIDColumn, direction)
+		query.sortAndPagination += getCountSort(sceneTable, userHistoryTable(ctx, scenesViewDatesTable), sceneIDColumn, direction)
 	case "last_played_at":
-		query.sortAndPagination += fmt.Sprintf(" ORDER BY (SELECT MAX(view_date) FROM %s AS sort WHERE sort.%s = %s.id) %s", scenesViewDatesTable, sceneIDColumn, sceneTable, getSortDirection(direction))
+		query.sortAndPagination += fmt.Sprintf(" ORDER BY (SELECT MAX(view_date) FROM %s AS sort WHERE sort.%s = %s.id) %s", userHistoryTable(ctx, scenesViewDatesTable), sceneIDColumn, sceneTable, getSortDirection(direction))
 	case "last_o_at":
-		query.sortAndPagination += fmt.Sprintf(" ORDER BY (SELECT MAX(o_date) FROM %s AS sort WHERE sort.%s = %s.id) %s", scenesODatesTable, sceneIDColumn, sceneTable, getSortDirection(direction))
+		query.sortAndPagination += fmt.Sprintf(" ORDER BY (SELECT MAX(o_date) FROM %s AS sort WHERE sort.%s = %s.id) %s", userHistoryTable(ctx, scenesODatesTable), sceneIDColumn, sceneTable, getSortDirection(direction))
 	case "o_counter":
-		query.sortAndPagination += getCountSort(sceneTable, scenesODatesTable, sceneIDColumn, direction)
+		query.sortAndPagination += getCountSort(sceneTable, userHistoryTable(ctx, scenesODatesTable), sceneIDColumn, direction)
+	case "rating":
+		query.sortAndPagination += getRatingSort(ctx, sceneRatingsTableMgr, sceneTable, direction)
+	case "resume_time":
+		query.sortAndPagination += " ORDER BY " + sceneResumeTimeColumnSQL(ctx) + " " + getSortDirection(direction)
 	default:
 		query.sortAndPagination += getSort(sort, direction, "scenes")
 	}
@@ -1197,6 +1322,16 @@ func (qb *SceneStore) setSceneSort(query *queryBuilder, findFilter *models.FindF
 	return nil
 }
 
+// sceneResumeTimeColumnSQL returns the sql expression for the resume time of
+// the current user, or the shared resume time if there is no current user.
+func sceneResumeTimeColumnSQL(ctx context.Context) string {
+	if userID := currentUserID(ctx); userID != nil {
+		return "COALESCE(" + sceneResumeTimesTableMgr.valueSQL(*userID, sceneTable+"."+idColumn) + ", 0)"
+	}
+
+	return sceneTable + "." + sceneResumeTimeColumn
+}
+
 func (qb *SceneStore) SaveActivity(ctx context.Context, id int, resumeTime *float64, playDuration *float64) (bool, error) {
 	if err := qb.tableMgr.checkIDExists(ctx, id); err != nil {
 		return false, err
@@ -1205,7 +1340,13 @@ func (qb *SceneStore) SaveActivity(ctx context.Context, id int, resumeTime *floa
 	record := goqu.Record{}
 
 	if resumeTime != nil {
-		record["resume_time"] = resumeTime
+		if currentUserID(ctx) != nil {
+			if err := qb.setUserResumeTime(ctx, id, *resumeTime); err != nil {
+				return false, err
+			}
+		} else {
+			record["resume_time"] = resumeTime
+		}
 	}
 
 	if playDuration != nil {
@@ -1229,7 +1370,13 @@ func (qb *SceneStore) ResetActivity(ctx context.Context, id int, resetResume boo
 	record := goqu.Record{}
 
 	if resetResume {
-		record["resume_time"] = 0.0
+		if currentUserID(ctx) != nil {
+			if err := qb.setUserResumeTime(ctx, id, 0); err != nil {
+				return false, err
+			}
+		} else {
+			record["resume_time"] = 0.0
+		}
 	}
 
 	if resetDuration {
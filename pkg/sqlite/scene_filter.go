@@ -92,7 +92,7 @@ func (qb *sceneFilterHandler) criterionHandler() criterionHandler {
 
 		qb.phashDistanceCriterionHandler(sceneFilter.PhashDistance),
 
-		intCriterionHandler(sceneFilter.Rating100, "scenes.rating", nil),
+		ratingCriterionHandler(sceneFilter.Rating100, sceneRatingsTableMgr, sceneTable),
 		qb.oCountCriterionHandler(sceneFilter.OCounter),
 		boolCriterionHandler(sceneFilter.Organized, "scenes.organized", nil),
 
@@ -127,13 +127,13 @@ func (qb *sceneFilterHandler) criterionHandler() criterionHandler {
 
 		qb.captionCriterionHandler(sceneFilter.Captions),
 
-		floatIntCriterionHandler(sceneFilter.ResumeTime, "scenes.resume_time", nil),
+		qb.resumeTimeCriterionHandler(sceneFilter.ResumeTime),
 		floatIntCriterionHandler(sceneFilter.PlayDuration, "scenes.play_duration", nil),
 		qb.playCountCriterionHandler(sceneFilter.PlayCount),
 		criterionHandlerFunc(func(ctx context.Context, f *filterBuilder) {
 			if sceneFilter.LastPlayedAt != nil {
 				f.addLeftJoin(
-					fmt.Sprintf("(SELECT %s, MAX(%s) as last_played_at FROM %s GROUP BY %s)", sceneIDColumn, sceneViewDateColumn, scenesViewDatesTable, sceneIDColumn),
+					fmt.Sprintf("(SELECT %s, MAX(%s) as last_played_at FROM %s GROUP BY %s)", sceneIDColumn, sceneViewDateColumn, userHistoryTable(ctx, scenesViewDatesTable), sceneIDColumn),
 					"scene_last_view",
 					fmt.Sprintf("scene_last_view.%s = scenes.id", sceneIDColumn),
 				)
@@ -159,6 +159,11 @@ func (qb *sceneFilterHandler) criterionHandler() criterionHandler {
 		&dateCriterionHandler{sceneFilter.Date, "scenes.date", nil},
 		&timestampCriterionHandler{sceneFilter.CreatedAt, "scenes.created_at", nil},
 		&timestampCriterionHandler{sceneFilter.UpdatedAt, "scenes.updated_at", nil},
+		&customFieldsFilterHandler{
+			criteria:    sceneFilter.CustomFields,
+			tableMgr:    sceneCustomFieldsTableMgr,
+			parentIDCol: "scenes.id",
+		},
 
 		&relatedFilterHandler{
 			relatedIDCol:   "scenes_galleries.gallery_id",
@@ -232,24 +237,34 @@ func (qb *sceneFilterHandler) addVideoFilesTable(f *filterBuilder) {
 	f.addLeftJoin(videoFileTable, "", "video_files.file_id = scenes_files.file_id")
 }
 
-func (qb *sceneFilterHandler) playCountCriterionHandler(count *models.IntCriterionInput) criterionHandlerFunc {
-	h := countCriterionHandlerBuilder{
-		primaryTable: sceneTable,
-		joinTable:    scenesViewDatesTable,
-		primaryFK:    sceneIDColumn,
+func (qb *sceneFilterHandler) resumeTimeCriterionHandler(resumeTime *models.IntCriterionInput) criterionHandlerFunc {
+	return func(ctx context.Context, f *filterBuilder) {
+		floatIntCriterionHandler(resumeTime, sceneResumeTimeColumnSQL(ctx), nil)(ctx, f)
 	}
+}
 
-	return h.handler(count)
+func (qb *sceneFilterHandler) playCountCriterionHandler(count *models.IntCriterionInput) criterionHandlerFunc {
+	return func(ctx context.Context, f *filterBuilder) {
+		h := countCriterionHandlerBuilder{
+			primaryTable: sceneTable,
+			joinTable:    userHistoryTable(ctx, scenesViewDatesTable),
+			primaryFK:    sceneIDColumn,
+		}
+
+		h.handler(count)(ctx, f)
+	}
 }
 
 func (qb *sceneFilterHandler) oCountCriterionHandler(count *models.IntCriterionInput) criterionHandlerFunc {
-	h := countCriterionHandlerBuilder{
-		primaryTable: sceneTable,
-		joinTable:    scenesODatesTable,
-		primaryFK:    sceneIDColumn,
-	}
+	return func(ctx context.Context, f *filterBuilder) {
+		h := countCriterionHandlerBuilder{
+			primaryTable: sceneTable,
+			joinTable:    userHistoryTable(ctx, scenesODatesTable),
+			primaryFK:    sceneIDColumn,
+		}
 
-	return h.handler(count)
+		h.handler(count)(ctx, f)
+	}
 }
 
 func (qb *sceneFilterHandler) fileCountCriterionHandler(fileCount *models.IntCriterionInput) criterionHandlerFunc {
@@ -277,7 +292,8 @@ func (qb *sceneFilterHandler) phashDuplicatedCriterionHandler(duplicatedFilter *
 				v = "="
 			}
 
-			f.addInnerJoin("(SELECT file_id FROM files_fingerprints INNER JOIN (SELECT fingerprint FROM files_fingerprints WHERE type = 'phash' GROUP BY fingerprint HAVING COUNT (fingerprint) "+v+" 1) dupes on files_fingerprints.fingerprint = dupes.fingerprint)", "scph", "scenes_files.file_id = scph.file_id")
+			// image files also have phashes, so only consider video files
+			f.addInnerJoin("(SELECT file_id FROM files_fingerprints INNER JOIN (SELECT fingerprint FROM files_fingerprints INNER JOIN video_files ON files_fingerprints.file_id = video_files.file_id WHERE type = 'phash' GROUP BY fingerprint HAVING COUNT (fingerprint) "+v+" 1) dupes on files_fingerprints.fingerprint = dupes.fingerprint)", "scph", "scenes_files.file_id = scph.file_id")
 		}
 	}
 }
@@ -9,6 +9,7 @@ import (
 	"github.com/doug-martin/goqu/v9"
 	"github.com/doug-martin/goqu/v9/exp"
 	"github.com/jmoiron/sqlx"
+	"gopkg.in/guregu/null.v4"
 
 	"github.com/stashapp/stash/pkg/models"
 	"github.com/stashapp/stash/pkg/sliceutil"
@@ -24,19 +25,21 @@ GROUP BY scene_markers.id
 `
 
 type sceneMarkerRow struct {
-	ID           int       `db:"id" goqu:"skipinsert"`
-	Title        string    `db:"title"` // TODO: make db schema (and gql schema) nullable
-	Seconds      float64   `db:"seconds"`
-	PrimaryTagID int       `db:"primary_tag_id"`
-	SceneID      int       `db:"scene_id"`
-	CreatedAt    Timestamp `db:"created_at"`
-	UpdatedAt    Timestamp `db:"updated_at"`
+	ID           int        `db:"id" goqu:"skipinsert"`
+	Title        string     `db:"title"` // TODO: make db schema (and gql schema) nullable
+	Seconds      float64    `db:"seconds"`
+	EndSeconds   null.Float `db:"end_seconds"`
+	PrimaryTagID int        `db:"primary_tag_id"`
+	SceneID      int        `db:"scene_id"`
+	CreatedAt    Timestamp  `db:"created_at"`
+	UpdatedAt    Timestamp  `db:"updated_at"`
 }
 
 func (r *sceneMarkerRow) fromSceneMarker(o models.SceneMarker) {
 	r.ID = o.ID
 	r.Title = o.Title
 	r.Seconds = o.Seconds
+	r.EndSeconds = null.FloatFromPtr(o.EndSeconds)
 	r.PrimaryTagID = o.PrimaryTagID
 	r.SceneID = o.SceneID
 	r.CreatedAt = Timestamp{Timestamp: o.CreatedAt}
@@ -48,6 +51,7 @@ func (r *sceneMarkerRow) resolve() *models.SceneMarker {
 		ID:           r.ID,
 		Title:        r.Title,
 		Seconds:      r.Seconds,
+		EndSeconds:   nullFloatPtr(r.EndSeconds),
 		PrimaryTagID: r.PrimaryTagID,
 		SceneID:      r.SceneID,
 		CreatedAt:    r.CreatedAt.Timestamp,
@@ -69,6 +73,7 @@ func (r *sceneMarkerRowRecord) fromPartial(o models.SceneMarkerPartial) {
 		r.set("title", o.Title.Value)
 	}
 	r.setFloat64("seconds", o.Seconds)
+	r.setNullFloat64("end_seconds", o.EndSeconds)
 	r.setInt("primary_tag_id", o.PrimaryTagID)
 	r.setInt("scene_id", o.SceneID)
 	r.setTimestamp("created_at", o.CreatedAt)
@@ -353,6 +358,7 @@ func (qb *SceneMarkerStore) QueryCount(ctx context.Context, sceneMarkerFilter *m
 
 var sceneMarkerSortOptions = sortOptions{
 	"created_at",
+	"duration",
 	"id",
 	"title",
 	"random",
@@ -376,6 +382,8 @@ func (qb *SceneMarkerStore) setSceneMarkerSort(query *queryBuilder, findFilter *
 		sort = "updated_at"
 		query.join(sceneTable, "", "scenes.id = scene_markers.scene_id")
 		query.sortAndPagination += getSort(sort, direction, sceneTable)
+	case "duration":
+		query.sortAndPagination += " ORDER BY (scene_markers.end_seconds - scene_markers.seconds) " + direction
 	case "title":
 		query.join(tagTable, "", "scene_markers.primary_tag_id = tags.id")
 		query.sortAndPagination += " ORDER BY COALESCE(NULLIF(scene_markers.title,''), tags.name) COLLATE NATURAL_CI " + direction
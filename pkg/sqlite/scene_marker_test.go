@@ -110,6 +110,105 @@ func TestMarkerQuerySortBySceneUpdated(t *testing.T) {
 	})
 }
 
+func TestMarkerEndSeconds(t *testing.T) {
+	if err := withRollbackTxn(func(ctx context.Context) error {
+		mqb := db.SceneMarker
+
+		endSeconds := 40.5
+		marker := models.SceneMarker{
+			SceneID:      sceneIDs[sceneIdxWithMarkers],
+			PrimaryTagID: tagIDs[tagIdxWithPrimaryMarkers],
+			Seconds:      10,
+			EndSeconds:   &endSeconds,
+		}
+
+		if err := mqb.Create(ctx, &marker); err != nil {
+			return err
+		}
+
+		found, err := mqb.Find(ctx, marker.ID)
+		if err != nil {
+			return err
+		}
+		assert.Equal(t, &endSeconds, found.EndSeconds)
+
+		// unset the end seconds
+		partial := models.NewSceneMarkerPartial()
+		partial.EndSeconds = models.NewOptionalFloat64Ptr(nil)
+		updated, err := mqb.UpdatePartial(ctx, marker.ID, partial)
+		if err != nil {
+			return err
+		}
+		assert.Nil(t, updated.EndSeconds)
+
+		return nil
+	}); err != nil {
+		t.Error(err.Error())
+	}
+}
+
+func TestMarkerQueryDuration(t *testing.T) {
+	if err := withRollbackTxn(func(ctx context.Context) error {
+		mqb := db.SceneMarker
+
+		endSeconds := 40.5
+		marker := models.SceneMarker{
+			SceneID:      sceneIDs[sceneIdxWithMarkers],
+			PrimaryTagID: tagIDs[tagIdxWithPrimaryMarkers],
+			Seconds:      10,
+			EndSeconds:   &endSeconds,
+		}
+
+		if err := mqb.Create(ctx, &marker); err != nil {
+			return err
+		}
+
+		perPage := -1
+		findFilter := &models.FindFilterType{
+			PerPage: &perPage,
+		}
+
+		query := func(c models.IntCriterionInput) []int {
+			t.Helper()
+			markers, _, err := mqb.Query(ctx, &models.SceneMarkerFilterType{
+				Duration: &c,
+			}, findFilter)
+			if err != nil {
+				t.Errorf("Error querying scene markers: %s", err.Error())
+			}
+
+			var ids []int
+			for _, m := range markers {
+				ids = append(ids, m.ID)
+			}
+			return ids
+		}
+
+		ids := query(models.IntCriterionInput{
+			Value:    30,
+			Modifier: models.CriterionModifierEquals,
+		})
+		assert.Equal(t, []int{marker.ID}, ids)
+
+		ids = query(models.IntCriterionInput{
+			Value:    30,
+			Modifier: models.CriterionModifierGreaterThan,
+		})
+		assert.Len(t, ids, 0)
+
+		// markers without an end have no duration
+		ids = query(models.IntCriterionInput{
+			Modifier: models.CriterionModifierIsNull,
+		})
+		assert.NotContains(t, ids, marker.ID)
+		assert.Len(t, ids, len(markerIDs))
+
+		return nil
+	}); err != nil {
+		t.Error(err.Error())
+	}
+}
+
 func verifyIDs(t *testing.T, modifier models.CriterionModifier, values []int, results []int) {
 	t.Helper()
 	switch modifier {
@@ -87,6 +87,8 @@ const (
 
 const dupeScenePhashes = 2
 
+const dupeImagePhashes = 2
+
 const (
 	imageIdxWithGallery = iota
 	imageIdx1WithGallery
@@ -924,6 +926,10 @@ func getScenePhash(index int, field string) int64 {
 	return int64(index % (totalScenes - dupeScenePhashes) * 1234)
 }
 
+func getImagePhash(index int) int64 {
+	return int64(index % (totalImages - dupeImagePhashes) * 1234)
+}
+
 func getSceneStringPtr(index int, field string) *string {
 	v := getPrefixedStringValue("scene", index, field)
 	return &v
@@ -1184,6 +1190,10 @@ func makeImageFile(i int) *models.ImageFile {
 					Type:        models.FingerprintTypeMD5,
 					Fingerprint: getImageStringValue(i, checksumField),
 				},
+				{
+					Type:        models.FingerprintTypePhash,
+					Fingerprint: getImagePhash(i),
+				},
 			},
 		},
 		Height: getHeight(i),
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/doug-martin/goqu/v9"
 	"github.com/doug-martin/goqu/v9/exp"
@@ -14,6 +15,7 @@ import (
 
 	"github.com/stashapp/stash/pkg/models"
 	"github.com/stashapp/stash/pkg/sliceutil"
+	"github.com/stashapp/stash/pkg/sliceutil/stringslice"
 	"github.com/stashapp/stash/pkg/studio"
 )
 
@@ -26,6 +28,8 @@ const (
 	studioNameColumn      = "name"
 	studioImageBlobColumn = "image_blob"
 	studiosTagsTable      = "studios_tags"
+
+	studioCustomFieldsTable = "studio_custom_fields"
 )
 
 type studioRow struct {
@@ -141,6 +145,7 @@ var (
 type StudioStore struct {
 	blobJoinQueryBuilder
 	tagRelationshipStore
+	customFieldsStore
 
 	tableMgr *table
 }
@@ -156,6 +161,9 @@ func NewStudioStore(blobStore *BlobStore) *StudioStore {
 				joinTable: studiosTagsTableMgr,
 			},
 		},
+		customFieldsStore: customFieldsStore{
+			tableMgr: studioCustomFieldsTableMgr,
+		},
 
 		tableMgr: studioTableMgr,
 	}
@@ -175,11 +183,20 @@ func (qb *StudioStore) Create(ctx context.Context, newObject *models.Studio) err
 	var r studioRow
 	r.fromStudio(*newObject)
 
+	if currentUserID(ctx) != nil {
+		// rating is stored for the current user
+		r.Rating = null.Int{}
+	}
+
 	id, err := qb.tableMgr.insertID(ctx, r)
 	if err != nil {
 		return err
 	}
 
+	if _, err := setUserRating(ctx, studioRatingsTableMgr, id, newObject.Rating); err != nil {
+		return err
+	}
+
 	if newObject.Aliases.Loaded() {
 		if err := studio.EnsureAliasesUnique(ctx, id, newObject.Aliases.List(), qb); err != nil {
 			return err
@@ -210,6 +227,11 @@ func (qb *StudioStore) Create(ctx context.Context, newObject *models.Studio) err
 }
 
 func (qb *StudioStore) UpdatePartial(ctx context.Context, input models.StudioPartial) (*models.Studio, error) {
+	editBefore, err := takeEditSnapshot(ctx, input.ID, qb.editSnapshot)
+	if err != nil {
+		return nil, err
+	}
+
 	r := studioRowRecord{
 		updateRecord{
 			Record: make(exp.Record),
@@ -218,6 +240,10 @@ func (qb *StudioStore) UpdatePartial(ctx context.Context, input models.StudioPar
 
 	r.fromPartial(input)
 
+	if err := setUserRatingPartial(ctx, studioRatingsTableMgr, input.ID, input.Rating, &r.updateRecord); err != nil {
+		return nil, err
+	}
+
 	if len(r.Record) > 0 {
 		if err := qb.tableMgr.updateByID(ctx, input.ID, r.Record); err != nil {
 			return nil, err
@@ -244,18 +270,38 @@ func (qb *StudioStore) UpdatePartial(ctx context.Context, input models.StudioPar
 		}
 	}
 
+	if err := recordEdit(ctx, models.EditObjectTypeStudio, input.ID, editBefore, qb.editSnapshot); err != nil {
+		return nil, err
+	}
+
 	return qb.Find(ctx, input.ID)
 }
 
 // This is only used by the Import/Export functionality
 func (qb *StudioStore) Update(ctx context.Context, updatedObject *models.Studio) error {
+	editBefore, err := takeEditSnapshot(ctx, updatedObject.ID, qb.editSnapshot)
+	if err != nil {
+		return err
+	}
+
 	var r studioRow
 	r.fromStudio(*updatedObject)
 
+	if currentUserID(ctx) != nil {
+		// retain the shared rating
+		if err := getSharedValue(ctx, qb.tableMgr, updatedObject.ID, userRatingColumn, &r.Rating); err != nil {
+			return err
+		}
+	}
+
 	if err := qb.tableMgr.updateByID(ctx, updatedObject.ID, r); err != nil {
 		return err
 	}
 
+	if _, err := setUserRating(ctx, studioRatingsTableMgr, updatedObject.ID, updatedObject.Rating); err != nil {
+		return err
+	}
+
 	if updatedObject.Aliases.Loaded() {
 		if err := studiosAliasesTableMgr.replaceJoins(ctx, updatedObject.ID, updatedObject.Aliases.List()); err != nil {
 			return err
@@ -272,6 +318,10 @@ func (qb *StudioStore) Update(ctx context.Context, updatedObject *models.Studio)
 		}
 	}
 
+	if err := recordEdit(ctx, models.EditObjectTypeStudio, updatedObject.ID, editBefore, qb.editSnapshot); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -366,9 +416,33 @@ func (qb *StudioStore) getMany(ctx context.Context, q *goqu.SelectDataset) ([]*m
 		return nil, err
 	}
 
+	if err := qb.applyUserRatings(ctx, ret); err != nil {
+		return nil, err
+	}
+
 	return ret, nil
 }
 
+// applyUserRatings replaces the ratings of the provided studios with those of
+// the current user.
+func (qb *StudioStore) applyUserRatings(ctx context.Context, studios []*models.Studio) error {
+	ids := make([]int, len(studios))
+	for i, o := range studios {
+		ids[i] = o.ID
+	}
+
+	ratings, err := getUserRatings(ctx, studioRatingsTableMgr, ids)
+	if err != nil || ratings == nil {
+		return err
+	}
+
+	for _, o := range studios {
+		o.Rating = userRating(ratings, o.ID)
+	}
+
+	return nil
+}
+
 func (qb *StudioStore) findBySubquery(ctx context.Context, sq *goqu.SelectDataset) ([]*models.Studio, error) {
 	table := qb.table()
 
@@ -539,7 +613,7 @@ func (qb *StudioStore) makeQuery(ctx context.Context, studioFilter *models.Studi
 	}
 
 	var err error
-	query.sortAndPagination, err = qb.getStudioSort(findFilter)
+	query.sortAndPagination, err = qb.getStudioSort(ctx, findFilter)
 	if err != nil {
 		return nil, err
 	}
@@ -589,7 +663,7 @@ var studioSortOptions = sortOptions{
 	"updated_at",
 }
 
-func (qb *StudioStore) getStudioSort(findFilter *models.FindFilterType) (string, error) {
+func (qb *StudioStore) getStudioSort(ctx context.Context, findFilter *models.FindFilterType) (string, error) {
 	var sort string
 	var direction string
 	if findFilter == nil {
@@ -617,6 +691,8 @@ func (qb *StudioStore) getStudioSort(findFilter *models.FindFilterType) (string,
 		sortQuery += getCountSort(studioTable, galleryTable, studioIDColumn, direction)
 	case "child_count":
 		sortQuery += getCountSort(studioTable, studioTable, studioParentIDColumn, direction)
+	case "rating":
+		sortQuery += getRatingSort(ctx, studioRatingsTableMgr, studioTable, direction)
 	default:
 		sortQuery += getSort(sort, direction, "studios")
 	}
@@ -649,3 +725,133 @@ func (qb *StudioStore) GetStashIDs(ctx context.Context, studioID int) ([]models.
 func (qb *StudioStore) GetAliases(ctx context.Context, studioID int) ([]string, error) {
 	return studiosAliasesTableMgr.get(ctx, studioID)
 }
+
+// Merge merges the source studios into the destination studio.
+// Scenes, images, galleries, groups and child studios of the source studios
+// are moved to the destination. The names and aliases of the source studios
+// are added as aliases of the destination, and their tags and stash IDs are
+// added to the destination. The source studios are then destroyed.
+//
+// If the destination is a descendant of a source studio, the destination is
+// moved to the closest ancestor that is not being merged. Callers should
+// use studio.ValidateMerge to ensure the merge does not create a parent cycle.
+func (qb *StudioStore) Merge(ctx context.Context, source []int, destination int) error {
+	if len(source) == 0 {
+		return nil
+	}
+
+	inBinding := getInBinding(len(source))
+
+	args := []interface{}{destination}
+	srcArgs := make([]interface{}, len(source))
+	for i, id := range source {
+		if id == destination {
+			return errors.New("cannot merge where source == destination")
+		}
+		srcArgs[i] = id
+	}
+
+	args = append(args, srcArgs...)
+
+	for _, table := range []string{sceneTable, imageTable, galleryTable, groupTable} {
+		if _, err := dbWrapper.Exec(ctx, "UPDATE "+table+" SET studio_id = ? WHERE studio_id IN "+inBinding, args...); err != nil {
+			return err
+		}
+	}
+
+	tagArgs := append(args, destination)
+	_, err := dbWrapper.Exec(ctx, `UPDATE OR IGNORE `+studiosTagsTable+`
+SET studio_id = ?
+WHERE studio_id IN `+inBinding+`
+AND NOT EXISTS(SELECT 1 FROM `+studiosTagsTable+` o WHERE o.tag_id = `+studiosTagsTable+`.tag_id AND o.studio_id = ?)`,
+		tagArgs...,
+	)
+	if err != nil {
+		return err
+	}
+
+	// delete source studio ids from the table where they couldn't be set
+	if _, err := dbWrapper.Exec(ctx, "DELETE FROM "+studiosTagsTable+" WHERE studio_id IN "+inBinding, srcArgs...); err != nil {
+		return err
+	}
+
+	dest, err := qb.find(ctx, destination)
+	if err != nil {
+		return fmt.Errorf("finding destination studio: %w", err)
+	}
+
+	// move the destination out from under the source studios
+	if dest.ParentID != nil && sliceutil.Contains(source, *dest.ParentID) {
+		parentID := dest.ParentID
+		for parentID != nil && sliceutil.Contains(source, *parentID) {
+			parent, err := qb.find(ctx, *parentID)
+			if err != nil {
+				return fmt.Errorf("finding parent studio: %w", err)
+			}
+			parentID = parent.ParentID
+		}
+
+		if _, err := dbWrapper.Exec(ctx, "UPDATE "+studioTable+" SET parent_id = ? WHERE id = ?", intFromPtr(parentID), destination); err != nil {
+			return err
+		}
+	}
+
+	if _, err := dbWrapper.Exec(ctx, "UPDATE "+studioTable+" SET parent_id = ? WHERE parent_id IN "+inBinding, args...); err != nil {
+		return err
+	}
+
+	aliases, err := qb.GetAliases(ctx, destination)
+	if err != nil {
+		return err
+	}
+	stashIDs, err := qb.GetStashIDs(ctx, destination)
+	if err != nil {
+		return err
+	}
+
+	for _, id := range source {
+		src, err := qb.find(ctx, id)
+		if err != nil {
+			return fmt.Errorf("finding source studio %d: %w", id, err)
+		}
+
+		srcAliases, err := qb.GetAliases(ctx, id)
+		if err != nil {
+			return err
+		}
+		srcStashIDs, err := qb.GetStashIDs(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		aliases = append(aliases, src.Name)
+		aliases = append(aliases, srcAliases...)
+		stashIDs = sliceutil.AppendUniques(stashIDs, srcStashIDs)
+	}
+
+	// aliases must not match the name of the destination studio
+	aliases = sliceutil.Filter(stringslice.UniqueFold(aliases), func(a string) bool {
+		return !strings.EqualFold(a, dest.Name)
+	})
+
+	// aliases are unique across studios, so the source aliases must be
+	// removed before they can be added to the destination
+	if err := studiosAliasesTableMgr.destroy(ctx, source); err != nil {
+		return err
+	}
+
+	if err := studiosAliasesTableMgr.replaceJoins(ctx, destination, aliases); err != nil {
+		return err
+	}
+	if err := studiosStashIDsTableMgr.replaceJoins(ctx, destination, stashIDs); err != nil {
+		return err
+	}
+
+	for _, id := range source {
+		if err := qb.Destroy(ctx, id); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -56,7 +56,7 @@ func (qb *studioFilterHandler) criterionHandler() criterionHandler {
 		stringCriterionHandler(studioFilter.Name, studioTable+".name"),
 		stringCriterionHandler(studioFilter.Details, studioTable+".details"),
 		stringCriterionHandler(studioFilter.URL, studioTable+".url"),
-		intCriterionHandler(studioFilter.Rating100, studioTable+".rating", nil),
+		ratingCriterionHandler(studioFilter.Rating100, studioRatingsTableMgr, studioTable),
 		boolCriterionHandler(studioFilter.Favorite, studioTable+".favorite", nil),
 		boolCriterionHandler(studioFilter.IgnoreAutoTag, studioTable+".ignore_auto_tag", nil),
 
@@ -84,6 +84,11 @@ func (qb *studioFilterHandler) criterionHandler() criterionHandler {
 		qb.childCountCriterionHandler(studioFilter.ChildCount),
 		&timestampCriterionHandler{studioFilter.CreatedAt, studioTable + ".created_at", nil},
 		&timestampCriterionHandler{studioFilter.UpdatedAt, studioTable + ".updated_at", nil},
+		&customFieldsFilterHandler{
+			criteria:    studioFilter.CustomFields,
+			tableMgr:    studioCustomFieldsTableMgr,
+			parentIDCol: studioTable + ".id",
+		},
 
 		&relatedFilterHandler{
 			relatedIDCol:   "scenes.id",
@@ -1122,6 +1122,119 @@ func TestStudioQueryFast(t *testing.T) {
 	})
 }
 
+func TestStudioMerge(t *testing.T) {
+	assert := assert.New(t)
+
+	// merge tests - perform these in a transaction that we'll rollback
+	if err := withRollbackTxn(func(ctx context.Context) error {
+		qb := db.Studio
+
+		// try merging into same studio
+		err := qb.Merge(ctx, []int{studioIDs[studioIdxWithScene]}, studioIDs[studioIdxWithScene])
+		assert.NotNil(err)
+
+		srcIdxs := []int{
+			studioIdxWithTwoScenes,
+			studioIdxWithImage,
+			studioIdxWithGallery,
+			studioIdxWithGroup,
+			studioIdxWithTag,
+			studioIdxWithChildStudio,
+		}
+		var srcIDs []int
+		for _, idx := range srcIdxs {
+			srcIDs = append(srcIDs, studioIDs[idx])
+		}
+
+		destID := studioIDs[studioIdxWithScene]
+
+		srcTagIDs, err := qb.GetTagIDs(ctx, studioIDs[studioIdxWithTag])
+		if err != nil {
+			return err
+		}
+
+		if err = qb.Merge(ctx, srcIDs, destID); err != nil {
+			return err
+		}
+
+		// ensure source studios are deleted
+		for _, id := range srcIDs {
+			s, err := qb.Find(ctx, id)
+			if err != nil {
+				return err
+			}
+
+			assert.Nil(s)
+		}
+
+		// ensure source names are set as aliases on the destination
+		destAliases, err := qb.GetAliases(ctx, destID)
+		if err != nil {
+			return err
+		}
+		for _, idx := range srcIdxs {
+			assert.Contains(destAliases, studioNames[idx])
+		}
+
+		destTagIDs, err := qb.GetTagIDs(ctx, destID)
+		if err != nil {
+			return err
+		}
+		assert.Subset(destTagIDs, srcTagIDs)
+
+		// ensure scenes, images, galleries and groups point to the destination
+		for _, idx := range []int{sceneIdx1WithStudio, sceneIdx2WithStudio} {
+			scene, err := db.Scene.Find(ctx, sceneIDs[idx])
+			if err != nil {
+				return err
+			}
+			assert.Equal(&destID, scene.StudioID)
+		}
+
+		image, err := db.Image.Find(ctx, imageIDs[imageIdxWithStudio])
+		if err != nil {
+			return err
+		}
+		assert.Equal(&destID, image.StudioID)
+
+		gallery, err := db.Gallery.Find(ctx, galleryIDs[galleryIdxWithStudio])
+		if err != nil {
+			return err
+		}
+		assert.Equal(&destID, gallery.StudioID)
+
+		group, err := db.Group.Find(ctx, groupIDs[groupIdxWithStudio])
+		if err != nil {
+			return err
+		}
+		assert.Equal(&destID, group.StudioID)
+
+		// ensure child studios are moved to the destination
+		child, err := qb.Find(ctx, studioIDs[studioIdxWithParentStudio])
+		if err != nil {
+			return err
+		}
+		assert.Equal(&destID, child.ParentID)
+
+		// merge parent into child - the child should be moved to the grandparent
+		destID = studioIDs[studioIdxWithGrandParent]
+		grandParentID := studioIDs[studioIdxWithGrandChild]
+		if err = qb.Merge(ctx, []int{studioIDs[studioIdxWithParentAndChild]}, destID); err != nil {
+			return err
+		}
+
+		dest, err := qb.Find(ctx, destID)
+		if err != nil {
+			return err
+		}
+		assert.Equal(&grandParentID, dest.ParentID)
+
+		return nil
+	}); err != nil {
+		t.Error(err.Error())
+	}
+}
+
 // TODO Create
 // TODO Update
 // TODO Destroy
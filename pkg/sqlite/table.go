@@ -846,9 +846,23 @@ func (t *relatedFilesTable) setPrimary(ctx context.Context, id int, fileID model
 	return nil
 }
 
+// viewHistoryTable stores dated history entries for objects. Entries are
+// scoped to the current user. Entries with a null user id belong to the
+// shared state that is used when there is no current user.
 type viewHistoryTable struct {
 	table
-	dateColumn exp.IdentifierExpression
+	dateColumn   exp.IdentifierExpression
+	userIDColumn exp.IdentifierExpression
+}
+
+// userCondition returns an expression matching the entries of the current
+// user, or the shared entries if there is no current user.
+func (t *viewHistoryTable) userCondition(ctx context.Context) exp.Expression {
+	if userID := currentUserID(ctx); userID != nil {
+		return t.userIDColumn.Eq(*userID)
+	}
+
+	return t.userIDColumn.IsNull()
 }
 
 func (t *viewHistoryTable) getDates(ctx context.Context, id int) ([]time.Time, error) {
@@ -858,6 +872,7 @@ func (t *viewHistoryTable) getDates(ctx context.Context, id int) ([]time.Time, e
 		t.dateColumn,
 	).From(table).Where(
 		t.idColumn.Eq(id),
+		t.userCondition(ctx),
 	).Order(t.dateColumn.Desc())
 
 	const single = false
@@ -884,6 +899,7 @@ func (t *viewHistoryTable) getManyDates(ctx context.Context, ids []int) ([][]tim
 		t.dateColumn,
 	).From(table).Where(
 		t.idColumn.In(ids),
+		t.userCondition(ctx),
 	).Order(t.dateColumn.Desc())
 
 	ret := make([][]time.Time, len(ids))
@@ -911,6 +927,7 @@ func (t *viewHistoryTable) getLastDate(ctx context.Context, id int) (*time.Time,
 	table := t.table.table
 	q := dialect.Select(t.dateColumn).From(table).Where(
 		t.idColumn.Eq(id),
+		t.userCondition(ctx),
 	).Order(t.dateColumn.Desc()).Limit(1)
 
 	var date NullTimestamp
@@ -929,6 +946,7 @@ func (t *viewHistoryTable) getManyLastDate(ctx context.Context, ids []int) ([]*t
 		goqu.MAX(t.dateColumn),
 	).From(table).Where(
 		t.idColumn.In(ids),
+		t.userCondition(ctx),
 	).GroupBy(t.idColumn)
 
 	ret := make([]*time.Time, len(ids))
@@ -962,7 +980,7 @@ func (t *viewHistoryTable) getManyLastDate(ctx context.Context, ids []int) ([]*t
 
 func (t *viewHistoryTable) getCount(ctx context.Context, id int) (int, error) {
 	table := t.table.table
-	q := dialect.Select(goqu.COUNT("*")).From(table).Where(t.idColumn.Eq(id))
+	q := dialect.Select(goqu.COUNT("*")).From(table).Where(t.idColumn.Eq(id), t.userCondition(ctx))
 
 	const single = true
 	var ret int
@@ -986,6 +1004,7 @@ func (t *viewHistoryTable) getManyCount(ctx context.Context, ids []int) ([]int,
 		goqu.COUNT(t.dateColumn),
 	).From(table).Where(
 		t.idColumn.In(ids),
+		t.userCondition(ctx),
 	).GroupBy(t.idColumn)
 
 	ret := make([]int, len(ids))
@@ -1011,7 +1030,7 @@ func (t *viewHistoryTable) getManyCount(ctx context.Context, ids []int) ([]int,
 
 func (t *viewHistoryTable) getAllCount(ctx context.Context) (int, error) {
 	table := t.table.table
-	q := dialect.Select(goqu.COUNT("*")).From(table)
+	q := dialect.Select(goqu.COUNT("*")).From(table).Where(t.userCondition(ctx))
 
 	const single = true
 	var ret int
@@ -1029,7 +1048,7 @@ func (t *viewHistoryTable) getAllCount(ctx context.Context) (int, error) {
 
 func (t *viewHistoryTable) getUniqueCount(ctx context.Context) (int, error) {
 	table := t.table.table
-	q := dialect.Select(goqu.COUNT(goqu.DISTINCT(t.idColumn))).From(table)
+	q := dialect.Select(goqu.COUNT(goqu.DISTINCT(t.idColumn))).From(table).Where(t.userCondition(ctx))
 
 	const single = true
 	var ret int
@@ -1052,10 +1071,15 @@ func (t *viewHistoryTable) addDates(ctx context.Context, id int, dates []time.Ti
 		dates = []time.Time{time.Now()}
 	}
 
+	var userID interface{}
+	if id := currentUserID(ctx); id != nil {
+		userID = *id
+	}
+
 	for _, d := range dates {
-		q := dialect.Insert(table).Cols(t.idColumn.GetCol(), t.dateColumn.GetCol()).Vals(
+		q := dialect.Insert(table).Cols(t.idColumn.GetCol(), t.dateColumn.GetCol(), t.userIDColumn.GetCol()).Vals(
 			// convert all dates to UTC
-			goqu.Vals{id, UTCTimestamp{Timestamp{d}}},
+			goqu.Vals{id, UTCTimestamp{Timestamp{d}}, userID},
 		)
 
 		if _, err := exec(ctx, q); err != nil {
@@ -1081,11 +1105,13 @@ func (t *viewHistoryTable) deleteDates(ctx context.Context, id int, dates []time
 			// delete the most recent
 			subquery = dialect.Select("rowid").From(table).Where(
 				t.idColumn.Eq(id),
+				t.userCondition(ctx),
 			).Order(t.dateColumn.Desc()).Limit(1)
 		} else {
 			subquery = dialect.Select("rowid").From(table).Where(
 				t.idColumn.Eq(id),
 				t.dateColumn.Eq(UTCTimestamp{Timestamp{date}}),
+				t.userCondition(ctx),
 			).Limit(1)
 		}
 
@@ -1101,7 +1127,7 @@ func (t *viewHistoryTable) deleteDates(ctx context.Context, id int, dates []time
 
 func (t *viewHistoryTable) deleteAllDates(ctx context.Context, id int) (int, error) {
 	table := t.table.table
-	q := dialect.Delete(table).Where(t.idColumn.Eq(id))
+	q := dialect.Delete(table).Where(t.idColumn.Eq(id), t.userCondition(ctx))
 
 	if _, err := exec(ctx, q); err != nil {
 		return 0, fmt.Errorf("resetting dates for id %v: %w", id, err)
@@ -1110,6 +1136,78 @@ func (t *viewHistoryTable) deleteAllDates(ctx context.Context, id int) (int, err
 	return t.getCount(ctx, id)
 }
 
+// userValueTable stores a value per object and user, such as a user's
+// rating of an object.
+type userValueTable[T any] struct {
+	table
+	userIDColumn exp.IdentifierExpression
+	valueColumn  exp.IdentifierExpression
+}
+
+// getMany returns the values of the provided user for the objects with the
+// provided ids. Objects without a value are not included in the result.
+func (t *userValueTable[T]) getMany(ctx context.Context, userID int, ids []int) (map[int]T, error) {
+	ret := make(map[int]T)
+
+	if err := batchExec(ids, defaultBatchSize, func(batch []int) error {
+		q := dialect.Select(t.idColumn, t.valueColumn).From(t.table.table).Where(
+			t.userIDColumn.Eq(userID),
+			t.idColumn.In(batch),
+		)
+
+		const single = false
+		return queryFunc(ctx, q, single, func(rows *sqlx.Rows) error {
+			var id int
+			var v T
+			if err := rows.Scan(&id, &v); err != nil {
+				return err
+			}
+
+			ret[id] = v
+			return nil
+		})
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (t *userValueTable[T]) set(ctx context.Context, id int, userID int, v T) error {
+	idCol := t.idColumn.GetCol().(string)
+	userIDCol := t.userIDColumn.GetCol().(string)
+	valueCol := t.valueColumn.GetCol().(string)
+
+	q := dialect.Insert(t.table.table).Rows(goqu.Record{
+		idCol:     id,
+		userIDCol: userID,
+		valueCol:  v,
+	}).OnConflict(goqu.DoUpdate(idCol+", "+userIDCol, goqu.Record{valueCol: v}))
+
+	if _, err := exec(ctx, q); err != nil {
+		return fmt.Errorf("setting value in %s: %w", t.table.table.GetTable(), err)
+	}
+
+	return nil
+}
+
+func (t *userValueTable[T]) delete(ctx context.Context, id int, userID int) error {
+	q := dialect.Delete(t.table.table).Where(t.idColumn.Eq(id), t.userIDColumn.Eq(userID))
+
+	if _, err := exec(ctx, q); err != nil {
+		return fmt.Errorf("deleting from %s: %w", t.table.table.GetTable(), err)
+	}
+
+	return nil
+}
+
+// valueSQL returns a correlated subquery selecting the value of the provided
+// user for the object identified by objectIDColumn.
+func (t *userValueTable[T]) valueSQL(userID int, objectIDColumn string) string {
+	return fmt.Sprintf("(SELECT %s FROM %s WHERE %s = %s AND %s = %d)",
+		t.valueColumn.GetCol(), t.table.table.GetTable(), t.idColumn.GetCol(), objectIDColumn, t.userIDColumn.GetCol(), userID)
+}
+
 type sqler interface {
 	ToSQL() (sql string, params []interface{}, err error)
 }
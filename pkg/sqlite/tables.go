@@ -4,6 +4,8 @@ import (
 	"github.com/doug-martin/goqu/v9"
 
 	_ "github.com/doug-martin/goqu/v9/dialect/sqlite3"
+
+	"github.com/stashapp/stash/pkg/models"
 )
 
 var dialect = goqu.Dialect("sqlite3")
@@ -207,7 +209,8 @@ var (
 			table:    goqu.T(scenesViewDatesTable),
 			idColumn: goqu.T(scenesViewDatesTable).Col(sceneIDColumn),
 		},
-		dateColumn: goqu.T(scenesViewDatesTable).Col(sceneViewDateColumn),
+		dateColumn:   goqu.T(scenesViewDatesTable).Col(sceneViewDateColumn),
+		userIDColumn: goqu.T(scenesViewDatesTable).Col(userIDColumn),
 	}
 
 	scenesOTableMgr = &viewHistoryTable{
@@ -215,7 +218,8 @@ var (
 			table:    goqu.T(scenesODatesTable),
 			idColumn: goqu.T(scenesODatesTable).Col(sceneIDColumn),
 		},
-		dateColumn: goqu.T(scenesODatesTable).Col(sceneODateColumn),
+		dateColumn:   goqu.T(scenesODatesTable).Col(sceneODateColumn),
+		userIDColumn: goqu.T(scenesODatesTable).Col(userIDColumn),
 	}
 )
 
@@ -380,4 +384,86 @@ var (
 		table:    goqu.T(savedFilterTable),
 		idColumn: goqu.T(savedFilterTable).Col(idColumn),
 	}
+
+	userTableMgr = &table{
+		table:    goqu.T(userTable),
+		idColumn: goqu.T(userTable).Col(idColumn),
+	}
+
+	apiKeyTableMgr = &table{
+		table:    goqu.T(apiKeyTable),
+		idColumn: goqu.T(apiKeyTable).Col(idColumn),
+	}
+
+	apiKeysScopesTableMgr = &stringTable{
+		table: table{
+			table:    goqu.T(apiKeyScopesTable),
+			idColumn: goqu.T(apiKeyScopesTable).Col(apiKeyIDColumn),
+		},
+		stringColumn: goqu.T(apiKeyScopesTable).Col(apiKeyScopeColumn),
+	}
+
+	editHistoryTableMgr = &table{
+		table:    goqu.T(editHistoryTable),
+		idColumn: goqu.T(editHistoryTable).Col(idColumn),
+	}
+
+	trashedFileTableMgr = &table{
+		table:    goqu.T(trashedFileTable),
+		idColumn: goqu.T(trashedFileTable).Col(idColumn),
+	}
+
+	webhookDeliveryTableMgr = &table{
+		table:    goqu.T(webhookDeliveryTable),
+		idColumn: goqu.T(webhookDeliveryTable).Col(idColumn),
+	}
+
+	queuedJobTableMgr = &table{
+		table:    goqu.T(queuedJobTable),
+		idColumn: goqu.T(queuedJobTable).Col(idColumn),
+	}
+
+	customFieldDefinitionTableMgr = &table{
+		table:    goqu.T(customFieldDefinitionTable),
+		idColumn: goqu.T(customFieldDefinitionTable).Col(idColumn),
+	}
+
+	sceneResumeTimesTableMgr = newUserValueTable[float64](sceneResumeTimesTable, sceneIDColumn, sceneResumeTimeColumn)
+	sceneRatingsTableMgr     = newUserValueTable[int](sceneRatingsTable, sceneIDColumn, userRatingColumn)
+	imageRatingsTableMgr     = newUserValueTable[int](imageRatingsTable, imageIDColumn, userRatingColumn)
+	galleryRatingsTableMgr   = newUserValueTable[int](galleryRatingsTable, galleryIDColumn, userRatingColumn)
+	groupRatingsTableMgr     = newUserValueTable[int](groupRatingsTable, groupIDColumn, userRatingColumn)
+	performerRatingsTableMgr = newUserValueTable[int](performerRatingsTable, performerIDColumn, userRatingColumn)
+	studioRatingsTableMgr    = newUserValueTable[int](studioRatingsTable, studioIDColumn, userRatingColumn)
+
+	sceneCustomFieldsTableMgr     = newCustomFieldsTable(sceneCustomFieldsTable, sceneIDColumn, models.CustomFieldEntityTypeScene)
+	imageCustomFieldsTableMgr     = newCustomFieldsTable(imageCustomFieldsTable, imageIDColumn, models.CustomFieldEntityTypeImage)
+	galleryCustomFieldsTableMgr   = newCustomFieldsTable(galleryCustomFieldsTable, galleryIDColumn, models.CustomFieldEntityTypeGallery)
+	performerCustomFieldsTableMgr = newCustomFieldsTable(performerCustomFieldsTable, performerIDColumn, models.CustomFieldEntityTypePerformer)
+	studioCustomFieldsTableMgr    = newCustomFieldsTable(studioCustomFieldsTable, studioIDColumn, models.CustomFieldEntityTypeStudio)
+	tagCustomFieldsTableMgr       = newCustomFieldsTable(tagCustomFieldsTable, tagIDColumn, models.CustomFieldEntityTypeTag)
+	groupCustomFieldsTableMgr     = newCustomFieldsTable(groupCustomFieldsTable, groupIDColumn, models.CustomFieldEntityTypeGroup)
 )
+
+func newUserValueTable[T any](tableName string, fkColumn string, valueColumn string) *userValueTable[T] {
+	t := goqu.T(tableName)
+	return &userValueTable[T]{
+		table: table{
+			table:    t,
+			idColumn: t.Col(fkColumn),
+		},
+		userIDColumn: t.Col(userIDColumn),
+		valueColumn:  t.Col(valueColumn),
+	}
+}
+
+func newCustomFieldsTable(tableName string, fkColumn string, entityType models.CustomFieldEntityType) *customFieldsTable {
+	t := goqu.T(tableName)
+	return &customFieldsTable{
+		table: table{
+			table:    t,
+			idColumn: t.Col(fkColumn),
+		},
+		entityType: entityType,
+	}
+}
@@ -28,6 +28,8 @@ const (
 	tagRelationsTable = "tags_relations"
 	tagParentIDColumn = "parent_id"
 	tagChildIDColumn  = "child_id"
+
+	tagCustomFieldsTable = "tag_custom_fields"
 )
 
 type tagRow struct {
@@ -146,6 +148,7 @@ var (
 
 type TagStore struct {
 	blobJoinQueryBuilder
+	customFieldsStore
 
 	tableMgr *table
 }
@@ -156,6 +159,10 @@ func NewTagStore(blobStore *BlobStore) *TagStore {
 			blobStore: blobStore,
 			joinTable: tagTable,
 		},
+		customFieldsStore: customFieldsStore{
+			tableMgr: tagCustomFieldsTableMgr,
+		},
+
 		tableMgr: tagTableMgr,
 	}
 }
@@ -206,6 +213,11 @@ func (qb *TagStore) Create(ctx context.Context, newObject *models.Tag) error {
 }
 
 func (qb *TagStore) UpdatePartial(ctx context.Context, id int, partial models.TagPartial) (*models.Tag, error) {
+	editBefore, err := takeEditSnapshot(ctx, id, qb.editSnapshot)
+	if err != nil {
+		return nil, err
+	}
+
 	r := tagRowRecord{
 		updateRecord{
 			Record: make(exp.Record),
@@ -238,10 +250,19 @@ func (qb *TagStore) UpdatePartial(ctx context.Context, id int, partial models.Ta
 		}
 	}
 
+	if err := recordEdit(ctx, models.EditObjectTypeTag, id, editBefore, qb.editSnapshot); err != nil {
+		return nil, err
+	}
+
 	return qb.find(ctx, id)
 }
 
 func (qb *TagStore) Update(ctx context.Context, updatedObject *models.Tag) error {
+	editBefore, err := takeEditSnapshot(ctx, updatedObject.ID, qb.editSnapshot)
+	if err != nil {
+		return err
+	}
+
 	var r tagRow
 	r.fromTag(*updatedObject)
 
@@ -267,6 +288,10 @@ func (qb *TagStore) Update(ctx context.Context, updatedObject *models.Tag) error
 		}
 	}
 
+	if err := recordEdit(ctx, models.EditObjectTypeTag, updatedObject.ID, editBefore, qb.editSnapshot); err != nil {
+		return err
+	}
+
 	return nil
 }
 
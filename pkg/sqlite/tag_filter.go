@@ -85,6 +85,11 @@ func (qb *tagFilterHandler) criterionHandler() criterionHandler {
 		tagHierarchyHandler.ChildCountCriterionHandler(tagFilter.ChildCount),
 		&timestampCriterionHandler{tagFilter.CreatedAt, "tags.created_at", nil},
 		&timestampCriterionHandler{tagFilter.UpdatedAt, "tags.updated_at", nil},
+		&customFieldsFilterHandler{
+			criteria:    tagFilter.CustomFields,
+			tableMgr:    tagCustomFieldsTableMgr,
+			parentIDCol: "tags.id",
+		},
 
 		&relatedFilterHandler{
 			relatedIDCol:   "scenes_tags.scene_id",
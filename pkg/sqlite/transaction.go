@@ -139,5 +139,12 @@ func (db *Database) Repository() models.Repository {
 		Studio:         db.Studio,
 		Tag:            db.Tag,
 		SavedFilter:    db.SavedFilter,
+		User:           db.User,
+		APIKey:         db.APIKey,
+		EditHistory:    db.EditHistory,
+		TrashedFile:    db.TrashedFile,
+		QueuedJob:      db.QueuedJob,
+		WebhookLog:     db.WebhookLog,
+		CustomField:    db.CustomField,
 	}
 }
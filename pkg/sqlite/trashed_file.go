@@ -0,0 +1,185 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/doug-martin/goqu/v9/exp"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/sliceutil"
+)
+
+const (
+	trashedFileTable           = "trashed_files"
+	trashedFileTrashedAtColumn = "trashed_at"
+)
+
+type trashedFileRow struct {
+	ID           int       `db:"id" goqu:"skipinsert"`
+	OriginalPath string    `db:"original_path"`
+	TrashPath    string    `db:"trash_path"`
+	Size         int64     `db:"size"`
+	TrashedAt    Timestamp `db:"trashed_at"`
+}
+
+func (r *trashedFileRow) fromTrashedFile(o models.TrashedFile) {
+	r.ID = o.ID
+	r.OriginalPath = o.OriginalPath
+	r.TrashPath = o.TrashPath
+	r.Size = o.Size
+	r.TrashedAt = Timestamp{Timestamp: o.TrashedAt}
+}
+
+func (r *trashedFileRow) resolve() *models.TrashedFile {
+	return &models.TrashedFile{
+		ID:           r.ID,
+		OriginalPath: r.OriginalPath,
+		TrashPath:    r.TrashPath,
+		Size:         r.Size,
+		TrashedAt:    r.TrashedAt.Timestamp,
+	}
+}
+
+type TrashedFileStore struct {
+	repository
+	tableMgr *table
+}
+
+func NewTrashedFileStore() *TrashedFileStore {
+	return &TrashedFileStore{
+		repository: repository{
+			tableName: trashedFileTable,
+			idColumn:  idColumn,
+		},
+		tableMgr: trashedFileTableMgr,
+	}
+}
+
+func (qb *TrashedFileStore) table() exp.IdentifierExpression {
+	return qb.tableMgr.table
+}
+
+func (qb *TrashedFileStore) selectDataset() *goqu.SelectDataset {
+	return dialect.From(qb.table()).Select(qb.table().All())
+}
+
+func (qb *TrashedFileStore) Create(ctx context.Context, newObject *models.TrashedFile) error {
+	var r trashedFileRow
+	r.fromTrashedFile(*newObject)
+
+	id, err := qb.tableMgr.insertID(ctx, r)
+	if err != nil {
+		return err
+	}
+
+	updated, err := qb.find(ctx, id)
+	if err != nil {
+		return fmt.Errorf("finding after create: %w", err)
+	}
+
+	*newObject = *updated
+
+	return nil
+}
+
+func (qb *TrashedFileStore) Destroy(ctx context.Context, id int) error {
+	return qb.tableMgr.destroyExisting(ctx, []int{id})
+}
+
+// returns nil, nil if not found
+func (qb *TrashedFileStore) Find(ctx context.Context, id int) (*models.TrashedFile, error) {
+	ret, err := qb.find(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	return ret, err
+}
+
+func (qb *TrashedFileStore) FindMany(ctx context.Context, ids []int) ([]*models.TrashedFile, error) {
+	ret := make([]*models.TrashedFile, len(ids))
+
+	table := qb.table()
+	if err := batchExec(ids, defaultBatchSize, func(batch []int) error {
+		q := qb.selectDataset().Prepared(true).Where(table.Col(idColumn).In(batch))
+		unsorted, err := qb.getMany(ctx, q)
+		if err != nil {
+			return err
+		}
+
+		for _, s := range unsorted {
+			i := sliceutil.Index(ids, s.ID)
+			ret[i] = s
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	for i := range ret {
+		if ret[i] == nil {
+			return nil, fmt.Errorf("trashed file with id %d not found", ids[i])
+		}
+	}
+
+	return ret, nil
+}
+
+// returns nil, sql.ErrNoRows if not found
+func (qb *TrashedFileStore) find(ctx context.Context, id int) (*models.TrashedFile, error) {
+	q := qb.selectDataset().Where(qb.tableMgr.byID(id))
+
+	ret, err := qb.getMany(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ret) == 0 {
+		return nil, sql.ErrNoRows
+	}
+
+	return ret[0], nil
+}
+
+func (qb *TrashedFileStore) getMany(ctx context.Context, q *goqu.SelectDataset) ([]*models.TrashedFile, error) {
+	const single = false
+	var ret []*models.TrashedFile
+	if err := queryFunc(ctx, q, single, func(r *sqlx.Rows) error {
+		var f trashedFileRow
+		if err := r.StructScan(&f); err != nil {
+			return err
+		}
+
+		ret = append(ret, f.resolve())
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (qb *TrashedFileStore) All(ctx context.Context) ([]*models.TrashedFile, error) {
+	table := qb.table()
+	q := qb.selectDataset().Order(
+		table.Col(trashedFileTrashedAtColumn).Desc(),
+		table.Col(idColumn).Desc(),
+	)
+
+	return qb.getMany(ctx, q)
+}
+
+func (qb *TrashedFileStore) FindTrashedBefore(ctx context.Context, t time.Time) ([]*models.TrashedFile, error) {
+	table := qb.table()
+	q := qb.selectDataset().Where(
+		table.Col(trashedFileTrashedAtColumn).Lt(Timestamp{Timestamp: t}),
+	).Order(table.Col(idColumn).Asc())
+
+	return qb.getMany(ctx, q)
+}
@@ -0,0 +1,81 @@
+//go:build integration
+// +build integration
+
+package sqlite_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func createTestTrashedFile(ctx context.Context, t *testing.T, path string, trashedAt time.Time) *models.TrashedFile {
+	f := &models.TrashedFile{
+		OriginalPath: path,
+		TrashPath:    "/trash/" + path,
+		Size:         100,
+		TrashedAt:    trashedAt,
+	}
+
+	if err := db.TrashedFile.Create(ctx, f); err != nil {
+		t.Fatalf("TrashedFileStore.Create() error = %v", err)
+	}
+
+	return f
+}
+
+func TestTrashedFileStore(t *testing.T) {
+	withRollbackTxn(func(ctx context.Context) error {
+		qb := db.TrashedFile
+		now := time.Now()
+
+		older := createTestTrashedFile(ctx, t, "older.mp4", now.AddDate(0, 0, -10))
+		newer := createTestTrashedFile(ctx, t, "newer.mp4", now.AddDate(0, 0, -1))
+
+		got, err := qb.Find(ctx, older.ID)
+		if err != nil {
+			t.Errorf("TrashedFileStore.Find() error = %v", err)
+			return nil
+		}
+		assert.Equal(t, older.OriginalPath, got.OriginalPath)
+		assert.Equal(t, older.TrashPath, got.TrashPath)
+		assert.Equal(t, older.Size, got.Size)
+
+		// most recently trashed first
+		all, err := qb.All(ctx)
+		if err != nil {
+			t.Errorf("TrashedFileStore.All() error = %v", err)
+			return nil
+		}
+		if assert.Len(t, all, 2) {
+			assert.Equal(t, newer.ID, all[0].ID)
+			assert.Equal(t, older.ID, all[1].ID)
+		}
+
+		expired, err := qb.FindTrashedBefore(ctx, now.AddDate(0, 0, -5))
+		if err != nil {
+			t.Errorf("TrashedFileStore.FindTrashedBefore() error = %v", err)
+			return nil
+		}
+		if assert.Len(t, expired, 1) {
+			assert.Equal(t, older.ID, expired[0].ID)
+		}
+
+		if err := qb.Destroy(ctx, older.ID); err != nil {
+			t.Errorf("TrashedFileStore.Destroy() error = %v", err)
+			return nil
+		}
+
+		got, err = qb.Find(ctx, older.ID)
+		if err != nil {
+			t.Errorf("TrashedFileStore.Find() error = %v", err)
+			return nil
+		}
+		assert.Nil(t, got)
+
+		return nil
+	})
+}
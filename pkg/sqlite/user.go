@@ -0,0 +1,395 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/doug-martin/goqu/v9/exp"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/session"
+	"github.com/stashapp/stash/pkg/sliceutil"
+)
+
+const (
+	userTable    = "users"
+	userIDColumn = "user_id"
+
+	userRatingColumn = "rating"
+
+	sceneResumeTimesTable = "scene_resume_times"
+	sceneResumeTimeColumn = "resume_time"
+	sceneRatingsTable     = "scene_ratings"
+	imageRatingsTable     = "image_ratings"
+	galleryRatingsTable   = "gallery_ratings"
+	groupRatingsTable     = "group_ratings"
+	performerRatingsTable = "performer_ratings"
+	studioRatingsTable    = "studio_ratings"
+)
+
+type userRow struct {
+	ID           int       `db:"id" goqu:"skipinsert"`
+	Username     string    `db:"username"`
+	PasswordHash string    `db:"password_hash"`
+	Role         string    `db:"role"`
+	CreatedAt    Timestamp `db:"created_at"`
+	UpdatedAt    Timestamp `db:"updated_at"`
+}
+
+func (r *userRow) fromUser(o models.User) {
+	r.ID = o.ID
+	r.Username = o.Username
+	r.PasswordHash = o.PasswordHash
+	r.Role = o.Role.String()
+	r.CreatedAt = Timestamp{Timestamp: o.CreatedAt}
+	r.UpdatedAt = Timestamp{Timestamp: o.UpdatedAt}
+}
+
+func (r *userRow) resolve() *models.User {
+	return &models.User{
+		ID:           r.ID,
+		Username:     r.Username,
+		PasswordHash: r.PasswordHash,
+		Role:         models.UserRole(r.Role),
+		CreatedAt:    r.CreatedAt.Timestamp,
+		UpdatedAt:    r.UpdatedAt.Timestamp,
+	}
+}
+
+type userRowRecord struct {
+	updateRecord
+}
+
+func (r *userRowRecord) fromPartial(o models.UserPartial) {
+	r.setString("username", o.Username)
+	r.setString("password_hash", o.PasswordHash)
+	r.setString("role", o.Role)
+	r.setTimestamp("created_at", o.CreatedAt)
+	r.setTimestamp("updated_at", o.UpdatedAt)
+}
+
+type UserStore struct {
+	repository
+	tableMgr *table
+}
+
+func NewUserStore() *UserStore {
+	return &UserStore{
+		repository: repository{
+			tableName: userTable,
+			idColumn:  idColumn,
+		},
+		tableMgr: userTableMgr,
+	}
+}
+
+func (qb *UserStore) table() exp.IdentifierExpression {
+	return qb.tableMgr.table
+}
+
+func (qb *UserStore) selectDataset() *goqu.SelectDataset {
+	return dialect.From(qb.table()).Select(qb.table().All())
+}
+
+func (qb *UserStore) Create(ctx context.Context, newObject *models.User) error {
+	var r userRow
+	r.fromUser(*newObject)
+
+	id, err := qb.tableMgr.insertID(ctx, r)
+	if err != nil {
+		return err
+	}
+
+	updated, err := qb.find(ctx, id)
+	if err != nil {
+		return fmt.Errorf("finding after create: %w", err)
+	}
+
+	*newObject = *updated
+
+	return nil
+}
+
+func (qb *UserStore) UpdatePartial(ctx context.Context, id int, partial models.UserPartial) (*models.User, error) {
+	r := userRowRecord{
+		updateRecord{
+			Record: make(exp.Record),
+		},
+	}
+
+	r.fromPartial(partial)
+
+	if len(r.Record) > 0 {
+		if err := qb.tableMgr.updateByID(ctx, id, r.Record); err != nil {
+			return nil, err
+		}
+	}
+
+	return qb.find(ctx, id)
+}
+
+func (qb *UserStore) Destroy(ctx context.Context, id int) error {
+	return qb.tableMgr.destroyExisting(ctx, []int{id})
+}
+
+// returns nil, nil if not found
+func (qb *UserStore) Find(ctx context.Context, id int) (*models.User, error) {
+	ret, err := qb.find(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	return ret, err
+}
+
+func (qb *UserStore) FindMany(ctx context.Context, ids []int) ([]*models.User, error) {
+	ret := make([]*models.User, len(ids))
+
+	table := qb.table()
+	q := qb.selectDataset().Prepared(true).Where(table.Col(idColumn).In(ids))
+	unsorted, err := qb.getMany(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, s := range unsorted {
+		i := sliceutil.Index(ids, s.ID)
+		ret[i] = s
+	}
+
+	for i := range ret {
+		if ret[i] == nil {
+			return nil, fmt.Errorf("user with id %d not found", ids[i])
+		}
+	}
+
+	return ret, nil
+}
+
+// returns nil, sql.ErrNoRows if not found
+func (qb *UserStore) find(ctx context.Context, id int) (*models.User, error) {
+	q := qb.selectDataset().Where(qb.tableMgr.byID(id))
+
+	ret, err := qb.get(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+// returns nil, sql.ErrNoRows if not found
+func (qb *UserStore) get(ctx context.Context, q *goqu.SelectDataset) (*models.User, error) {
+	ret, err := qb.getMany(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ret) == 0 {
+		return nil, sql.ErrNoRows
+	}
+
+	return ret[0], nil
+}
+
+func (qb *UserStore) getMany(ctx context.Context, q *goqu.SelectDataset) ([]*models.User, error) {
+	const single = false
+	var ret []*models.User
+	if err := queryFunc(ctx, q, single, func(r *sqlx.Rows) error {
+		var f userRow
+		if err := r.StructScan(&f); err != nil {
+			return err
+		}
+
+		ret = append(ret, f.resolve())
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+// returns nil, nil if not found
+func (qb *UserStore) FindByUsername(ctx context.Context, username string) (*models.User, error) {
+	q := qb.selectDataset().Where(qb.table().Col("username").Eq(username))
+
+	ret, err := qb.get(ctx, q)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	return ret, err
+}
+
+func (qb *UserStore) All(ctx context.Context) ([]*models.User, error) {
+	return qb.getMany(ctx, qb.selectDataset().Order(qb.table().Col("username").Asc()))
+}
+
+func (qb *UserStore) Count(ctx context.Context) (int, error) {
+	q := dialect.Select(goqu.COUNT("*")).From(qb.table())
+	return count(ctx, q)
+}
+
+func (qb *UserStore) CountByRole(ctx context.Context, role models.UserRole) (int, error) {
+	q := dialect.Select(goqu.COUNT("*")).From(qb.table()).Where(qb.table().Col("role").Eq(role.String()))
+	return count(ctx, q)
+}
+
+func (qb *UserStore) CopySharedState(ctx context.Context, id int) error {
+	historyTables := []string{scenesViewDatesTable, scenesODatesTable}
+	for _, t := range historyTables {
+		dateCol := sceneViewDateColumn
+		if t == scenesODatesTable {
+			dateCol = sceneODateColumn
+		}
+
+		query := fmt.Sprintf("INSERT INTO %[1]s (%[2]s, %[3]s, %[4]s) SELECT %[2]s, %[3]s, ? FROM %[1]s WHERE %[4]s IS NULL",
+			t, sceneIDColumn, dateCol, userIDColumn)
+		if _, err := dbWrapper.Exec(ctx, query, id); err != nil {
+			return fmt.Errorf("copying %s: %w", t, err)
+		}
+	}
+
+	query := fmt.Sprintf("INSERT OR IGNORE INTO %s (%s, %s, %s) SELECT id, ?, resume_time FROM %s WHERE resume_time > 0",
+		sceneResumeTimesTable, sceneIDColumn, userIDColumn, sceneResumeTimeColumn, sceneTable)
+	if _, err := dbWrapper.Exec(ctx, query, id); err != nil {
+		return fmt.Errorf("copying resume times: %w", err)
+	}
+
+	ratingTables := []struct {
+		ratingsTable string
+		fkColumn     string
+		primaryTable string
+	}{
+		{sceneRatingsTable, sceneIDColumn, sceneTable},
+		{imageRatingsTable, imageIDColumn, imageTable},
+		{galleryRatingsTable, galleryIDColumn, galleryTable},
+		{groupRatingsTable, groupIDColumn, groupTable},
+		{performerRatingsTable, performerIDColumn, performerTable},
+		{studioRatingsTable, studioIDColumn, studioTable},
+	}
+
+	for _, t := range ratingTables {
+		query := fmt.Sprintf("INSERT OR IGNORE INTO %s (%s, %s, %s) SELECT id, ?, rating FROM %s WHERE rating IS NOT NULL",
+			t.ratingsTable, t.fkColumn, userIDColumn, userRatingColumn, t.primaryTable)
+		if _, err := dbWrapper.Exec(ctx, query, id); err != nil {
+			return fmt.Errorf("copying %s: %w", t.ratingsTable, err)
+		}
+	}
+
+	return nil
+}
+
+// currentUserID returns the id of the current user, or nil if there is no
+// current user. Per-user state is read and written for the current user.
+// If there is no current user, then the shared state is used.
+func currentUserID(ctx context.Context) *int {
+	if u := session.GetCurrentUser(ctx); u != nil {
+		return &u.ID
+	}
+
+	return nil
+}
+
+// historyUserCondition returns an sql condition matching the rows of the
+// provided history table that belong to the current user.
+func historyUserCondition(ctx context.Context, historyTable string) string {
+	if userID := currentUserID(ctx); userID != nil {
+		return fmt.Sprintf("%s.%s = %d", historyTable, userIDColumn, *userID)
+	}
+
+	return fmt.Sprintf("%s.%s IS NULL", historyTable, userIDColumn)
+}
+
+// userHistoryTable returns a table expression containing the rows of the
+// provided history table that belong to the current user.
+func userHistoryTable(ctx context.Context, historyTable string) string {
+	return fmt.Sprintf("(SELECT * FROM %s WHERE %s)", historyTable, historyUserCondition(ctx, historyTable))
+}
+
+// ratingColumnSQL returns the sql expression for the rating of the objects
+// in primaryTable. This is the current user's rating if there is a current
+// user, otherwise the shared rating.
+func ratingColumnSQL(ctx context.Context, ratings *userValueTable[int], primaryTable string) string {
+	if userID := currentUserID(ctx); userID != nil {
+		return ratings.valueSQL(*userID, primaryTable+"."+idColumn)
+	}
+
+	return primaryTable + "." + userRatingColumn
+}
+
+func ratingCriterionHandler(rating *models.IntCriterionInput, ratings *userValueTable[int], primaryTable string) criterionHandlerFunc {
+	return func(ctx context.Context, f *filterBuilder) {
+		intCriterionHandler(rating, ratingColumnSQL(ctx, ratings, primaryTable), nil)(ctx, f)
+	}
+}
+
+func getRatingSort(ctx context.Context, ratings *userValueTable[int], primaryTable string, direction string) string {
+	return " ORDER BY " + ratingColumnSQL(ctx, ratings, primaryTable) + " " + getSortDirection(direction)
+}
+
+// getUserRatings returns the current user's ratings for the objects with the
+// provided ids. Returns nil if there is no current user.
+func getUserRatings(ctx context.Context, ratings *userValueTable[int], ids []int) (map[int]int, error) {
+	userID := currentUserID(ctx)
+	if userID == nil || len(ids) == 0 {
+		return nil, nil
+	}
+
+	return ratings.getMany(ctx, *userID, ids)
+}
+
+func userRating(ratings map[int]int, id int) *int {
+	if rating, ok := ratings[id]; ok {
+		return &rating
+	}
+
+	return nil
+}
+
+// setUserRating sets the current user's rating of the object with the
+// provided id. Returns false if there is no current user, in which case the
+// shared rating should be set instead.
+func setUserRating(ctx context.Context, ratings *userValueTable[int], id int, rating *int) (bool, error) {
+	userID := currentUserID(ctx)
+	if userID == nil {
+		return false, nil
+	}
+
+	if rating == nil {
+		return true, ratings.delete(ctx, id, *userID)
+	}
+
+	return true, ratings.set(ctx, id, *userID, *rating)
+}
+
+// setUserRatingPartial sets the current user's rating from the provided
+// partial value. If the rating was set, then it is removed from the record
+// so that the shared rating is not changed.
+func setUserRatingPartial(ctx context.Context, ratings *userValueTable[int], id int, rating models.OptionalInt, r *updateRecord) error {
+	if !rating.Set {
+		return nil
+	}
+
+	set, err := setUserRating(ctx, ratings, id, rating.Ptr())
+	if err != nil {
+		return err
+	}
+
+	if set {
+		delete(r.Record, userRatingColumn)
+	}
+
+	return nil
+}
+
+// getSharedValue scans the value of the provided column of the object with
+// the provided id into dest. It is used to retain the shared state when
+// updating an object while there is a current user.
+func getSharedValue(ctx context.Context, t *table, id int, column string, dest interface{}) error {
+	q := dialect.Select(t.table.Col(column)).From(t.table).Where(t.byID(id))
+	return querySimple(ctx, q, dest)
+}
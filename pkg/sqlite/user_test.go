@@ -0,0 +1,335 @@
+//go:build integration
+// +build integration
+
+package sqlite_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/session"
+	"github.com/stretchr/testify/assert"
+)
+
+func createTestUser(ctx context.Context, t *testing.T, username string, role models.UserRole) *models.User {
+	newUser := models.NewUser()
+	newUser.Username = username
+	newUser.PasswordHash = "hash"
+	newUser.Role = role
+
+	if err := db.User.Create(ctx, &newUser); err != nil {
+		t.Fatalf("Error creating user: %v", err)
+	}
+
+	return &newUser
+}
+
+func TestUserCreateUpdateDestroy(t *testing.T) {
+	withRollbackTxn(func(ctx context.Context) error {
+		qb := db.User
+
+		u := createTestUser(ctx, t, "user", models.UserRoleViewer)
+
+		found, err := qb.FindByUsername(ctx, "user")
+		if err != nil {
+			t.Errorf("UserStore.FindByUsername() error = %v", err)
+			return nil
+		}
+		assert.Equal(t, u.ID, found.ID)
+		assert.Equal(t, models.UserRoleViewer, found.Role)
+		assert.Equal(t, "hash", found.PasswordHash)
+
+		partial := models.NewUserPartial()
+		partial.Username = models.NewOptionalString("renamed")
+		partial.Role = models.NewOptionalString(models.UserRoleEditor.String())
+
+		updated, err := qb.UpdatePartial(ctx, u.ID, partial)
+		if err != nil {
+			t.Errorf("UserStore.UpdatePartial() error = %v", err)
+			return nil
+		}
+		assert.Equal(t, "renamed", updated.Username)
+		assert.Equal(t, models.UserRoleEditor, updated.Role)
+
+		count, err := qb.CountByRole(ctx, models.UserRoleEditor)
+		if err != nil {
+			t.Errorf("UserStore.CountByRole() error = %v", err)
+			return nil
+		}
+		assert.Equal(t, 1, count)
+
+		if err := qb.Destroy(ctx, u.ID); err != nil {
+			t.Errorf("UserStore.Destroy() error = %v", err)
+			return nil
+		}
+
+		found, err = qb.Find(ctx, u.ID)
+		if err != nil {
+			t.Errorf("UserStore.Find() error = %v", err)
+			return nil
+		}
+		assert.Nil(t, found)
+
+		return nil
+	})
+}
+
+func TestUserUniqueUsername(t *testing.T) {
+	withRollbackTxn(func(ctx context.Context) error {
+		createTestUser(ctx, t, "user", models.UserRoleViewer)
+
+		newUser := models.NewUser()
+		newUser.Username = "user"
+		newUser.Role = models.UserRoleViewer
+
+		err := db.User.Create(ctx, &newUser)
+		assert.NotNil(t, err)
+
+		return nil
+	})
+}
+
+func TestUserSceneRating(t *testing.T) {
+	withRollbackTxn(func(ctx context.Context) error {
+		qb := db.Scene
+		sceneID := sceneIDs[sceneIdxWithGallery]
+
+		shared, err := qb.Find(ctx, sceneID)
+		if err != nil {
+			t.Errorf("SceneStore.Find() error = %v", err)
+			return nil
+		}
+
+		u1 := createTestUser(ctx, t, "user1", models.UserRoleViewer)
+		u2 := createTestUser(ctx, t, "user2", models.UserRoleViewer)
+		ctx1 := session.SetCurrentUser(ctx, u1)
+		ctx2 := session.SetCurrentUser(ctx, u2)
+
+		const rating = 73
+		partial := models.NewScenePartial()
+		partial.Rating = models.NewOptionalInt(rating)
+		if _, err := qb.UpdatePartial(ctx1, sceneID, partial); err != nil {
+			t.Errorf("SceneStore.UpdatePartial() error = %v", err)
+			return nil
+		}
+
+		got, err := qb.Find(ctx1, sceneID)
+		if err != nil {
+			t.Errorf("SceneStore.Find() error = %v", err)
+			return nil
+		}
+		assert.Equal(t, rating, *got.Rating)
+
+		got, err = qb.Find(ctx2, sceneID)
+		if err != nil {
+			t.Errorf("SceneStore.Find() error = %v", err)
+			return nil
+		}
+		assert.Nil(t, got.Rating)
+
+		// shared rating is unchanged
+		got, err = qb.Find(ctx, sceneID)
+		if err != nil {
+			t.Errorf("SceneStore.Find() error = %v", err)
+			return nil
+		}
+		assert.Equal(t, shared.Rating, got.Rating)
+
+		// filtering uses the user's rating
+		ratingCriterion := &models.IntCriterionInput{
+			Value:    rating,
+			Modifier: models.CriterionModifierEquals,
+		}
+		sceneFilter := &models.SceneFilterType{
+			Rating100: ratingCriterion,
+		}
+
+		scenes := queryScene(ctx1, t, qb, sceneFilter, nil)
+		assert.Len(t, scenes, 1)
+
+		scenes = queryScene(ctx2, t, qb, sceneFilter, nil)
+		assert.Len(t, scenes, 0)
+
+		return nil
+	})
+}
+
+func TestUserPerformerRating(t *testing.T) {
+	withRollbackTxn(func(ctx context.Context) error {
+		qb := db.Performer
+		performerID := performerIDs[performerIdx1WithScene]
+
+		u := createTestUser(ctx, t, "user", models.UserRoleViewer)
+		userCtx := session.SetCurrentUser(ctx, u)
+
+		const rating = 42
+		partial := models.NewPerformerPartial()
+		partial.Rating = models.NewOptionalInt(rating)
+		if _, err := qb.UpdatePartial(userCtx, performerID, partial); err != nil {
+			t.Errorf("PerformerStore.UpdatePartial() error = %v", err)
+			return nil
+		}
+
+		got, err := qb.Find(userCtx, performerID)
+		if err != nil {
+			t.Errorf("PerformerStore.Find() error = %v", err)
+			return nil
+		}
+		assert.Equal(t, rating, *got.Rating)
+
+		// destroying the user removes the rating
+		if err := db.User.Destroy(ctx, u.ID); err != nil {
+			t.Errorf("UserStore.Destroy() error = %v", err)
+			return nil
+		}
+
+		got, err = qb.Find(userCtx, performerID)
+		if err != nil {
+			t.Errorf("PerformerStore.Find() error = %v", err)
+			return nil
+		}
+		assert.Nil(t, got.Rating)
+
+		return nil
+	})
+}
+
+func TestUserSceneHistory(t *testing.T) {
+	withRollbackTxn(func(ctx context.Context) error {
+		qb := db.Scene
+		sceneID := sceneIDs[sceneIdxWithGallery]
+
+		sharedViews, err := qb.CountViews(ctx, sceneID)
+		if err != nil {
+			t.Errorf("SceneStore.CountViews() error = %v", err)
+			return nil
+		}
+
+		u := createTestUser(ctx, t, "user", models.UserRoleViewer)
+		userCtx := session.SetCurrentUser(ctx, u)
+
+		now := time.Now()
+		if _, err := qb.AddViews(userCtx, sceneID, []time.Time{now, now.Add(time.Hour)}); err != nil {
+			t.Errorf("SceneStore.AddViews() error = %v", err)
+			return nil
+		}
+		if _, err := qb.AddO(userCtx, sceneID, []time.Time{now}); err != nil {
+			t.Errorf("SceneStore.AddO() error = %v", err)
+			return nil
+		}
+
+		views, err := qb.CountViews(userCtx, sceneID)
+		if err != nil {
+			t.Errorf("SceneStore.CountViews() error = %v", err)
+			return nil
+		}
+		assert.Equal(t, 2, views)
+
+		oCount, err := qb.GetOCount(userCtx, sceneID)
+		if err != nil {
+			t.Errorf("SceneStore.GetOCount() error = %v", err)
+			return nil
+		}
+		assert.Equal(t, 1, oCount)
+
+		views, err = qb.CountViews(ctx, sceneID)
+		if err != nil {
+			t.Errorf("SceneStore.CountViews() error = %v", err)
+			return nil
+		}
+		assert.Equal(t, sharedViews, views)
+
+		if _, err := qb.DeleteAllViews(userCtx, sceneID); err != nil {
+			t.Errorf("SceneStore.DeleteAllViews() error = %v", err)
+			return nil
+		}
+
+		views, err = qb.CountViews(ctx, sceneID)
+		if err != nil {
+			t.Errorf("SceneStore.CountViews() error = %v", err)
+			return nil
+		}
+		assert.Equal(t, sharedViews, views)
+
+		return nil
+	})
+}
+
+func TestUserSceneResumeTime(t *testing.T) {
+	withRollbackTxn(func(ctx context.Context) error {
+		qb := db.Scene
+		sceneID := sceneIDs[sceneIdxWithGallery]
+
+		u := createTestUser(ctx, t, "user", models.UserRoleViewer)
+		userCtx := session.SetCurrentUser(ctx, u)
+
+		const resumeTime = 12.5
+		resume := resumeTime
+		if _, err := qb.SaveActivity(userCtx, sceneID, &resume, nil); err != nil {
+			t.Errorf("SceneStore.SaveActivity() error = %v", err)
+			return nil
+		}
+
+		got, err := qb.Find(userCtx, sceneID)
+		if err != nil {
+			t.Errorf("SceneStore.Find() error = %v", err)
+			return nil
+		}
+		assert.Equal(t, resumeTime, got.ResumeTime)
+
+		got, err = qb.Find(ctx, sceneID)
+		if err != nil {
+			t.Errorf("SceneStore.Find() error = %v", err)
+			return nil
+		}
+		assert.Equal(t, getSceneResumeTime(sceneIdxWithGallery), got.ResumeTime)
+
+		return nil
+	})
+}
+
+func TestUserCopySharedState(t *testing.T) {
+	withRollbackTxn(func(ctx context.Context) error {
+		qb := db.Scene
+		sceneID := sceneIDs[sceneIdxWithGallery]
+
+		shared, err := qb.Find(ctx, sceneID)
+		if err != nil {
+			t.Errorf("SceneStore.Find() error = %v", err)
+			return nil
+		}
+
+		sharedViews, err := qb.CountViews(ctx, sceneID)
+		if err != nil {
+			t.Errorf("SceneStore.CountViews() error = %v", err)
+			return nil
+		}
+
+		u := createTestUser(ctx, t, "user", models.UserRoleAdmin)
+		if err := db.User.CopySharedState(ctx, u.ID); err != nil {
+			t.Errorf("UserStore.CopySharedState() error = %v", err)
+			return nil
+		}
+
+		userCtx := session.SetCurrentUser(ctx, u)
+
+		got, err := qb.Find(userCtx, sceneID)
+		if err != nil {
+			t.Errorf("SceneStore.Find() error = %v", err)
+			return nil
+		}
+		assert.Equal(t, shared.Rating, got.Rating)
+		assert.Equal(t, shared.ResumeTime, got.ResumeTime)
+
+		views, err := qb.CountViews(userCtx, sceneID)
+		if err != nil {
+			t.Errorf("SceneStore.CountViews() error = %v", err)
+			return nil
+		}
+		assert.Equal(t, sharedViews, views)
+
+		return nil
+	})
+}
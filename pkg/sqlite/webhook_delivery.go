@@ -0,0 +1,165 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/doug-martin/goqu/v9/exp"
+	"github.com/jmoiron/sqlx"
+	"gopkg.in/guregu/null.v4"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+const (
+	webhookDeliveryTable = "webhook_deliveries"
+	webhookIDColumn      = "webhook_id"
+)
+
+type webhookDeliveryRow struct {
+	ID          int           `db:"id" goqu:"skipinsert"`
+	WebhookID   int           `db:"webhook_id"`
+	WebhookName string        `db:"webhook_name"`
+	URL         string        `db:"url"`
+	Event       string        `db:"event"`
+	Status      string        `db:"status"`
+	Attempts    int           `db:"attempts"`
+	StatusCode  null.Int      `db:"status_code"`
+	Error       null.String   `db:"error"`
+	CreatedAt   Timestamp     `db:"created_at"`
+	CompletedAt NullTimestamp `db:"completed_at"`
+}
+
+func (r *webhookDeliveryRow) fromWebhookDelivery(o models.WebhookDelivery) {
+	r.ID = o.ID
+	r.WebhookID = o.WebhookID
+	r.WebhookName = o.WebhookName
+	r.URL = o.URL
+	r.Event = o.Event
+	r.Status = o.Status.String()
+	r.Attempts = o.Attempts
+	r.StatusCode = intFromPtr(o.StatusCode)
+	r.Error = null.StringFromPtr(o.Error)
+	r.CreatedAt = Timestamp{Timestamp: o.CreatedAt}
+	r.CompletedAt = NullTimestampFromTimePtr(o.CompletedAt)
+}
+
+func (r *webhookDeliveryRow) resolve() *models.WebhookDelivery {
+	return &models.WebhookDelivery{
+		ID:          r.ID,
+		WebhookID:   r.WebhookID,
+		WebhookName: r.WebhookName,
+		URL:         r.URL,
+		Event:       r.Event,
+		Status:      models.WebhookDeliveryStatus(r.Status),
+		Attempts:    r.Attempts,
+		StatusCode:  nullIntPtr(r.StatusCode),
+		Error:       r.Error.Ptr(),
+		CreatedAt:   r.CreatedAt.Timestamp,
+		CompletedAt: r.CompletedAt.TimePtr(),
+	}
+}
+
+type WebhookDeliveryStore struct {
+	repository
+	tableMgr *table
+}
+
+func NewWebhookDeliveryStore() *WebhookDeliveryStore {
+	return &WebhookDeliveryStore{
+		repository: repository{
+			tableName: webhookDeliveryTable,
+			idColumn:  idColumn,
+		},
+		tableMgr: webhookDeliveryTableMgr,
+	}
+}
+
+func (qb *WebhookDeliveryStore) table() exp.IdentifierExpression {
+	return qb.tableMgr.table
+}
+
+func (qb *WebhookDeliveryStore) selectDataset() *goqu.SelectDataset {
+	return dialect.From(qb.table()).Select(qb.table().All())
+}
+
+func (qb *WebhookDeliveryStore) Create(ctx context.Context, newObject *models.WebhookDelivery) error {
+	var r webhookDeliveryRow
+	r.fromWebhookDelivery(*newObject)
+
+	id, err := qb.tableMgr.insertID(ctx, r)
+	if err != nil {
+		return err
+	}
+
+	newObject.ID = id
+
+	return nil
+}
+
+func (qb *WebhookDeliveryStore) Update(ctx context.Context, updatedObject *models.WebhookDelivery) error {
+	var r webhookDeliveryRow
+	r.fromWebhookDelivery(*updatedObject)
+
+	return qb.tableMgr.updateByID(ctx, updatedObject.ID, r)
+}
+
+func (qb *WebhookDeliveryStore) FailPending(ctx context.Context, errorMessage string, completedAt time.Time) error {
+	table := qb.table()
+	q := dialect.Update(table).Prepared(true).Set(goqu.Record{
+		"status":       models.WebhookDeliveryStatusFailed.String(),
+		"error":        errorMessage,
+		"completed_at": Timestamp{Timestamp: completedAt},
+	}).Where(table.Col("status").Eq(models.WebhookDeliveryStatusPending.String()))
+
+	if _, err := exec(ctx, q); err != nil {
+		return fmt.Errorf("failing pending webhook deliveries: %w", err)
+	}
+
+	return nil
+}
+
+func (qb *WebhookDeliveryStore) Prune(ctx context.Context, keep int) error {
+	table := qb.table()
+
+	// find the id of the oldest delivery to keep
+	sq := dialect.From(table).Select(table.Col(idColumn)).Order(table.Col(idColumn).Desc()).Limit(1).Offset(uint(keep - 1))
+
+	q := dialect.Delete(table).Where(table.Col(idColumn).Lt(sq))
+	if _, err := exec(ctx, q); err != nil {
+		return fmt.Errorf("pruning webhook deliveries: %w", err)
+	}
+
+	return nil
+}
+
+func (qb *WebhookDeliveryStore) getMany(ctx context.Context, q *goqu.SelectDataset) ([]*models.WebhookDelivery, error) {
+	const single = false
+	var ret []*models.WebhookDelivery
+	if err := queryFunc(ctx, q, single, func(r *sqlx.Rows) error {
+		var f webhookDeliveryRow
+		if err := r.StructScan(&f); err != nil {
+			return err
+		}
+
+		ret = append(ret, f.resolve())
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("getting webhook deliveries: %w", err)
+	}
+
+	return ret, nil
+}
+
+func (qb *WebhookDeliveryStore) FindRecent(ctx context.Context, webhookID *int, limit int) ([]*models.WebhookDelivery, error) {
+	table := qb.table()
+	q := qb.selectDataset().Order(table.Col(idColumn).Desc()).Limit(uint(limit))
+
+	if webhookID != nil {
+		q = q.Where(table.Col(webhookIDColumn).Eq(*webhookID))
+	}
+
+	return qb.getMany(ctx, q)
+}
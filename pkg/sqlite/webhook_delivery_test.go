@@ -0,0 +1,97 @@
+//go:build integration
+// +build integration
+
+package sqlite_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebhookDeliveryStore(t *testing.T) {
+	withRollbackTxn(func(ctx context.Context) error {
+		qb := db.WebhookLog
+		now := time.Now().Truncate(time.Second)
+
+		var created []*models.WebhookDelivery
+		for i, webhookID := range []int{1, 2, 1} {
+			d := &models.WebhookDelivery{
+				WebhookID:   webhookID,
+				WebhookName: "hook",
+				URL:         "https://example.com/hook",
+				Event:       "Scan.Complete",
+				Status:      models.WebhookDeliveryStatusPending,
+				CreatedAt:   now.Add(time.Duration(i) * time.Second),
+			}
+			if err := qb.Create(ctx, d); err != nil {
+				t.Errorf("WebhookDeliveryStore.Create() error = %v", err)
+				return nil
+			}
+			created = append(created, d)
+		}
+
+		statusCode := 200
+		created[0].Status = models.WebhookDeliveryStatusSucceeded
+		created[0].Attempts = 1
+		created[0].StatusCode = &statusCode
+		created[0].CompletedAt = &now
+		if err := qb.Update(ctx, created[0]); err != nil {
+			t.Errorf("WebhookDeliveryStore.Update() error = %v", err)
+			return nil
+		}
+
+		webhookID := 1
+		got, err := qb.FindRecent(ctx, &webhookID, 10)
+		if err != nil {
+			t.Errorf("WebhookDeliveryStore.FindRecent() error = %v", err)
+			return nil
+		}
+
+		// newest first
+		if assert.Len(t, got, 2) {
+			assert.Equal(t, created[2].ID, got[0].ID)
+			assert.Equal(t, created[0].ID, got[1].ID)
+			assert.Equal(t, models.WebhookDeliveryStatusSucceeded, got[1].Status)
+			assert.Equal(t, &statusCode, got[1].StatusCode)
+		}
+
+		if err := qb.FailPending(ctx, "interrupted", now); err != nil {
+			t.Errorf("WebhookDeliveryStore.FailPending() error = %v", err)
+			return nil
+		}
+
+		got, err = qb.FindRecent(ctx, nil, 10)
+		if err != nil {
+			t.Errorf("WebhookDeliveryStore.FindRecent() error = %v", err)
+			return nil
+		}
+
+		if assert.Len(t, got, 3) {
+			assert.Equal(t, models.WebhookDeliveryStatusFailed, got[0].Status)
+			assert.Equal(t, "interrupted", *got[0].Error)
+			assert.Equal(t, models.WebhookDeliveryStatusSucceeded, got[2].Status)
+		}
+
+		if err := qb.Prune(ctx, 2); err != nil {
+			t.Errorf("WebhookDeliveryStore.Prune() error = %v", err)
+			return nil
+		}
+
+		got, err = qb.FindRecent(ctx, nil, 10)
+		if err != nil {
+			t.Errorf("WebhookDeliveryStore.FindRecent() error = %v", err)
+			return nil
+		}
+
+		if assert.Len(t, got, 2) {
+			assert.Equal(t, created[2].ID, got[0].ID)
+			assert.Equal(t, created[1].ID, got[1].ID)
+		}
+
+		return nil
+	})
+}
@@ -11,6 +11,7 @@ import (
 var (
 	ErrNameMissing       = errors.New("studio name must not be blank")
 	ErrStudioOwnAncestor = errors.New("studio cannot be an ancestor of itself")
+	ErrMergeParentCycle  = errors.New("merge would create a parent cycle")
 )
 
 type NameExistsError struct {
@@ -166,3 +167,49 @@ func validateParent(ctx context.Context, studioID int, newParentID int, qb model
 
 	return nil
 }
+
+// ValidateMerge returns an error if merging the source studios into the
+// destination studio would create a parent cycle.
+//
+// Children of the source studios are moved to the destination. A cycle is
+// created if a source studio is an ancestor of the destination, and there is
+// a studio that is not being merged between them in the hierarchy.
+func ValidateMerge(ctx context.Context, destination int, sources []int, qb models.StudioGetter) error {
+	isSource := make(map[int]bool)
+	for _, id := range sources {
+		if id == destination {
+			return errors.New("cannot merge where source == destination")
+		}
+		isSource[id] = true
+	}
+
+	dest, err := qb.Find(ctx, destination)
+	if err != nil {
+		return err
+	}
+	if dest == nil {
+		return fmt.Errorf("studio with id %d not found", destination)
+	}
+
+	intermediate := false
+	parentID := dest.ParentID
+	for parentID != nil {
+		parent, err := qb.Find(ctx, *parentID)
+		if err != nil {
+			return fmt.Errorf("error finding parent studio: %v", err)
+		}
+		if parent == nil {
+			return fmt.Errorf("studio with id %d not found", *parentID)
+		}
+
+		if !isSource[parent.ID] {
+			intermediate = true
+		} else if intermediate {
+			return ErrMergeParentCycle
+		}
+
+		parentID = parent.ParentID
+	}
+
+	return nil
+}
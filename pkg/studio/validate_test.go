@@ -102,3 +102,55 @@ func TestValidateUpdateName(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateMerge(t *testing.T) {
+	db := mocks.NewDatabase()
+
+	const (
+		rootID = iota + 1
+		childID
+		grandchildID
+		otherChildID
+		otherID
+	)
+
+	intPtr := func(i int) *int {
+		return &i
+	}
+
+	studios := []*models.Studio{
+		{ID: rootID},
+		{ID: childID, ParentID: intPtr(rootID)},
+		{ID: grandchildID, ParentID: intPtr(childID)},
+		{ID: otherChildID, ParentID: intPtr(rootID)},
+		{ID: otherID},
+	}
+
+	for _, s := range studios {
+		db.Studio.On("Find", testCtx, s.ID).Return(s, nil)
+	}
+
+	tests := []struct {
+		name        string
+		destination int
+		sources     []int
+		wantErr     bool
+	}{
+		{"same studio", grandchildID, []int{grandchildID}, true},
+		{"unrelated", grandchildID, []int{otherID}, false},
+		{"direct parent", grandchildID, []int{childID}, false},
+		{"merge into child", otherChildID, []int{rootID}, false},
+		{"all ancestors", grandchildID, []int{rootID, childID}, false},
+		{"ancestor with intermediate", grandchildID, []int{rootID}, true},
+		{"sibling", otherChildID, []int{childID}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateMerge(testCtx, tt.destination, tt.sources, db.Studio)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateMerge() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
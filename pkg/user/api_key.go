@@ -0,0 +1,88 @@
+package user
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/txn"
+)
+
+const (
+	// apiKeyPrefix distinguishes stored API keys from the legacy
+	// API key in the application configuration.
+	apiKeyPrefix = "stash_"
+	apiKeyLength = 32
+
+	// lastUsedInterval is the minimum interval between updates of the
+	// last used time of an API key.
+	lastUsedInterval = time.Minute
+)
+
+var ErrAPIKeyNameMissing = errors.New("api key name must not be blank")
+
+// GenerateAPIKey returns a new random API key and its hash.
+func GenerateAPIKey() (key string, keyHash string, err error) {
+	b := make([]byte, apiKeyLength)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", fmt.Errorf("generating api key: %w", err)
+	}
+
+	key = apiKeyPrefix + hex.EncodeToString(b)
+	return key, HashAPIKey(key), nil
+}
+
+// HashAPIKey returns the hash of the API key that is stored in the database.
+func HashAPIKey(key string) string {
+	h := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(h[:])
+}
+
+// AuthenticateAPIKey returns the API key matching the provided key, and the
+// user that owns it. Returns nil if the key does not exist, has been revoked
+// or has expired. The last used time of the key is updated.
+func (s *Service) AuthenticateAPIKey(ctx context.Context, key string) (*models.APIKey, *models.User, error) {
+	var (
+		apiKey *models.APIKey
+		u      *models.User
+	)
+
+	if err := txn.WithReadTxn(ctx, s.TxnManager, func(ctx context.Context) error {
+		var err error
+		apiKey, err = s.APIKeyRepository.FindByKeyHash(ctx, HashAPIKey(key))
+		if err != nil || apiKey == nil {
+			return err
+		}
+
+		u, err = s.Repository.Find(ctx, apiKey.UserID)
+		return err
+	}); err != nil {
+		return nil, nil, err
+	}
+
+	now := time.Now()
+	if apiKey == nil || u == nil || !apiKey.IsActive(now) {
+		return nil, nil, nil
+	}
+
+	if apiKey.LastUsedAt == nil || now.Sub(*apiKey.LastUsedAt) >= lastUsedInterval {
+		if err := txn.WithTxn(ctx, s.TxnManager, func(ctx context.Context) error {
+			partial := models.APIKeyPartial{
+				LastUsedAt: models.NewOptionalTime(now),
+			}
+
+			var err error
+			apiKey, err = s.APIKeyRepository.UpdatePartial(ctx, apiKey.ID, partial)
+			return err
+		}); err != nil {
+			return nil, nil, fmt.Errorf("updating api key last used time: %w", err)
+		}
+	}
+
+	return apiKey, u, nil
+}
@@ -0,0 +1,106 @@
+package user
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/models/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGenerateAPIKey(t *testing.T) {
+	key, keyHash, err := GenerateAPIKey()
+	if err != nil {
+		t.Fatalf("GenerateAPIKey() error = %v", err)
+	}
+
+	assert.NotEqual(t, key, keyHash)
+	assert.Equal(t, HashAPIKey(key), keyHash)
+
+	other, _, err := GenerateAPIKey()
+	if err != nil {
+		t.Fatalf("GenerateAPIKey() error = %v", err)
+	}
+
+	assert.NotEqual(t, key, other)
+}
+
+func TestService_AuthenticateAPIKey(t *testing.T) {
+	const (
+		userID = 1
+
+		activeKey  = "active"
+		recentKey  = "recent"
+		expiredKey = "expired"
+		revokedKey = "revoked"
+		missingKey = "missing"
+	)
+
+	now := time.Now()
+	past := now.Add(-time.Hour)
+	recent := now.Add(-time.Second)
+
+	u := &models.User{ID: userID}
+
+	keys := map[string]*models.APIKey{
+		activeKey:  {ID: 1, UserID: userID},
+		recentKey:  {ID: 2, UserID: userID, LastUsedAt: &recent},
+		expiredKey: {ID: 3, UserID: userID, ExpiresAt: &past},
+		revokedKey: {ID: 4, UserID: userID, RevokedAt: &past},
+	}
+
+	tests := []struct {
+		name        string
+		key         string
+		wantKey     bool
+		wantUpdated bool
+	}{
+		{"active", activeKey, true, true},
+		{"recently used", recentKey, true, false},
+		{"expired", expiredKey, false, false},
+		{"revoked", revokedKey, false, false},
+		{"missing", missingKey, false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := mocks.NewDatabase()
+
+			s := &Service{
+				TxnManager:       db,
+				Repository:       db.User,
+				APIKeyRepository: db.APIKey,
+			}
+
+			var found *models.APIKey
+			if k, ok := keys[tt.key]; ok {
+				found = k
+			}
+
+			db.APIKey.On("FindByKeyHash", mock.Anything, HashAPIKey(tt.key)).Return(found, nil).Once()
+			if found != nil {
+				db.User.On("Find", mock.Anything, userID).Return(u, nil).Once()
+			}
+			if tt.wantUpdated {
+				db.APIKey.On("UpdatePartial", mock.Anything, found.ID, mock.AnythingOfType("models.APIKeyPartial")).Return(found, nil).Once()
+			}
+
+			gotKey, gotUser, err := s.AuthenticateAPIKey(testCtx, tt.key)
+			if err != nil {
+				t.Errorf("Service.AuthenticateAPIKey() error = %v", err)
+				return
+			}
+
+			if tt.wantKey {
+				assert.Equal(t, found, gotKey)
+				assert.Equal(t, u, gotUser)
+			} else {
+				assert.Nil(t, gotKey)
+				assert.Nil(t, gotUser)
+			}
+
+			db.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,2 @@
+// Package user provides the application logic for user accounts.
+package user
@@ -0,0 +1,192 @@
+package user
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/txn"
+)
+
+// Config provides the credentials configured in the application
+// configuration. The configured user is always an admin user.
+type Config interface {
+	HasCredentials() bool
+	GetUsername() string
+	GetPasswordHash() string
+}
+
+type Service struct {
+	TxnManager       models.TxnManager
+	Repository       models.UserReaderWriter
+	APIKeyRepository models.APIKeyReaderWriter
+	Config           Config
+}
+
+// Authenticate returns the user with the provided credentials.
+// Returns nil if the credentials are invalid.
+//
+// The credentials of the configured user are validated against the
+// application configuration. All other users are validated against the
+// stored password hash.
+func (s *Service) Authenticate(ctx context.Context, username string, password string) (*models.User, error) {
+	if s.isConfiguredUser(username) {
+		if !CheckPassword(s.Config.GetPasswordHash(), password) {
+			return nil, nil
+		}
+
+		return s.EnsureAdmin(ctx)
+	}
+
+	u, err := s.FindByUsername(ctx, username)
+	if err != nil || u == nil {
+		return nil, err
+	}
+
+	if !CheckPassword(u.PasswordHash, password) {
+		return nil, nil
+	}
+
+	return u, nil
+}
+
+func (s *Service) Find(ctx context.Context, id int) (*models.User, error) {
+	var ret *models.User
+	if err := txn.WithReadTxn(ctx, s.TxnManager, func(ctx context.Context) error {
+		var err error
+		ret, err = s.Repository.Find(ctx, id)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+// FindByUsername returns the user with the provided username.
+// The configured user is created if it does not exist.
+func (s *Service) FindByUsername(ctx context.Context, username string) (*models.User, error) {
+	var ret *models.User
+	if err := txn.WithReadTxn(ctx, s.TxnManager, func(ctx context.Context) error {
+		var err error
+		ret, err = s.Repository.FindByUsername(ctx, username)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	if ret == nil && s.isConfiguredUser(username) {
+		return s.EnsureAdmin(ctx)
+	}
+
+	return ret, nil
+}
+
+// RenameAdmin renames the user with the previous configured username to the
+// currently configured username, so that the configured user retains its
+// play history and ratings when the configured username is changed.
+// The configured user is then synchronised with the configuration.
+func (s *Service) RenameAdmin(ctx context.Context, previousUsername string) (*models.User, error) {
+	if !s.Config.HasCredentials() {
+		return nil, nil
+	}
+
+	username := s.Config.GetUsername()
+	if previousUsername != "" && previousUsername != username {
+		if err := txn.WithTxn(ctx, s.TxnManager, func(ctx context.Context) error {
+			r := s.Repository
+
+			previous, err := r.FindByUsername(ctx, previousUsername)
+			if err != nil || previous == nil {
+				return err
+			}
+
+			existing, err := r.FindByUsername(ctx, username)
+			if err != nil || existing != nil {
+				return err
+			}
+
+			partial := models.NewUserPartial()
+			partial.Username = models.NewOptionalString(username)
+
+			_, err = r.UpdatePartial(ctx, previous.ID, partial)
+			return err
+		}); err != nil {
+			return nil, fmt.Errorf("renaming admin user: %w", err)
+		}
+	}
+
+	return s.EnsureAdmin(ctx)
+}
+
+func (s *Service) isConfiguredUser(username string) bool {
+	return s.Config.HasCredentials() && username == s.Config.GetUsername()
+}
+
+// EnsureAdmin ensures that the user configured in the application
+// configuration exists as an admin user, with the configured password.
+// If the user is the first user to be created, then the shared play history,
+// resume times and ratings are copied to the new user.
+//
+// Returns nil if no credentials are configured.
+func (s *Service) EnsureAdmin(ctx context.Context) (*models.User, error) {
+	if !s.Config.HasCredentials() {
+		return nil, nil
+	}
+
+	username := s.Config.GetUsername()
+	passwordHash := s.Config.GetPasswordHash()
+
+	var ret *models.User
+	if err := txn.WithTxn(ctx, s.TxnManager, func(ctx context.Context) error {
+		r := s.Repository
+
+		existing, err := r.FindByUsername(ctx, username)
+		if err != nil {
+			return err
+		}
+
+		if existing != nil {
+			ret = existing
+			if existing.PasswordHash == passwordHash && existing.Role == models.UserRoleAdmin {
+				return nil
+			}
+
+			partial := models.NewUserPartial()
+			partial.PasswordHash = models.NewOptionalString(passwordHash)
+			partial.Role = models.NewOptionalString(models.UserRoleAdmin.String())
+
+			ret, err = r.UpdatePartial(ctx, existing.ID, partial)
+			return err
+		}
+
+		count, err := r.Count(ctx)
+		if err != nil {
+			return err
+		}
+
+		newUser := models.NewUser()
+		newUser.Username = username
+		newUser.PasswordHash = passwordHash
+		newUser.Role = models.UserRoleAdmin
+
+		if err := r.Create(ctx, &newUser); err != nil {
+			return err
+		}
+
+		if count == 0 {
+			if err := r.CopySharedState(ctx, newUser.ID); err != nil {
+				return fmt.Errorf("copying shared state to user: %w", err)
+			}
+		}
+
+		logger.Infof("Created admin user %s", username)
+		ret = &newUser
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("ensuring admin user: %w", err)
+	}
+
+	return ret, nil
+}
@@ -0,0 +1,63 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+var (
+	ErrUsernameMissing = errors.New("username must not be blank")
+	ErrPasswordMissing = errors.New("password must not be blank")
+	ErrLastAdmin       = errors.New("at least one admin user is required")
+)
+
+type UsernameExistsError struct {
+	Username string
+}
+
+func (e *UsernameExistsError) Error() string {
+	return fmt.Sprintf("user with username '%s' already exists", e.Username)
+}
+
+// ValidateUsername returns an error if the username is blank or used by
+// a user other than the user with the provided id.
+func ValidateUsername(ctx context.Context, id int, username string, qb models.UserFinder) error {
+	if strings.TrimSpace(username) == "" {
+		return ErrUsernameMissing
+	}
+
+	existing, err := qb.FindByUsername(ctx, username)
+	if err != nil {
+		return err
+	}
+
+	if existing != nil && existing.ID != id {
+		return &UsernameExistsError{Username: username}
+	}
+
+	return nil
+}
+
+// ValidateRemoveAdmin returns an error if the provided user is the last
+// admin user. It should be called before destroying a user or changing
+// its role.
+func ValidateRemoveAdmin(ctx context.Context, u *models.User, qb models.UserCounter) error {
+	if u.Role != models.UserRoleAdmin {
+		return nil
+	}
+
+	count, err := qb.CountByRole(ctx, models.UserRoleAdmin)
+	if err != nil {
+		return err
+	}
+
+	if count <= 1 {
+		return ErrLastAdmin
+	}
+
+	return nil
+}
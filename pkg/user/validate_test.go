@@ -0,0 +1,73 @@
+package user
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/models/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var testCtx = context.Background()
+
+func TestValidateUsername(t *testing.T) {
+	db := mocks.NewDatabase()
+
+	const (
+		username1   = "user1"
+		newUsername = "new user"
+	)
+
+	existing1 := models.User{
+		ID:       1,
+		Username: username1,
+	}
+
+	db.User.On("FindByUsername", testCtx, username1).Return(&existing1, nil)
+	db.User.On("FindByUsername", testCtx, mock.Anything).Return(nil, nil)
+
+	tests := []struct {
+		name     string
+		id       int
+		username string
+		want     error
+	}{
+		{"missing username", 0, " ", ErrUsernameMissing},
+		{"new username", 0, newUsername, nil},
+		{"existing username", 0, username1, &UsernameExistsError{username1}},
+		{"same user", existing1.ID, username1, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ValidateUsername(testCtx, tt.id, tt.username, db.User)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestValidateRemoveAdmin(t *testing.T) {
+	tests := []struct {
+		name       string
+		role       models.UserRole
+		adminCount int
+		want       error
+	}{
+		{"viewer", models.UserRoleViewer, 1, nil},
+		{"last admin", models.UserRoleAdmin, 1, ErrLastAdmin},
+		{"other admins", models.UserRoleAdmin, 2, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := mocks.NewDatabase()
+			db.User.On("CountByRole", testCtx, models.UserRoleAdmin).Return(tt.adminCount, nil)
+
+			u := &models.User{ID: 1, Role: tt.role}
+			got := ValidateRemoveAdmin(testCtx, u, db.User)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
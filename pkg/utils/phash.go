@@ -2,6 +2,7 @@ package utils
 
 import (
 	"math"
+	"math/bits"
 	"strconv"
 
 	"github.com/corona10/goimagehash"
@@ -76,3 +77,151 @@ func StringToPhash(s string) (int64, error) {
 
 	return int64(ret), nil
 }
+
+// FindHashDuplicates returns groups of ids whose hashes are within distance
+// of each other, in the order in which the first hash of each group appears.
+// Unlike FindDuplicates, durations are not compared, and hashes are not
+// compared with every other hash. Identical hashes are grouped, and the
+// remaining distinct hashes are indexed in a BK-tree, so that each hash is
+// only compared with hashes that may be within distance. This is suitable
+// for large numbers of hashes, such as those of images, provided that
+// distance is small.
+func FindHashDuplicates(hashes []*Phash, distance int) [][]int {
+	// group identical hashes
+	hashIndex := make(map[uint64]int)
+	var distinct []uint64
+	for _, h := range hashes {
+		hash := uint64(h.Hash)
+		if _, found := hashIndex[hash]; !found {
+			hashIndex[hash] = len(distinct)
+			distinct = append(distinct, hash)
+		}
+	}
+
+	tree := &bkTree{}
+	for i, hash := range distinct {
+		tree.insert(hash, i)
+	}
+
+	// join each hash with the hashes within distance
+	groups := newUnionFind(len(distinct))
+	for i, hash := range distinct {
+		tree.search(hash, distance, func(j int) {
+			groups.union(i, j)
+		})
+	}
+
+	bucketIndex := make(map[int]int)
+	var buckets [][]int
+	for _, h := range hashes {
+		root := groups.find(hashIndex[uint64(h.Hash)])
+		b, found := bucketIndex[root]
+		if !found {
+			b = len(buckets)
+			bucketIndex[root] = b
+			buckets = append(buckets, nil)
+		}
+
+		buckets[b] = sliceutil.AppendUnique(buckets[b], h.SceneID)
+	}
+
+	var ret [][]int
+	for _, bucket := range buckets {
+		if len(bucket) > 1 {
+			ret = append(ret, bucket)
+		}
+	}
+
+	return ret
+}
+
+// bkTree is a BK-tree of 64-bit hashes, using the hamming distance
+// between hashes.
+type bkTree struct {
+	root *bkTreeNode
+}
+
+type bkTreeNode struct {
+	hash     uint64
+	index    int
+	children map[int]*bkTreeNode
+}
+
+func hammingDistance(a, b uint64) int {
+	return bits.OnesCount64(a ^ b)
+}
+
+func (t *bkTree) insert(hash uint64, index int) {
+	newNode := &bkTreeNode{hash: hash, index: index}
+	if t.root == nil {
+		t.root = newNode
+		return
+	}
+
+	node := t.root
+	for {
+		d := hammingDistance(hash, node.hash)
+		child, found := node.children[d]
+		if !found {
+			if node.children == nil {
+				node.children = make(map[int]*bkTreeNode)
+			}
+			node.children[d] = newNode
+			return
+		}
+		node = child
+	}
+}
+
+// search calls fn with the index of each hash within distance of hash.
+func (t *bkTree) search(hash uint64, distance int, fn func(index int)) {
+	if t.root == nil {
+		return
+	}
+
+	stack := []*bkTreeNode{t.root}
+	for len(stack) > 0 {
+		node := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		d := hammingDistance(hash, node.hash)
+		if d <= distance {
+			fn(node.index)
+		}
+
+		// only children within distance of d can contain matches
+		for childDistance, child := range node.children {
+			if childDistance >= d-distance && childDistance <= d+distance {
+				stack = append(stack, child)
+			}
+		}
+	}
+}
+
+// unionFind is a disjoint set of the integers [0, n).
+type unionFind struct {
+	parent []int
+}
+
+func newUnionFind(n int) *unionFind {
+	parent := make([]int, n)
+	for i := range parent {
+		parent[i] = i
+	}
+	return &unionFind{parent: parent}
+}
+
+func (u *unionFind) find(i int) int {
+	for u.parent[i] != i {
+		u.parent[i] = u.parent[u.parent[i]]
+		i = u.parent[i]
+	}
+	return i
+}
+
+func (u *unionFind) union(i, j int) {
+	ri, rj := u.find(i), u.find(j)
+	if ri != rj {
+		u.parent[rj] = ri
+	}
+}
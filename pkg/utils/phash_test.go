@@ -0,0 +1,81 @@
+package utils
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestPhashes(hashes map[int]int64, order []int) []*Phash {
+	var ret []*Phash
+	for _, id := range order {
+		ret = append(ret, &Phash{SceneID: id, Hash: hashes[id], Duration: -1, Bucket: -1})
+	}
+	return ret
+}
+
+func TestFindHashDuplicates(t *testing.T) {
+	hashes := map[int]int64{
+		1: 0x0,
+		2: 0x1, // distance 1 from 1
+		3: 0x3, // distance 1 from 2, 2 from 1
+		4: 0xff00,
+		5: 0xff00, // identical to 4
+		6: 0x7fff0000,
+	}
+	order := []int{4, 1, 2, 3, 5, 6}
+
+	tests := []struct {
+		name     string
+		distance int
+		want     [][]int
+	}{
+		{"exact", 0, [][]int{{4, 5}}},
+		{"transitive", 1, [][]int{{4, 5}, {1, 2, 3}}},
+		{"joined groups", 8, [][]int{{4, 1, 2, 3, 5}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := FindHashDuplicates(newTestPhashes(hashes, order), tt.distance)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+// sortGroups sorts the ids of each group and the groups, so that results
+// can be compared regardless of order.
+func sortGroups(groups [][]int) [][]int {
+	for _, g := range groups {
+		sort.Ints(g)
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		return groups[i][0] < groups[j][0]
+	})
+	return groups
+}
+
+func TestFindHashDuplicatesMatchesFindDuplicates(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+
+	// cluster hashes around a few base values so that there are duplicates
+	bases := []uint64{r.Uint64(), r.Uint64(), r.Uint64(), r.Uint64()}
+	hashes := make(map[int]int64)
+	var order []int
+	for id := 1; id <= 500; id++ {
+		h := bases[r.Intn(len(bases))]
+		for i := 0; i < r.Intn(6); i++ {
+			h ^= 1 << uint(r.Intn(64))
+		}
+		hashes[id] = int64(h)
+		order = append(order, id)
+	}
+
+	for _, distance := range []int{0, 2, 4} {
+		want := sortGroups(FindDuplicates(newTestPhashes(hashes, order), distance, -1))
+		got := sortGroups(FindHashDuplicates(newTestPhashes(hashes, order), distance))
+		assert.Equal(t, want, got, "distance %d", distance)
+	}
+}
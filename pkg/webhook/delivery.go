@@ -0,0 +1,84 @@
+package webhook
+
+import (
+	"context"
+	"sync"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+// maxDeliveries is the maximum number of deliveries returned by Deliveries,
+// and the number of deliveries kept by the in-memory delivery log.
+const maxDeliveries = 200
+
+// DeliveryStore persists the delivery log.
+type DeliveryStore interface {
+	Create(ctx context.Context, d *models.WebhookDelivery) error
+	Update(ctx context.Context, d *models.WebhookDelivery) error
+	// FindRecent returns the most recent deliveries, newest first. If
+	// webhookID is not nil, only deliveries to that webhook are returned.
+	FindRecent(ctx context.Context, webhookID *int, limit int) ([]*models.WebhookDelivery, error)
+}
+
+// memoryStore stores the most recent deliveries in memory. It is used until
+// a persistent store is set.
+type memoryStore struct {
+	mutex      sync.Mutex
+	nextID     int
+	deliveries []models.WebhookDelivery
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{
+		nextID: 1,
+	}
+}
+
+func (s *memoryStore) Create(ctx context.Context, d *models.WebhookDelivery) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	d.ID = s.nextID
+	s.nextID++
+
+	s.deliveries = append([]models.WebhookDelivery{*d}, s.deliveries...)
+	if len(s.deliveries) > maxDeliveries {
+		s.deliveries = s.deliveries[:maxDeliveries]
+	}
+
+	return nil
+}
+
+func (s *memoryStore) Update(ctx context.Context, d *models.WebhookDelivery) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	// the delivery may have been removed from the log
+	for i := range s.deliveries {
+		if s.deliveries[i].ID == d.ID {
+			s.deliveries[i] = *d
+			break
+		}
+	}
+
+	return nil
+}
+
+func (s *memoryStore) FindRecent(ctx context.Context, webhookID *int, limit int) ([]*models.WebhookDelivery, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	var ret []*models.WebhookDelivery
+	for _, d := range s.deliveries {
+		if len(ret) >= limit {
+			break
+		}
+
+		if webhookID == nil || d.WebhookID == *webhookID {
+			d := d
+			ret = append(ret, &d)
+		}
+	}
+
+	return ret, nil
+}
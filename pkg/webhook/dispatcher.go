@@ -0,0 +1,327 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+)
+
+const (
+	// EventHeader is the request header containing the event type.
+	EventHeader = "X-Stash-Event"
+	// DeliveryHeader is the request header containing the delivery ID.
+	DeliveryHeader = "X-Stash-Delivery"
+	// SignatureHeader is the request header containing the hex-encoded
+	// HMAC-SHA256 of the request body, prefixed with "sha256=". It is only
+	// set if the webhook has a secret.
+	SignatureHeader = "X-Stash-Signature-256"
+
+	requestTimeout = 30 * time.Second
+
+	defaultMaxAttempts    = 5
+	defaultInitialBackoff = 2 * time.Second
+
+	// numWorkers is the number of deliveries that may be sent concurrently.
+	numWorkers = 4
+	// maxPending is the maximum number of queued or retrying deliveries.
+	maxPending = 1000
+)
+
+// Config provides the configured webhooks.
+type Config interface {
+	GetWebhooks() []*Webhook
+}
+
+// Payload is the JSON body of a webhook request.
+type Payload struct {
+	Event     Event       `json:"event"`
+	Timestamp time.Time   `json:"timestamp"`
+	Data      interface{} `json:"data"`
+}
+
+// task is a delivery waiting to be sent by a worker.
+type task struct {
+	webhook  Webhook
+	delivery *models.WebhookDelivery
+	body     []byte
+	backoff  time.Duration
+}
+
+// Dispatcher sends events to the configured webhooks. Requests are queued
+// and sent by a fixed number of workers, and are retried with exponential
+// backoff if they fail. Deliveries are dropped if too many are pending.
+type Dispatcher struct {
+	config Config
+	client *http.Client
+
+	webhooksMutex sync.RWMutex
+	webhooks      []*Webhook
+
+	storeMutex sync.RWMutex
+	store      DeliveryStore
+
+	queue    chan *task
+	pending  atomic.Int32
+	stop     chan struct{}
+	stopOnce sync.Once
+
+	maxAttempts    int
+	initialBackoff time.Duration
+}
+
+// NewDispatcher returns a new Dispatcher for the webhooks in config and
+// starts its workers. Deliveries are logged in memory until SetStore is
+// called.
+func NewDispatcher(config Config) *Dispatcher {
+	d := &Dispatcher{
+		config: config,
+		client: &http.Client{
+			Timeout: requestTimeout,
+		},
+		store:          newMemoryStore(),
+		queue:          make(chan *task, maxPending),
+		stop:           make(chan struct{}),
+		maxAttempts:    defaultMaxAttempts,
+		initialBackoff: defaultInitialBackoff,
+	}
+
+	d.Refresh()
+
+	for i := 0; i < numWorkers; i++ {
+		go d.work()
+	}
+
+	return d
+}
+
+// Refresh reloads the webhooks from the configuration. Must be called after
+// the configured webhooks are changed.
+func (d *Dispatcher) Refresh() {
+	webhooks := d.config.GetWebhooks()
+
+	d.webhooksMutex.Lock()
+	defer d.webhooksMutex.Unlock()
+	d.webhooks = webhooks
+}
+
+// SetStore sets the store used to log deliveries.
+func (d *Dispatcher) SetStore(store DeliveryStore) {
+	d.storeMutex.Lock()
+	defer d.storeMutex.Unlock()
+	d.store = store
+}
+
+func (d *Dispatcher) getStore() DeliveryStore {
+	d.storeMutex.RLock()
+	defer d.storeMutex.RUnlock()
+	return d.store
+}
+
+// Stop stops the workers. Pending deliveries are not sent.
+func (d *Dispatcher) Stop() {
+	d.stopOnce.Do(func() {
+		close(d.stop)
+	})
+}
+
+// Notify queues the event to be sent to all enabled webhooks that match it.
+// data is encoded as the data field of the payload. Notify does not block.
+func (d *Dispatcher) Notify(event Event, data interface{}) {
+	var webhooks []*Webhook
+
+	d.webhooksMutex.RLock()
+	for _, w := range d.webhooks {
+		if w.Matches(event) {
+			webhooks = append(webhooks, w)
+		}
+	}
+	d.webhooksMutex.RUnlock()
+
+	if len(webhooks) == 0 {
+		return
+	}
+
+	body, err := json.Marshal(Payload{
+		Event:     event,
+		Timestamp: time.Now(),
+		Data:      data,
+	})
+	if err != nil {
+		logger.Errorf("[webhook] error encoding %s payload: %v", event, err)
+		return
+	}
+
+	for _, w := range webhooks {
+		if d.pending.Add(1) > maxPending {
+			d.pending.Add(-1)
+			logger.Warnf("[webhook] too many pending deliveries, dropping %s event for %s", event, w.Name)
+			continue
+		}
+
+		d.enqueue(&task{
+			webhook: *w,
+			delivery: &models.WebhookDelivery{
+				WebhookID:   w.ID,
+				WebhookName: w.Name,
+				URL:         w.URL,
+				Event:       event.String(),
+				Status:      models.WebhookDeliveryStatusPending,
+				CreatedAt:   time.Now(),
+			},
+			body:    body,
+			backoff: d.initialBackoff,
+		})
+	}
+}
+
+// Deliveries returns the most recent deliveries, newest first. If webhookID
+// is not nil, only deliveries to that webhook are returned.
+func (d *Dispatcher) Deliveries(ctx context.Context, webhookID *int) ([]*models.WebhookDelivery, error) {
+	return d.getStore().FindRecent(ctx, webhookID, maxDeliveries)
+}
+
+// enqueue adds the task to the queue. It does not block, since the queue
+// has room for all pending deliveries.
+func (d *Dispatcher) enqueue(t *task) {
+	select {
+	case d.queue <- t:
+	case <-d.stop:
+	}
+}
+
+func (d *Dispatcher) work() {
+	for {
+		select {
+		case t := <-d.queue:
+			d.attempt(t)
+		case <-d.stop:
+			return
+		}
+	}
+}
+
+// attempt makes a single attempt of the delivery. If it fails and may be
+// retried, the task is queued again after the backoff.
+func (d *Dispatcher) attempt(t *task) {
+	ctx := context.Background()
+	store := d.getStore()
+	w := t.webhook
+	delivery := t.delivery
+
+	if delivery.ID == 0 {
+		if err := store.Create(ctx, delivery); err != nil {
+			logger.Errorf("[webhook] error logging delivery to %s: %v", w.Name, err)
+		}
+	}
+
+	delivery.Attempts++
+	statusCode, err := d.send(w, delivery.ID, Event(delivery.Event), t.body)
+
+	final := err == nil || !isRetryable(statusCode) || delivery.Attempts >= d.maxAttempts
+
+	delivery.StatusCode = nil
+	if statusCode != 0 {
+		delivery.StatusCode = &statusCode
+	}
+
+	delivery.Error = nil
+	if err != nil {
+		errStr := err.Error()
+		delivery.Error = &errStr
+	}
+
+	if final {
+		now := time.Now()
+		delivery.CompletedAt = &now
+
+		delivery.Status = models.WebhookDeliveryStatusSucceeded
+		if err != nil {
+			delivery.Status = models.WebhookDeliveryStatusFailed
+		}
+	}
+
+	if err := store.Update(ctx, delivery); err != nil {
+		logger.Errorf("[webhook] error logging delivery %d to %s: %v", delivery.ID, w.Name, err)
+	}
+
+	switch {
+	case err == nil:
+		logger.Debugf("[webhook] delivered %d to %s", delivery.ID, w.Name)
+	case final:
+		logger.Warnf("[webhook] delivery %d to %s failed after %d attempts: %v", delivery.ID, w.Name, delivery.Attempts, err)
+	default:
+		logger.Debugf("[webhook] delivery %d to %s failed, retrying in %s: %v", delivery.ID, w.Name, t.backoff, err)
+
+		backoff := t.backoff
+		t.backoff *= 2
+		time.AfterFunc(backoff, func() {
+			d.enqueue(t)
+		})
+		return
+	}
+
+	d.pending.Add(-1)
+}
+
+// send makes a single request to the webhook. Returns the response status
+// code, or zero if no response was received.
+func (d *Dispatcher) send(w Webhook, delivery int, event Event, body []byte) (int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "stash-webhook")
+	req.Header.Set(EventHeader, event.String())
+	req.Header.Set(DeliveryHeader, strconv.Itoa(delivery))
+	if w.Secret != "" {
+		req.Header.Set(SignatureHeader, Sign(w.Secret, body))
+	}
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	// drain the body so that the connection can be reused
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("http error %d", resp.StatusCode)
+	}
+
+	return resp.StatusCode, nil
+}
+
+// isRetryable returns true if a request that failed with the provided status
+// code should be retried. A zero status code means that no response was
+// received.
+func isRetryable(statusCode int) bool {
+	return statusCode == 0 || statusCode == http.StatusTooManyRequests || statusCode >= 500
+}
+
+// Sign returns the signature of body using secret, in the format used by the
+// signature header.
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,211 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/plugin/hook"
+	"github.com/stretchr/testify/assert"
+)
+
+type testConfig []*Webhook
+
+func (c testConfig) GetWebhooks() []*Webhook {
+	return append([]*Webhook(nil), c...)
+}
+
+func TestWebhook_Matches(t *testing.T) {
+	tests := []struct {
+		name    string
+		webhook Webhook
+		event   Event
+		want    bool
+	}{
+		{"disabled", Webhook{}, EventScanComplete, false},
+		{"all events", Webhook{Enabled: true}, EventScanComplete, true},
+		{"matching event", Webhook{Enabled: true, Events: []Event{EventJobFinished, EventScanComplete}}, EventScanComplete, true},
+		{"other event", Webhook{Enabled: true, Events: []Event{EventJobFinished}}, EventScanComplete, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.webhook.Matches(tt.event))
+		})
+	}
+}
+
+func TestWebhook_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		webhook Webhook
+		wantErr bool
+	}{
+		{"valid", Webhook{Name: "name", URL: "https://example.com/hook", Events: []Event{Event(hook.SceneCreatePost)}}, false},
+		{"blank name", Webhook{Name: " ", URL: "https://example.com/hook"}, true},
+		{"invalid url", Webhook{Name: "name", URL: "example.com/hook"}, true},
+		{"unsupported scheme", Webhook{Name: "name", URL: "ftp://example.com/hook"}, true},
+		{"pre hook event", Webhook{Name: "name", URL: "https://example.com/hook", Events: []Event{Event(hook.SceneCreatePre)}}, true},
+		{"unknown event", Webhook{Name: "name", URL: "https://example.com/hook", Events: []Event{"Unknown"}}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.webhook.Validate()
+			assert.Equal(t, tt.wantErr, err != nil, "Validate() error = %v", err)
+		})
+	}
+}
+
+// waitForCompletion waits until all deliveries in the log are completed.
+func waitForCompletion(t *testing.T, d *Dispatcher) []*models.WebhookDelivery {
+	t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		deliveries, err := d.Deliveries(context.Background(), nil)
+		if err != nil {
+			t.Fatalf("Deliveries() error = %v", err)
+		}
+
+		completed := len(deliveries) > 0
+		for _, dd := range deliveries {
+			if dd.Status == models.WebhookDeliveryStatusPending {
+				completed = false
+			}
+		}
+
+		if completed {
+			return deliveries
+		}
+
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	t.Fatal("timed out waiting for deliveries")
+	return nil
+}
+
+func TestDispatcher_Notify(t *testing.T) {
+	const secret = "secret"
+
+	var (
+		mutex    sync.Mutex
+		requests int
+		received Payload
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		requests++
+
+		body, _ := io.ReadAll(r.Body)
+		assert.Equal(t, Sign(secret, body), r.Header.Get(SignatureHeader))
+		assert.Equal(t, EventScanComplete.String(), r.Header.Get(EventHeader))
+
+		// fail the first request to test retrying
+		if requests == 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		_ = json.Unmarshal(body, &received)
+	}))
+	defer server.Close()
+
+	d := NewDispatcher(testConfig{
+		{ID: 1, Name: "scan", URL: server.URL, Secret: secret, Enabled: true, Events: []Event{EventScanComplete}},
+		{ID: 2, Name: "jobs", URL: server.URL, Enabled: true, Events: []Event{EventJobFinished}},
+	})
+	defer d.Stop()
+	d.initialBackoff = time.Millisecond
+
+	d.Notify(EventScanComplete, map[string]string{"key": "value"})
+
+	deliveries := waitForCompletion(t, d)
+	if assert.Len(t, deliveries, 1) {
+		got := deliveries[0]
+		assert.Equal(t, 1, got.WebhookID)
+		assert.Equal(t, models.WebhookDeliveryStatusSucceeded, got.Status)
+		assert.Equal(t, 2, got.Attempts)
+		assert.Nil(t, got.Error)
+	}
+
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	assert.Equal(t, 2, requests)
+	assert.Equal(t, EventScanComplete, received.Event)
+	assert.Equal(t, map[string]interface{}{"key": "value"}, received.Data)
+}
+
+func TestDispatcher_NotifyFailure(t *testing.T) {
+	var (
+		mutex    sync.Mutex
+		requests int
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		requests++
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	d := NewDispatcher(testConfig{
+		{ID: 1, Name: "hook", URL: server.URL, Enabled: true},
+	})
+	defer d.Stop()
+	d.initialBackoff = time.Millisecond
+
+	d.Notify(EventJobFinished, nil)
+
+	deliveries := waitForCompletion(t, d)
+	if assert.Len(t, deliveries, 1) {
+		got := deliveries[0]
+		assert.Equal(t, models.WebhookDeliveryStatusFailed, got.Status)
+		assert.Equal(t, 1, got.Attempts)
+		assert.Equal(t, http.StatusNotFound, *got.StatusCode)
+		assert.NotNil(t, got.Error)
+	}
+
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	// client errors are not retried
+	assert.Equal(t, 1, requests)
+}
+
+func TestDispatcher_Refresh(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	config := testConfig{
+		{ID: 1, Name: "hook", URL: server.URL},
+	}
+
+	d := NewDispatcher(config)
+	defer d.Stop()
+
+	// the webhook is disabled in the loaded configuration
+	config[0] = &Webhook{ID: 1, Name: "hook", URL: server.URL, Enabled: true}
+	d.Notify(EventJobFinished, nil)
+
+	deliveries, err := d.Deliveries(context.Background(), nil)
+	assert.NoError(t, err)
+	assert.Len(t, deliveries, 0)
+
+	d.Refresh()
+	d.Notify(EventJobFinished, nil)
+
+	deliveries = waitForCompletion(t, d)
+	assert.Len(t, deliveries, 1)
+}
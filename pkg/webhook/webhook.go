@@ -0,0 +1,110 @@
+// Package webhook sends signed HTTP notifications of library events to
+// user-configured endpoints.
+package webhook
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/stashapp/stash/pkg/plugin/hook"
+)
+
+// Event is the type of event that triggers a webhook. Post-hook events use
+// the value of the corresponding hook.TriggerEnum.
+type Event string
+
+const (
+	// EventJobFinished is sent when a job is finished, failed or cancelled.
+	EventJobFinished Event = "Job.Finished"
+	// EventScanComplete is sent when a scan completes without being
+	// cancelled.
+	EventScanComplete Event = "Scan.Complete"
+)
+
+// postHookSuffix is the suffix of the hook triggers that may be used as
+// webhook events. Pre-hooks are not sent to webhooks.
+const postHookSuffix = ".Post"
+
+// AllEvents returns all events that may trigger a webhook.
+func AllEvents() []Event {
+	ret := []Event{EventJobFinished, EventScanComplete}
+	for _, t := range hook.AllHookTriggerEnum {
+		if strings.HasSuffix(t.String(), postHookSuffix) {
+			ret = append(ret, Event(t))
+		}
+	}
+
+	return ret
+}
+
+// IsValid returns true if e is an event that may trigger a webhook.
+func (e Event) IsValid() bool {
+	for _, v := range AllEvents() {
+		if e == v {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (e Event) String() string {
+	return string(e)
+}
+
+// Webhook is an endpoint that is sent a POST request when any of its
+// events occur.
+type Webhook struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+	URL  string `json:"url"`
+	// Secret is used to sign the request body. Requests are not signed if
+	// empty.
+	Secret string `json:"secret"`
+	// Events that trigger the webhook. All events trigger the webhook if
+	// empty.
+	Events  []Event `json:"events"`
+	Enabled bool    `json:"enabled"`
+}
+
+// Matches returns true if the webhook is enabled and should be triggered by
+// the provided event.
+func (w Webhook) Matches(e Event) bool {
+	if !w.Enabled {
+		return false
+	}
+
+	if len(w.Events) == 0 {
+		return true
+	}
+
+	for _, v := range w.Events {
+		if v == e {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Validate returns an error if the webhook configuration is invalid.
+func (w Webhook) Validate() error {
+	if strings.TrimSpace(w.Name) == "" {
+		return errors.New("name cannot be blank")
+	}
+
+	u, err := url.Parse(w.URL)
+	if err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
+		return fmt.Errorf("invalid url %q", w.URL)
+	}
+
+	for _, e := range w.Events {
+		if !e.IsValid() {
+			return fmt.Errorf("invalid event %q", e)
+		}
+	}
+
+	return nil
+}
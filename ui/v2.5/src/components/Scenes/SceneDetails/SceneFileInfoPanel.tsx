@@ -21,7 +21,9 @@ import { PatchComponent } from "../../../patch";
 
 interface IFileInfoPanelProps {
   sceneID: string;
-  file: GQL.VideoFileDataFragment;
+  file: GQL.VideoFileDataFragment & {
+    streams?: GQL.VideoStreamDataFragment[];
+  };
   primary?: boolean;
   ofMany?: boolean;
   onSetPrimaryFile?: () => void;
@@ -55,6 +57,22 @@ const FileInfoPanel: React.FC<IFileInfoPanelProps> = (
     );
   }
 
+  function renderTracks(type: GQL.VideoStreamType, id: string) {
+    const tracks = (props.file.streams ?? []).filter((s) => s.type === type);
+    if (tracks.length === 0) {
+      return;
+    }
+
+    const value = tracks
+      .map((s) => {
+        const name = [s.language, s.title].filter((v) => !!v).join(" - ");
+        return name ? `${name} (${s.codec})` : s.codec;
+      })
+      .join(", ");
+
+    return <TextField id={id} value={value} truncate />;
+  }
+
   // TODO - generalise fingerprints
   const oshash = props.file.fingerprints.find((f) => f.type === "oshash");
   const phash = props.file.fingerprints.find((f) => f.type === "phash");
@@ -138,6 +156,11 @@ const FileInfoPanel: React.FC<IFileInfoPanelProps> = (
           value={props.file.audio_codec ?? ""}
           truncate
         />
+        {renderTracks(GQL.VideoStreamType.Audio, "media_info.audio_tracks")}
+        {renderTracks(
+          GQL.VideoStreamType.Subtitle,
+          "media_info.subtitle_tracks"
+        )}
       </dl>
       {props.ofMany && props.onSetPrimaryFile && !props.primary && (
         <div>
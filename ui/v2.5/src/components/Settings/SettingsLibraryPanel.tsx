@@ -3,7 +3,12 @@ import { Icon } from "../Shared/Icon";
 import { LoadingIndicator } from "../Shared/LoadingIndicator";
 import { StashSetting } from "./StashConfiguration";
 import { SettingSection } from "./SettingSection";
-import { BooleanSetting, StringListSetting, StringSetting } from "./Inputs";
+import {
+  BooleanSetting,
+  NumberSetting,
+  StringListSetting,
+  StringSetting,
+} from "./Inputs";
 import { useSettings } from "./context";
 import { useIntl } from "react-intl";
 import { faQuestionCircle } from "@fortawesome/free-solid-svg-icons";
@@ -36,6 +41,16 @@ export const SettingsLibraryPanel: React.FC = () => {
         onChange={(v) => saveGeneral({ stashes: v })}
       />
 
+      <SettingSection headingID="config.library.trash">
+        <NumberSetting
+          id="trash-retention-days"
+          headingID="config.general.trash_retention_days_head"
+          subHeadingID="config.general.trash_retention_days_desc"
+          value={general.trashRetentionDays ?? undefined}
+          onChange={(v) => saveGeneral({ trashRetentionDays: v })}
+        />
+      </SettingSection>
+
       <SettingSection headingID="config.library.media_content_extensions">
         <StringSetting
           id="video-extensions"
@@ -138,6 +153,14 @@ export const SettingsLibraryPanel: React.FC = () => {
           value={general.galleryCoverRegex ?? ""}
           onChange={(v) => saveGeneral({ galleryCoverRegex: v })}
         />
+
+        <StringSetting
+          id="chapter-marker-primary-tag"
+          headingID="config.general.chapter_marker_primary_tag_label"
+          subHeadingID="config.general.chapter_marker_primary_tag_desc"
+          value={general.chapterMarkerPrimaryTag ?? ""}
+          onChange={(v) => saveGeneral({ chapterMarkerPrimaryTag: v })}
+        />
       </SettingSection>
 
       <SettingSection headingID="config.ui.delete_options.heading">
@@ -302,6 +302,25 @@ export const SettingsConfigurationPanel: React.FC = () => {
           ))}
         </SelectSetting>
 
+        <SelectSetting
+          advanced
+          id="transcode-video-codec"
+          headingID="config.general.transcode_video_codec_head"
+          subHeadingID="config.general.transcode_video_codec_desc"
+          onChange={(v) =>
+            saveGeneral({
+              transcodeVideoCodec: (v as GQL.TranscodeVideoCodec) ?? undefined,
+            })
+          }
+          value={general.transcodeVideoCodec ?? undefined}
+        >
+          {Object.values(GQL.TranscodeVideoCodec).map((c) => (
+            <option key={c} value={c}>
+              {c}
+            </option>
+          ))}
+        </SelectSetting>
+
         <SelectSetting
           id="streaming-transcode-size"
           headingID="config.general.maximum_streaming_transcode_size_head"
@@ -320,6 +339,22 @@ export const SettingsConfigurationPanel: React.FC = () => {
           ))}
         </SelectSetting>
 
+        <NumberSetting
+          id="max-live-transcodes"
+          headingID="config.general.max_live_transcodes_head"
+          subHeadingID="config.general.max_live_transcodes_desc"
+          value={general.maxLiveTranscodes ?? undefined}
+          onChange={(v) => saveGeneral({ maxLiveTranscodes: v })}
+        />
+
+        <NumberSetting
+          id="max-live-transcodes-per-client"
+          headingID="config.general.max_live_transcodes_per_client_head"
+          subHeadingID="config.general.max_live_transcodes_per_client_desc"
+          value={general.maxLiveTranscodesPerClient ?? undefined}
+          onChange={(v) => saveGeneral({ maxLiveTranscodesPerClient: v })}
+        />
+
         <BooleanSetting
           id="hardware-encoding"
           headingID="config.general.ffmpeg.hardware_acceleration.heading"
@@ -373,6 +408,30 @@ export const SettingsConfigurationPanel: React.FC = () => {
         />
       </SettingSection>
 
+      <SettingSection headingID="config.general.job_concurrency_head">
+        <NumberSetting
+          id="io-job-concurrency"
+          headingID="config.general.io_job_concurrency_head"
+          subHeadingID="config.general.io_job_concurrency_desc"
+          value={general.ioJobConcurrency ?? undefined}
+          onChange={(v) => saveGeneral({ ioJobConcurrency: v })}
+        />
+        <NumberSetting
+          id="cpu-job-concurrency"
+          headingID="config.general.cpu_job_concurrency_head"
+          subHeadingID="config.general.cpu_job_concurrency_desc"
+          value={general.cpuJobConcurrency ?? undefined}
+          onChange={(v) => saveGeneral({ cpuJobConcurrency: v })}
+        />
+        <NumberSetting
+          id="network-job-concurrency"
+          headingID="config.general.network_job_concurrency_head"
+          subHeadingID="config.general.network_job_concurrency_desc"
+          value={general.networkJobConcurrency ?? undefined}
+          onChange={(v) => saveGeneral({ networkJobConcurrency: v })}
+        />
+      </SettingSection>
+
       <SettingSection headingID="config.general.preview_generation">
         <SelectSetting
           id="scene-gen-preview-preset"
@@ -13,6 +13,7 @@ interface IStashProps {
   stash: GQL.StashConfig;
   onSave: (instance: GQL.StashConfig) => void;
   onEdit: () => void;
+  onEditTrash: () => void;
   onDelete: () => void;
 }
 
@@ -21,6 +22,7 @@ const Stash: React.FC<IStashProps> = ({
   stash,
   onSave,
   onEdit,
+  onEditTrash,
   onDelete,
 }) => {
   // eslint-disable-next-line
@@ -38,6 +40,14 @@ const Stash: React.FC<IStashProps> = ({
     <Row className={`stash-row align-items-center ${classAdd}`}>
       <Form.Label column md={7}>
         {stash.path}
+        {stash.trashPath && (
+          <div className="text-muted">
+            <FormattedMessage
+              id="config.general.trash_path"
+              values={{ path: stash.trashPath }}
+            />
+          </div>
+        )}
       </Form.Label>
       <Col md={2} xs={4} className="col form-label">
         {/* NOTE - language is opposite to meaning:
@@ -79,6 +89,14 @@ const Stash: React.FC<IStashProps> = ({
             <Dropdown.Item onClick={() => onEdit()}>
               <FormattedMessage id="actions.edit" />
             </Dropdown.Item>
+            <Dropdown.Item onClick={() => onEditTrash()}>
+              <FormattedMessage id="actions.set_trash_directory" />
+            </Dropdown.Item>
+            {stash.trashPath && (
+              <Dropdown.Item onClick={() => handleInput("trashPath", null)}>
+                <FormattedMessage id="actions.clear_trash_directory" />
+              </Dropdown.Item>
+            )}
             <Dropdown.Item onClick={() => onDelete()}>
               <FormattedMessage id="actions.delete" />
             </Dropdown.Item>
@@ -100,6 +118,9 @@ const StashConfiguration: React.FC<IStashConfigurationProps> = ({
 }) => {
   const [isCreating, setIsCreating] = useState(false);
   const [editingIndex, setEditingIndex] = useState<number | undefined>();
+  const [editingTrashIndex, setEditingTrashIndex] = useState<
+    number | undefined
+  >();
 
   function onEdit(index: number) {
     setEditingIndex(index);
@@ -156,6 +177,27 @@ const StashConfiguration: React.FC<IStashConfigurationProps> = ({
         />
       ) : undefined}
 
+      {editingTrashIndex !== undefined ? (
+        <FolderSelectDialog
+          defaultValue={stashes[editingTrashIndex].trashPath ?? undefined}
+          onClose={(v) => {
+            if (v)
+              setStashes(
+                stashes.map((vv, index) => {
+                  if (index === editingTrashIndex) {
+                    return {
+                      ...vv,
+                      trashPath: v,
+                    };
+                  }
+                  return vv;
+                })
+              );
+            setEditingTrashIndex(undefined);
+          }}
+        />
+      ) : undefined}
+
       <div className="content" id="stash-table">
         {stashes.length > 0 && (
           <Row className="d-none d-md-flex">
@@ -176,6 +218,7 @@ const StashConfiguration: React.FC<IStashConfigurationProps> = ({
             stash={stash}
             onSave={(s) => handleSave(index, s)}
             onEdit={() => onEdit(index)}
+            onEditTrash={() => setEditingTrashIndex(index)}
             onDelete={() => onDelete(index)}
             key={stash.path}
           />
@@ -119,6 +119,13 @@ export const GenerateOptions: React.FC<IGenerateOptions> = ({
             tooltipID="dialogs.scene_gen.marker_screenshots_tooltip"
             onChange={(v) => setOptions({ markerScreenshots: v })}
           />
+          <BooleanSetting
+            id="chapter-marker-task"
+            checked={options.chapterMarkers ?? false}
+            headingID="dialogs.scene_gen.chapter_markers"
+            tooltipID="dialogs.scene_gen.chapter_markers_tooltip"
+            onChange={(v) => setOptions({ chapterMarkers: v })}
+          />
 
           <BooleanSetting
             advanced
@@ -171,6 +178,12 @@ export const GenerateOptions: React.FC<IGenerateOptions> = ({
             headingID="dialogs.scene_gen.image_thumbnails"
             onChange={(v) => setOptions({ imageThumbnails: v })}
           />
+          <BooleanSetting
+            id="image-phashes"
+            checked={options.imagePhashes ?? false}
+            headingID="dialogs.scene_gen.image_phashes"
+            onChange={(v) => setOptions({ imagePhashes: v })}
+          />
         </>
       )}
       <BooleanSetting
@@ -1,6 +1,9 @@
 import React, { useState, useEffect } from "react";
 import { Button, Card, ProgressBar } from "react-bootstrap";
 import {
+  mutatePauseJob,
+  mutateResumeJob,
+  mutateSetJobPriority,
   mutateStopJob,
   useJobQueue,
   useJobsSubscribe,
@@ -9,25 +12,36 @@ import * as GQL from "src/core/generated-graphql";
 import { Icon } from "src/components/Shared/Icon";
 import { useIntl } from "react-intl";
 import {
+  faArrowUp,
   faBan,
   faCheck,
   faCircle,
   faCircleExclamation,
   faCog,
   faHourglassStart,
+  faPause,
+  faPlay,
   faTimes,
 } from "@fortawesome/free-solid-svg-icons";
 
 type JobFragment = Pick<
   GQL.Job,
-  "id" | "status" | "subTasks" | "description" | "progress" | "error"
+  | "id"
+  | "status"
+  | "subTasks"
+  | "description"
+  | "progress"
+  | "error"
+  | "priority"
 >;
 
 interface IJob {
   job: JobFragment;
+  onPriorityChanged: () => void;
 }
 
-const Task: React.FC<IJob> = ({ job }) => {
+const Task: React.FC<IJob> = ({ job, onPriorityChanged }) => {
+  const intl = useIntl();
   const [stopping, setStopping] = useState(false);
   const [className, setClassName] = useState("");
 
@@ -53,18 +67,40 @@ const Task: React.FC<IJob> = ({ job }) => {
     await mutateStopJob(job.id);
   }
 
+  async function togglePaused() {
+    if (job.status === GQL.JobStatus.Paused) {
+      await mutateResumeJob(job.id);
+    } else {
+      await mutatePauseJob(job.id);
+    }
+  }
+
+  async function raisePriority() {
+    await mutateSetJobPriority(job.id, job.priority + 1);
+    onPriorityChanged();
+  }
+
   function canStop() {
     return (
       !stopping &&
       (job.status === GQL.JobStatus.Ready ||
+        job.status === GQL.JobStatus.Paused ||
         job.status === GQL.JobStatus.Running)
     );
   }
 
+  function isQueued() {
+    return (
+      job.status === GQL.JobStatus.Ready || job.status === GQL.JobStatus.Paused
+    );
+  }
+
   function getStatusClass() {
     switch (job.status) {
       case GQL.JobStatus.Ready:
         return "ready";
+      case GQL.JobStatus.Paused:
+        return "paused";
       case GQL.JobStatus.Running:
         return "running";
       case GQL.JobStatus.Stopping:
@@ -85,6 +121,9 @@ const Task: React.FC<IJob> = ({ job }) => {
       case GQL.JobStatus.Ready:
         icon = faHourglassStart;
         break;
+      case GQL.JobStatus.Paused:
+        icon = faPause;
+        break;
       case GQL.JobStatus.Running:
         icon = faCog;
         iconClass = "fa-spin";
@@ -166,6 +205,33 @@ const Task: React.FC<IJob> = ({ job }) => {
           <div>{maybeRenderProgress()}</div>
           {maybeRenderSubTasks()}
         </div>
+        {isQueued() ? (
+          <div className="job-queue-actions">
+            <Button
+              className="minimal"
+              size="sm"
+              title={intl.formatMessage({ id: "actions.raise_priority" })}
+              onClick={() => raisePriority()}
+            >
+              <Icon icon={faArrowUp} />
+            </Button>
+            <Button
+              className="minimal"
+              size="sm"
+              title={intl.formatMessage({
+                id:
+                  job.status === GQL.JobStatus.Paused
+                    ? "actions.resume"
+                    : "actions.pause",
+              })}
+              onClick={() => togglePaused()}
+            >
+              <Icon
+                icon={job.status === GQL.JobStatus.Paused ? faPlay : faPause}
+              />
+            </Button>
+          </div>
+        ) : undefined}
       </div>
     </li>
   );
@@ -228,7 +294,11 @@ export const JobTable: React.FC = () => {
           </span>
         ) : undefined}
         {(queue ?? []).map((j) => (
-          <Task job={j} key={j.id} />
+          <Task
+            job={j}
+            key={j.id}
+            onPriorityChanged={() => jobStatus.refetch()}
+          />
         ))}
       </ul>
     </Card>
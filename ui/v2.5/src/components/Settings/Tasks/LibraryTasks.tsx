@@ -90,6 +90,8 @@ export const LibraryTasks: React.FC = () => {
       scanGeneratePhashes: false,
       scanGenerateThumbnails: false,
       scanGenerateClipPreviews: false,
+      scanGenerateImagePhashes: false,
+      scanGenerateChapterMarkers: false,
     };
   }
 
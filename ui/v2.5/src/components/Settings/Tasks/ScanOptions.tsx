@@ -19,6 +19,8 @@ export const ScanOptions: React.FC<IScanOptions> = ({
     scanGeneratePhashes,
     scanGenerateThumbnails,
     scanGenerateClipPreviews,
+    scanGenerateImagePhashes,
+    scanGenerateChapterMarkers,
   } = options;
 
   function setOptions(input: Partial<GQL.ScanMetadataInput>) {
@@ -77,6 +79,20 @@ export const ScanOptions: React.FC<IScanOptions> = ({
         headingID="config.tasks.generate_clip_previews_during_scan"
         onChange={(v) => setOptions({ scanGenerateClipPreviews: v })}
       />
+      <BooleanSetting
+        id="scan-generate-image-phashes"
+        checked={scanGenerateImagePhashes ?? false}
+        headingID="config.tasks.generate_image_phashes_during_scan"
+        tooltipID="config.tasks.generate_image_phashes_during_scan_tooltip"
+        onChange={(v) => setOptions({ scanGenerateImagePhashes: v })}
+      />
+      <BooleanSetting
+        id="scan-generate-chapter-markers"
+        checked={scanGenerateChapterMarkers ?? false}
+        headingID="config.tasks.generate_chapter_markers_during_scan"
+        tooltipID="config.tasks.generate_chapter_markers_during_scan_tooltip"
+        onChange={(v) => setOptions({ scanGenerateChapterMarkers: v })}
+      />
     </>
   );
 };
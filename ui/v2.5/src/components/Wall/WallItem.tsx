@@ -183,7 +183,12 @@ export const WallItem = <T extends WallItemType>({
       case "sceneMarker":
         const sceneMarker = data as GQL.SceneMarkerDataFragment;
         const newTitle = markerTitle(sceneMarker);
-        const seconds = TextUtils.secondsToTimestamp(sceneMarker.seconds);
+        let seconds = TextUtils.secondsToTimestamp(sceneMarker.seconds);
+        if (sceneMarker.end_seconds) {
+          seconds += ` - ${TextUtils.secondsToTimestamp(
+            sceneMarker.end_seconds
+          )}`;
+        }
         if (newTitle) {
           return `${newTitle} - ${seconds}`;
         } else {
@@ -2514,6 +2514,24 @@ export const mutateStopJob = (jobID: string) =>
     variables: { job_id: jobID },
   });
 
+export const mutateSetJobPriority = (jobID: string, priority: number) =>
+  client.mutate<GQL.SetJobPriorityMutation>({
+    mutation: GQL.SetJobPriorityDocument,
+    variables: { job_id: jobID, priority },
+  });
+
+export const mutatePauseJob = (jobID: string) =>
+  client.mutate<GQL.PauseJobMutation>({
+    mutation: GQL.PauseJobDocument,
+    variables: { job_id: jobID },
+  });
+
+export const mutateResumeJob = (jobID: string) =>
+  client.mutate<GQL.ResumeJobMutation>({
+    mutation: GQL.ResumeJobDocument,
+    variables: { job_id: jobID },
+  });
+
 const setupMutationImpactedQueries = [
   GQL.ConfigurationDocument,
   GQL.SystemStatusDocument,
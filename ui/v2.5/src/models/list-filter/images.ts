@@ -9,6 +9,7 @@ import { PerformerFavoriteCriterionOption } from "./criteria/favorite";
 import { ImageIsMissingCriterionOption } from "./criteria/is-missing";
 import { OrganizedCriterionOption } from "./criteria/organized";
 import { PathCriterionOption } from "./criteria/path";
+import { PhashCriterionOption } from "./criteria/phash";
 import { PerformersCriterionOption } from "./criteria/performers";
 import { RatingCriterionOption } from "./criteria/rating";
 import { ResolutionCriterionOption } from "./criteria/resolution";
@@ -40,6 +41,7 @@ const criterionOptions = [
   createStringCriterionOption("details"),
   createStringCriterionOption("photographer"),
   createMandatoryStringCriterionOption("checksum", "media_info.checksum"),
+  PhashCriterionOption,
   PathCriterionOption,
   GalleriesCriterionOption,
   OrganizedCriterionOption,
@@ -5,6 +5,7 @@ import { ListFilterOptions } from "./filter-options";
 import { DisplayMode } from "./types";
 import {
   createDateCriterionOption,
+  createDurationCriterionOption,
   createMandatoryTimestampCriterionOption,
 } from "./criteria/criterion";
 
@@ -12,6 +13,7 @@ const defaultSortBy = "title";
 const sortByOptions = [
   "title",
   "seconds",
+  "duration",
   "scene_id",
   "random",
   "scenes_updated_at",
@@ -22,6 +24,7 @@ const criterionOptions = [
   MarkersScenesCriterionOption,
   SceneTagsCriterionOption,
   PerformersCriterionOption,
+  createDurationCriterionOption("duration"),
   createMandatoryTimestampCriterionOption("created_at"),
   createMandatoryTimestampCriterionOption("updated_at"),
   createDateCriterionOption("scene_date"),